@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/gin-gonic/gin"
 )
@@ -15,9 +17,15 @@ import (
 type FacadeHandler interface {
 	GetBalance(address string) (*big.Int, error)
 	GetAccount(address string) (*state.Account, error)
+	GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error)
+	GetValueForKey(address string, key string) (string, error)
+	GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error)
 	IsInterfaceNil() bool
 }
 
+const defaultPageSize = 100
+const maxPageSize = 1000
+
 type accountResponse struct {
 	Address  string `json:"address"`
 	Nonce    uint64 `json:"nonce"`
@@ -31,10 +39,14 @@ type accountResponse struct {
 func Routes(router *gin.RouterGroup) {
 	router.GET("/:address", GetAccount)
 	router.GET("/:address/balance", GetBalance)
+	router.GET("/:address/keys", GetKeyValuePairs)
+	router.GET("/:address/key/:key", GetValueForKey)
 }
 
 // GetAccount returns an accountResponse containing information
-//  about the account correlated with provided address
+//  about the account correlated with provided address. The optional blockNonce
+//  query parameter selects the state left by a past block, answering not found for
+//  an unknown block and gone for a block whose state was pruned
 func GetAccount(c *gin.Context) {
 	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
 	if !ok {
@@ -42,16 +54,29 @@ func GetAccount(c *gin.Context) {
 		return
 	}
 
+	blockNonce, hasBlockNonce, err := getBlockNonceParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
 	addr := c.Param("address")
-	acc, err := ef.GetAccount(addr)
+	var acc *state.Account
+	if hasBlockNonce {
+		acc, err = ef.GetAccountAtBlockNonce(addr, blockNonce)
+	} else {
+		acc, err = ef.GetAccount(addr)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
+		status := errors.HistoricalStateStatus(err, http.StatusInternalServerError)
+		c.JSON(status, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"account": accountResponseFromBaseAccount(addr, acc)})
 }
 
-// GetBalance returns the balance for the address parameter
+// GetBalance returns the balance for the address parameter. The optional blockNonce query parameter selects the
+// state left by a past block, answering not found for an unknown block and gone for a block whose state was pruned
 func GetBalance(c *gin.Context) {
 	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
 	if !ok {
@@ -65,15 +90,104 @@ func GetBalance(c *gin.Context) {
 		return
 	}
 
-	balance, err := ef.GetBalance(addr)
+	blockNonce, hasBlockNonce, err := getBlockNonceParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	var balance *big.Int
+	if hasBlockNonce {
+		var acc *state.Account
+		acc, err = ef.GetAccountAtBlockNonce(addr, blockNonce)
+		if err == nil {
+			balance = acc.Balance
+		}
+	} else {
+		balance, err = ef.GetBalance(addr)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
+		status := errors.HistoricalStateStatus(err, http.StatusInternalServerError)
+		c.JSON(status, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"balance": balance.String()})
 }
 
+// GetValueForKey returns the value stored under the given hex encoded key in the account's data trie
+func GetValueForKey(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	addr := c.Param("address")
+	key := c.Param("key")
+	value, err := ef.GetValueForKey(addr, key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"value": value})
+}
+
+// GetKeyValuePairs returns a page of the account's data trie entries, in ascending key order. The page resumes from the optional continuationToken query parameter, as returned with the previous page, and
+// holds at most pageSize entries
+func GetKeyValuePairs(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	pageSize, err := getPageSizeParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	addr := c.Param("address")
+	pairs, err := ef.GetKeyValuePairs(addr, c.Query("continuationToken"), pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pairs": pairs.Pairs, "continuationToken": pairs.ContinuationToken})
+}
+
+func getPageSizeParam(c *gin.Context) (int, error) {
+	pageSizeStr := c.Query("pageSize")
+	if pageSizeStr == "" {
+		return defaultPageSize, nil
+	}
+
+	pageSize, err := strconv.Atoi(pageSizeStr)
+	if err != nil || pageSize <= 0 || pageSize > maxPageSize {
+		return 0, errors.ErrInvalidPageSize
+	}
+
+	return pageSize, nil
+}
+
+// getBlockNonceParam returns the value of the optional blockNonce query parameter and whether it was provided
+func getBlockNonceParam(c *gin.Context) (uint64, bool, error) {
+	blockNonceStr, ok := c.GetQuery("blockNonce")
+	if !ok {
+		return 0, false, nil
+	}
+
+	blockNonce, err := strconv.ParseUint(blockNonceStr, 10, 64)
+	if err != nil {
+		return 0, false, errors.ErrInvalidBlockNonce
+	}
+
+	return blockNonce, true, nil
+}
+
 func accountResponseFromBaseAccount(address string, account *state.Account) accountResponse {
 	return accountResponse{
 		Address:  address,
@@ -15,7 +15,9 @@ import (
 	errors2 "github.com/ElrondNetwork/elrond-go/api/errors"
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/mock"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process/historicalState"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -26,7 +28,7 @@ type GeneralResponse struct {
 	Error string `json:"error"`
 }
 
-//addressResponse structure
+// addressResponse structure
 type addressResponse struct {
 	GeneralResponse
 	Balance string `json:"balance"`
@@ -54,6 +56,17 @@ type AccountResponse struct {
 	} `json:"account"`
 }
 
+type valueForKeyResponse struct {
+	GeneralResponse
+	Value string `json:"value"`
+}
+
+type keyValuePairsResponse struct {
+	GeneralResponse
+	Pairs             []*api.KeyValuePair `json:"pairs"`
+	ContinuationToken string              `json:"continuationToken"`
+}
+
 func TestAddressRoute_EmptyTrailReturns404(t *testing.T) {
 	t.Parallel()
 	facade := mock.Facade{}
@@ -242,6 +255,262 @@ func TestGetAccount_ReturnsSuccessfully(t *testing.T) {
 	assert.Empty(t, accountResponse.Error)
 }
 
+func TestGetAccount_WithBlockNonceShouldReadTheStateOfTheBlock(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			return &state.Account{
+				Nonce:   blockNonce,
+				Balance: big.NewInt(100),
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test?blockNonce=37", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, uint64(37), accountResponse.Account.Nonce)
+	assert.Equal(t, "100", accountResponse.Account.Balance)
+	assert.Empty(t, accountResponse.Error)
+}
+
+func TestGetAccount_WithInvalidBlockNonceShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test?blockNonce=latest", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(accountResponse.Error, errors2.ErrInvalidBlockNonce.Error()))
+}
+
+func TestGetBalance_WithBlockNonceShouldReadTheStateOfTheBlock(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			return &state.Account{Balance: big.NewInt(int64(blockNonce))}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/balance?blockNonce=37", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "37", addressResponse.Balance)
+	assert.Empty(t, addressResponse.Error)
+}
+
+func TestGetBalance_WithBlockNonceFacadeErrorShouldErr(t *testing.T) {
+	t.Parallel()
+	returnedError := "the state of the requested block was pruned"
+	facade := mock.Facade{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/balance?blockNonce=37", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.True(t, strings.Contains(addressResponse.Error, returnedError))
+}
+
+func TestGetAccount_WithBlockNonceMissingStateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err            error
+		expectedStatus int
+	}{
+		{fmt.Errorf("%w: block nonce 37", historicalState.ErrBlockNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: block nonce 37", historicalState.ErrStatePruned), http.StatusGone},
+	}
+	for _, tt := range tests {
+		returnedErr := tt.err
+		facade := mock.Facade{
+			GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+				return nil, returnedErr
+			},
+		}
+		ws := startNodeServer(&facade)
+
+		for _, route := range []string{"/address/test?blockNonce=37", "/address/test/balance?blockNonce=37"} {
+			req, _ := http.NewRequest("GET", route, nil)
+			resp := httptest.NewRecorder()
+			ws.ServeHTTP(resp, req)
+
+			response := GeneralResponse{}
+			loadResponse(resp.Body, &response)
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+			assert.True(t, strings.Contains(response.Error, returnedErr.Error()))
+		}
+	}
+}
+
+func TestGetValueForKey_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/address/test/key/aa", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	valueResponse := valueForKeyResponse{}
+	loadResponse(resp.Body, &valueResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), valueResponse.Error)
+}
+
+func TestGetValueForKey_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	facade := mock.Facade{
+		GetValueForKeyHandler: func(address string, key string) (string, error) {
+			return "", errExpected
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/key/aa", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	valueResponse := valueForKeyResponse{}
+	loadResponse(resp.Body, &valueResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetAccountStorage.Error(), errExpected.Error()), valueResponse.Error)
+}
+
+func TestGetValueForKey_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetValueForKeyHandler: func(address string, key string) (string, error) {
+			assert.Equal(t, "test", address)
+			assert.Equal(t, "aa", key)
+			return "bb", nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/key/aa", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	valueResponse := valueForKeyResponse{}
+	loadResponse(resp.Body, &valueResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, valueResponse.Error)
+	assert.Equal(t, "bb", valueResponse.Value)
+}
+
+func TestGetKeyValuePairs_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/address/test/keys", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	pairsResponse := keyValuePairsResponse{}
+	loadResponse(resp.Body, &pairsResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), pairsResponse.Error)
+}
+
+func TestGetKeyValuePairs_InvalidPageSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	for _, pageSize := range []string{"abc", "0", "-1", "1001"} {
+		req, _ := http.NewRequest("GET", "/address/test/keys?pageSize="+pageSize, nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		pairsResponse := keyValuePairsResponse{}
+		loadResponse(resp.Body, &pairsResponse)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, pairsResponse.Error, errors2.ErrInvalidPageSize.Error())
+	}
+}
+
+func TestGetKeyValuePairs_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	facade := mock.Facade{
+		GetKeyValuePairsHandler: func(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error) {
+			return nil, errExpected
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/keys", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	pairsResponse := keyValuePairsResponse{}
+	loadResponse(resp.Body, &pairsResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetAccountStorage.Error(), errExpected.Error()), pairsResponse.Error)
+}
+
+func TestGetKeyValuePairs_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetKeyValuePairsHandler: func(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error) {
+			assert.Equal(t, "test", address)
+			assert.Equal(t, "0a", continuationToken)
+			assert.Equal(t, 2, maxNumKeys)
+
+			return &api.AccountKeyValuePairs{
+				Pairs: []*api.KeyValuePair{
+					{Key: "0a", Value: "01"},
+					{Key: "0b", Value: "02"},
+				},
+				ContinuationToken: "0c",
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/keys?continuationToken=0a&pageSize=2", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	pairsResponse := keyValuePairsResponse{}
+	loadResponse(resp.Body, &pairsResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, pairsResponse.Error)
+	assert.Equal(t, 2, len(pairsResponse.Pairs))
+	assert.Equal(t, "0b", pairsResponse.Pairs[1].Key)
+	assert.Equal(t, "02", pairsResponse.Pairs[1].Value)
+	assert.Equal(t, "0c", pairsResponse.ContinuationToken)
+}
+
 func loadResponse(rsp io.Reader, destination interface{}) {
 	jsonParser := json.NewDecoder(rsp)
 	err := jsonParser.Decode(destination)
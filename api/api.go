@@ -5,9 +5,12 @@ import (
 	"reflect"
 
 	"github.com/ElrondNetwork/elrond-go/api/address"
+	"github.com/ElrondNetwork/elrond-go/api/block"
+	"github.com/ElrondNetwork/elrond-go/api/events"
 	"github.com/ElrondNetwork/elrond-go/api/logs"
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/node"
+	"github.com/ElrondNetwork/elrond-go/api/proof"
 	"github.com/ElrondNetwork/elrond-go/api/transaction"
 	valStats "github.com/ElrondNetwork/elrond-go/api/validator"
 	"github.com/ElrondNetwork/elrond-go/api/vmValues"
@@ -93,10 +96,22 @@ func registerRoutes(ws *gin.Engine, elrondFacade middleware.ElrondHandler) {
 	vmValuesRoutes.Use(middleware.WithElrondFacade(elrondFacade))
 	vmValues.Routes(vmValuesRoutes)
 
+	blockRoutes := ws.Group("/block")
+	blockRoutes.Use(middleware.WithElrondFacade(elrondFacade))
+	block.Routes(blockRoutes)
+
 	validatorRoutes := ws.Group("/validator")
 	validatorRoutes.Use(middleware.WithElrondFacade(elrondFacade))
 	valStats.Routes(validatorRoutes)
 
+	proofRoutes := ws.Group("/proof")
+	proofRoutes.Use(middleware.WithElrondFacade(elrondFacade))
+	proof.Routes(proofRoutes)
+
+	eventsRoutes := ws.Group("/events")
+	eventsRoutes.Use(middleware.WithElrondFacade(elrondFacade))
+	events.Routes(eventsRoutes)
+
 	apiHandler, ok := elrondFacade.(MainApiHandler)
 	if ok && apiHandler.PprofEnabled() {
 		pprof.Register(ws)
@@ -0,0 +1,123 @@
+package block
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/gin-gonic/gin"
+)
+
+// FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
+type FacadeHandler interface {
+	GetBlockByNonce(nonce uint64) (*api.Block, error)
+	GetBlockByHash(hash string) (*api.Block, error)
+	GetHyperBlockByNonce(nonce uint64) (*api.Block, error)
+	GetHyperBlockByHash(hash string) (*api.Block, error)
+	IsInterfaceNil() bool
+}
+
+// Routes defines block related routes
+func Routes(router *gin.RouterGroup) {
+	router.GET("/by-nonce/:nonce", GetBlockByNonce)
+	router.GET("/by-hash/:hash", GetBlockByHash)
+	router.GET("/hyperblock/by-nonce/:nonce", GetHyperBlockByNonce)
+	router.GET("/hyperblock/by-hash/:hash", GetHyperBlockByHash)
+}
+
+// GetBlockByNonce returns the block of the node's shard having the provided nonce
+func GetBlockByNonce(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	nonce, err := getNonceParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	apiBlock, err := ef.GetBlockByNonce(nonce)
+	returnBlockResponse(c, apiBlock, err)
+}
+
+// GetBlockByHash returns the block of the node's shard having the provided hash
+func GetBlockByHash(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	hash := c.Param("hash")
+	if hash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyBlockHash.Error())})
+		return
+	}
+
+	apiBlock, err := ef.GetBlockByHash(hash)
+	returnBlockResponse(c, apiBlock, err)
+}
+
+// GetHyperBlockByNonce returns the metablock having the provided nonce, together with the notarized shard blocks
+func GetHyperBlockByNonce(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	nonce, err := getNonceParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	apiBlock, err := ef.GetHyperBlockByNonce(nonce)
+	returnBlockResponse(c, apiBlock, err)
+}
+
+// GetHyperBlockByHash returns the metablock having the provided hash, together with the notarized shard blocks
+func GetHyperBlockByHash(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	hash := c.Param("hash")
+	if hash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyBlockHash.Error())})
+		return
+	}
+
+	apiBlock, err := ef.GetHyperBlockByHash(hash)
+	returnBlockResponse(c, apiBlock, err)
+}
+
+func getNonceParam(c *gin.Context) (uint64, error) {
+	nonceStr := c.Param("nonce")
+	if nonceStr == "" {
+		return 0, errors.ErrValidationEmptyBlockNonce
+	}
+
+	return strconv.ParseUint(nonceStr, 10, 64)
+}
+
+func returnBlockResponse(c *gin.Context, apiBlock *api.Block, err error) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBlock.Error(), err.Error())})
+		return
+	}
+
+	if apiBlock == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errors.ErrBlockNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"block": apiBlock})
+}
@@ -0,0 +1,241 @@
+package block_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/api/block"
+	apiErrors "github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/api/middleware"
+	"github.com/ElrondNetwork/elrond-go/api/mock"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type blockResponse struct {
+	Block *api.Block `json:"block"`
+	Error string     `json:"error"`
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestGetBlockByNonce_WrongFacadeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/block/by-nonce/1", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestGetBlockByNonce_InvalidNonceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetBlockByNonceHandler: func(nonce uint64) (*api.Block, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/by-nonce/invalid", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, response.Error, apiErrors.ErrValidation.Error())
+}
+
+func TestGetBlockByNonce_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade := mock.Facade{
+		GetBlockByNonceHandler: func(nonce uint64) (*api.Block, error) {
+			return nil, expectedErr
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/by-nonce/1", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", apiErrors.ErrGetBlock.Error(), expectedErr.Error()), response.Error)
+}
+
+func TestGetBlockByNonce_MissingBlockShouldReturnNotFound(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetBlockByNonceHandler: func(nonce uint64) (*api.Block, error) {
+			return nil, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/by-nonce/1", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, apiErrors.ErrBlockNotFound.Error(), response.Error)
+}
+
+func TestGetBlockByNonce_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedBlock := &api.Block{
+		Nonce: 37,
+		Round: 39,
+		Hash:  "aabb",
+		MiniBlocks: []*api.MiniBlock{
+			{
+				Hash:     "ccdd",
+				TxHashes: []string{"eeff"},
+			},
+		},
+	}
+	facade := mock.Facade{
+		GetBlockByNonceHandler: func(nonce uint64) (*api.Block, error) {
+			assert.Equal(t, expectedBlock.Nonce, nonce)
+			return expectedBlock, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/by-nonce/37", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, expectedBlock, response.Block)
+}
+
+func TestGetBlockByHash_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedBlock := &api.Block{
+		Nonce: 37,
+		Hash:  "aabb",
+	}
+	facade := mock.Facade{
+		GetBlockByHashHandler: func(hash string) (*api.Block, error) {
+			assert.Equal(t, expectedBlock.Hash, hash)
+			return expectedBlock, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/by-hash/aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, expectedBlock, response.Block)
+}
+
+func TestGetHyperBlockByNonce_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedBlock := &api.Block{
+		Nonce: 5,
+		Hash:  "aabb",
+		NotarizedBlocks: []*api.NotarizedBlock{
+			{
+				Hash:    "ccdd",
+				ShardID: 1,
+			},
+		},
+	}
+	facade := mock.Facade{
+		GetHyperBlockByNonceHandler: func(nonce uint64) (*api.Block, error) {
+			assert.Equal(t, expectedBlock.Nonce, nonce)
+			return expectedBlock, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/hyperblock/by-nonce/5", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, expectedBlock, response.Block)
+}
+
+func TestGetHyperBlockByHash_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade := mock.Facade{
+		GetHyperBlockByHashHandler: func(hash string) (*api.Block, error) {
+			return nil, expectedErr
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/block/hyperblock/by-hash/aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := blockResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, response.Error, expectedErr.Error())
+}
+
+func loadResponse(rsp io.Reader, destination interface{}) {
+	jsonParser := json.NewDecoder(rsp)
+	err := jsonParser.Decode(destination)
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func startNodeServer(handler block.FacadeHandler) *gin.Engine {
+	ws := gin.New()
+	ws.Use(cors.Default())
+	blockRoutes := ws.Group("/block")
+	if handler != nil {
+		blockRoutes.Use(middleware.WithElrondFacade(handler))
+	}
+	block.Routes(blockRoutes)
+	return ws
+}
+
+func startNodeServerWrongFacade() *gin.Engine {
+	ws := gin.New()
+	ws.Use(cors.Default())
+	ws.Use(func(c *gin.Context) {
+		c.Set("elrondFacade", mock.WrongFacade{})
+	})
+	blockRoutes := ws.Group("/block")
+	block.Routes(blockRoutes)
+	return ws
+}
@@ -36,3 +36,36 @@ var ErrGetTransaction = errors.New("transaction getting failed")
 
 // ErrTxNotFound signals an error happened trying to fetch a transaction
 var ErrTxNotFound = errors.New("transaction was not found")
+
+// ErrValidationEmptyBlockHash signals an empty block hash was provided
+var ErrValidationEmptyBlockHash = errors.New("block hash is empty")
+
+// ErrValidationEmptyBlockNonce signals an empty block nonce was provided
+var ErrValidationEmptyBlockNonce = errors.New("block nonce is empty")
+
+// ErrGetBlock signals an error happened trying to fetch a block
+var ErrGetBlock = errors.New("block getting failed")
+
+// ErrBlockNotFound signals that the requested block is not in the node's storage
+var ErrBlockNotFound = errors.New("block was not found")
+
+// ErrTxSimulationFailed signals an error happened while simulating a transaction
+var ErrTxSimulationFailed = errors.New("transaction simulation failed")
+
+// ErrTxCostEstimationFailed signals an error happened while estimating the gas limit of a transaction
+var ErrTxCostEstimationFailed = errors.New("transaction cost estimation failed")
+
+// ErrGetAccountStorage signals an error happened while reading the data trie of an account
+var ErrGetAccountStorage = errors.New("could not read account storage")
+
+// ErrInvalidPageSize signals an invalid page size was provided
+var ErrInvalidPageSize = errors.New("invalid page size")
+
+// ErrValidationEmptyRootHash signals an empty root hash was provided
+var ErrValidationEmptyRootHash = errors.New("root hash is empty")
+
+// ErrGetProof signals an error happened while generating a Merkle proof
+var ErrGetProof = errors.New("proof getting failed")
+
+// ErrInvalidBlockNonce signals an invalid block nonce was provided
+var ErrInvalidBlockNonce = errors.New("invalid block nonce")
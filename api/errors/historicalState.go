@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ElrondNetwork/elrond-go/process/historicalState"
+)
+
+// HistoricalStateStatus returns the HTTP status of an error returned while reading the state of a past block: not
+// found if the node does not know the block, gone if the state of the block was pruned and the provided status for any
+// other error
+func HistoricalStateStatus(err error, defaultStatus int) int {
+	switch {
+	case errors.Is(err, historicalState.ErrBlockNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, historicalState.ErrStatePruned):
+		return http.StatusGone
+	default:
+		return defaultStatus
+	}
+}
@@ -0,0 +1,132 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/gorilla/websocket"
+)
+
+const disconnectMessage = -1
+
+// SubscribeRequest represents the first message a client sends after opening the web socket connection
+type SubscribeRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+type eventsSender struct {
+	facade       FacadeHandler
+	conn         wsConn
+	log          logger.Logger
+	subscription *eventsNotifier.Subscription
+}
+
+// NewEventsSender returns a new component that, after receiving the subscription request of the client, will
+// push the chain events on the provided web socket connection
+func NewEventsSender(facade FacadeHandler, conn wsConn, log logger.Logger) (*eventsSender, error) {
+	if check.IfNil(facade) {
+		return nil, ErrNilFacadeHandler
+	}
+	if check.IfNil(log) {
+		return nil, ErrNilLogger
+	}
+	if conn == nil {
+		return nil, ErrNilWsConn
+	}
+
+	return &eventsSender{
+		facade: facade,
+		conn:   conn,
+		log:    log,
+	}, nil
+}
+
+// StartSendingBlocking waits for the subscription request and after that will start sending the chain events
+// while monitoring the current connection. When the connection ends the subscription is removed
+func (es *eventsSender) StartSendingBlocking() {
+	defer func() {
+		_ = es.conn.Close()
+		es.facade.UnsubscribeFromEvents(es.subscription)
+	}()
+
+	err := es.waitForSubscribeRequest()
+	if err != nil {
+		es.log.Debug("websocket events subscription failed", "error", err.Error())
+		es.sendError(err)
+		return
+	}
+
+	go es.monitorConnection()
+	es.doSendContinuously()
+}
+
+func (es *eventsSender) waitForSubscribeRequest() error {
+	_, message, err := es.conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	request := &SubscribeRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		return err
+	}
+
+	es.subscription, err = es.facade.SubscribeToEvents(request.Addresses)
+	if err != nil {
+		return err
+	}
+
+	es.log.Debug("websocket events subscription", "id", es.subscription.ID(), "num addresses", len(request.Addresses))
+
+	return nil
+}
+
+func (es *eventsSender) sendError(err error) {
+	data, errMarshal := json.Marshal(map[string]string{"error": err.Error()})
+	if errMarshal != nil {
+		return
+	}
+
+	_ = es.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (es *eventsSender) monitorConnection() {
+	var err error
+	var mt int
+
+	defer es.facade.UnsubscribeFromEvents(es.subscription)
+
+	for {
+		mt, _, err = es.conn.ReadMessage()
+		if mt == websocket.CloseMessage || mt == disconnectMessage {
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (es *eventsSender) doSendContinuously() {
+	for event := range es.subscription.Events() {
+		data, err := json.Marshal(event)
+		if err != nil {
+			es.log.Error("web socket events marshal error", "error", err.Error())
+			continue
+		}
+
+		err = es.conn.WriteMessage(websocket.TextMessage, data)
+		if err != nil {
+			isConnectionClosed := strings.Contains(err.Error(), "websocket: close sent")
+			if !isConnectionClosed {
+				es.log.Debug("web socket events error", "error", err.Error())
+			}
+
+			return
+		}
+	}
+}
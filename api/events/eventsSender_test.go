@@ -0,0 +1,212 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/api/events"
+	"github.com/ElrondNetwork/elrond-go/api/mock"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timeout = time.Second * 2
+
+func createFacadeWithNotifier(t *testing.T) (*mock.Facade, eventsNotifier.EventsNotifier) {
+	notifier, err := eventsNotifier.NewEventsNotifier(10)
+	require.Nil(t, err)
+
+	facade := &mock.Facade{
+		SubscribeToEventsHandler: func(addresses []string) (*eventsNotifier.Subscription, error) {
+			return notifier.Subscribe(nil), nil
+		},
+		UnsubscribeFromEventsHandler: func(subscription *eventsNotifier.Subscription) {
+			notifier.Unsubscribe(subscription)
+		},
+	}
+
+	return facade, notifier
+}
+
+//------- NewEventsSender
+
+func TestNewEventsSender_NilFacadeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	es, err := events.NewEventsSender(nil, &mock.WsConnStub{}, &mock.LoggerStub{})
+
+	assert.Nil(t, es)
+	assert.Equal(t, events.ErrNilFacadeHandler, err)
+}
+
+func TestNewEventsSender_NilLoggerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	es, err := events.NewEventsSender(&mock.Facade{}, &mock.WsConnStub{}, nil)
+
+	assert.Nil(t, es)
+	assert.Equal(t, events.ErrNilLogger, err)
+}
+
+func TestNewEventsSender_NilConnShouldErr(t *testing.T) {
+	t.Parallel()
+
+	es, err := events.NewEventsSender(&mock.Facade{}, nil, &mock.LoggerStub{})
+
+	assert.Nil(t, es)
+	assert.Equal(t, events.ErrNilWsConn, err)
+}
+
+func TestNewEventsSender_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	es, err := events.NewEventsSender(&mock.Facade{}, &mock.WsConnStub{}, &mock.LoggerStub{})
+
+	assert.NotNil(t, es)
+	assert.Nil(t, err)
+}
+
+//------- StartSendingBlocking
+
+func TestEventsSender_StartSendingBlockingSubscribeErrorShouldSendErrorAndClose(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade := &mock.Facade{
+		SubscribeToEventsHandler: func(addresses []string) (*eventsNotifier.Subscription, error) {
+			return nil, expectedErr
+		},
+	}
+
+	var closeCalled atomic.Value
+	closeCalled.Store(false)
+	var written []byte
+	conn := &mock.WsConnStub{}
+	conn.SetReadMessageHandler(func() (messageType int, p []byte, err error) {
+		return websocket.TextMessage, []byte(`{"addresses":["aa"]}`), nil
+	})
+	conn.SetWriteMessageHandler(func(messageType int, data []byte) error {
+		written = data
+		return nil
+	})
+	conn.SetCloseHandler(func() error {
+		closeCalled.Store(true)
+		return nil
+	})
+
+	es, _ := events.NewEventsSender(facade, conn, &mock.LoggerStub{})
+	es.StartSendingBlocking()
+
+	response := make(map[string]string)
+	err := json.Unmarshal(written, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedErr.Error(), response["error"])
+	assert.True(t, closeCalled.Load().(bool))
+}
+
+func TestEventsSender_StartSendingBlockingInvalidRequestShouldNotSubscribe(t *testing.T) {
+	t.Parallel()
+
+	facade := &mock.Facade{
+		SubscribeToEventsHandler: func(addresses []string) (*eventsNotifier.Subscription, error) {
+			assert.Fail(t, "should have not subscribed")
+			return nil, nil
+		},
+	}
+
+	conn := &mock.WsConnStub{}
+	conn.SetReadMessageHandler(func() (messageType int, p []byte, err error) {
+		return websocket.TextMessage, []byte("not a json"), nil
+	})
+	conn.SetWriteMessageHandler(func(messageType int, data []byte) error {
+		return nil
+	})
+	conn.SetCloseHandler(func() error {
+		return nil
+	})
+
+	es, _ := events.NewEventsSender(facade, conn, &mock.LoggerStub{})
+	es.StartSendingBlocking()
+}
+
+func TestEventsSender_StartSendingBlockingShouldSendEventsUntilConnectionCloses(t *testing.T) {
+	t.Parallel()
+
+	facade, notifier := createFacadeWithNotifier(t)
+
+	var numReads uint32
+	var connClosedByClient atomic.Value
+	connClosedByClient.Store(false)
+	chWritten := make(chan []byte, 10)
+	chDone := make(chan struct{})
+	conn := &mock.WsConnStub{}
+	conn.SetReadMessageHandler(func() (messageType int, p []byte, err error) {
+		if atomic.AddUint32(&numReads, 1) == 1 {
+			return websocket.TextMessage, []byte(`{"addresses":[]}`), nil
+		}
+		if connClosedByClient.Load().(bool) {
+			return websocket.CloseMessage, nil, nil
+		}
+
+		time.Sleep(time.Millisecond)
+		return websocket.PingMessage, nil, nil
+	})
+	conn.SetWriteMessageHandler(func(messageType int, data []byte) error {
+		chWritten <- data
+		return nil
+	})
+	conn.SetCloseHandler(func() error {
+		return nil
+	})
+
+	es, _ := events.NewEventsSender(facade, conn, &mock.LoggerStub{})
+	go func() {
+		es.StartSendingBlocking()
+		close(chDone)
+	}()
+
+	header := &block.Header{Nonce: 7, ShardId: 1}
+	select {
+	case data := <-waitForEvent(notifier, header, chWritten):
+		event := &api.Event{}
+		err := json.Unmarshal(data, event)
+		assert.Nil(t, err)
+		assert.Equal(t, api.EventTypeShardBlock, event.Type)
+		assert.Equal(t, uint64(7), event.Nonce)
+		assert.Equal(t, uint32(1), event.ShardID)
+		assert.Equal(t, "aabb", event.Hash)
+	case <-time.After(timeout):
+		assert.Fail(t, "timeout while waiting for the event")
+	}
+
+	connClosedByClient.Store(true)
+	select {
+	case <-chDone:
+	case <-time.After(timeout):
+		assert.Fail(t, "timeout while waiting for the sender to stop")
+	}
+}
+
+func waitForEvent(notifier eventsNotifier.EventsNotifier, header *block.Header, chWritten chan []byte) chan []byte {
+	chEvent := make(chan []byte, 1)
+	go func() {
+		for {
+			notifier.NotifyCommittedBlock(header, []byte{0xaa, 0xbb}, nil)
+			select {
+			case data := <-chWritten:
+				chEvent <- data
+				return
+			case <-time.After(time.Millisecond * 10):
+			}
+		}
+	}()
+
+	return chEvent
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"io"
+
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+)
+
+// FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
+type FacadeHandler interface {
+	SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error)
+	UnsubscribeFromEvents(subscription *eventsNotifier.Subscription)
+	IsInterfaceNil() bool
+}
+
+type wsConn interface {
+	io.Closer
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"net/http"
+
+	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+var log = logger.GetOrCreate("api/events")
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// Routes defines the chain events related routes
+func Routes(router *gin.RouterGroup) {
+	router.GET("/subscribe", Subscribe)
+}
+
+// Subscribe upgrades the connection to a web socket one and pushes the committed and notarized blocks, together
+// with the transactions touching the addresses sent by the client in its first message, as JSON text messages
+func Subscribe(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Debug("websocket events upgrade", "error", err.Error())
+		return
+	}
+
+	es, err := NewEventsSender(ef, conn, log)
+	if err != nil {
+		log.Error(err.Error())
+		_ = conn.Close()
+		return
+	}
+
+	es.StartSendingBlocking()
+}
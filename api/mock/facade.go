@@ -4,7 +4,9 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/external"
@@ -15,21 +17,36 @@ import (
 
 // Facade is the mock implementation of a node router handler
 type Facade struct {
-	Running                     bool
-	ShouldErrorStart            bool
-	ShouldErrorStop             bool
-	TpsBenchmarkHandler         func() *statistics.TpsBenchmark
-	GetHeartbeatsHandler        func() ([]heartbeat.PubKeyHeartbeat, error)
-	BalanceHandler              func(string) (*big.Int, error)
-	GetAccountHandler           func(address string) (*state.Account, error)
-	GenerateTransactionHandler  func(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
-	GetTransactionHandler       func(hash string) (*transaction.Transaction, error)
-	SendTransactionHandler      func(nonce uint64, sender string, receiver string, value string, gasPrice uint64, gasLimit uint64, data []byte, signature []byte) (string, error)
-	CreateTransactionHandler    func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*transaction.Transaction, error)
-	SendBulkTransactionsHandler func(txs []*transaction.Transaction) (uint64, error)
-	ExecuteSCQueryHandler       func(query *process.SCQuery) (*vmcommon.VMOutput, error)
-	StatusMetricsHandler        func() external.StatusMetricsHandler
-	ValidatorStatisticsHandler  func() (map[string]*state.ValidatorApiResponse, error)
+	Running                           bool
+	ShouldErrorStart                  bool
+	ShouldErrorStop                   bool
+	TpsBenchmarkHandler               func() *statistics.TpsBenchmark
+	GetHeartbeatsHandler              func() ([]heartbeat.PubKeyHeartbeat, error)
+	BalanceHandler                    func(string) (*big.Int, error)
+	GetAccountHandler                 func(address string) (*state.Account, error)
+	GenerateTransactionHandler        func(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
+	GetTransactionHandler             func(hash string) (*api.Transaction, error)
+	SendTransactionHandler            func(nonce uint64, sender string, receiver string, value string, gasPrice uint64, gasLimit uint64, data []byte, signature []byte) (string, error)
+	CreateTransactionHandler          func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*transaction.Transaction, error)
+	SendBulkTransactionsHandler       func(txs []*transaction.Transaction) (uint64, error)
+	ExecuteSCQueryHandler             func(query *process.SCQuery) (*vmcommon.VMOutput, error)
+	StatusMetricsHandler              func() external.StatusMetricsHandler
+	ValidatorStatisticsHandler        func() (map[string]*state.ValidatorApiResponse, error)
+	GetBlockByNonceHandler            func(nonce uint64) (*api.Block, error)
+	GetBlockByHashHandler             func(hash string) (*api.Block, error)
+	GetHyperBlockByNonceHandler       func(nonce uint64) (*api.Block, error)
+	GetHyperBlockByHashHandler        func(hash string) (*api.Block, error)
+	SimulateTransactionHandler        func(tx *transaction.Transaction) (*api.SimulationResults, error)
+	ComputeTxGasLimitHandler          func(tx *transaction.Transaction) (uint64, error)
+	GetValueForKeyHandler             func(address string, key string) (string, error)
+	GetKeyValuePairsHandler           func(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error)
+	GetProofHandler                   func(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonceHandler      func(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHashHandler       func(headerHash string, address string, key string) (*api.AccountProof, error)
+	SubscribeToEventsHandler          func(addresses []string) (*eventsNotifier.Subscription, error)
+	UnsubscribeFromEventsHandler      func(subscription *eventsNotifier.Subscription)
+	GetAccountAtBlockNonceHandler     func(address string, blockNonce uint64) (*state.Account, error)
+	ExecuteSCQueryAtBlockNonceHandler func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
 }
 
 // RestApiInterface -
@@ -92,6 +109,21 @@ func (f *Facade) GetAccount(address string) (*state.Account, error) {
 	return f.GetAccountHandler(address)
 }
 
+// GetAccountAtBlockNonce is the mock implementation of a handler's GetAccountAtBlockNonce method
+func (f *Facade) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return f.GetAccountAtBlockNonceHandler(address, blockNonce)
+}
+
+// GetValueForKey is the mock implementation of a handler's GetValueForKey method
+func (f *Facade) GetValueForKey(address string, key string) (string, error) {
+	return f.GetValueForKeyHandler(address, key)
+}
+
+// GetKeyValuePairs is the mock implementation of a handler's GetKeyValuePairs method
+func (f *Facade) GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error) {
+	return f.GetKeyValuePairsHandler(address, continuationToken, maxNumKeys)
+}
+
 // GenerateTransaction is the mock implementation of a handler's GenerateTransaction method
 func (f *Facade) GenerateTransaction(sender string, receiver string, value *big.Int,
 	code string) (*transaction.Transaction, error) {
@@ -114,7 +146,7 @@ func (f *Facade) CreateTransaction(
 }
 
 // GetTransaction is the mock implementation of a handler's GetTransaction method
-func (f *Facade) GetTransaction(hash string) (*transaction.Transaction, error) {
+func (f *Facade) GetTransaction(hash string) (*api.Transaction, error) {
 	return f.GetTransactionHandler(hash)
 }
 
@@ -128,6 +160,43 @@ func (f *Facade) SendBulkTransactions(txs []*transaction.Transaction) (uint64, e
 	return f.SendBulkTransactionsHandler(txs)
 }
 
+// SimulateTransactionExecution is the mock implementation of a handler's SimulateTransactionExecution method
+func (f *Facade) SimulateTransactionExecution(tx *transaction.Transaction) (*api.SimulationResults, error) {
+	return f.SimulateTransactionHandler(tx)
+}
+
+// ComputeTransactionGasLimit is the mock implementation of a handler's ComputeTransactionGasLimit method
+func (f *Facade) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	return f.ComputeTxGasLimitHandler(tx)
+}
+
+// GetProof is the mock implementation of a handler's GetProof method
+func (f *Facade) GetProof(rootHash string, address string, key string) (*api.AccountProof, error) {
+	return f.GetProofHandler(rootHash, address, key)
+}
+
+// GetProofByHeaderNonce is the mock implementation of a handler's GetProofByHeaderNonce method
+func (f *Facade) GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error) {
+	return f.GetProofByHeaderNonceHandler(nonce, address, key)
+}
+
+// GetProofByHeaderHash is the mock implementation of a handler's GetProofByHeaderHash method
+func (f *Facade) GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error) {
+	return f.GetProofByHeaderHashHandler(headerHash, address, key)
+}
+
+// SubscribeToEvents is the mock implementation of a handler's SubscribeToEvents method
+func (f *Facade) SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error) {
+	return f.SubscribeToEventsHandler(addresses)
+}
+
+// UnsubscribeFromEvents is the mock implementation of a handler's UnsubscribeFromEvents method
+func (f *Facade) UnsubscribeFromEvents(subscription *eventsNotifier.Subscription) {
+	if f.UnsubscribeFromEventsHandler != nil {
+		f.UnsubscribeFromEventsHandler(subscription)
+	}
+}
+
 // ValidatorStatisticsApi is the mock implementation of a handler's ValidatorStatisticsApi method
 func (f *Facade) ValidatorStatisticsApi() (map[string]*state.ValidatorApiResponse, error) {
 	return f.ValidatorStatisticsHandler()
@@ -138,11 +207,36 @@ func (f *Facade) ExecuteSCQuery(query *process.SCQuery) (*vmcommon.VMOutput, err
 	return f.ExecuteSCQueryHandler(query)
 }
 
+// ExecuteSCQueryAtBlockNonce is a mock implementation.
+func (f *Facade) ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	return f.ExecuteSCQueryAtBlockNonceHandler(query, blockNonce)
+}
+
 // StatusMetrics is the mock implementation for the StatusMetrics
 func (f *Facade) StatusMetrics() external.StatusMetricsHandler {
 	return f.StatusMetricsHandler()
 }
 
+// GetBlockByNonce is the mock implementation of a handler's GetBlockByNonce method
+func (f *Facade) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+	return f.GetBlockByNonceHandler(nonce)
+}
+
+// GetBlockByHash is the mock implementation of a handler's GetBlockByHash method
+func (f *Facade) GetBlockByHash(hash string) (*api.Block, error) {
+	return f.GetBlockByHashHandler(hash)
+}
+
+// GetHyperBlockByNonce is the mock implementation of a handler's GetHyperBlockByNonce method
+func (f *Facade) GetHyperBlockByNonce(nonce uint64) (*api.Block, error) {
+	return f.GetHyperBlockByNonceHandler(nonce)
+}
+
+// GetHyperBlockByHash is the mock implementation of a handler's GetHyperBlockByHash method
+func (f *Facade) GetHyperBlockByHash(hash string) (*api.Block, error) {
+	return f.GetHyperBlockByHashHandler(hash)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (f *Facade) IsInterfaceNil() bool {
 	return f == nil
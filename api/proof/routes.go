@@ -0,0 +1,115 @@
+package proof
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/gin-gonic/gin"
+)
+
+// FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
+type FacadeHandler interface {
+	GetProof(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error)
+	IsInterfaceNil() bool
+}
+
+// Routes defines Merkle proof related routes
+func Routes(router *gin.RouterGroup) {
+	router.GET("/root-hash/:roothash/address/:address", GetProof)
+	router.GET("/header-nonce/:nonce/address/:address", GetProofByHeaderNonce)
+	router.GET("/header-hash/:hash/address/:address", GetProofByHeaderHash)
+}
+
+// GetProof returns the Merkle proof of an account against the given state root hash. If the optional hex encoded
+// key query parameter is provided, the proof of that key against the account's data trie is also returned
+func GetProof(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	rootHash := c.Param("roothash")
+	if rootHash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyRootHash.Error())})
+		return
+	}
+
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+
+	proof, err := ef.GetProof(rootHash, address, c.Query("key"))
+	returnProofResponse(c, proof, err)
+}
+
+// GetProofByHeaderNonce returns the Merkle proof of an account against the state root hash of the node's shard block
+// having the given nonce, together with the hash of that block
+func GetProofByHeaderNonce(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	nonceStr := c.Param("nonce")
+	if nonceStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyBlockNonce.Error())})
+		return
+	}
+	nonce, err := strconv.ParseUint(nonceStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrInvalidBlockNonce.Error())})
+		return
+	}
+
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+
+	proof, err := ef.GetProofByHeaderNonce(nonce, address, c.Query("key"))
+	returnProofResponse(c, proof, err)
+}
+
+// GetProofByHeaderHash returns the Merkle proof of an account against the state root hash of the node's shard block
+// having the given hash
+func GetProofByHeaderHash(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	hash := c.Param("hash")
+	if hash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyBlockHash.Error())})
+		return
+	}
+
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+
+	proof, err := ef.GetProofByHeaderHash(hash, address, c.Query("key"))
+	returnProofResponse(c, proof, err)
+}
+
+func returnProofResponse(c *gin.Context, proof *api.AccountProof, err error) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetProof.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"proof": proof})
+}
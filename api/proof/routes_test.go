@@ -0,0 +1,233 @@
+package proof_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiErrors "github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/api/middleware"
+	"github.com/ElrondNetwork/elrond-go/api/mock"
+	"github.com/ElrondNetwork/elrond-go/api/proof"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type proofResponse struct {
+	Proof *api.AccountProof `json:"proof"`
+	Error string            `json:"error"`
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestGetProof_WrongFacadeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/proof/root-hash/aa/address/bb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, apiErrors.ErrInvalidAppContext.Error(), response.Error)
+}
+
+func TestGetProof_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade := mock.Facade{
+		GetProofHandler: func(rootHash string, address string, key string) (*api.AccountProof, error) {
+			return nil, expectedErr
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/proof/root-hash/aa/address/bb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", apiErrors.ErrGetProof.Error(), expectedErr.Error()), response.Error)
+}
+
+func TestGetProof_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &api.AccountProof{
+		RootHash: "aa",
+		Account: &api.MerkleProof{
+			Key:   "bb",
+			Value: "0102",
+			Proof: []string{"0a0b"},
+		},
+		DataTrieRootHash: "cc",
+		DataTrieKey: &api.MerkleProof{
+			Key:   "dd",
+			Proof: []string{"0c"},
+		},
+	}
+	facade := mock.Facade{
+		GetProofHandler: func(rootHash string, address string, key string) (*api.AccountProof, error) {
+			assert.Equal(t, "aa", rootHash)
+			assert.Equal(t, "bb", address)
+			assert.Equal(t, "dd", key)
+			return expectedProof, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/proof/root-hash/aa/address/bb?key=dd", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, response.Error)
+	assert.Equal(t, expectedProof, response.Proof)
+}
+
+func TestGetProofByHeaderNonce_InvalidNonceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServer(&mock.Facade{})
+	req, _ := http.NewRequest("GET", "/proof/header-nonce/abc/address/bb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", apiErrors.ErrValidation.Error(), apiErrors.ErrInvalidBlockNonce.Error()), response.Error)
+}
+
+func TestGetProofByHeaderNonce_FacadeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade := mock.Facade{
+		GetProofByHeaderNonceHandler: func(nonce uint64, address string, key string) (*api.AccountProof, error) {
+			return nil, expectedErr
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/proof/header-nonce/10/address/bb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", apiErrors.ErrGetProof.Error(), expectedErr.Error()), response.Error)
+}
+
+func TestGetProofByHeaderNonce_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &api.AccountProof{
+		HeaderHash:  "ee",
+		HeaderNonce: 10,
+		RootHash:    "aa",
+		Account: &api.MerkleProof{
+			Key:   "bb",
+			Value: "0102",
+			Proof: []string{"0a0b"},
+		},
+	}
+	facade := mock.Facade{
+		GetProofByHeaderNonceHandler: func(nonce uint64, address string, key string) (*api.AccountProof, error) {
+			assert.Equal(t, uint64(10), nonce)
+			assert.Equal(t, "bb", address)
+			assert.Equal(t, "dd", key)
+			return expectedProof, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/proof/header-nonce/10/address/bb?key=dd", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, response.Error)
+	assert.Equal(t, expectedProof, response.Proof)
+}
+
+func TestGetProofByHeaderHash_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &api.AccountProof{
+		HeaderHash:  "ee",
+		HeaderNonce: 10,
+		RootHash:    "aa",
+		Account: &api.MerkleProof{
+			Key:   "bb",
+			Proof: []string{"0a0b"},
+		},
+	}
+	facade := mock.Facade{
+		GetProofByHeaderHashHandler: func(headerHash string, address string, key string) (*api.AccountProof, error) {
+			assert.Equal(t, "ee", headerHash)
+			assert.Equal(t, "bb", address)
+			assert.Empty(t, key)
+			return expectedProof, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/proof/header-hash/ee/address/bb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := proofResponse{}
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, response.Error)
+	assert.Equal(t, expectedProof, response.Proof)
+}
+
+func loadResponse(rsp io.Reader, destination interface{}) {
+	jsonParser := json.NewDecoder(rsp)
+	err := jsonParser.Decode(destination)
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func startNodeServer(handler proof.FacadeHandler) *gin.Engine {
+	ws := gin.New()
+	ws.Use(cors.Default())
+	proofRoutes := ws.Group("/proof")
+	if handler != nil {
+		proofRoutes.Use(middleware.WithElrondFacade(handler))
+	}
+	proof.Routes(proofRoutes)
+	return ws
+}
+
+func startNodeServerWrongFacade() *gin.Engine {
+	ws := gin.New()
+	ws.Use(cors.Default())
+	ws.Use(func(c *gin.Context) {
+		c.Set("elrondFacade", mock.WrongFacade{})
+	})
+	proofRoutes := ws.Group("/proof")
+	proof.Routes(proofRoutes)
+	return ws
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/gin-gonic/gin"
 )
@@ -16,7 +17,9 @@ type TxService interface {
 	CreateTransaction(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*transaction.Transaction, error)
 	SendTransaction(nonce uint64, sender string, receiver string, value string, gasPrice uint64, gasLimit uint64, txData []byte, signature []byte) (string, error)
 	SendBulkTransactions([]*transaction.Transaction) (uint64, error)
-	GetTransaction(hash string) (*transaction.Transaction, error)
+	GetTransaction(hash string) (*api.Transaction, error)
+	SimulateTransactionExecution(tx *transaction.Transaction) (*api.SimulationResults, error)
+	ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error)
 	IsInterfaceNil() bool
 }
 
@@ -47,20 +50,12 @@ type SendTxRequest struct {
 	Signature string `form:"signature" json:"signature"`
 }
 
-//TxResponse represents the structure on which the response will be validated against
-type TxResponse struct {
-	SendTxRequest
-	ShardID     uint32 `json:"shardId"`
-	Hash        string `json:"hash"`
-	BlockNumber uint64 `json:"blockNumber"`
-	BlockHash   string `json:"blockHash"`
-	Timestamp   uint64 `json:"timestamp"`
-}
-
 // Routes defines transaction related routes
 func Routes(router *gin.RouterGroup) {
 	router.POST("/send", SendTransaction)
 	router.POST("/send-multiple", SendMultipleTransactions)
+	router.POST("/simulate", SimulateTransaction)
+	router.POST("/cost", ComputeTransactionGasLimit)
 	router.GET("/:txhash", GetTransaction)
 }
 
@@ -138,7 +133,87 @@ func SendMultipleTransactions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"txsSent": numOfSentTxs})
 }
 
-// GetTransaction returns transaction details for a given txhash
+// SimulateTransaction will receive a transaction from the client and will execute it on a copy of the current
+// state, without propagating it, returning the balance changes, the generated results and the consumed gas
+func SimulateTransaction(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(TxService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	var gtx = SendTxRequest{}
+	err := c.ShouldBindJSON(&gtx)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	tx, err := ef.CreateTransaction(
+		gtx.Nonce,
+		gtx.Value,
+		gtx.Receiver,
+		gtx.Sender,
+		gtx.GasPrice,
+		gtx.GasLimit,
+		gtx.Data,
+		gtx.Signature,
+	)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxGenerationFailed.Error(), err.Error())})
+		return
+	}
+
+	results, err := ef.SimulateTransactionExecution(tx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxSimulationFailed.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": results})
+}
+
+// ComputeTransactionGasLimit will receive a transaction from the client and will return the estimated gas limit
+// needed for its execution, without propagating it
+func ComputeTransactionGasLimit(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(TxService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	var gtx = SendTxRequest{}
+	err := c.ShouldBindJSON(&gtx)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	tx, err := ef.CreateTransaction(
+		gtx.Nonce,
+		gtx.Value,
+		gtx.Receiver,
+		gtx.Sender,
+		gtx.GasPrice,
+		gtx.GasLimit,
+		gtx.Data,
+		gtx.Signature,
+	)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxGenerationFailed.Error(), err.Error())})
+		return
+	}
+
+	gasLimit, err := ef.ComputeTransactionGasLimit(tx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxCostEstimationFailed.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"txGasUnits": gasLimit})
+}
+
+// GetTransaction returns transaction details for a given txhash, together with its status and generated results
 func GetTransaction(c *gin.Context) {
 
 	ef, ok := c.MustGet("elrondFacade").(TxService)
@@ -164,19 +239,5 @@ func GetTransaction(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"transaction": txResponseFromTransaction(tx)})
-}
-
-func txResponseFromTransaction(tx *transaction.Transaction) TxResponse {
-	response := TxResponse{}
-	response.Nonce = tx.Nonce
-	response.Sender = hex.EncodeToString(tx.SndAddr)
-	response.Receiver = hex.EncodeToString(tx.RcvAddr)
-	response.Data = tx.Data
-	response.Signature = hex.EncodeToString(tx.Signature)
-	response.Value = tx.Value.String()
-	response.GasLimit = tx.GasLimit
-	response.GasPrice = tx.GasPrice
-
-	return response
+	c.JSON(http.StatusOK, gin.H{"transaction": tx})
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/mock"
 	"github.com/ElrondNetwork/elrond-go/api/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	tr "github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -29,7 +30,17 @@ type GeneralResponse struct {
 
 type TransactionResponse struct {
 	GeneralResponse
-	TxResp *transaction.TxResponse `json:"transaction,omitempty"`
+	TxResp *api.Transaction `json:"transaction,omitempty"`
+}
+
+type SimulationResponse struct {
+	GeneralResponse
+	Result *api.SimulationResults `json:"result,omitempty"`
+}
+
+type TransactionCostResponse struct {
+	GeneralResponse
+	TxGasUnits uint64 `json:"txGasUnits"`
 }
 
 type TransactionHashResponse struct {
@@ -48,12 +59,13 @@ func TestGetTransaction_WithCorrectHashShouldReturnTransaction(t *testing.T) {
 	data := []byte("data")
 	hash := "hash"
 	facade := mock.Facade{
-		GetTransactionHandler: func(hash string) (i *tr.Transaction, e error) {
-			return &tr.Transaction{
-				SndAddr: []byte(sender),
-				RcvAddr: []byte(receiver),
-				Data:    data,
-				Value:   value,
+		GetTransactionHandler: func(hash string) (i *api.Transaction, e error) {
+			return &api.Transaction{
+				Sender:   hex.EncodeToString([]byte(sender)),
+				Receiver: hex.EncodeToString([]byte(receiver)),
+				Data:     data,
+				Value:    value.String(),
+				Status:   api.TxStatusExecuted,
 			}, nil
 		},
 	}
@@ -73,6 +85,7 @@ func TestGetTransaction_WithCorrectHashShouldReturnTransaction(t *testing.T) {
 	assert.Equal(t, hex.EncodeToString([]byte(receiver)), txResp.Receiver)
 	assert.Equal(t, value.String(), txResp.Value)
 	assert.Equal(t, data, txResp.Data)
+	assert.Equal(t, api.TxStatusExecuted, txResp.Status)
 }
 
 func TestGetTransaction_WithUnknownHashShouldReturnNil(t *testing.T) {
@@ -83,15 +96,15 @@ func TestGetTransaction_WithUnknownHashShouldReturnNil(t *testing.T) {
 	hs := "hash"
 	wrongHash := "wronghash"
 	facade := mock.Facade{
-		GetTransactionHandler: func(hash string) (i *tr.Transaction, e error) {
+		GetTransactionHandler: func(hash string) (i *api.Transaction, e error) {
 			if hash != hs {
 				return nil, nil
 			}
-			return &tr.Transaction{
-				SndAddr: []byte(sender),
-				RcvAddr: []byte(receiver),
-				Data:    []byte(data),
-				Value:   value,
+			return &api.Transaction{
+				Sender:   sender,
+				Receiver: receiver,
+				Data:     []byte(data),
+				Value:    value.String(),
 			}, nil
 		},
 	}
@@ -277,6 +290,194 @@ func TestSendTransaction_ReturnsSuccessfully(t *testing.T) {
 	assert.Equal(t, txHashResponse.TxHash, txHash)
 }
 
+func TestSimulateTransaction_ErrorWithWrongFacade(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("POST", "/transaction/simulate", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	simulationResponse := SimulationResponse{}
+	loadResponse(resp.Body, &simulationResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), simulationResponse.Error)
+}
+
+func TestSimulateTransaction_WrongParametersShouldErrorOnValidation(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/simulate", bytes.NewBuffer([]byte(`{"nonce": "wrong"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	simulationResponse := SimulationResponse{}
+	loadResponse(resp.Body, &simulationResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, simulationResponse.Error, errors2.ErrValidation.Error())
+	assert.Nil(t, simulationResponse.Result)
+}
+
+func TestSimulateTransaction_ErrorWhenCreateTransactionErrors(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	facade := mock.Facade{
+		CreateTransactionHandler: func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*tr.Transaction, error) {
+			return nil, errExpected
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/simulate", bytes.NewBuffer([]byte(`{"nonce": 1, "value": "10"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	simulationResponse := SimulationResponse{}
+	loadResponse(resp.Body, &simulationResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, simulationResponse.Error, errors2.ErrTxGenerationFailed.Error())
+	assert.Contains(t, simulationResponse.Error, errExpected.Error())
+}
+
+func TestSimulateTransaction_ErrorWhenSimulationErrors(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	facade := mock.Facade{
+		CreateTransactionHandler: func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*tr.Transaction, error) {
+			return &tr.Transaction{}, nil
+		},
+		SimulateTransactionHandler: func(tx *tr.Transaction) (*api.SimulationResults, error) {
+			return nil, errExpected
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/simulate", bytes.NewBuffer([]byte(`{"nonce": 1, "value": "10"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	simulationResponse := SimulationResponse{}
+	loadResponse(resp.Body, &simulationResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, simulationResponse.Error, errors2.ErrTxSimulationFailed.Error())
+	assert.Contains(t, simulationResponse.Error, errExpected.Error())
+}
+
+func TestSimulateTransaction_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := &api.SimulationResults{
+		Hash:          "hash",
+		FailReason:    "insufficient funds",
+		GasUsed:       50000,
+		BalanceDeltas: map[string]string{"sender": "-50000"},
+	}
+	facade := mock.Facade{
+		CreateTransactionHandler: func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*tr.Transaction, error) {
+			return &tr.Transaction{Nonce: nonce}, nil
+		},
+		SimulateTransactionHandler: func(tx *tr.Transaction) (*api.SimulationResults, error) {
+			return expectedResults, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/simulate", bytes.NewBuffer([]byte(`{"nonce": 1, "value": "10"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	simulationResponse := SimulationResponse{}
+	loadResponse(resp.Body, &simulationResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, simulationResponse.Error)
+	assert.Equal(t, expectedResults, simulationResponse.Result)
+}
+
+func TestComputeTransactionGasLimit_ErrorWithWrongFacade(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("POST", "/transaction/cost", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	costResponse := TransactionCostResponse{}
+	loadResponse(resp.Body, &costResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), costResponse.Error)
+}
+
+func TestComputeTransactionGasLimit_WrongParametersShouldErrorOnValidation(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/cost", bytes.NewBuffer([]byte(`{"nonce": "wrong"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	costResponse := TransactionCostResponse{}
+	loadResponse(resp.Body, &costResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, costResponse.Error, errors2.ErrValidation.Error())
+}
+
+func TestComputeTransactionGasLimit_ErrorWhenEstimationErrors(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	facade := mock.Facade{
+		CreateTransactionHandler: func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*tr.Transaction, error) {
+			return &tr.Transaction{}, nil
+		},
+		ComputeTxGasLimitHandler: func(tx *tr.Transaction) (uint64, error) {
+			return 0, errExpected
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/cost", bytes.NewBuffer([]byte(`{"nonce": 1, "value": "0"}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	costResponse := TransactionCostResponse{}
+	loadResponse(resp.Body, &costResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, costResponse.Error, errors2.ErrTxCostEstimationFailed.Error())
+	assert.Contains(t, costResponse.Error, errExpected.Error())
+}
+
+func TestComputeTransactionGasLimit_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+
+	expectedGasLimit := uint64(51234)
+	facade := mock.Facade{
+		CreateTransactionHandler: func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64, gasLimit uint64, data []byte, signatureHex string) (*tr.Transaction, error) {
+			return &tr.Transaction{Data: data}, nil
+		},
+		ComputeTxGasLimitHandler: func(tx *tr.Transaction) (uint64, error) {
+			return expectedGasLimit, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("POST", "/transaction/cost", bytes.NewBuffer([]byte(`{"nonce": 1, "value": "0", "data": "ZnVuY3Rpb24="}`)))
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	costResponse := TransactionCostResponse{}
+	loadResponse(resp.Body, &costResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, costResponse.Error)
+	assert.Equal(t, expectedGasLimit, costResponse.TxGasUnits)
+}
+
 func TestSendMultipleTransactions_ErrorWithWrongFacade(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/ElrondNetwork/elrond-go/api/errors"
 	"github.com/ElrondNetwork/elrond-go/process"
@@ -14,6 +15,7 @@ import (
 // FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
 type FacadeHandler interface {
 	ExecuteSCQuery(*process.SCQuery) (*vmcommon.VMOutput, error)
+	ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
 	IsInterfaceNil() bool
 }
 
@@ -92,7 +94,17 @@ func doExecuteQuery(context *gin.Context) (*vmcommon.VMOutput, error) {
 		return nil, err
 	}
 
-	vmOutput, err := facade.ExecuteSCQuery(command)
+	blockNonce, hasBlockNonce, err := getBlockNonceParam(context)
+	if err != nil {
+		return nil, err
+	}
+
+	var vmOutput *vmcommon.VMOutput
+	if hasBlockNonce {
+		vmOutput, err = facade.ExecuteSCQueryAtBlockNonce(command, blockNonce)
+	} else {
+		vmOutput, err = facade.ExecuteSCQuery(command)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +112,22 @@ func doExecuteQuery(context *gin.Context) (*vmcommon.VMOutput, error) {
 	return vmOutput, nil
 }
 
+// getBlockNonceParam returns the value of the optional blockNonce query parameter, selecting the state of a past
+// block, and whether it was provided
+func getBlockNonceParam(context *gin.Context) (uint64, bool, error) {
+	blockNonceStr, ok := context.GetQuery("blockNonce")
+	if !ok {
+		return 0, false, nil
+	}
+
+	blockNonce, err := strconv.ParseUint(blockNonceStr, 10, 64)
+	if err != nil {
+		return 0, false, errors.ErrInvalidBlockNonce
+	}
+
+	return blockNonce, true, nil
+}
+
 func createSCQuery(request *VMValueRequest) (*process.SCQuery, error) {
 	decodedAddress, err := hex.DecodeString(request.ScAddress)
 	if err != nil {
@@ -124,9 +152,11 @@ func createSCQuery(request *VMValueRequest) (*process.SCQuery, error) {
 	}, nil
 }
 
+// returnBadRequest answers with the error, as a bad request unless it reports an unknown block or a pruned state for
+// a query on a past block
 func returnBadRequest(context *gin.Context, errScope string, err error) {
 	message := fmt.Sprintf("%s: %s", errScope, err)
-	context.JSON(http.StatusBadRequest, gin.H{"error": message})
+	context.JSON(errors.HistoricalStateStatus(err, http.StatusBadRequest), gin.H{"error": message})
 }
 
 func returnOkResponse(context *gin.Context, data interface{}) {
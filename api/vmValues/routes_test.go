@@ -14,6 +14,7 @@ import (
 	apiErrors "github.com/ElrondNetwork/elrond-go/api/errors"
 	"github.com/ElrondNetwork/elrond-go/api/mock"
 	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/historicalState"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -146,6 +147,81 @@ func TestQuery_ShouldWork(t *testing.T) {
 	require.Equal(t, int64(42), big.NewInt(0).SetBytes(response.Data.ReturnData[0]).Int64())
 }
 
+func TestQuery_WithBlockNonceShouldRunOnTheStateOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		ExecuteSCQueryAtBlockNonceHandler: func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{
+				ReturnData: [][]byte{big.NewInt(int64(blockNonce)).Bytes()},
+			}, nil
+		},
+	}
+
+	request := VMValueRequest{
+		ScAddress: DummyScAddress,
+		FuncName:  "function",
+		Args:      []string{},
+	}
+
+	response := vmOutputResponse{}
+	statusCode := doPost(&facade, "/vm-values/query?blockNonce=37", request, &response)
+
+	require.Equal(t, http.StatusOK, statusCode)
+	require.Equal(t, "", response.Error)
+	require.Equal(t, int64(37), big.NewInt(0).SetBytes(response.Data.ReturnData[0]).Int64())
+}
+
+func TestAllRoutes_WithBlockNonceMissingStateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	request := VMValueRequest{
+		ScAddress: DummyScAddress,
+		FuncName:  "function",
+		Args:      []string{},
+	}
+
+	tests := []struct {
+		err            error
+		expectedStatus int
+	}{
+		{fmt.Errorf("%w: block nonce 37", historicalState.ErrBlockNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: block nonce 37", historicalState.ErrStatePruned), http.StatusGone},
+	}
+	for _, tt := range tests {
+		returnedErr := tt.err
+		facade := mock.Facade{
+			ExecuteSCQueryAtBlockNonceHandler: func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+				return nil, returnedErr
+			},
+		}
+
+		response := simpleResponse{}
+		for _, route := range []string{"hex", "string", "int", "query"} {
+			statusCode := doPost(&facade, fmt.Sprintf("/vm-values/%s?blockNonce=37", route), request, &response)
+			require.Equal(t, tt.expectedStatus, statusCode)
+			require.Contains(t, response.Error, returnedErr.Error())
+		}
+	}
+}
+
+func TestAllRoutes_WhenBadBlockNonceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	request := VMValueRequest{
+		ScAddress: DummyScAddress,
+		FuncName:  "function",
+		Args:      []string{},
+	}
+
+	response := simpleResponse{}
+	for _, route := range []string{"hex", "string", "int", "query"} {
+		statusCode := doPost(&mock.Facade{}, fmt.Sprintf("/vm-values/%s?blockNonce=-1", route), request, &response)
+		require.Equal(t, http.StatusBadRequest, statusCode)
+		require.Contains(t, response.Error, apiErrors.ErrInvalidBlockNonce.Error())
+	}
+}
+
 func TestCreateSCQuery_ArgumentIsNotHexShouldErr(t *testing.T) {
 	request := VMValueRequest{
 		ScAddress: DummyScAddress,
@@ -0,0 +1,554 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process/block/bootstrapStorage"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage/snapshot"
+	"github.com/urfave/cli"
+)
+
+var errReadOnlyDatabase = errors.New("the database is opened read only")
+
+// readOnlyTrieDB serves the trie nodes from the main trie database and from its snapshot databases
+type readOnlyTrieDB struct {
+	group *unitGroup
+}
+
+// Get returns the node from the first database holding it
+func (ro *readOnlyTrieDB) Get(key []byte) ([]byte, error) {
+	val, ok := ro.group.get(key)
+	if !ok {
+		return nil, fmt.Errorf("key %x not found in %s", key, ro.group.identifier)
+	}
+
+	return val, nil
+}
+
+// Put returns an error as the databases are not written
+func (ro *readOnlyTrieDB) Put(_, _ []byte) error {
+	return errReadOnlyDatabase
+}
+
+// Remove returns an error as the databases are not written
+func (ro *readOnlyTrieDB) Remove(_ []byte) error {
+	return errReadOnlyDatabase
+}
+
+// Close closes the underlying databases
+func (ro *readOnlyTrieDB) Close() error {
+	ro.group.close()
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ro *readOnlyTrieDB) IsInterfaceNil() bool {
+	return ro == nil
+}
+
+// snapshotExporter writes into an archive everything the storage bootstrapper needs in order to resume from a final
+// block: the bootstrap records, the headers they reference together with their nonce indexes, the body of the
+// starting block and the state tries at its root hashes
+type snapshotExporter struct {
+	cfg             *config.Config
+	marshalizer     marshal.Marshalizer
+	hasher          hashing.Hasher
+	uint64Converter typeConverters.Uint64ByteSliceConverter
+	layout          *dbLayout
+	shardID         uint32
+	groups          map[string]*unitGroup
+	writer          *snapshot.Writer
+	walkedDataTries map[string]struct{}
+}
+
+func exportSnapshot(ctx *cli.Context) error {
+	outputPath := ctx.String(output.Name)
+	if len(outputPath) == 0 {
+		return fmt.Errorf("the %s flag is mandatory", output.Name)
+	}
+	if ctx.Int(numHeaders.Name) < 1 {
+		return fmt.Errorf("the %s flag must be at least 1", numHeaders.Name)
+	}
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizer(cfg)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := getHasher(cfg)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	shard, err := parseShardID(layout.shard)
+	if err != nil {
+		return err
+	}
+
+	exporter := &snapshotExporter{
+		cfg:             cfg,
+		marshalizer:     marshalizer,
+		hasher:          hasher,
+		uint64Converter: uint64ByteSlice.NewBigEndianConverter(),
+		layout:          layout,
+		shardID:         shard,
+		groups:          make(map[string]*unitGroup),
+		walkedDataTries: make(map[string]struct{}),
+	}
+	defer exporter.close()
+
+	return exporter.export(outputPath, ctx.Int(numHeaders.Name))
+}
+
+func parseShardID(shard string) (uint32, error) {
+	if shard == core.GetShardIdString(sharding.MetachainShardId) {
+		return sharding.MetachainShardId, nil
+	}
+
+	shardID, err := strconv.ParseUint(shard, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid shard %s", shard)
+	}
+
+	return uint32(shardID), nil
+}
+
+// export selects the starting block and writes the archive in a temporary file, renamed once complete
+func (se *snapshotExporter) export(outputPath string, maxHeaders int) error {
+	rounds, records, err := se.selectBootstrapRecords(maxHeaders)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := se.createManifest(rounds[0], records[rounds[0]])
+	if err != nil {
+		return err
+	}
+	manifest.NumHeaders = uint32(len(rounds))
+
+	tmpPath := outputPath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	se.writer, err = snapshot.NewWriter(file, manifest, se.marshalizer)
+	if err != nil {
+		return err
+	}
+
+	err = se.writeArchive(rounds, records, manifest)
+	if err != nil {
+		return err
+	}
+
+	err = se.writer.Close()
+	if err != nil {
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpPath, outputPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exported round %d, nonce %d, %d headers and %d pairs to %s\n",
+		manifest.Round, manifest.Nonce, manifest.NumHeaders, se.writer.NumPairs(), outputPath)
+
+	return nil
+}
+
+// selectBootstrapRecords starts from the highest saved round and follows the links between the bootstrap records
+// until it reaches a final block. That block's record and the ones preceding it are returned, at least as many as
+// the storage bootstrapper reads and at most the requested number if that is higher
+func (se *snapshotExporter) selectBootstrapRecords(maxHeaders int) ([]int64, map[int64]*bootstrapStorage.BootstrapData, error) {
+	round, err := se.getHighestRound()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	highest, err := se.getBootstrapData(round)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current := highest
+	for current.LastHeader.Nonce > highest.HighestFinalBlockNonce {
+		if current.LastRound == 0 {
+			return nil, nil, errors.New("no final block found in the bootstrap records")
+		}
+
+		round = current.LastRound
+		current, err = se.getBootstrapData(round)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	lowestNeededNonce := core.MaxUint64(current.HighestFinalBlockNonce-1, 1)
+	rounds := []int64{round}
+	records := map[int64]*bootstrapStorage.BootstrapData{round: current}
+	for current.LastRound != 0 {
+		isNeeded := current.LastHeader.Nonce > lowestNeededNonce
+		if !isNeeded && len(rounds) >= maxHeaders {
+			break
+		}
+
+		previousRound := current.LastRound
+		previous, errGet := se.getBootstrapData(previousRound)
+		if errGet != nil {
+			if isNeeded {
+				return nil, nil, errGet
+			}
+			break
+		}
+
+		rounds = append(rounds, previousRound)
+		records[previousRound] = previous
+		current = previous
+	}
+
+	return rounds, records, nil
+}
+
+func (se *snapshotExporter) getHighestRound() (int64, error) {
+	roundBytes, err := se.get(se.cfg.BootstrapStorage.DB.FilePath, []byte(bootstrapStorage.HighestRoundFromBootStorage))
+	if err != nil {
+		return 0, err
+	}
+
+	var round int64
+	err = se.marshalizer.Unmarshal(&round, roundBytes)
+	if err != nil {
+		return 0, err
+	}
+	if round == 0 {
+		return 0, errors.New("no block was saved in the bootstrap storage")
+	}
+
+	return round, nil
+}
+
+func (se *snapshotExporter) getBootstrapData(round int64) (*bootstrapStorage.BootstrapData, error) {
+	buff, err := se.get(se.cfg.BootstrapStorage.DB.FilePath, []byte(strconv.FormatInt(round, 10)))
+	if err != nil {
+		return nil, err
+	}
+
+	bootData := &bootstrapStorage.BootstrapData{}
+	err = se.marshalizer.Unmarshal(bootData, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return bootData, nil
+}
+
+func (se *snapshotExporter) createManifest(round int64, bootData *bootstrapStorage.BootstrapData) (*snapshot.Manifest, error) {
+	header, err := se.getHeader(bootData.LastHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot.Manifest{
+		ShardID:                se.shardID,
+		Round:                  round,
+		Nonce:                  header.GetNonce(),
+		HeaderHash:             bootData.LastHeader.Hash,
+		RootHash:               header.GetRootHash(),
+		ValidatorStatsRootHash: header.GetValidatorStatsRootHash(),
+	}, nil
+}
+
+// writeArchive writes the bootstrap records last, and the highest round after them, so that an interrupted import
+// leaves the node without a starting block instead of with a partially imported one
+func (se *snapshotExporter) writeArchive(
+	rounds []int64,
+	records map[int64]*bootstrapStorage.BootstrapData,
+	manifest *snapshot.Manifest,
+) error {
+	for _, round := range rounds {
+		err := se.exportHeaders(records[round])
+		if err != nil {
+			return err
+		}
+	}
+
+	err := se.exportBody(records[rounds[0]].LastHeader)
+	if err != nil {
+		return err
+	}
+
+	err = se.exportTrie(se.cfg.AccountsTrieStorage.DB.FilePath, manifest.RootHash, true)
+	if err != nil {
+		return err
+	}
+
+	if se.shardID == sharding.MetachainShardId {
+		err = se.exportTrie(se.cfg.PeerAccountsTrieStorage.DB.FilePath, manifest.ValidatorStatsRootHash, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	bootstrapUnit := se.cfg.BootstrapStorage.DB.FilePath
+	for i := len(rounds) - 1; i >= 0; i-- {
+		key := []byte(strconv.FormatInt(rounds[i], 10))
+		err = se.copyPair(bootstrapUnit, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	roundBytes, err := se.marshalizer.Marshal(&rounds[0])
+	if err != nil {
+		return err
+	}
+
+	return se.writer.Put(bootstrapUnit, []byte(bootstrapStorage.HighestRoundFromBootStorage), roundBytes)
+}
+
+// exportHeaders writes the last header of the record together with its notarized headers, and their nonce indexes
+func (se *snapshotExporter) exportHeaders(bootData *bootstrapStorage.BootstrapData) error {
+	headers := []bootstrapStorage.BootstrapHeaderInfo{bootData.LastHeader}
+	headers = append(headers, bootData.LastCrossNotarizedHeaders...)
+	headers = append(headers, bootData.LastSelfNotarizedHeaders...)
+
+	for _, headerInfo := range headers {
+		if headerInfo.Nonce == 0 {
+			// the genesis blocks are created by the node itself
+			continue
+		}
+
+		headersUnit, nonceUnit := se.getHeaderUnits(headerInfo.ShardId)
+		err := se.copyPair(headersUnit, headerInfo.Hash)
+		if err != nil {
+			return err
+		}
+
+		err = se.writer.Put(nonceUnit, se.uint64Converter.ToByteSlice(headerInfo.Nonce), headerInfo.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (se *snapshotExporter) getHeaderUnits(shardID uint32) (string, string) {
+	if shardID == sharding.MetachainShardId {
+		return se.cfg.MetaBlockStorage.DB.FilePath, se.cfg.MetaHdrNonceHashStorage.DB.FilePath
+	}
+
+	return se.cfg.BlockHeaderStorage.DB.FilePath, se.cfg.ShardHdrNonceHashStorage.DB.FilePath + fmt.Sprintf("%d", shardID)
+}
+
+func (se *snapshotExporter) getHeader(headerInfo bootstrapStorage.BootstrapHeaderInfo) (data.HeaderHandler, error) {
+	headersUnit, _ := se.getHeaderUnits(headerInfo.ShardId)
+	buff, err := se.get(headersUnit, headerInfo.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var header data.HeaderHandler = &block.Header{}
+	if headerInfo.ShardId == sharding.MetachainShardId {
+		header = &block.MetaBlock{}
+	}
+
+	err = se.marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// exportBody writes the miniblocks of the starting block, as the metablocks bodies are not loaded from storage
+func (se *snapshotExporter) exportBody(headerInfo bootstrapStorage.BootstrapHeaderInfo) error {
+	if headerInfo.ShardId == sharding.MetachainShardId {
+		return nil
+	}
+
+	headerHandler, err := se.getHeader(headerInfo)
+	if err != nil {
+		return err
+	}
+
+	header, ok := headerHandler.(*block.Header)
+	if !ok {
+		return errors.New("wrong type assertion for the shard header")
+	}
+
+	for _, miniBlockHeader := range header.MiniBlockHeaders {
+		err = se.copyPair(se.cfg.MiniBlocksStorage.DB.FilePath, miniBlockHeader.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportTrie writes all the nodes of the trie having the given root hash, read from the trie's main database or
+// from its snapshot databases. The data tries of the accounts are written as well, if requested
+func (se *snapshotExporter) exportTrie(trieUnit string, rootHash []byte, withDataTries bool) error {
+	db, err := se.openTrieDB(trieUnit)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return se.walkTrie(trieUnit, db, rootHash, withDataTries)
+}
+
+func (se *snapshotExporter) walkTrie(trieUnit string, db data.DBWriteCacher, rootHash []byte, withDataTries bool) error {
+	dataTriesRoots := make([][]byte, 0)
+	err := trie.WalkNodes(rootHash, db, se.marshalizer, se.hasher, func(hash []byte, encNode []byte, leafValue []byte) error {
+		if withDataTries && leafValue != nil {
+			account := &state.Account{}
+			errUnmarshal := se.marshalizer.Unmarshal(account, leafValue)
+			if errUnmarshal != nil {
+				return errUnmarshal
+			}
+			if len(account.RootHash) > 0 {
+				dataTriesRoots = append(dataTriesRoots, account.RootHash)
+			}
+		}
+
+		return se.writer.Put(trieUnit, hash, encNode)
+	})
+	if err != nil {
+		return fmt.Errorf("%s while exporting %s", err.Error(), trieUnit)
+	}
+
+	for _, dataTrieRoot := range dataTriesRoots {
+		_, walked := se.walkedDataTries[string(dataTrieRoot)]
+		if walked {
+			continue
+		}
+		se.walkedDataTries[string(dataTrieRoot)] = struct{}{}
+
+		err = se.walkTrie(trieUnit, db, dataTrieRoot, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openTrieDB opens the trie's main database followed by the snapshot databases found next to it
+func (se *snapshotExporter) openTrieDB(trieUnit string) (*readOnlyTrieDB, error) {
+	mainUnit, err := se.layout.staticUnit(trieUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := se.layout.allUnits()
+	if err != nil {
+		return nil, err
+	}
+
+	trieUnits := []*unitInfo{mainUnit}
+	snapshotsPrefix := path.Join(path.Dir(trieUnit), se.cfg.TrieSnapshotDB.FilePath) + "/"
+	for _, unit := range units {
+		if unit.epoch == staticEpochName && strings.HasPrefix(unit.identifier, snapshotsPrefix) {
+			trieUnits = append(trieUnits, unit)
+		}
+	}
+
+	group, err := openUnitGroup(trieUnits, trieUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyTrieDB{group: group}, nil
+}
+
+// copyPair writes into the archive the value found under the key in the given epoch unit
+func (se *snapshotExporter) copyPair(unit string, key []byte) error {
+	val, err := se.get(unit, key)
+	if err != nil {
+		return err
+	}
+
+	return se.writer.Put(unit, key, val)
+}
+
+func (se *snapshotExporter) get(unit string, key []byte) ([]byte, error) {
+	group, err := se.getGroup(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok := group.get(key)
+	if !ok {
+		return nil, fmt.Errorf("key %x not found in %s", key, unit)
+	}
+
+	return val, nil
+}
+
+// getGroup opens the unit from all the selected epochs the first time it is used
+func (se *snapshotExporter) getGroup(unit string) (*unitGroup, error) {
+	group, ok := se.groups[unit]
+	if ok {
+		return group, nil
+	}
+
+	units := se.layout.epochUnits(unit)
+	if len(units) == 0 {
+		return nil, fmt.Errorf("unit %s not found", unit)
+	}
+
+	group, err := openUnitGroup(units, unit)
+	if err != nil {
+		return nil, err
+	}
+	se.groups[unit] = group
+
+	return group, nil
+}
+
+func (se *snapshotExporter) close() {
+	for _, group := range se.groups {
+		group.close()
+	}
+}
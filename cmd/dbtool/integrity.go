@@ -0,0 +1,428 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/urfave/cli"
+)
+
+// unitGroup gathers the persisters of a unit from all the selected epochs, as the referenced data can be stored in
+// a different epoch than the data referencing it
+type unitGroup struct {
+	identifier string
+	persisters []storage.Persister
+}
+
+func openUnitGroup(units []*unitInfo, identifier string) (*unitGroup, error) {
+	group := &unitGroup{
+		identifier: identifier,
+		persisters: make([]storage.Persister, 0, len(units)),
+	}
+	for _, unit := range units {
+		db, err := openUnit(unit)
+		if err != nil {
+			group.close()
+			return nil, fmt.Errorf("%s while opening %s", err.Error(), unit.path)
+		}
+		group.persisters = append(group.persisters, db)
+	}
+
+	return group, nil
+}
+
+func (ug *unitGroup) get(key []byte) ([]byte, bool) {
+	for _, db := range ug.persisters {
+		val, err := db.Get(key)
+		if err == nil {
+			return val, true
+		}
+	}
+
+	return nil, false
+}
+
+func (ug *unitGroup) has(key []byte) bool {
+	for _, db := range ug.persisters {
+		if db.Has(key) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ug *unitGroup) iterate(handler func(key []byte, val []byte) bool) error {
+	for _, db := range ug.persisters {
+		err := db.Iterate(nil, handler)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ug *unitGroup) close() {
+	for _, db := range ug.persisters {
+		_ = db.Close()
+	}
+}
+
+type missingItem struct {
+	unit         string
+	key          []byte
+	referencedBy string
+}
+
+// blocksChecker follows the references stored in the headers: headers to miniblocks, miniblocks to transactions,
+// metablocks to shard headers and nonce indexes to headers
+type blocksChecker struct {
+	marshalizer    marshal.Marshalizer
+	groups         map[string]*unitGroup
+	headersUnit    string
+	metaBlocksUnit string
+	miniBlocksUnit string
+	txUnits        map[block.Type]string
+	nonceUnits     map[string]string
+
+	numChecked   map[string]uint64
+	numCorrupted uint64
+	missing      []missingItem
+}
+
+func checkBlocks(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizer(cfg)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	checker := newBlocksChecker(cfg, marshalizer)
+	defer checker.close()
+
+	err = checker.openGroups(layout)
+	if err != nil {
+		return err
+	}
+
+	isMetachain := layout.shard == core.GetShardIdString(sharding.MetachainShardId)
+	if isMetachain {
+		err = checker.checkMetaBlocks()
+	} else {
+		err = checker.checkShardBlocks()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = checker.checkNonceIndexes()
+	if err != nil {
+		return err
+	}
+
+	checker.printReport(ctx.Int(maxReported.Name))
+	if len(checker.missing) > 0 || checker.numCorrupted > 0 {
+		return errIntegrityCheckFailed
+	}
+
+	return nil
+}
+
+func newBlocksChecker(cfg *config.Config, marshalizer marshal.Marshalizer) *blocksChecker {
+	return &blocksChecker{
+		marshalizer:    marshalizer,
+		groups:         make(map[string]*unitGroup),
+		headersUnit:    cfg.BlockHeaderStorage.DB.FilePath,
+		metaBlocksUnit: cfg.MetaBlockStorage.DB.FilePath,
+		miniBlocksUnit: cfg.MiniBlocksStorage.DB.FilePath,
+		txUnits: map[block.Type]string{
+			block.TxBlock:                  cfg.TxStorage.DB.FilePath,
+			block.SmartContractResultBlock: cfg.UnsignedTransactionStorage.DB.FilePath,
+			block.RewardsBlock:             cfg.RewardTxStorage.DB.FilePath,
+		},
+		nonceUnits: map[string]string{
+			cfg.ShardHdrNonceHashStorage.DB.FilePath: cfg.BlockHeaderStorage.DB.FilePath,
+			cfg.MetaHdrNonceHashStorage.DB.FilePath:  cfg.MetaBlockStorage.DB.FilePath,
+		},
+		numChecked: make(map[string]uint64),
+		missing:    make([]missingItem, 0),
+	}
+}
+
+// openGroups opens all the units of the selected epochs. The sharded nonce units have the shard appended to their
+// identifier so they are matched by prefix
+func (bc *blocksChecker) openGroups(layout *dbLayout) error {
+	units, err := layout.allUnits()
+	if err != nil {
+		return err
+	}
+
+	unitsByIdentifier := make(map[string][]*unitInfo)
+	for _, unit := range units {
+		if unit.epoch == staticEpochName {
+			continue
+		}
+		unitsByIdentifier[unit.identifier] = append(unitsByIdentifier[unit.identifier], unit)
+	}
+
+	for identifier, identifierUnits := range unitsByIdentifier {
+		group, errOpen := openUnitGroup(identifierUnits, identifier)
+		if errOpen != nil {
+			return errOpen
+		}
+		bc.groups[identifier] = group
+	}
+
+	return nil
+}
+
+func (bc *blocksChecker) checkShardBlocks() error {
+	headers, ok := bc.groups[bc.headersUnit]
+	if !ok {
+		return fmt.Errorf("unit %s not found", bc.headersUnit)
+	}
+
+	return headers.iterate(func(key []byte, val []byte) bool {
+		header := &block.Header{}
+		if !bc.unmarshal(header, val, bc.headersUnit) {
+			return true
+		}
+
+		referencedBy := fmt.Sprintf("header %s (nonce %d)", hex.EncodeToString(key), header.Nonce)
+		for _, mbHeader := range header.MiniBlockHeaders {
+			bc.checkMiniBlock(mbHeader.Hash, referencedBy)
+		}
+
+		return true
+	})
+}
+
+func (bc *blocksChecker) checkMetaBlocks() error {
+	metaBlocks, ok := bc.groups[bc.metaBlocksUnit]
+	if !ok {
+		return fmt.Errorf("unit %s not found", bc.metaBlocksUnit)
+	}
+
+	return metaBlocks.iterate(func(key []byte, val []byte) bool {
+		metaBlock := &block.MetaBlock{}
+		if !bc.unmarshal(metaBlock, val, bc.metaBlocksUnit) {
+			return true
+		}
+
+		referencedBy := fmt.Sprintf("metablock %s (nonce %d)", hex.EncodeToString(key), metaBlock.Nonce)
+		for _, mbHeader := range metaBlock.MiniBlockHeaders {
+			bc.checkMiniBlock(mbHeader.Hash, referencedBy)
+		}
+		for _, shardData := range metaBlock.ShardInfo {
+			bc.checkPresent(bc.headersUnit, shardData.HeaderHash, referencedBy)
+		}
+
+		return true
+	})
+}
+
+func (bc *blocksChecker) checkMiniBlock(hash []byte, referencedBy string) {
+	buff, ok := bc.getPresent(bc.miniBlocksUnit, hash, referencedBy)
+	if !ok {
+		return
+	}
+
+	miniBlock := &block.MiniBlock{}
+	if !bc.unmarshal(miniBlock, buff, bc.miniBlocksUnit) {
+		return
+	}
+
+	txUnit, ok := bc.txUnits[miniBlock.Type]
+	if !ok {
+		return
+	}
+
+	mbReference := fmt.Sprintf("miniblock %s of %s", hex.EncodeToString(hash), referencedBy)
+	for _, txHash := range miniBlock.TxHashes {
+		bc.checkPresent(txUnit, txHash, mbReference)
+	}
+}
+
+func (bc *blocksChecker) checkNonceIndexes() error {
+	for identifier, group := range bc.groups {
+		headersUnit := ""
+		for noncePrefix, unit := range bc.nonceUnits {
+			if strings.HasPrefix(identifier, noncePrefix) {
+				headersUnit = unit
+			}
+		}
+		if len(headersUnit) == 0 {
+			continue
+		}
+
+		err := group.iterate(func(key []byte, val []byte) bool {
+			bc.checkPresent(headersUnit, val, fmt.Sprintf("%s entry %s", identifier, hex.EncodeToString(key)))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bc *blocksChecker) getPresent(unit string, key []byte, referencedBy string) ([]byte, bool) {
+	bc.numChecked[unit]++
+
+	group, ok := bc.groups[unit]
+	if ok {
+		val, found := group.get(key)
+		if found {
+			return val, true
+		}
+	}
+
+	bc.missing = append(bc.missing, missingItem{unit: unit, key: key, referencedBy: referencedBy})
+
+	return nil, false
+}
+
+func (bc *blocksChecker) checkPresent(unit string, key []byte, referencedBy string) {
+	bc.numChecked[unit]++
+
+	group, ok := bc.groups[unit]
+	if ok && group.has(key) {
+		return
+	}
+
+	bc.missing = append(bc.missing, missingItem{unit: unit, key: key, referencedBy: referencedBy})
+}
+
+func (bc *blocksChecker) unmarshal(obj interface{}, buff []byte, unit string) bool {
+	err := bc.marshalizer.Unmarshal(obj, buff)
+	if err != nil {
+		bc.numCorrupted++
+		fmt.Printf("%s: can not unmarshal stored data: %s\n", unit, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (bc *blocksChecker) printReport(maxItems int) {
+	for i, item := range bc.missing {
+		if i >= maxItems {
+			fmt.Printf("... and %d more missing items\n", len(bc.missing)-maxItems)
+			break
+		}
+		fmt.Printf("missing from %s: %s, referenced by %s\n", item.unit, hex.EncodeToString(item.key), item.referencedBy)
+	}
+
+	numMissing := make(map[string]uint64)
+	for _, item := range bc.missing {
+		numMissing[item.unit]++
+	}
+
+	units := make([]string, 0, len(bc.numChecked))
+	for unit := range bc.numChecked {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	for _, unit := range units {
+		fmt.Printf("%s: %d references checked, %d missing\n", unit, bc.numChecked[unit], numMissing[unit])
+	}
+	fmt.Printf("%d stored items could not be unmarshaled\n", bc.numCorrupted)
+}
+
+func (bc *blocksChecker) close() {
+	for _, group := range bc.groups {
+		group.close()
+	}
+}
+
+func checkTrie(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizer(cfg)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := getHasher(cfg)
+	if err != nil {
+		return err
+	}
+
+	root, err := decodeRootHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	identifier := ctx.String(trieIdentifier.Name)
+	if len(identifier) == 0 {
+		identifier = cfg.AccountsTrieStorage.DB.FilePath
+	}
+
+	unit, err := layout.staticUnit(identifier)
+	if err != nil {
+		return err
+	}
+
+	db, err := openUnit(unit)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	report, err := trie.CheckReachability(root, db, marshalizer, hasher)
+	if err != nil {
+		return err
+	}
+
+	printHashes("missing node", report.MissingHashes, ctx.Int(maxReported.Name))
+	printHashes("corrupted node", report.CorruptedHashes, ctx.Int(maxReported.Name))
+	fmt.Printf("%d nodes and %d leaves reached, %d nodes missing, %d nodes corrupted\n",
+		report.NumNodes, report.NumLeaves, len(report.MissingHashes), len(report.CorruptedHashes))
+
+	if !report.IsComplete() {
+		return errIntegrityCheckFailed
+	}
+
+	return nil
+}
+
+func printHashes(description string, hashes [][]byte, maxItems int) {
+	for i, hash := range hashes {
+		if i >= maxItems {
+			fmt.Printf("... and %d more\n", len(hashes)-maxItems)
+			return
+		}
+		fmt.Printf("%s: %s\n", description, hex.EncodeToString(hash))
+	}
+}
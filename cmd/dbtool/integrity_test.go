@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBlocksCheckerConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.BlockHeaderStorage.DB.FilePath = "BlockHeaders"
+	cfg.MetaBlockStorage.DB.FilePath = "MetaBlock"
+	cfg.MiniBlocksStorage.DB.FilePath = "MiniBlocks"
+	cfg.TxStorage.DB.FilePath = "Transactions"
+	cfg.UnsignedTransactionStorage.DB.FilePath = "UnsignedTransactions"
+	cfg.RewardTxStorage.DB.FilePath = "RewardTransactions"
+	cfg.ShardHdrNonceHashStorage.DB.FilePath = "ShardHdrHashNonce"
+	cfg.MetaHdrNonceHashStorage.DB.FilePath = "MetaHdrHashNonce"
+
+	return cfg
+}
+
+func marshalObject(t *testing.T, marshalizer marshal.Marshalizer, obj interface{}) []byte {
+	buff, err := marshalizer.Marshal(obj)
+	require.Nil(t, err)
+
+	return buff
+}
+
+func TestBlocksChecker_ShouldReportTheMissingReferencesAcrossEpochs(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createTestDBDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	marshalizer := &marshal.JsonMarshalizer{}
+	header := &block.Header{
+		Nonce: 1,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: []byte("mb1")},
+			{Hash: []byte("mb2")},
+		},
+	}
+	miniBlock := &block.MiniBlock{
+		Type:     block.TxBlock,
+		TxHashes: [][]byte{[]byte("tx1"), []byte("tx2")},
+	}
+
+	// the header of epoch 1 references a miniblock and a transaction saved in epoch 0
+	epoch0 := filepath.Join(dbPath, "Epoch_0", "Shard_0")
+	createUnit(t, filepath.Join(epoch0, "MiniBlocks"), storageUnit.LvlDB, map[string][]byte{
+		"mb1": marshalObject(t, marshalizer, miniBlock),
+	})
+	createUnit(t, filepath.Join(epoch0, "Transactions"), storageUnit.LvlDB, map[string][]byte{
+		"tx1": []byte("tx"),
+	})
+	epoch1 := filepath.Join(dbPath, "Epoch_1", "Shard_0")
+	createUnit(t, filepath.Join(epoch1, "BlockHeaders"), storageUnit.LvlDB, map[string][]byte{
+		"hdr1":      marshalObject(t, marshalizer, header),
+		"corrupted": []byte("not a header"),
+	})
+	createUnit(t, filepath.Join(epoch1, "ShardHdrHashNonce0"), storageUnit.LvlDB, map[string][]byte{
+		"1": []byte("hdr1"),
+		"2": []byte("hdr2"),
+	})
+
+	layout, err := newDBLayout(dbPath, "0", []uint32{0, 1}, nil)
+	require.Nil(t, err)
+
+	checker := newBlocksChecker(createBlocksCheckerConfig(), marshalizer)
+	defer checker.close()
+
+	require.Nil(t, checker.openGroups(layout))
+	require.Nil(t, checker.checkShardBlocks())
+	require.Nil(t, checker.checkNonceIndexes())
+
+	missing := make([]string, 0, len(checker.missing))
+	for _, item := range checker.missing {
+		missing = append(missing, item.unit+" "+string(item.key))
+	}
+	sort.Strings(missing)
+
+	assert.Equal(t, []string{"BlockHeaders hdr2", "MiniBlocks mb2", "Transactions tx2"}, missing)
+	assert.Equal(t, uint64(1), checker.numCorrupted)
+	assert.Equal(t, uint64(2), checker.numChecked["MiniBlocks"])
+	assert.Equal(t, uint64(2), checker.numChecked["Transactions"])
+	assert.Equal(t, uint64(2), checker.numChecked["BlockHeaders"])
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/boltdb"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
+	"github.com/ElrondNetwork/elrond-go/storage/pathmanager"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+)
+
+// the directory names must match the ones used by the node when creating its path manager
+const (
+	defaultEpochString    = "Epoch"
+	defaultStaticDbString = "Static"
+	defaultShardString    = "Shard"
+)
+
+// the files identifying the directory of an on-disk database
+const (
+	levelDBMarkerFile = "CURRENT"
+	boltDBMarkerFile  = "data.db"
+)
+
+const staticEpochName = "static"
+
+// unitInfo describes a database found in an epoch or in the static directory
+type unitInfo struct {
+	epoch         string
+	identifier    string
+	path          string
+	dbType        storageUnit.DBType
+	encryptionKey []byte
+}
+
+// dbLayout locates the units of a shard using the same path manager the node uses to create them
+type dbLayout struct {
+	pathManager   *pathmanager.PathManager
+	shard         string
+	epochs        []uint32
+	encryptionKey []byte
+}
+
+// newDBLayout creates the layout of the provided database directory. The units are opened with the provided storage
+// encryption key, which is verified against the directory's encryption marker
+func newDBLayout(dbPath string, shard string, epochs []uint32, encryptionKey []byte) (*dbLayout, error) {
+	_, err := encryption.VerifyMarker(dbPath, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pruningPathTemplate := filepath.Join(
+		dbPath,
+		fmt.Sprintf("%s_%s", defaultEpochString, core.PathEpochPlaceholder),
+		fmt.Sprintf("%s_%s", defaultShardString, core.PathShardPlaceholder),
+		core.PathIdentifierPlaceholder)
+
+	staticPathTemplate := filepath.Join(
+		dbPath,
+		defaultStaticDbString,
+		fmt.Sprintf("%s_%s", defaultShardString, core.PathShardPlaceholder),
+		core.PathIdentifierPlaceholder)
+
+	pathManager, err := pathmanager.NewPathManager(pruningPathTemplate, staticPathTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbLayout{
+		pathManager:   pathManager,
+		shard:         shard,
+		epochs:        epochs,
+		encryptionKey: encryptionKey,
+	}, nil
+}
+
+// findEpochs returns, in ascending order, the epochs having a directory in the provided database directory
+func findEpochs(dbPath string) ([]uint32, error) {
+	entries, err := ioutil.ReadDir(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	epochs := make([]uint32, 0)
+	prefix := defaultEpochString + "_"
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		epoch, errParse := strconv.ParseUint(strings.TrimPrefix(entry.Name(), prefix), 10, 32)
+		if errParse != nil {
+			continue
+		}
+		epochs = append(epochs, uint32(epoch))
+	}
+
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+
+	return epochs, nil
+}
+
+// allUnits returns the units of all the selected epochs followed by the static units
+func (dl *dbLayout) allUnits() ([]*unitInfo, error) {
+	units := make([]*unitInfo, 0)
+	for _, epoch := range dl.epochs {
+		epochUnits, err := findUnits(dl.pathManager.PathForEpoch(dl.shard, epoch, ""), fmt.Sprintf("%d", epoch))
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, epochUnits...)
+	}
+
+	staticUnits, err := findUnits(dl.pathManager.PathForStatic(dl.shard, ""), staticEpochName)
+	if err != nil {
+		return nil, err
+	}
+
+	units = append(units, staticUnits...)
+	for _, unit := range units {
+		unit.encryptionKey = dl.encryptionKey
+	}
+
+	return units, nil
+}
+
+// epochUnits returns the unit having the given identifier from every selected epoch holding it
+func (dl *dbLayout) epochUnits(identifier string) []*unitInfo {
+	units := make([]*unitInfo, 0)
+	for _, epoch := range dl.epochs {
+		path := dl.pathManager.PathForEpoch(dl.shard, epoch, identifier)
+		dbType, ok := detectDBType(path)
+		if !ok {
+			continue
+		}
+
+		units = append(units, &unitInfo{
+			epoch:         fmt.Sprintf("%d", epoch),
+			identifier:    identifier,
+			path:          path,
+			dbType:        dbType,
+			encryptionKey: dl.encryptionKey,
+		})
+	}
+
+	return units
+}
+
+// staticUnit returns the static unit having the given identifier
+func (dl *dbLayout) staticUnit(identifier string) (*unitInfo, error) {
+	path := dl.pathManager.PathForStatic(dl.shard, identifier)
+	dbType, ok := detectDBType(path)
+	if !ok {
+		return nil, fmt.Errorf("no database found in %s", path)
+	}
+
+	return &unitInfo{
+		epoch:         staticEpochName,
+		identifier:    identifier,
+		path:          path,
+		dbType:        dbType,
+		encryptionKey: dl.encryptionKey,
+	}, nil
+}
+
+// findUnits walks the given directory and returns every database found inside it. A missing directory holds no unit
+func findUnits(root string, epoch string) ([]*unitInfo, error) {
+	units := make([]*unitInfo, 0)
+	_, err := os.Stat(root)
+	if os.IsNotExist(err) {
+		return units, nil
+	}
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		dbType, ok := detectDBType(path)
+		if !ok {
+			return nil
+		}
+
+		identifier, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		units = append(units, &unitInfo{
+			epoch:      epoch,
+			identifier: filepath.ToSlash(identifier),
+			path:       path,
+			dbType:     dbType,
+		})
+
+		return filepath.SkipDir
+	})
+
+	return units, err
+}
+
+func detectDBType(path string) (storageUnit.DBType, bool) {
+	if fileExists(filepath.Join(path, levelDBMarkerFile)) {
+		return storageUnit.LvlDB, true
+	}
+	if fileExists(filepath.Join(path, boltDBMarkerFile)) {
+		return storageUnit.BoltDB, true
+	}
+
+	return "", false
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return !info.IsDir()
+}
+
+// maxOpenFiles is the number of files a unit opened by the tool keeps open
+const maxOpenFiles = 10
+
+// openUnit opens the unit's database in read-only mode, so the inspecting commands never write in it. The values are
+// decrypted, as the node's persister factory does, if the storage encryption is enabled
+func openUnit(unit *unitInfo) (storage.Persister, error) {
+	var db storage.Persister
+	var err error
+	switch unit.dbType {
+	case storageUnit.LvlDB, storageUnit.LvlDbSerial:
+		db, err = leveldb.NewReadOnlyDB(unit.path, maxOpenFiles)
+	case storageUnit.BoltDB:
+		db, err = boltdb.NewReadOnlyDB(unit.path)
+	default:
+		return nil, storage.ErrNotSupportedDBType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapIfEncrypted(db, unit.encryptionKey)
+}
+
+// openUnitForWriting opens the unit's database in read-write mode. Only the compact command uses it
+func openUnitForWriting(unit *unitInfo) (storage.Persister, error) {
+	db, err := storageUnit.NewDB(unit.dbType, unit.path, 1, 1, maxOpenFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapIfEncrypted(db, unit.encryptionKey)
+}
+
+func wrapIfEncrypted(db storage.Persister, encryptionKey []byte) (storage.Persister, error) {
+	if len(encryptionKey) == 0 {
+		return db, nil
+	}
+
+	encryptedDB, err := encryption.NewEncryptedDB(db, encryptionKey)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return encryptedDB, nil
+}
+
+// diskSize returns the size of all the files in the unit's directory
+func diskSize(unit *unitInfo) (int64, error) {
+	size := int64(0)
+	err := filepath.Walk(unit.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createUnit(t *testing.T, path string, dbType storageUnit.DBType, pairs map[string][]byte) {
+	require.Nil(t, os.MkdirAll(path, os.ModePerm))
+	db, err := storageUnit.NewDB(dbType, path, 1, 1, maxOpenFiles)
+	require.Nil(t, err)
+
+	for key, val := range pairs {
+		require.Nil(t, db.Put([]byte(key), val))
+	}
+	require.Nil(t, db.Close())
+}
+
+func createTestDBDir(t *testing.T) string {
+	dbPath, err := ioutil.TempDir("", "dbtool")
+	require.Nil(t, err)
+
+	return dbPath
+}
+
+func TestFindEpochs_ShouldReturnTheEpochDirectoriesInOrder(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createTestDBDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	for _, dir := range []string{"Epoch_10", "Epoch_2", "Epoch_x", "Static"} {
+		require.Nil(t, os.MkdirAll(filepath.Join(dbPath, dir), os.ModePerm))
+	}
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dbPath, "Epoch_3"), []byte("not a directory"), 0600))
+
+	epochs, err := findEpochs(dbPath)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{2, 10}, epochs)
+}
+
+func TestDBLayout_AllUnitsShouldFindTheUnitsOfTheSelectedEpochsAndTheStaticUnits(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createTestDBDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	createUnit(t, filepath.Join(dbPath, "Epoch_0", "Shard_1", "BlockHeaders"), storageUnit.LvlDB, nil)
+	createUnit(t, filepath.Join(dbPath, "Epoch_1", "Shard_1", "AccountsTrie", "MainDB"), storageUnit.BoltDB, nil)
+	createUnit(t, filepath.Join(dbPath, "Epoch_2", "Shard_1", "BlockHeaders"), storageUnit.LvlDB, nil)
+	createUnit(t, filepath.Join(dbPath, "Epoch_1", "Shard_0", "BlockHeaders"), storageUnit.LvlDB, nil)
+	createUnit(t, filepath.Join(dbPath, "Static", "Shard_1", "ShardHdrHashNonce1"), storageUnit.LvlDB, nil)
+	require.Nil(t, os.MkdirAll(filepath.Join(dbPath, "Epoch_1", "Shard_1", "NotADatabase"), os.ModePerm))
+
+	layout, err := newDBLayout(dbPath, "1", []uint32{0, 1}, nil)
+	require.Nil(t, err)
+
+	units, err := layout.allUnits()
+	require.Nil(t, err)
+
+	found := make([]unitInfo, 0, len(units))
+	for _, unit := range units {
+		found = append(found, unitInfo{epoch: unit.epoch, identifier: unit.identifier, dbType: unit.dbType})
+	}
+	expected := []unitInfo{
+		{epoch: "0", identifier: "BlockHeaders", dbType: storageUnit.LvlDB},
+		{epoch: "1", identifier: "AccountsTrie/MainDB", dbType: storageUnit.BoltDB},
+		{epoch: staticEpochName, identifier: "ShardHdrHashNonce1", dbType: storageUnit.LvlDB},
+	}
+	assert.Equal(t, expected, found)
+
+	assert.Equal(t, 1, len(layout.epochUnits("BlockHeaders")))
+	_, err = layout.staticUnit("ShardHdrHashNonce1")
+	assert.Nil(t, err)
+	_, err = layout.staticUnit("MetaHdrHashNonce")
+	assert.NotNil(t, err)
+}
+
+func TestFindUnits_MissingDirectoryShouldHoldNoUnit(t *testing.T) {
+	t.Parallel()
+
+	units, err := findUnits(filepath.Join(os.TempDir(), "dbtool-missing-directory"), "0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(units))
+}
+
+func TestOpenUnit_ShouldOpenTheUnitReadOnly(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createTestDBDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	for _, dbType := range []storageUnit.DBType{storageUnit.LvlDB, storageUnit.BoltDB} {
+		path := filepath.Join(dbPath, string(dbType))
+		createUnit(t, path, dbType, map[string][]byte{"key": []byte("value")})
+
+		db, err := openUnit(&unitInfo{path: path, dbType: dbType})
+		require.Nil(t, err)
+
+		val, err := db.Get([]byte("key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), val)
+		assert.NotNil(t, db.Put([]byte("key2"), []byte("value")), string(dbType))
+
+		_ = db.Close()
+	}
+}
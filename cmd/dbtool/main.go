@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/hashing/sha256"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	storageFactory "github.com/ElrondNetwork/elrond-go/storage/factory"
+	"github.com/urfave/cli"
+)
+
+var (
+	dbToolHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} [global options] command [command options]
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+COMMANDS:
+   {{range .Commands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+   {{end}}{{end}}{{if .VisibleFlags}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// dbPath is the directory holding the epoch and static directories of a chain
+	dbPath = cli.StringFlag{
+		Name:  "db-path",
+		Usage: "The node's database directory for a chain, as in <working directory>/db/<chain ID>. The node must be stopped",
+	}
+	// configurationFile is the node's main configuration file, used for the unit identifiers, the marshalizer, the hasher
+	// and the storage encryption
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The node's main configuration file",
+		Value: "./config/config.toml",
+	}
+	// shardID selects the shard directories to be opened
+	shardID = cli.StringFlag{
+		Name:  "shard",
+		Usage: "The shard of the node which wrote the database: a shard number or \"metachain\"",
+		Value: "0",
+	}
+	// epoch restricts the commands to a single epoch
+	epoch = cli.IntFlag{
+		Name:  "epoch",
+		Usage: "Restricts the command to the given epoch. All the epochs are used if not set",
+		Value: -1,
+	}
+	// maxReported limits the number of missing items printed by the check commands
+	maxReported = cli.IntFlag{
+		Name:  "max-reported",
+		Usage: "The maximum number of missing items printed by the check commands",
+		Value: 100,
+	}
+	// rootHash is the hex encoded root hash of the trie to be checked
+	rootHash = cli.StringFlag{
+		Name:  "root-hash",
+		Usage: "The hex encoded root hash of the trie to be checked",
+	}
+	// trieIdentifier is the unit holding the trie nodes
+	trieIdentifier = cli.StringFlag{
+		Name:  "trie",
+		Usage: "The static unit holding the trie nodes. Defaults to the accounts trie storage from the configuration file",
+	}
+	// output is the file the snapshot archive is written to
+	output = cli.StringFlag{
+		Name:  "output",
+		Usage: "The file the snapshot archive is written to",
+	}
+	// numHeaders is the number of bootstrap records, with their headers, exported in the snapshot archive
+	numHeaders = cli.IntFlag{
+		Name:  "num-headers",
+		Usage: "The number of blocks exported in the snapshot archive, ending with the last final block. More are exported if the node needs them in order to bootstrap",
+		Value: 10,
+	}
+)
+
+var errIntegrityCheckFailed = errors.New("integrity check failed")
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = dbToolHelpTemplate
+	app.Name = "Database tool"
+	app.Version = "v0.0.1"
+	app.Usage = "This binary inspects, compacts, checks the integrity of and exports snapshots from a stopped node's databases"
+	app.Flags = []cli.Flag{dbPath, configurationFile, shardID, epoch}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Elrond Team",
+			Email: "contact@elrond.com",
+		},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "lists the units found in the epoch and static directories",
+			Action: listUnits,
+		},
+		{
+			Name:   "stats",
+			Usage:  "reports the number of keys, the size of the pairs and the size on disk of every unit",
+			Action: reportStats,
+		},
+		{
+			Name:   "compact",
+			Usage:  "compacts the LevelDB units",
+			Action: compactUnits,
+		},
+		{
+			Name:   "check-blocks",
+			Usage:  "checks that the miniblocks, transactions and headers referenced by the stored blocks are present",
+			Flags:  []cli.Flag{maxReported},
+			Action: checkBlocks,
+		},
+		{
+			Name:   "check-trie",
+			Usage:  "checks that all the trie nodes reachable from a root hash are present",
+			Flags:  []cli.Flag{rootHash, trieIdentifier, maxReported},
+			Action: checkTrie,
+		},
+		{
+			Name:   "export-snapshot",
+			Usage:  "exports the last final block, its state tries and the bootstrap data into an archive a new node can be started from",
+			Flags:  []cli.Flag{output, numHeaders},
+			Action: exportSnapshot,
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+// createLayout creates the layout of the database directory, whose units are opened with the storage encryption key
+// of the configuration file
+func createLayout(ctx *cli.Context, cfg *config.Config) (*dbLayout, error) {
+	path := ctx.GlobalString(dbPath.Name)
+	if len(path) == 0 {
+		return nil, fmt.Errorf("the %s flag is mandatory", dbPath.Name)
+	}
+
+	epochs, err := selectEpochs(path, ctx.GlobalInt(epoch.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKey, err := storageFactory.LoadEncryptionKey(cfg.StorageEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDBLayout(path, ctx.GlobalString(shardID.Name), epochs, encryptionKey)
+}
+
+func selectEpochs(path string, selectedEpoch int) ([]uint32, error) {
+	if selectedEpoch >= 0 {
+		return []uint32{uint32(selectedEpoch)}, nil
+	}
+
+	return findEpochs(path)
+}
+
+func loadConfig(ctx *cli.Context) (*config.Config, error) {
+	cfg := &config.Config{}
+	err := core.LoadTomlFile(cfg, ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func getMarshalizer(cfg *config.Config) (marshal.Marshalizer, error) {
+	switch cfg.Marshalizer.Type {
+	case "json":
+		return &marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("no marshalizer provided in config file")
+}
+
+func getHasher(cfg *config.Config) (hashing.Hasher, error) {
+	switch cfg.Hasher.Type {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("no hasher provided in config file")
+}
+
+func decodeRootHash(ctx *cli.Context) ([]byte, error) {
+	encoded := ctx.String(rootHash.Name)
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("the %s flag is mandatory", rootHash.Name)
+	}
+
+	return hex.DecodeString(encoded)
+}
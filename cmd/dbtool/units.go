@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+)
+
+// compacter is implemented by the persisters able to compact their data files
+type compacter interface {
+	Compact() error
+}
+
+func listUnits(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	units, err := layout.allUnits()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "EPOCH\tUNIT\tTYPE\tPATH")
+	for _, unit := range units {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", unit.epoch, unit.identifier, unit.dbType, unit.path)
+	}
+
+	return w.Flush()
+}
+
+func reportStats(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	units, err := layout.allUnits()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(w, "EPOCH\tUNIT\tKEYS\tKEYS SIZE\tVALUES SIZE\tDISK SIZE\t")
+	for _, unit := range units {
+		numKeys, keysSize, valuesSize, errCount := countPairs(unit)
+		if errCount != nil {
+			_, _ = fmt.Fprintf(w, "%s\t%s\terror: %s\t\t\t\t\n", unit.epoch, unit.identifier, errCount.Error())
+			continue
+		}
+
+		size, errSize := diskSize(unit)
+		if errSize != nil {
+			return errSize
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t\n", unit.epoch, unit.identifier, numKeys, keysSize, valuesSize, size)
+	}
+
+	return w.Flush()
+}
+
+func countPairs(unit *unitInfo) (uint64, uint64, uint64, error) {
+	db, err := openUnit(unit)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	numKeys, keysSize, valuesSize := uint64(0), uint64(0), uint64(0)
+	err = db.Iterate(nil, func(key []byte, val []byte) bool {
+		numKeys++
+		keysSize += uint64(len(key))
+		valuesSize += uint64(len(val))
+		return true
+	})
+
+	return numKeys, keysSize, valuesSize, err
+}
+
+func compactUnits(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	layout, err := createLayout(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	units, err := layout.allUnits()
+	if err != nil {
+		return err
+	}
+
+	for _, unit := range units {
+		sizeBefore, _ := diskSize(unit)
+
+		compacted, errCompact := compactUnit(unit)
+		if errCompact != nil {
+			fmt.Printf("%s %s: compaction failed: %s\n", unit.epoch, unit.identifier, errCompact.Error())
+			continue
+		}
+		if !compacted {
+			fmt.Printf("%s %s: skipped, %s units can not be compacted\n", unit.epoch, unit.identifier, unit.dbType)
+			continue
+		}
+
+		sizeAfter, _ := diskSize(unit)
+		fmt.Printf("%s %s: %d -> %d bytes\n", unit.epoch, unit.identifier, sizeBefore, sizeAfter)
+	}
+
+	return nil
+}
+
+func compactUnit(unit *unitInfo) (bool, error) {
+	db, err := openUnitForWriting(unit)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	c, ok := db.(compacter)
+	if !ok {
+		return false, nil
+	}
+
+	return true, c.Compact()
+}
@@ -230,13 +230,20 @@ func createTries(
 
 	trieContainer := state.NewDataTriesHolder()
 
+	encryptionKey, err := storageFactory.LoadEncryptionKey(args.config.StorageEncryption)
+	if err != nil {
+		return nil, err
+	}
+
 	trieFactoryArgs := factory.TrieFactoryArgs{
 		EvictionWaitingListCfg: args.config.EvictionWaitingList,
 		SnapshotDbCfg:          args.config.TrieSnapshotDB,
+		TrieNodesCacheCfg:      args.config.TrieNodesCache,
 		Marshalizer:            marshalizer,
 		Hasher:                 hasher,
 		PathManager:            args.pathManager,
 		ShardId:                args.shardId,
+		EncryptionKey:          encryptionKey,
 	}
 	trieFactory, err := factory.NewTrieFactory(trieFactoryArgs)
 	if err != nil {
@@ -346,6 +353,7 @@ type dataComponentsFactoryArgs struct {
 	shardCoordinator   sharding.Coordinator
 	core               *Core
 	pathManager        storage.PathManagerHandler
+	archivePathManager storage.PathManagerHandler
 	epochStartNotifier EpochStartNotifier
 	currentEpoch       uint32
 }
@@ -357,6 +365,7 @@ func NewDataComponentsFactoryArgs(
 	shardCoordinator sharding.Coordinator,
 	core *Core,
 	pathManager storage.PathManagerHandler,
+	archivePathManager storage.PathManagerHandler,
 	epochStartNotifier EpochStartNotifier,
 	currentEpoch uint32,
 ) *dataComponentsFactoryArgs {
@@ -366,6 +375,7 @@ func NewDataComponentsFactoryArgs(
 		shardCoordinator:   shardCoordinator,
 		core:               core,
 		pathManager:        pathManager,
+		archivePathManager: archivePathManager,
 		epochStartNotifier: epochStartNotifier,
 		currentEpoch:       currentEpoch,
 	}
@@ -383,6 +393,7 @@ func DataComponentsFactory(args *dataComponentsFactoryArgs) (*Data, error) {
 		args.config,
 		args.shardCoordinator,
 		args.pathManager,
+		args.archivePathManager,
 		args.epochStartNotifier,
 		args.currentEpoch,
 	)
@@ -974,10 +985,7 @@ func getMarshalizerFromConfig(cfg *config.Config) (marshal.Marshalizer, error) {
 }
 
 func createBlockChainFromConfig(config *config.Config, coordinator sharding.Coordinator, ash core.AppStatusHandler) (data.ChainHandler, error) {
-	badBlockCache, err := storageUnit.NewCache(
-		storageUnit.CacheType(config.BadBlocksCache.Type),
-		config.BadBlocksCache.Size,
-		config.BadBlocksCache.Shards)
+	badBlockCache, err := storageUnit.NewCacheFromConfig(storageFactory.GetCacherFromConfig(config.BadBlocksCache))
 	if err != nil {
 		return nil, err
 	}
@@ -1021,6 +1029,7 @@ func createDataStoreFromConfig(
 	config *config.Config,
 	shardCoordinator sharding.Coordinator,
 	pathManager storage.PathManagerHandler,
+	archivePathManager storage.PathManagerHandler,
 	epochStartNotifier EpochStartNotifier,
 	currentEpoch uint32,
 ) (dataRetriever.StorageService, error) {
@@ -1028,6 +1037,7 @@ func createDataStoreFromConfig(
 		config,
 		shardCoordinator,
 		pathManager,
+		archivePathManager,
 		epochStartNotifier,
 		currentEpoch,
 	)
@@ -1077,21 +1087,21 @@ func createDataPoolFromConfig(args *dataComponentsFactoryArgs) (dataRetriever.Po
 	}
 
 	cacherCfg := storageFactory.GetCacherFromConfig(config.TxBlockBodyDataPool)
-	txBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	txBlockBody, err := storageUnit.NewCacheFromConfig(cacherCfg)
 	if err != nil {
 		log.Error("error creating txBlockBody")
 		return nil, err
 	}
 
 	cacherCfg = storageFactory.GetCacherFromConfig(config.PeerBlockBodyDataPool)
-	peerChangeBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	peerChangeBlockBody, err := storageUnit.NewCacheFromConfig(cacherCfg)
 	if err != nil {
 		log.Error("error creating peerChangeBlockBody")
 		return nil, err
 	}
 
 	cacherCfg = storageFactory.GetCacherFromConfig(config.TrieNodesDataPool)
-	trieNodes, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	trieNodes, err := storageUnit.NewCacheFromConfig(cacherCfg)
 	if err != nil {
 		log.Info("error creating trieNodes")
 		return nil, err
@@ -21,13 +21,19 @@ import (
 	"github.com/ElrondNetwork/elrond-go/cmd/node/metrics"
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/indexer/backfill"
 	"github.com/ElrondNetwork/elrond-go/core/serviceContainer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/ElrondNetwork/elrond-go/crypto/signing/kyber"
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/blockchain"
 	"github.com/ElrondNetwork/elrond-go/data/state"
+	factoryState "github.com/ElrondNetwork/elrond-go/data/state/factory"
+	trieFactory "github.com/ElrondNetwork/elrond-go/data/trie/factory"
 	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/display"
@@ -41,17 +47,31 @@ import (
 	"github.com/ElrondNetwork/elrond-go/node/external"
 	"github.com/ElrondNetwork/elrond-go/ntp"
 	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/accountProof"
+	"github.com/ElrondNetwork/elrond-go/process/block/bootstrapStorage"
+	"github.com/ElrondNetwork/elrond-go/process/block/preprocess"
+	"github.com/ElrondNetwork/elrond-go/process/coordinator"
 	"github.com/ElrondNetwork/elrond-go/process/economics"
 	"github.com/ElrondNetwork/elrond-go/process/factory/metachain"
 	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
+	"github.com/ElrondNetwork/elrond-go/process/historicalState"
 	"github.com/ElrondNetwork/elrond-go/process/rating"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract/hooks"
+	"github.com/ElrondNetwork/elrond-go/process/sync/storageBootstrap"
+	processTransaction "github.com/ElrondNetwork/elrond-go/process/transaction"
+	"github.com/ElrondNetwork/elrond-go/process/transactionEvaluator"
+	"github.com/ElrondNetwork/elrond-go/process/txsimulator"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	storageFactory "github.com/ElrondNetwork/elrond-go/storage/factory"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/ElrondNetwork/elrond-go/storage/pathmanager"
+	"github.com/ElrondNetwork/elrond-go/storage/snapshot"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/ElrondNetwork/elrond-go/storage/timecache"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/google/gops/agent"
 	"github.com/urfave/cli"
 )
@@ -64,6 +84,8 @@ const (
 	defaultStaticDbString = "Static"
 	defaultShardString    = "Shard"
 	metachainShardName    = "metachain"
+	// eventsBufferSize is the number of chain events kept for a web socket subscriber that does not read fast enough
+	eventsBufferSize = 1000
 )
 
 var (
@@ -296,6 +318,32 @@ VERSION:
 		Value: uint64(2),
 	}
 
+	// backfillIndexer starts the node in the indexer backfill mode: the blocks found in the local storage are
+	//  replayed into the configured indexer and the node exits without joining the network
+	backfillIndexer = cli.BoolFlag{
+		Name:  "backfill-indexer",
+		Usage: "Replays the blocks between backfill-start-nonce and backfill-end-nonce from the local storage into the configured indexer, then exits",
+	}
+
+	backfillStartNonce = cli.Uint64Flag{
+		Name:  "backfill-start-nonce",
+		Usage: "The nonce of the first block replayed by the indexer backfill",
+		Value: uint64(0),
+	}
+
+	backfillEndNonce = cli.Uint64Flag{
+		Name:  "backfill-end-nonce",
+		Usage: "The nonce of the last block replayed by the indexer backfill",
+		Value: uint64(0),
+	}
+
+	// importSnapshot writes the content of a snapshot archive, exported by the dbtool from another node of the same
+	//  shard, into the empty storage before the node bootstraps, so that it resumes syncing from the archive's block
+	importSnapshot = cli.StringFlag{
+		Name:  "import-snapshot",
+		Usage: "The snapshot archive to be imported into the node's empty storage. The node resumes syncing from the archive's block",
+	}
+
 	rm *statistics.ResourceMonitor
 )
 
@@ -362,6 +410,10 @@ func main() {
 		isNodefullArchive,
 		numEpochsToSave,
 		numActivePersisters,
+		backfillIndexer,
+		backfillStartNonce,
+		backfillEndNonce,
+		importSnapshot,
 	}
 	app.Authors = []cli.Author{
 		{
@@ -552,6 +604,11 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		return err
 	}
 
+	archivePathManager, err := createArchivePathManager(generalConfig.StoragePruning.Archive, workingDir, nodesConfig.ChainID)
+	if err != nil {
+		return err
+	}
+
 	storageCleanupFlagValue := ctx.GlobalBool(storageCleanup.Name)
 	if storageCleanupFlagValue {
 		dbPath := filepath.Join(
@@ -564,6 +621,16 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		}
 	}
 
+	log.Trace("checking the storage encryption marker")
+	encryptionKey, err := storageFactory.LoadEncryptionKey(generalConfig.StorageEncryption)
+	if err != nil {
+		return err
+	}
+	err = encryption.CheckMarker(filepath.Join(workingDir, defaultDBPath, nodesConfig.ChainID), encryptionKey)
+	if err != nil {
+		return err
+	}
+
 	log.Trace("creating core components")
 	coreArgs := factory.NewCoreComponentsFactoryArgs(generalConfig, pathManager, shardId, []byte(nodesConfig.ChainID))
 	coreComponents, err := factory.CoreComponentsFactory(coreArgs)
@@ -636,7 +703,7 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 	metrics.InitMetrics(coreComponents.StatusHandler, pubKey, nodeType, shardCoordinator, nodesConfig, version, economicsConfig)
 
 	log.Trace("creating data components")
-	dataArgs := factory.NewDataComponentsFactoryArgs(generalConfig, economicsData, shardCoordinator, coreComponents, pathManager, epochStartNotifier, currentEpoch)
+	dataArgs := factory.NewDataComponentsFactoryArgs(generalConfig, economicsData, shardCoordinator, coreComponents, pathManager, archivePathManager, epochStartNotifier, currentEpoch)
 	dataComponents, err := factory.DataComponentsFactory(dataArgs)
 	if err != nil {
 		return err
@@ -647,6 +714,20 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		return err
 	}
 
+	if ctx.IsSet(importSnapshot.Name) {
+		err = importSnapshotArchive(ctx.GlobalString(importSnapshot.Name), log, generalConfig, dataComponents, coreComponents, shardCoordinator)
+		if err != nil {
+			return err
+		}
+	}
+
+	if generalConfig.StorageDurability.CheckLastBlockOnStartup {
+		err = checkLastBlockInStorage(log, generalConfig.StorageDurability, dataComponents, coreComponents, shardCoordinator)
+		if err != nil {
+			log.Warn("the blocks from storage can not be used, the node will sync from the network", "error", err.Error())
+		}
+	}
+
 	log.Trace("creating crypto components")
 	cryptoArgs := factory.NewCryptoComponentsFactoryArgs(
 		ctx,
@@ -714,25 +795,39 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		return err
 	}
 
+	log.Trace("creating chain events notifier")
+	chainEventsNotifier, err := eventsNotifier.NewEventsNotifier(eventsBufferSize)
+	if err != nil {
+		return err
+	}
+
+	if ctx.GlobalBool(backfillIndexer.Name) {
+		return backfillIndexerFromStorage(ctx, log, generalConfig.Explorer, workingDir, dataComponents, coreComponents, shardCoordinator)
+	}
+
 	if generalConfig.Explorer.Enabled {
-		log.Trace("creating elastic search components")
+		log.Trace("creating indexer components", "driver", generalConfig.Explorer.Driver)
 		serversConfigurationFileName := ctx.GlobalString(serversConfigurationFile.Name)
-		dbIndexer, err = createElasticIndexer(
+		dbIndexer, err = createIndexer(
 			ctx,
 			serversConfigurationFileName,
-			generalConfig.Explorer.IndexerURL,
+			generalConfig.Explorer,
+			generalConfig.StorageEncryption,
+			workingDir,
+			pathManager,
 			shardCoordinator,
 			coreComponents.Marshalizer,
 			coreComponents.Hasher,
+			coreComponents.StatusHandler,
 		)
 		if err != nil {
 			return err
 		}
+	}
 
-		err = setServiceContainer(shardCoordinator, tpsBenchmark)
-		if err != nil {
-			return err
-		}
+	err = setServiceContainer(shardCoordinator, tpsBenchmark, chainEventsNotifier)
+	if err != nil {
+		return err
 	}
 
 	gasScheduleConfigurationFileName := ctx.GlobalString(gasScheduleConfigurationFile.Name)
@@ -774,6 +869,8 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		return err
 	}
 
+	processComponents.BlockTracker.RegisterCrossNotarizedHeadersHandler(chainEventsNotifier.NotarizedHeadersHandler)
+
 	var elasticIndexer indexer.Indexer
 	if coreServiceContainer == nil || coreServiceContainer.IsInterfaceNil() {
 		elasticIndexer = nil
@@ -803,6 +900,7 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		version,
 		elasticIndexer,
 		requestedItemsHandler,
+		chainEventsNotifier,
 	)
 	if err != nil {
 		return err
@@ -828,11 +926,13 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		dataComponents.Store,
 		dataComponents.Blkc,
 		coreComponents.Marshalizer,
+		coreComponents.Hasher,
 		coreComponents.Uint64ByteSliceConverter,
 		shardCoordinator,
 		statusHandlersInfo.StatusMetrics,
 		gasSchedule,
 		economicsData,
+		coreComponents.TriesContainer.Get([]byte(trieFactory.UserAccountTrie)),
 	)
 	if err != nil {
 		return err
@@ -855,6 +955,16 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 		return err
 	}
 
+	err = metrics.StartStorageStatisticsPolling(
+		coreComponents.StatusHandler,
+		generalConfig.GeneralSettings.StatusPollingIntervalSec,
+		dataComponents,
+		coreComponents.TriesContainer,
+	)
+	if err != nil {
+		return err
+	}
+
 	log.Trace("creating elrond node facade")
 	restAPIServerDebugMode := ctx.GlobalBool(restApiDebug.Name)
 	ef := facade.NewElrondNodeFacade(currentNode, apiResolver, restAPIServerDebugMode)
@@ -884,6 +994,13 @@ func startNode(ctx *cli.Context, log logger.Logger, version string) error {
 	<-sigs
 	log.Info("terminating at user's signal...")
 
+	indexerCloser, ok := dbIndexer.(io.Closer)
+	if ok {
+		log.Debug("closing the indexer....")
+		err = indexerCloser.Close()
+		log.LogIfError(err)
+	}
+
 	log.Debug("closing all store units....")
 	err = dataComponents.Store.CloseAll()
 	log.LogIfError(err)
@@ -956,6 +1073,31 @@ func getWorkingDir(ctx *cli.Context, log logger.Logger) string {
 	return workingDir
 }
 
+// createArchivePathManager creates the path manager for the archived epochs, having the same layout as the node's
+// database directory
+func createArchivePathManager(archiveConfig config.ArchiveConfig, workingDir string, chainID string) (storage.PathManagerHandler, error) {
+	archiveDir := archiveConfig.Path
+	if !filepath.IsAbs(archiveDir) {
+		archiveDir = filepath.Join(workingDir, archiveDir)
+	}
+
+	pathTemplateForPruningStorer := filepath.Join(
+		archiveDir,
+		chainID,
+		fmt.Sprintf("%s_%s", defaultEpochString, core.PathEpochPlaceholder),
+		fmt.Sprintf("%s_%s", defaultShardString, core.PathShardPlaceholder),
+		core.PathIdentifierPlaceholder)
+
+	pathTemplateForStaticStorer := filepath.Join(
+		archiveDir,
+		chainID,
+		defaultStaticDbString,
+		fmt.Sprintf("%s_%s", defaultShardString, core.PathShardPlaceholder),
+		core.PathIdentifierPlaceholder)
+
+	return pathmanager.NewPathManager(pathTemplateForPruningStorer, pathTemplateForStaticStorer)
+}
+
 func prepareLogFile(workingDir string) (*os.File, error) {
 	logDirectory := filepath.Join(workingDir, defaultLogsPath)
 	fileForLog, err := core.CreateFile("elrond-go", logDirectory, "log")
@@ -1180,32 +1322,327 @@ func processDestinationShardAsObserver(settingsConfig config.GeneralSettingsConf
 
 // createElasticIndexer creates a new elasticIndexer where the server listens on the url,
 // authentication for the server is using the username and password
-func createElasticIndexer(
+func createIndexer(
 	ctx *cli.Context,
 	serversConfigurationFileName string,
-	url string,
+	explorerConfig config.ExplorerConfig,
+	encryptionConfig config.StorageEncryptionConfig,
+	workingDir string,
+	pathManager storage.PathManagerHandler,
 	coordinator sharding.Coordinator,
 	marshalizer marshal.Marshalizer,
 	hasher hashing.Hasher,
+	statusHandler core.AppStatusHandler,
 ) (indexer.Indexer, error) {
-	serversConfig, err := core.LoadServersPConfig(serversConfigurationFileName)
+	args, err := createIndexerFactoryArgs(ctx, serversConfigurationFileName, explorerConfig, workingDir, coordinator, marshalizer, hasher)
 	if err != nil {
 		return nil, err
 	}
 
-	dbIndexer, err = indexer.NewElasticIndexer(
-		url,
-		serversConfig.ElasticSearch.Username,
-		serversConfig.ElasticSearch.Password,
-		coordinator,
-		marshalizer,
-		hasher,
-		&indexer.Options{TxIndexingEnabled: ctx.GlobalBoolT(enableTxIndexing.Name)})
+	if explorerConfig.Queue.Enabled {
+		queueStorer, err := createIndexingQueueStorer(explorerConfig.Queue.Storage, encryptionConfig, pathManager, coordinator)
+		if err != nil {
+			return nil, err
+		}
+
+		args.Queue = &indexer.ArgsIndexingQueue{
+			Storer:          queueStorer,
+			StatusHandler:   statusHandler,
+			MaxPendingItems: explorerConfig.Queue.MaxPendingItems,
+			InitialBackoff:  time.Duration(explorerConfig.Queue.InitialBackoffInMs) * time.Millisecond,
+			MaxBackoff:      time.Duration(explorerConfig.Queue.MaxBackoffInMs) * time.Millisecond,
+			MaxAttempts:     explorerConfig.Queue.MaxAttempts,
+		}
+	}
+
+	return indexer.NewIndexer(args)
+}
+
+func createIndexerFactoryArgs(
+	ctx *cli.Context,
+	serversConfigurationFileName string,
+	explorerConfig config.ExplorerConfig,
+	workingDir string,
+	coordinator sharding.Coordinator,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (indexer.ArgsIndexerFactory, error) {
+	args := indexer.ArgsIndexerFactory{
+		Driver:           explorerConfig.Driver,
+		Url:              explorerConfig.IndexerURL,
+		DataPath:         explorerConfig.DataPath,
+		ShardCoordinator: coordinator,
+		Marshalizer:      marshalizer,
+		Hasher:           hasher,
+		Options:          &indexer.Options{TxIndexingEnabled: ctx.GlobalBoolT(enableTxIndexing.Name)},
+	}
+	if args.DataPath != "" && !filepath.IsAbs(args.DataPath) {
+		args.DataPath = filepath.Join(workingDir, args.DataPath)
+	}
+
+	isElasticSearchDriver := args.Driver == "" || args.Driver == indexer.ElasticSearchDriver
+	if isElasticSearchDriver {
+		serversConfig, err := core.LoadServersPConfig(serversConfigurationFileName)
+		if err != nil {
+			return indexer.ArgsIndexerFactory{}, err
+		}
+
+		args.Username = serversConfig.ElasticSearch.Username
+		args.Password = serversConfig.ElasticSearch.Password
+	}
+
+	return args, nil
+}
+
+func createIndexingQueueStorer(
+	storageConfig config.StorageConfig,
+	encryptionConfig config.StorageEncryptionConfig,
+	pathManager storage.PathManagerHandler,
+	coordinator sharding.Coordinator,
+) (storage.Storer, error) {
+	encryptionKey, err := storageFactory.LoadEncryptionKey(encryptionConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return dbIndexer, nil
+	dbConfig := storageFactory.GetDBFromConfig(storageConfig.DB)
+	shardId := core.GetShardIdString(coordinator.SelfId())
+	dbConfig.FilePath = pathManager.PathForStatic(shardId, storageConfig.DB.FilePath)
+	dbConfig.EncryptionKey = encryptionKey
+	// the queue flushes the unit on every push and every written item, so they are not lost to a crash
+	dbConfig.FlushOnCommit = true
+
+	return storageUnit.NewStorageUnitFromConf(
+		storageFactory.GetCacherFromConfig(storageConfig.Cache),
+		dbConfig,
+		storageFactory.GetBloomFromConfig(storageConfig.Bloom),
+	)
+}
+
+// importSnapshotArchive writes the content of a snapshot archive into the empty storage of the node, so that the
+// node bootstraps from the exported block instead of syncing from genesis
+func importSnapshotArchive(
+	archivePath string,
+	log logger.Logger,
+	generalConfig *config.Config,
+	dataComponents *factory.Data,
+	coreComponents *factory.Core,
+	shardCoordinator sharding.Coordinator,
+) error {
+	bootStorer, err := bootstrapStorage.NewBootstrapStorer(coreComponents.Marshalizer, dataComponents.Store.GetStorer(dataRetriever.BootstrapUnit))
+	if err != nil {
+		return err
+	}
+	if bootStorer.GetHighestRound() != 0 {
+		return fmt.Errorf("the snapshot can only be imported into an empty storage, start the node with the %s flag", storageCleanup.Name)
+	}
+
+	importer, err := snapshot.NewImporter(snapshot.ArgsImporter{
+		Units:            createSnapshotUnits(generalConfig, dataComponents, coreComponents, shardCoordinator),
+		Marshalizer:      coreComponents.Marshalizer,
+		ShardCoordinator: shardCoordinator,
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	log.Info("importing snapshot", "file", archivePath)
+	result, err := importer.Import(file)
+	if err != nil {
+		return fmt.Errorf("%s while importing the snapshot, the storage has to be cleaned before retrying", err.Error())
+	}
+
+	log.Info("snapshot imported",
+		"round", result.Manifest.Round,
+		"nonce", result.Manifest.Nonce,
+		"header hash", result.Manifest.HeaderHash,
+		"root hash", result.Manifest.RootHash,
+		"headers", result.Manifest.NumHeaders,
+		"pairs", result.NumPairs,
+	)
+
+	return nil
+}
+
+// checkLastBlockInStorage drops the last blocks recorded in the bootstrap storage if they were only partially written
+// when the node stopped, so the node starts from the last complete block
+func checkLastBlockInStorage(
+	log logger.Logger,
+	durabilityConfig config.StorageDurabilityConfig,
+	dataComponents *factory.Data,
+	coreComponents *factory.Core,
+	shardCoordinator sharding.Coordinator,
+) error {
+	bootStorer, err := bootstrapStorage.NewBootstrapStorer(coreComponents.Marshalizer, dataComponents.Store.GetStorer(dataRetriever.BootstrapUnit))
+	if err != nil {
+		return err
+	}
+
+	var peerAccountsTrieStorage data.DBWriteCacher
+	peerAccountsTrie := coreComponents.TriesContainer.Get([]byte(trieFactory.PeerAccountTrie))
+	if !check.IfNil(peerAccountsTrie) {
+		peerAccountsTrieStorage = peerAccountsTrie.Database()
+	}
+	var accountsTrieStorage data.DBWriteCacher
+	accountsTrie := coreComponents.TriesContainer.Get([]byte(trieFactory.UserAccountTrie))
+	if !check.IfNil(accountsTrie) {
+		accountsTrieStorage = accountsTrie.Database()
+	}
+
+	checker, err := storageBootstrap.NewLastBlockChecker(storageBootstrap.ArgsLastBlockChecker{
+		BootStorer:              bootStorer,
+		Store:                   dataComponents.Store,
+		Marshalizer:             coreComponents.Marshalizer,
+		Hasher:                  coreComponents.Hasher,
+		Uint64Converter:         coreComponents.Uint64ByteSliceConverter,
+		ShardCoordinator:        shardCoordinator,
+		AccountsTrieStorage:     accountsTrieStorage,
+		PeerAccountsTrieStorage: peerAccountsTrieStorage,
+		TrieCheckDepth:          durabilityConfig.TrieCheckDepth,
+		FullTrieCheck:           durabilityConfig.FullTrieCheckOnStartup,
+	})
+	if err != nil {
+		return err
+	}
+
+	round, err := checker.CheckAndRecover()
+	if err != nil {
+		return err
+	}
+
+	log.Debug("last block in storage checked", "round", round)
+
+	return nil
+}
+
+// createSnapshotUnits maps the units a snapshot archive can hold, identified as in the configuration, to the storers
+// and the trie databases of the node
+func createSnapshotUnits(
+	generalConfig *config.Config,
+	dataComponents *factory.Data,
+	coreComponents *factory.Core,
+	shardCoordinator sharding.Coordinator,
+) map[string]snapshot.UnitWriter {
+	store := dataComponents.Store
+	units := map[string]snapshot.UnitWriter{
+		generalConfig.BootstrapStorage.DB.FilePath:        store.GetStorer(dataRetriever.BootstrapUnit),
+		generalConfig.BlockHeaderStorage.DB.FilePath:      store.GetStorer(dataRetriever.BlockHeaderUnit),
+		generalConfig.MetaBlockStorage.DB.FilePath:        store.GetStorer(dataRetriever.MetaBlockUnit),
+		generalConfig.MiniBlocksStorage.DB.FilePath:       store.GetStorer(dataRetriever.MiniBlockUnit),
+		generalConfig.MetaHdrNonceHashStorage.DB.FilePath: store.GetStorer(dataRetriever.MetaHdrNonceHashDataUnit),
+	}
+
+	for shard := uint32(0); shard < shardCoordinator.NumberOfShards(); shard++ {
+		storer := store.GetStorer(dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(shard))
+		if check.IfNil(storer) {
+			continue
+		}
+		units[generalConfig.ShardHdrNonceHashStorage.DB.FilePath+fmt.Sprintf("%d", shard)] = storer
+	}
+
+	tries := map[string]string{
+		generalConfig.AccountsTrieStorage.DB.FilePath:     trieFactory.UserAccountTrie,
+		generalConfig.PeerAccountsTrieStorage.DB.FilePath: trieFactory.PeerAccountTrie,
+	}
+	for identifier, trieKey := range tries {
+		tr := coreComponents.TriesContainer.Get([]byte(trieKey))
+		if check.IfNil(tr) {
+			continue
+		}
+		units[identifier] = tr.Database()
+	}
+
+	return units
+}
+
+// backfillIndexerFromStorage replays the blocks in the configured nonce range from the local storage into the
+// configured indexer driver. The indexing queue is bypassed and every block is written inline, so all the blocks are
+// indexed when the backfill returns
+func backfillIndexerFromStorage(
+	ctx *cli.Context,
+	log logger.Logger,
+	explorerConfig config.ExplorerConfig,
+	workingDir string,
+	dataComponents *factory.Data,
+	coreComponents *factory.Core,
+	shardCoordinator sharding.Coordinator,
+) error {
+	if !explorerConfig.Enabled {
+		return errors.New("the explorer must be enabled in order to backfill the indexer")
+	}
+
+	args, err := createIndexerFactoryArgs(
+		ctx,
+		ctx.GlobalString(serversConfigurationFile.Name),
+		explorerConfig,
+		workingDir,
+		shardCoordinator,
+		coreComponents.Marshalizer,
+		coreComponents.Hasher,
+	)
+	if err != nil {
+		return err
+	}
+
+	documentsWriter, err := indexer.NewDocumentsWriter(args)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		writerCloser, ok := documentsWriter.(io.Closer)
+		if ok {
+			log.LogIfError(writerCloser.Close())
+		}
+	}()
+
+	blockWriter, err := indexer.NewBlockWriter(indexer.ArgsBlockWriter{
+		Writer:           documentsWriter,
+		ShardCoordinator: shardCoordinator,
+		Marshalizer:      coreComponents.Marshalizer,
+		Hasher:           coreComponents.Hasher,
+		Options:          args.Options,
+	})
+	if err != nil {
+		return err
+	}
+
+	backfiller, err := backfill.NewBackfiller(backfill.ArgsBackfiller{
+		Store:            dataComponents.Store,
+		BlockWriter:      blockWriter,
+		Marshalizer:      coreComponents.Marshalizer,
+		Uint64Converter:  coreComponents.Uint64ByteSliceConverter,
+		ShardCoordinator: shardCoordinator,
+	})
+	if err != nil {
+		return err
+	}
+
+	startNonce := ctx.GlobalUint64(backfillStartNonce.Name)
+	endNonce := ctx.GlobalUint64(backfillEndNonce.Name)
+	log.Info("backfilling the indexer", "start nonce", startNonce, "end nonce", endNonce)
+
+	result, err := backfiller.Backfill(startNonce, endNonce)
+	if result != nil {
+		log.Info("indexer backfill done",
+			"indexed blocks", result.NumIndexedBlocks,
+			"missing blocks", result.NumMissingBlocks,
+			"indexed txs", result.NumIndexedTxs,
+			"missing txs", result.NumMissingTxs,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	return dataComponents.Store.CloseAll()
 }
 
 func getConsensusGroupSize(nodesConfig *sharding.NodesSetup, shardCoordinator sharding.Coordinator) (uint32, error) {
@@ -1240,6 +1677,7 @@ func createNode(
 	version string,
 	indexer indexer.Indexer,
 	requestedItemsHandler dataRetriever.RequestedItemsHandler,
+	chainEventsNotifier eventsNotifier.EventsNotifier,
 ) (*node.Node, error) {
 	consensusGroupSize, err := getConsensusGroupSize(nodesConfig, shardCoordinator)
 	if err != nil {
@@ -1291,6 +1729,7 @@ func createNode(
 		node.WithChainID(core.ChainID),
 		node.WithBlockTracker(process.BlockTracker),
 		node.WithRequestHandler(process.RequestHandler),
+		node.WithEventsNotifier(chainEventsNotifier),
 	)
 	if err != nil {
 		return nil, errors.New("error creating node: " + err.Error())
@@ -1352,10 +1791,16 @@ func initStatsFileMonitor(
 	return nil
 }
 
-func setServiceContainer(shardCoordinator sharding.Coordinator, tpsBenchmark *statistics.TpsBenchmark) error {
+func setServiceContainer(
+	shardCoordinator sharding.Coordinator,
+	tpsBenchmark *statistics.TpsBenchmark,
+	chainEventsNotifier eventsNotifier.EventsNotifier,
+) error {
 	var err error
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
-		coreServiceContainer, err = serviceContainer.NewServiceContainer(serviceContainer.WithIndexer(dbIndexer))
+		coreServiceContainer, err = serviceContainer.NewServiceContainer(
+			serviceContainer.WithIndexer(dbIndexer),
+			serviceContainer.WithEventsNotifier(chainEventsNotifier))
 		if err != nil {
 			return err
 		}
@@ -1364,7 +1809,8 @@ func setServiceContainer(shardCoordinator sharding.Coordinator, tpsBenchmark *st
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
 		coreServiceContainer, err = serviceContainer.NewServiceContainer(
 			serviceContainer.WithIndexer(dbIndexer),
-			serviceContainer.WithTPSBenchmark(tpsBenchmark))
+			serviceContainer.WithTPSBenchmark(tpsBenchmark),
+			serviceContainer.WithEventsNotifier(chainEventsNotifier))
 		if err != nil {
 			return err
 		}
@@ -1410,15 +1856,14 @@ func createApiResolver(
 	storageService dataRetriever.StorageService,
 	blockChain data.ChainHandler,
 	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
 	uint64Converter typeConverters.Uint64ByteSliceConverter,
 	shardCoordinator sharding.Coordinator,
 	statusMetrics external.StatusMetricsHandler,
 	gasSchedule map[string]map[string]uint64,
 	economics *economics.EconomicsData,
+	accountsTrie data.Trie,
 ) (facade.ApiResolver, error) {
-	var vmFactory process.VirtualMachinesContainerFactory
-	var err error
-
 	argsHook := hooks.ArgBlockChainHook{
 		Accounts:         accnts,
 		AddrConv:         addrConv,
@@ -1429,16 +1874,232 @@ func createApiResolver(
 		Uint64Converter:  uint64Converter,
 	}
 
-	if shardCoordinator.SelfId() == sharding.MetachainShardId {
-		vmFactory, err = metachain.NewVMContainerFactory(argsHook, economics)
-		if err != nil {
-			return nil, err
-		}
+	vmFactory, err := createVMContainerFactory(argsHook, gasSchedule, economics)
+	if err != nil {
+		return nil, err
+	}
+
+	vmContainer, err := vmFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+
+	scQueryService, err := smartContract.NewSCQueryService(vmContainer, economics.MaxGasLimitPerBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	txSimulator, err := createTxSimulator(argsHook, hasher, gasSchedule, economics, accountsTrie)
+	if err != nil {
+		return nil, err
+	}
+
+	txTypeHandler, err := coordinator.NewTxTypeHandler(addrConv, shardCoordinator, accnts)
+	if err != nil {
+		return nil, err
+	}
+
+	txCostEstimator, err := transactionEvaluator.NewTransactionCostEstimator(txTypeHandler, economics, scQueryService)
+	if err != nil {
+		return nil, err
+	}
+
+	argsAccountProof := accountProof.ArgsAccountProofProvider{
+		StateTrie:        accountsTrie,
+		AddressConverter: addrConv,
+		Marshalizer:      marshalizer,
+		Store:            storageService,
+		Uint64Converter:  uint64Converter,
+		ShardCoordinator: shardCoordinator,
+	}
+	accountProofProvider, err := accountProof.NewAccountProofProvider(argsAccountProof)
+	if err != nil {
+		return nil, err
+	}
+
+	historicalStateProvider, err := createHistoricalStateProvider(argsHook, hasher, gasSchedule, economics, accountsTrie)
+	if err != nil {
+		return nil, err
+	}
+
+	return external.NewNodeApiResolver(
+		scQueryService,
+		statusMetrics,
+		txSimulator,
+		txCostEstimator,
+		accountProofProvider,
+		historicalStateProvider,
+	)
+}
+
+func createVMContainerFactory(
+	argsHook hooks.ArgBlockChainHook,
+	gasSchedule map[string]map[string]uint64,
+	economics *economics.EconomicsData,
+) (process.VirtualMachinesContainerFactory, error) {
+	if argsHook.ShardCoordinator.SelfId() == sharding.MetachainShardId {
+		return metachain.NewVMContainerFactory(argsHook, economics)
+	}
+
+	return shard.NewVMContainerFactory(economics.MaxGasLimitPerBlock(), gasSchedule, argsHook)
+}
+
+// createTxSimulator creates a transaction simulator having its own accounts adapter, VM container and processors,
+// so that the transactions simulated through the api never alter the node's state
+func createTxSimulator(
+	argsHook hooks.ArgBlockChainHook,
+	hasher hashing.Hasher,
+	gasSchedule map[string]map[string]uint64,
+	economics *economics.EconomicsData,
+	accountsTrie data.Trie,
+) (external.TransactionSimulator, error) {
+	accountFactory, err := factoryState.NewAccountFactoryCreator(factoryState.UserAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	simulationTrie, err := accountsTrie.Recreate(make([]byte, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	simulationAccounts, err := state.NewAccountsDB(simulationTrie, hasher, argsHook.Marshalizer, accountFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	argsHook.Accounts = simulationAccounts
+
+	vmFactory, err := createVMContainerFactory(argsHook, gasSchedule, economics)
+	if err != nil {
+		return nil, err
+	}
+
+	vmContainer, err := vmFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+
+	argsParser, err := vmcommon.NewAtArgumentParser()
+	if err != nil {
+		return nil, err
+	}
+
+	resultsCollector, err := txsimulator.NewIntermediateResultsCollector(argsHook.Marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	txTypeHandler, err := coordinator.NewTxTypeHandler(argsHook.AddrConv, argsHook.ShardCoordinator, simulationAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	gasHandler, err := preprocess.NewGasComputation(economics)
+	if err != nil {
+		return nil, err
+	}
+
+	scProcessor, err := smartContract.NewSmartContractProcessor(
+		vmContainer,
+		argsParser,
+		hasher,
+		argsHook.Marshalizer,
+		simulationAccounts,
+		vmFactory.BlockChainHookImpl(),
+		argsHook.AddrConv,
+		argsHook.ShardCoordinator,
+		resultsCollector,
+		&metachain.TransactionFeeHandler{},
+		economics,
+		txTypeHandler,
+		gasHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var txProcessor process.TransactionProcessor
+	if argsHook.ShardCoordinator.SelfId() == sharding.MetachainShardId {
+		txProcessor, err = processTransaction.NewMetaTxProcessor(
+			simulationAccounts,
+			argsHook.AddrConv,
+			argsHook.ShardCoordinator,
+			scProcessor,
+			txTypeHandler,
+			economics,
+		)
 	} else {
-		vmFactory, err = shard.NewVMContainerFactory(economics.MaxGasLimitPerBlock(), gasSchedule, argsHook)
-		if err != nil {
-			return nil, err
-		}
+		txProcessor, err = processTransaction.NewTxProcessor(
+			simulationAccounts,
+			hasher,
+			argsHook.AddrConv,
+			argsHook.Marshalizer,
+			argsHook.ShardCoordinator,
+			scProcessor,
+			&metachain.TransactionFeeHandler{},
+			txTypeHandler,
+			economics,
+			resultsCollector,
+			resultsCollector,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	argsTxSimulator := txsimulator.ArgsTxSimulator{
+		TransactionProcessor: txProcessor,
+		BlockChain:           argsHook.BlockChain,
+		SimulationAccounts:   simulationAccounts,
+		ResultsCollector:     resultsCollector,
+		GasHandler:           gasHandler,
+		TxTypeHandler:        txTypeHandler,
+		EconomicsFee:         economics,
+		ShardCoordinator:     argsHook.ShardCoordinator,
+		AddressConverter:     argsHook.AddrConv,
+		Marshalizer:          argsHook.Marshalizer,
+		Hasher:               hasher,
+	}
+
+	return txsimulator.NewTransactionSimulator(argsTxSimulator)
+}
+
+// createHistoricalStateProvider creates the provider of the states of past blocks, having its own accounts adapter,
+// chain handler and VM container, so that moving them to an old block never alters the node's state and the contracts
+// queried on that block see its header instead of the node's current one
+func createHistoricalStateProvider(
+	argsHook hooks.ArgBlockChainHook,
+	hasher hashing.Hasher,
+	gasSchedule map[string]map[string]uint64,
+	economics *economics.EconomicsData,
+	accountsTrie data.Trie,
+) (external.HistoricalStateProvider, error) {
+	accountFactory, err := factoryState.NewAccountFactoryCreator(factoryState.UserAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	historicalTrie, err := accountsTrie.Recreate(make([]byte, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	historicalAccounts, err := state.NewAccountsDB(historicalTrie, hasher, argsHook.Marshalizer, accountFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	historicalChain, err := createHistoricalChain(argsHook.ShardCoordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	argsHook.Accounts = historicalAccounts
+	argsHook.BlockChain = historicalChain
+	vmFactory, err := createVMContainerFactory(argsHook, gasSchedule, economics)
+	if err != nil {
+		return nil, err
 	}
 
 	vmContainer, err := vmFactory.Create()
@@ -1451,5 +2112,31 @@ func createApiResolver(
 		return nil, err
 	}
 
-	return external.NewNodeApiResolver(scQueryService, statusMetrics)
+	argsHistoricalState := historicalState.ArgsHistoricalStateProvider{
+		Accounts:         historicalAccounts,
+		BlockChain:       historicalChain,
+		SCQueryService:   scQueryService,
+		AddressConverter: argsHook.AddrConv,
+		Store:            argsHook.StorageService,
+		Marshalizer:      argsHook.Marshalizer,
+		Uint64Converter:  argsHook.Uint64Converter,
+		ShardCoordinator: argsHook.ShardCoordinator,
+	}
+
+	return historicalState.NewHistoricalStateProvider(argsHistoricalState)
+}
+
+// createHistoricalChain creates the chain handler the historical state provider moves to the header of the requested
+// block. It never records bad blocks so its cache holds a single entry
+func createHistoricalChain(shardCoordinator sharding.Coordinator) (data.ChainHandler, error) {
+	badBlocksCache, err := lrucache.NewCache(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if shardCoordinator.SelfId() == sharding.MetachainShardId {
+		return blockchain.NewMetaChain(badBlocksCache)
+	}
+
+	return blockchain.NewBlockChain(badBlocksCache)
 }
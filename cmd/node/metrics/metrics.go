@@ -55,6 +55,9 @@ func InitMetrics(
 	appStatusHandler.SetStringValue(core.MetricPublicKeyTxSign, initString)
 	appStatusHandler.SetUInt64Value(core.MetricHighestFinalBlockInShard, initUint)
 	appStatusHandler.SetUInt64Value(core.MetricCountConsensusAcceptedBlocks, initUint)
+	appStatusHandler.SetUInt64Value(core.MetricTrieSyncNumReceivedNodes, initUint)
+	appStatusHandler.SetUInt64Value(core.MetricTrieSyncNumReceivedBytes, initUint)
+	appStatusHandler.SetUInt64Value(core.MetricIndexerDroppedItems, initUint)
 	appStatusHandler.SetStringValue(core.MetricRewardsValue, economicsConfig.RewardsSettings.RewardsValue)
 	appStatusHandler.SetStringValue(core.MetricLeaderPercentage, fmt.Sprintf("%f", economicsConfig.RewardsSettings.LeaderPercentage))
 	appStatusHandler.SetStringValue(core.MetricCommunityPercentage, fmt.Sprintf("%f", economicsConfig.RewardsSettings.CommunityPercentage))
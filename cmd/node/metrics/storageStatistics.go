@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/cmd/node/factory"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/appStatusPolling"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	trieFactory "github.com/ElrondNetwork/elrond-go/data/trie/factory"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// namedCache pairs a data pool or trie nodes cache with the name used in its metrics
+type namedCache struct {
+	name  string
+	stats storage.CacheStatsHandler
+}
+
+// storerNames holds the names used in the metrics of the storers, by unit type
+var storerNames = map[dataRetriever.UnitType]string{
+	dataRetriever.TransactionUnit:          "transactions",
+	dataRetriever.MiniBlockUnit:            "miniblocks",
+	dataRetriever.PeerChangesUnit:          "peer_changes",
+	dataRetriever.BlockHeaderUnit:          "block_headers",
+	dataRetriever.MetaBlockUnit:            "meta_blocks",
+	dataRetriever.UnsignedTransactionUnit:  "unsigned_transactions",
+	dataRetriever.RewardTransactionUnit:    "reward_transactions",
+	dataRetriever.MetaHdrNonceHashDataUnit: "meta_nonce_hash",
+	dataRetriever.MiniBlockHeaderUnit:      "miniblock_headers",
+	dataRetriever.TransactionMetadataUnit:  "transaction_metadata",
+}
+
+// trieNames holds the names used in the metrics of the trie nodes caches, by trie identifier
+var trieNames = map[string]string{
+	trieFactory.UserAccountTrie: "user_account_trie_nodes",
+	trieFactory.PeerAccountTrie: "peer_account_trie_nodes",
+}
+
+// StartStorageStatisticsPolling will periodically publish the counters of the data pool caches, of the trie nodes
+// caches and of the storers
+func StartStorageStatisticsPolling(
+	ash core.AppStatusHandler,
+	pollingInterval int,
+	dataComponents *factory.Data,
+	tries state.TriesHolder,
+) error {
+	if ash == nil {
+		return errors.New("nil AppStatusHandler")
+	}
+	if dataComponents == nil {
+		return errors.New("nil data components")
+	}
+
+	appStatusPollingHandler, err := appStatusPolling.NewAppStatusPolling(ash, pollingInterval)
+	if err != nil {
+		return errors.New("cannot init AppStatusPolling")
+	}
+
+	caches := append(getNamedCaches(dataComponents.Datapool), getTrieNodesCaches(tries)...)
+	err = appStatusPollingHandler.RegisterPollingFunc(func(appStatusHandler core.AppStatusHandler) {
+		saveCachesStatistics(appStatusHandler, caches)
+	})
+	if err != nil {
+		return errors.New("cannot register handler func for caches statistics")
+	}
+
+	err = appStatusPollingHandler.RegisterPollingFunc(func(appStatusHandler core.AppStatusHandler) {
+		saveStorersStatistics(appStatusHandler, dataComponents.Store)
+	})
+	if err != nil {
+		return errors.New("cannot register handler func for storers statistics")
+	}
+
+	appStatusPollingHandler.Poll()
+
+	return nil
+}
+
+func getNamedCaches(pools dataRetriever.PoolsHolder) []namedCache {
+	if pools == nil || pools.IsInterfaceNil() {
+		return nil
+	}
+
+	candidates := []struct {
+		name  string
+		cache interface{}
+	}{
+		{name: "transactions", cache: pools.Transactions()},
+		{name: "unsigned_transactions", cache: pools.UnsignedTransactions()},
+		{name: "reward_transactions", cache: pools.RewardTransactions()},
+		{name: "headers", cache: pools.Headers()},
+		{name: "miniblocks", cache: pools.MiniBlocks()},
+		{name: "peer_changes", cache: pools.PeerChangesBlocks()},
+		{name: "trie_nodes", cache: pools.TrieNodes()},
+	}
+
+	caches := make([]namedCache, 0, len(candidates))
+	for _, candidate := range candidates {
+		statsHandler, ok := candidate.cache.(storage.CacheStatsHandler)
+		if !ok {
+			continue
+		}
+
+		caches = append(caches, namedCache{name: candidate.name, stats: statsHandler})
+	}
+
+	return caches
+}
+
+func getTrieNodesCaches(tries state.TriesHolder) []namedCache {
+	if tries == nil || tries.IsInterfaceNil() {
+		return nil
+	}
+
+	caches := make([]namedCache, 0, len(trieNames))
+	for trieId, name := range trieNames {
+		tr := tries.Get([]byte(trieId))
+		if check.IfNil(tr) {
+			continue
+		}
+
+		statsHandler, ok := tr.Database().(storage.CacheStatsHandler)
+		if !ok {
+			continue
+		}
+
+		caches = append(caches, namedCache{name: name, stats: statsHandler})
+	}
+
+	return caches
+}
+
+func saveCachesStatistics(appStatusHandler core.AppStatusHandler, caches []namedCache) {
+	hitRates := make([]string, 0, len(caches))
+	for _, cache := range caches {
+		stats := cache.stats.Stats()
+		prefix := core.MetricCachePrefix + cache.name
+
+		appStatusHandler.SetUInt64Value(prefix+"_gets", stats.NumGets)
+		appStatusHandler.SetUInt64Value(prefix+"_hits", stats.NumHits)
+		appStatusHandler.SetUInt64Value(prefix+"_misses", stats.NumMisses)
+		appStatusHandler.SetUInt64Value(prefix+"_puts", stats.NumPuts)
+		appStatusHandler.SetUInt64Value(prefix+"_evictions", stats.NumEvictions)
+		appStatusHandler.SetUInt64Value(prefix+"_bytes_added", stats.NumBytesAdded)
+
+		hitRates = append(hitRates, fmt.Sprintf("%s %.1f%%", cache.name, stats.HitRatePercent()))
+	}
+
+	appStatusHandler.SetStringValue(core.MetricCacheHitRates, strings.Join(hitRates, ", "))
+}
+
+func saveStorersStatistics(appStatusHandler core.AppStatusHandler, store dataRetriever.StorageService) {
+	if store == nil || store.IsInterfaceNil() {
+		return
+	}
+
+	for unitType, name := range storerNames {
+		statsHandler, ok := store.GetStorer(unitType).(storage.UnitStatsHandler)
+		if !ok {
+			continue
+		}
+
+		stats := statsHandler.Stats()
+		prefix := core.MetricStorerPrefix + name
+
+		appStatusHandler.SetUInt64Value(prefix+"_gets", stats.NumGets)
+		appStatusHandler.SetUInt64Value(prefix+"_cache_hits", stats.NumCacheHits)
+		appStatusHandler.SetUInt64Value(prefix+"_persister_hits", stats.NumPersisterHits)
+		appStatusHandler.SetUInt64Value(prefix+"_bloom_filter_misses", stats.NumBloomFilterMiss)
+		appStatusHandler.SetUInt64Value(prefix+"_misses", stats.NumMisses)
+	}
+}
@@ -21,12 +21,15 @@ type DBConfig struct {
 	BatchDelaySeconds int    `json:"batchDelaySeconds"`
 	MaxBatchSize      int    `json:"maxBatchSize"`
 	MaxOpenFiles      int    `json:"maxOpenFiles"`
+	FlushOnCommit     bool   `json:"flushOnCommit"`
 }
 
 // BloomFilterConfig will map the json bloom filter configuration
 type BloomFilterConfig struct {
 	Size     uint     `json:"size"`
 	HashFunc []string `json:"hashFunc"`
+	Type     string   `json:"type"`
+	Persist  bool     `json:"persist"`
 }
 
 // StorageConfig will map the json storage unit configuration
@@ -85,6 +88,7 @@ type Config struct {
 	ShardHdrNonceHashStorage   StorageConfig
 	MetaHdrNonceHashStorage    StorageConfig
 	StatusMetricsStorage       StorageConfig
+	TxMetadataStorage          StorageConfig
 
 	BootstrapStorage StorageConfig
 	MetaBlockStorage StorageConfig
@@ -94,6 +98,7 @@ type Config struct {
 	TrieSnapshotDB          DBConfig
 	EvictionWaitingList     EvictionWaitingListConfig
 	StateTrieConfig         StateTrieConfig
+	TrieNodesCache          TrieNodesCacheConfig
 	BadBlocksCache          CacheConfig
 
 	TxBlockBodyDataPool         CacheConfig
@@ -109,12 +114,14 @@ type Config struct {
 	MultisigHasher              TypeConfig
 	Marshalizer                 MarshalizerConfig
 
-	ResourceStats   ResourceStatsConfig
-	Heartbeat       HeartbeatConfig
-	GeneralSettings GeneralSettingsConfig
-	Consensus       TypeConfig
-	Explorer        ExplorerConfig
-	StoragePruning  StoragePruningConfig
+	ResourceStats     ResourceStatsConfig
+	Heartbeat         HeartbeatConfig
+	GeneralSettings   GeneralSettingsConfig
+	Consensus         TypeConfig
+	Explorer          ExplorerConfig
+	StoragePruning    StoragePruningConfig
+	StorageDurability StorageDurabilityConfig
+	StorageEncryption StorageEncryptionConfig
 
 	NTPConfig         NTPConfig
 	HeadersPoolConfig HeadersPoolConfig
@@ -133,6 +140,29 @@ type StoragePruningConfig struct {
 	FullArchive         bool
 	NumEpochsToKeep     uint64
 	NumActivePersisters uint64
+	Archive             ArchiveConfig
+}
+
+// StorageDurabilityConfig will hold settings related to recovering the storage after a crash
+type StorageDurabilityConfig struct {
+	CheckLastBlockOnStartup bool
+	TrieCheckDepth          uint32
+	FullTrieCheckOnStartup  bool
+}
+
+// StorageEncryptionConfig will hold settings related to encrypting the stored values
+type StorageEncryptionConfig struct {
+	Enabled        bool
+	KeyFile        string
+	KeyEnvVariable string
+}
+
+// ArchiveConfig will hold settings related to moving the pruned epochs to an archive directory
+type ArchiveConfig struct {
+	Enabled           bool
+	Path              string
+	Compress          bool
+	MaxOpenedArchives uint32
 }
 
 // KadDhtPeerDiscoveryConfig will hold the kad-dht discovery config settings
@@ -176,7 +206,20 @@ type GeneralSettingsConfig struct {
 // ExplorerConfig will hold the configuration for the explorer indexer
 type ExplorerConfig struct {
 	Enabled    bool
+	Driver     string
 	IndexerURL string
+	DataPath   string
+	Queue      IndexerQueueConfig
+}
+
+// IndexerQueueConfig will hold the configuration for the persistent indexing queue
+type IndexerQueueConfig struct {
+	Enabled            bool
+	MaxPendingItems    uint64
+	InitialBackoffInMs uint64
+	MaxBackoffInMs     uint64
+	MaxAttempts        uint32
+	Storage            StorageConfig
 }
 
 // ServersConfig will hold all the confidential settings for servers
@@ -201,3 +244,10 @@ type StateTrieConfig struct {
 	RoundsModulus  uint
 	PruningEnabled bool
 }
+
+// TrieNodesCacheConfig will hold the settings of the cache placed by the trie storage manager in front of the trie
+// database. A zero size disables the cache
+type TrieNodesCacheConfig struct {
+	SizeInBytes     uint64
+	NumPinnedLevels uint32
+}
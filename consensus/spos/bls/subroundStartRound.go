@@ -187,7 +187,7 @@ func (sr *subroundStartRound) indexRoundIfNeeded(pubKeys []string) {
 		Timestamp:        time.Duration(sr.RoundTimeStamp.Unix()),
 	}
 
-	go sr.indexer.SaveRoundInfo(roundInfo)
+	sr.indexer.SaveRoundInfo(roundInfo)
 }
 
 func (sr *subroundStartRound) generateNextConsensusGroup(roundIndex int64) error {
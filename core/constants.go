@@ -213,5 +213,27 @@ const MetricCreatedProposedBlock = "erd_consensus_created_proposed_block"
 //subround spare duration)
 const MetricProcessedProposedBlock = "erd_consensus_processed_proposed_block"
 
+// MetricCachePrefix is the prefix of the metrics holding the counters of the data pool caches. It is followed by the
+// cache name and the counter name, as in erd_cache_miniblocks_hits
+const MetricCachePrefix = "erd_cache_"
+
+// MetricStorerPrefix is the prefix of the metrics holding the lookup counters of the storers. It is followed by the
+// storer name and the counter name, as in erd_storer_block_headers_cache_hits
+const MetricStorerPrefix = "erd_storer_"
+
+// MetricCacheHitRates is the metric holding a short summary of the hit rates of the data pool caches
+const MetricCacheHitRates = "erd_cache_hit_rates"
+
+// MetricIndexerDroppedItems is the metric holding the number of items dropped because the indexing queue was full
+const MetricIndexerDroppedItems = "erd_indexer_dropped_items"
+
+// MetricTrieSyncNumReceivedNodes is the metric holding the number of trie nodes received from the network while
+// syncing the tries
+const MetricTrieSyncNumReceivedNodes = "erd_trie_sync_num_received_nodes"
+
+// MetricTrieSyncNumReceivedBytes is the metric holding the size in bytes of the trie nodes received from the network
+// while syncing the tries
+const MetricTrieSyncNumReceivedBytes = "erd_trie_sync_num_received_bytes"
+
 // MegabyteSize represents the size in bytes of a megabyte
 const MegabyteSize = 1024 * 1024
@@ -0,0 +1,6 @@
+package eventsNotifier
+
+import "errors"
+
+// ErrInvalidBufferSize signals that an invalid subscription buffer size has been provided
+var ErrInvalidBufferSize = errors.New("invalid subscription buffer size")
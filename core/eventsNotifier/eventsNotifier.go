@@ -0,0 +1,194 @@
+package eventsNotifier
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/logger"
+)
+
+var log = logger.GetOrCreate("core/eventsNotifier")
+
+type eventsNotifier struct {
+	mutSubscriptions sync.RWMutex
+	subscriptions    map[uint64]*Subscription
+	lastID           uint64
+	bufferSize       int
+}
+
+// NewEventsNotifier creates a component that pushes committed blocks, notarized headers and transactions touching
+// watched addresses to its subscribers. Each subscriber gets a buffered channel of the given size; events that
+// do not fit in a subscriber's buffer are dropped for that subscriber, so a slow subscriber never blocks the node
+func NewEventsNotifier(bufferSize int) (*eventsNotifier, error) {
+	if bufferSize <= 0 {
+		return nil, ErrInvalidBufferSize
+	}
+
+	return &eventsNotifier{
+		subscriptions: make(map[uint64]*Subscription),
+		bufferSize:    bufferSize,
+	}, nil
+}
+
+// Subscribe registers a new subscriber. Block events are always delivered, while transaction events are only
+// delivered if the sender or the receiver is one of the provided addresses
+func (en *eventsNotifier) Subscribe(addresses [][]byte) *Subscription {
+	en.mutSubscriptions.Lock()
+	defer en.mutSubscriptions.Unlock()
+
+	en.lastID++
+	subscription := &Subscription{
+		id:        en.lastID,
+		addresses: make(map[string]struct{}, len(addresses)),
+		events:    make(chan *api.Event, en.bufferSize),
+	}
+	for _, address := range addresses {
+		subscription.addresses[string(address)] = struct{}{}
+	}
+	en.subscriptions[subscription.id] = subscription
+
+	return subscription
+}
+
+// Unsubscribe removes the subscriber and closes its events channel
+func (en *eventsNotifier) Unsubscribe(subscription *Subscription) {
+	if subscription == nil {
+		return
+	}
+
+	en.mutSubscriptions.Lock()
+	defer en.mutSubscriptions.Unlock()
+
+	_, found := en.subscriptions[subscription.id]
+	if !found {
+		return
+	}
+
+	delete(en.subscriptions, subscription.id)
+	close(subscription.events)
+}
+
+// NotifyCommittedBlock pushes the event of a block committed by this node and the events of its transactions
+func (en *eventsNotifier) NotifyCommittedBlock(
+	header data.HeaderHandler,
+	headerHash []byte,
+	txs map[string]data.TransactionHandler,
+) {
+	if check.IfNil(header) {
+		return
+	}
+
+	en.mutSubscriptions.RLock()
+	defer en.mutSubscriptions.RUnlock()
+
+	if len(en.subscriptions) == 0 {
+		return
+	}
+
+	en.pushToAll(createHeaderEvent(header, headerHash))
+
+	blockHash := hex.EncodeToString(headerHash)
+	for txHash, tx := range txs {
+		if check.IfNil(tx) {
+			continue
+		}
+
+		var event *api.Event
+		for _, subscription := range en.subscriptions {
+			if !subscription.isWatching(tx.GetSndAddress()) && !subscription.isWatching(tx.GetRecvAddress()) {
+				continue
+			}
+
+			if event == nil {
+				event = createTransactionEvent(header, blockHash, []byte(txHash), tx)
+			}
+			en.push(subscription, event)
+		}
+	}
+}
+
+// NotarizedHeadersHandler pushes the events of the headers notarized by this node. It has the signature of the
+// block tracker notifiers handlers so it can be registered directly on them
+func (en *eventsNotifier) NotarizedHeadersHandler(_ uint32, headers []data.HeaderHandler, headersHashes [][]byte) {
+	en.mutSubscriptions.RLock()
+	defer en.mutSubscriptions.RUnlock()
+
+	if len(en.subscriptions) == 0 || len(headers) != len(headersHashes) {
+		return
+	}
+
+	for i, header := range headers {
+		if check.IfNil(header) {
+			continue
+		}
+
+		en.pushToAll(createHeaderEvent(header, headersHashes[i]))
+	}
+}
+
+func (en *eventsNotifier) pushToAll(event *api.Event) {
+	for _, subscription := range en.subscriptions {
+		en.push(subscription, event)
+	}
+}
+
+func (en *eventsNotifier) push(subscription *Subscription, event *api.Event) {
+	select {
+	case subscription.events <- event:
+	default:
+		log.Trace("eventsNotifier: subscriber buffer is full, event dropped",
+			"subscription", subscription.id,
+			"type", event.Type,
+			"hash", event.Hash,
+		)
+	}
+}
+
+func createHeaderEvent(header data.HeaderHandler, headerHash []byte) *api.Event {
+	eventType := api.EventTypeShardBlock
+	_, isMetaBlock := header.(*block.MetaBlock)
+	if isMetaBlock {
+		eventType = api.EventTypeMetaBlock
+	}
+
+	return &api.Event{
+		Type:     eventType,
+		ShardID:  header.GetShardID(),
+		Nonce:    header.GetNonce(),
+		Round:    header.GetRound(),
+		Hash:     hex.EncodeToString(headerHash),
+		RootHash: hex.EncodeToString(header.GetRootHash()),
+	}
+}
+
+func createTransactionEvent(
+	header data.HeaderHandler,
+	blockHash string,
+	txHash []byte,
+	tx data.TransactionHandler,
+) *api.Event {
+	event := &api.Event{
+		Type:      api.EventTypeTransaction,
+		ShardID:   header.GetShardID(),
+		Nonce:     header.GetNonce(),
+		Round:     header.GetRound(),
+		Hash:      hex.EncodeToString(txHash),
+		BlockHash: blockHash,
+		Sender:    hex.EncodeToString(tx.GetSndAddress()),
+		Receiver:  hex.EncodeToString(tx.GetRecvAddress()),
+	}
+	if tx.GetValue() != nil {
+		event.Value = tx.GetValue().String()
+	}
+
+	return event
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (en *eventsNotifier) IsInterfaceNil() bool {
+	return en == nil
+}
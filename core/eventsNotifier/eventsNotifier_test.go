@@ -0,0 +1,150 @@
+package eventsNotifier_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func drainEvents(subscription *eventsNotifier.Subscription) []*api.Event {
+	events := make([]*api.Event, 0)
+	for {
+		select {
+		case event := <-subscription.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestNewEventsNotifier_InvalidBufferSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	en, err := eventsNotifier.NewEventsNotifier(0)
+
+	assert.True(t, check.IfNil(en))
+	assert.Equal(t, eventsNotifier.ErrInvalidBufferSize, err)
+}
+
+func TestNewEventsNotifier_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	en, err := eventsNotifier.NewEventsNotifier(10)
+
+	assert.False(t, check.IfNil(en))
+	assert.Nil(t, err)
+}
+
+func TestEventsNotifier_SubscribeShouldAssignUniqueIDs(t *testing.T) {
+	t.Parallel()
+
+	en, _ := eventsNotifier.NewEventsNotifier(10)
+
+	sub1 := en.Subscribe(nil)
+	sub2 := en.Subscribe(nil)
+
+	assert.NotEqual(t, sub1.ID(), sub2.ID())
+}
+
+func TestEventsNotifier_UnsubscribeShouldCloseChannel(t *testing.T) {
+	t.Parallel()
+
+	en, _ := eventsNotifier.NewEventsNotifier(10)
+	subscription := en.Subscribe(nil)
+
+	en.Unsubscribe(subscription)
+	en.Unsubscribe(subscription)
+	en.Unsubscribe(nil)
+
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+
+	en.NotifyCommittedBlock(&block.Header{Nonce: 1}, []byte("hash"), nil)
+}
+
+func TestEventsNotifier_NotifyCommittedBlockShouldPushBlockAndWatchedTransactions(t *testing.T) {
+	t.Parallel()
+
+	watched := []byte("watched")
+	en, _ := eventsNotifier.NewEventsNotifier(10)
+	watcher := en.Subscribe([][]byte{watched})
+	other := en.Subscribe(nil)
+
+	header := &block.Header{Nonce: 7, Round: 8, ShardId: 1, RootHash: []byte("root")}
+	txs := map[string]data.TransactionHandler{
+		"tx1": &transaction.Transaction{SndAddr: watched, RcvAddr: []byte("other"), Value: big.NewInt(5)},
+		"tx2": &transaction.Transaction{SndAddr: []byte("a"), RcvAddr: []byte("b"), Value: big.NewInt(6)},
+		"tx3": &transaction.Transaction{SndAddr: []byte("c"), RcvAddr: watched, Value: big.NewInt(7)},
+	}
+	en.NotifyCommittedBlock(header, []byte("hash"), txs)
+
+	events := drainEvents(watcher)
+	require.Equal(t, 3, len(events))
+	assert.Equal(t, api.EventTypeShardBlock, events[0].Type)
+	assert.Equal(t, uint64(7), events[0].Nonce)
+	assert.Equal(t, uint64(8), events[0].Round)
+	assert.Equal(t, uint32(1), events[0].ShardID)
+	assert.Equal(t, hex.EncodeToString([]byte("hash")), events[0].Hash)
+	assert.Equal(t, hex.EncodeToString([]byte("root")), events[0].RootHash)
+
+	txHashes := make(map[string]*api.Event)
+	for _, event := range events[1:] {
+		assert.Equal(t, api.EventTypeTransaction, event.Type)
+		assert.Equal(t, hex.EncodeToString([]byte("hash")), event.BlockHash)
+		txHashes[event.Hash] = event
+	}
+	require.NotNil(t, txHashes[hex.EncodeToString([]byte("tx1"))])
+	require.NotNil(t, txHashes[hex.EncodeToString([]byte("tx3"))])
+	assert.Equal(t, "7", txHashes[hex.EncodeToString([]byte("tx3"))].Value)
+	assert.Equal(t, hex.EncodeToString(watched), txHashes[hex.EncodeToString([]byte("tx3"))].Receiver)
+
+	events = drainEvents(other)
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, api.EventTypeShardBlock, events[0].Type)
+}
+
+func TestEventsNotifier_NotarizedHeadersHandlerShouldPushHeaders(t *testing.T) {
+	t.Parallel()
+
+	en, _ := eventsNotifier.NewEventsNotifier(10)
+	subscription := en.Subscribe(nil)
+
+	headers := []data.HeaderHandler{
+		&block.MetaBlock{Nonce: 1},
+		&block.MetaBlock{Nonce: 2},
+	}
+	en.NotarizedHeadersHandler(0, headers, [][]byte{[]byte("h1"), []byte("h2")})
+	en.NotarizedHeadersHandler(0, headers, [][]byte{[]byte("h1")})
+
+	events := drainEvents(subscription)
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, api.EventTypeMetaBlock, events[0].Type)
+	assert.Equal(t, uint64(1), events[0].Nonce)
+	assert.Equal(t, hex.EncodeToString([]byte("h2")), events[1].Hash)
+}
+
+func TestEventsNotifier_FullBufferShouldDropEvents(t *testing.T) {
+	t.Parallel()
+
+	en, _ := eventsNotifier.NewEventsNotifier(2)
+	subscription := en.Subscribe(nil)
+
+	for i := uint64(0); i < 5; i++ {
+		en.NotifyCommittedBlock(&block.Header{Nonce: i}, []byte("hash"), nil)
+	}
+
+	events := drainEvents(subscription)
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, uint64(0), events[0].Nonce)
+	assert.Equal(t, uint64(1), events[1].Nonce)
+}
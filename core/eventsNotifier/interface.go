@@ -0,0 +1,14 @@
+package eventsNotifier
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// EventsNotifier defines the behaviour of a component that pushes chain events to its subscribers
+type EventsNotifier interface {
+	Subscribe(addresses [][]byte) *Subscription
+	Unsubscribe(subscription *Subscription)
+	NotifyCommittedBlock(header data.HeaderHandler, headerHash []byte, txs map[string]data.TransactionHandler)
+	NotarizedHeadersHandler(shardID uint32, headers []data.HeaderHandler, headersHashes [][]byte)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,27 @@
+package eventsNotifier
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/api"
+)
+
+// Subscription holds the events channel of a subscriber, together with the addresses it watches
+type Subscription struct {
+	id        uint64
+	addresses map[string]struct{}
+	events    chan *api.Event
+}
+
+// ID returns the unique identifier of the subscription
+func (s *Subscription) ID() uint64 {
+	return s.id
+}
+
+// Events returns the channel the events are pushed on. The channel is closed when the subscription is removed
+func (s *Subscription) Events() <-chan *api.Event {
+	return s.events
+}
+
+func (s *Subscription) isWatching(address []byte) bool {
+	_, ok := s.addresses[string(address)]
+	return ok
+}
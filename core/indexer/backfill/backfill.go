@@ -0,0 +1,262 @@
+package backfill
+
+import (
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+var log = logger.GetOrCreate("core/indexer/backfill")
+
+// ArgsBackfiller holds all the arguments needed to create a backfiller
+type ArgsBackfiller struct {
+	Store            dataRetriever.StorageService
+	BlockWriter      BlockWriter
+	Marshalizer      marshal.Marshalizer
+	Uint64Converter  typeConverters.Uint64ByteSliceConverter
+	ShardCoordinator sharding.Coordinator
+}
+
+// Result holds the outcome of a backfill run
+type Result struct {
+	NumIndexedBlocks uint64
+	NumMissingBlocks uint64
+	NumIndexedTxs    uint64
+	NumMissingTxs    uint64
+}
+
+// backfiller reads the blocks of the node's own shard from the local storage and replays them into an indexer.
+// Every block is written inline, so all the replayed blocks are indexed when the backfill returns. The consensus
+// signers are not stored together with the blocks so the replayed blocks are indexed without them. The lookups are
+// not limited to the active epochs: the headers are searched in all the epochs kept by the storers and their bodies
+// and transactions in the epoch of the header first
+type backfiller struct {
+	store            dataRetriever.StorageService
+	blockWriter      BlockWriter
+	marshalizer      marshal.Marshalizer
+	uint64Converter  typeConverters.Uint64ByteSliceConverter
+	shardCoordinator sharding.Coordinator
+}
+
+// NewBackfiller creates a new backfiller instance
+func NewBackfiller(args ArgsBackfiller) (*backfiller, error) {
+	if check.IfNil(args.Store) {
+		return nil, ErrNilStorageService
+	}
+	if check.IfNil(args.BlockWriter) {
+		return nil, ErrNilBlockWriter
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.Uint64Converter) {
+		return nil, ErrNilUint64ByteSliceConverter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+
+	return &backfiller{
+		store:            args.Store,
+		blockWriter:      args.BlockWriter,
+		marshalizer:      args.Marshalizer,
+		uint64Converter:  args.Uint64Converter,
+		shardCoordinator: args.ShardCoordinator,
+	}, nil
+}
+
+// Backfill replays into the indexer all the blocks having the nonce in the [startNonce, endNonce] interval.
+// Blocks missing from the local storage are skipped and counted in the returned result. The backfill stops at the
+// first failed write and returns the error together with the result of the blocks indexed so far
+func (b *backfiller) Backfill(startNonce uint64, endNonce uint64) (*Result, error) {
+	if startNonce > endNonce {
+		return nil, ErrInvalidNonceRange
+	}
+
+	result := &Result{}
+	for nonce := startNonce; ; nonce++ {
+		err := b.backfillNonce(nonce, result)
+		if err != nil {
+			return result, fmt.Errorf("%w for the block with nonce %d", err, nonce)
+		}
+
+		if nonce == endNonce {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (b *backfiller) backfillNonce(nonce uint64, result *Result) error {
+	isMetachain := b.shardCoordinator.SelfId() == sharding.MetachainShardId
+
+	header, err := b.getHeaderByNonce(nonce, isMetachain)
+	if err != nil {
+		log.Debug("backfill: block not found", "nonce", nonce, "error", err.Error())
+		result.NumMissingBlocks++
+		return nil
+	}
+
+	if isMetachain {
+		err = b.blockWriter.WriteMetaBlock(header)
+		if err != nil {
+			return err
+		}
+
+		result.NumIndexedBlocks++
+		return nil
+	}
+
+	shardHeader, ok := header.(*block.Header)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	body, txPool, numMissingTxs := b.getBodyAndTransactions(shardHeader)
+	err = b.blockWriter.WriteShardBlock(body, header, txPool)
+	if err != nil {
+		return err
+	}
+
+	result.NumIndexedBlocks++
+	result.NumIndexedTxs += uint64(len(txPool))
+	result.NumMissingTxs += numMissingTxs
+
+	return nil
+}
+
+func (b *backfiller) getHeaderByNonce(nonce uint64, isMetachain bool) (data.HeaderHandler, error) {
+	hdrUnit := dataRetriever.BlockHeaderUnit
+	nonceUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(b.shardCoordinator.SelfId())
+	if isMetachain {
+		hdrUnit = dataRetriever.MetaBlockUnit
+		nonceUnit = dataRetriever.MetaHdrNonceHashDataUnit
+	}
+
+	headerHash, err := b.searchFirst(nonceUnit, b.uint64Converter.ToByteSlice(nonce))
+	if err != nil {
+		return nil, err
+	}
+
+	buff, err := b.searchFirst(hdrUnit, headerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var header data.HeaderHandler = &block.Header{}
+	if isMetachain {
+		header = &block.MetaBlock{}
+	}
+
+	err = b.marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (b *backfiller) getBodyAndTransactions(header *block.Header) (block.Body, map[string]data.TransactionHandler, uint64) {
+	body := make(block.Body, 0, len(header.MiniBlockHeaders))
+	txPool := make(map[string]data.TransactionHandler)
+	numMissingTxs := uint64(0)
+
+	for _, mbHeader := range header.MiniBlockHeaders {
+		buff, err := b.getFromEpoch(dataRetriever.MiniBlockUnit, mbHeader.Hash, header.Epoch)
+		if err != nil {
+			log.Debug("backfill: miniblock not found", "hash", mbHeader.Hash, "error", err.Error())
+			continue
+		}
+
+		miniBlock := &block.MiniBlock{}
+		err = b.marshalizer.Unmarshal(miniBlock, buff)
+		if err != nil {
+			log.Debug("backfill: miniblock unmarshal", "hash", mbHeader.Hash, "error", err.Error())
+			continue
+		}
+		body = append(body, miniBlock)
+
+		for _, txHash := range miniBlock.TxHashes {
+			tx, errGet := b.getTransaction(miniBlock.Type, txHash, header.Epoch)
+			if errGet != nil {
+				log.Trace("backfill: transaction not found", "hash", txHash, "error", errGet.Error())
+				numMissingTxs++
+				continue
+			}
+
+			txPool[string(txHash)] = tx
+		}
+	}
+
+	return body, txPool, numMissingTxs
+}
+
+func (b *backfiller) getTransaction(mbType block.Type, txHash []byte, epoch uint32) (data.TransactionHandler, error) {
+	var unit dataRetriever.UnitType
+	var tx data.TransactionHandler
+	switch mbType {
+	case block.SmartContractResultBlock:
+		unit = dataRetriever.UnsignedTransactionUnit
+		tx = &smartContractResult.SmartContractResult{}
+	case block.RewardsBlock:
+		unit = dataRetriever.RewardTransactionUnit
+		tx = &rewardTx.RewardTx{}
+	default:
+		unit = dataRetriever.TransactionUnit
+		tx = &transaction.Transaction{}
+	}
+
+	buff, err := b.getFromEpoch(unit, txHash, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.marshalizer.Unmarshal(tx, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// searchFirst searches the key in all the epochs kept by the unit's storer, from the newest to the oldest
+func (b *backfiller) searchFirst(unit dataRetriever.UnitType, key []byte) ([]byte, error) {
+	storer := b.store.GetStorer(unit)
+	if check.IfNil(storer) {
+		return nil, fmt.Errorf("%w: %v", ErrNilStorer, unit)
+	}
+
+	return storer.SearchFirst(key)
+}
+
+// getFromEpoch reads the key from the provided epoch of the unit's storer and falls back to searching it in all the
+// epochs, as the data of a block committed right after an epoch change can be stored in the previous epoch
+func (b *backfiller) getFromEpoch(unit dataRetriever.UnitType, key []byte, epoch uint32) ([]byte, error) {
+	storer := b.store.GetStorer(unit)
+	if check.IfNil(storer) {
+		return nil, fmt.Errorf("%w: %v", ErrNilStorer, unit)
+	}
+
+	buff, err := storer.GetFromEpoch(key, epoch)
+	if err == nil {
+		return buff, nil
+	}
+
+	return storer.SearchFirst(key)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (b *backfiller) IsInterfaceNil() bool {
+	return b == nil
+}
@@ -0,0 +1,370 @@
+package backfill_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/indexer/backfill"
+	"github.com/ElrondNetwork/elrond-go/core/mock"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/epochStart/notifier"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage/factory"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/pathmanager"
+	"github.com/ElrondNetwork/elrond-go/storage/pruning"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var marshalizer = &mock.MarshalizerMock{}
+var uint64Converter = uint64ByteSlice.NewBigEndianConverter()
+
+func createMemoryStore() dataRetriever.StorageService {
+	store := dataRetriever.NewChainStorer()
+	for _, unit := range []dataRetriever.UnitType{
+		dataRetriever.TransactionUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.MetaBlockUnit,
+		dataRetriever.UnsignedTransactionUnit,
+		dataRetriever.RewardTransactionUnit,
+		dataRetriever.MetaHdrNonceHashDataUnit,
+		dataRetriever.ShardHdrNonceHashDataUnit,
+	} {
+		cacher, _ := lrucache.NewCache(10)
+		storer, _ := storageUnit.NewStorageUnit(cacher, memorydb.New())
+		store.AddStorer(unit, storer)
+	}
+
+	return store
+}
+
+func putMarshalized(t *testing.T, store dataRetriever.StorageService, unit dataRetriever.UnitType, key []byte, obj interface{}) {
+	buff, err := marshalizer.Marshal(obj)
+	require.Nil(t, err)
+
+	err = store.Put(unit, key, buff)
+	require.Nil(t, err)
+}
+
+func putShardBlock(t *testing.T, store dataRetriever.StorageService, nonce uint64) {
+	tx := &transaction.Transaction{Nonce: nonce, Value: big.NewInt(10)}
+	reward := &rewardTx.RewardTx{Round: nonce, Value: big.NewInt(1)}
+	txHash := []byte(fmt.Sprintf("tx%d", nonce))
+	rewardHash := []byte(fmt.Sprintf("reward%d", nonce))
+	putMarshalized(t, store, dataRetriever.TransactionUnit, txHash, tx)
+	putMarshalized(t, store, dataRetriever.RewardTransactionUnit, rewardHash, reward)
+
+	txMiniBlock := &block.MiniBlock{Type: block.TxBlock, TxHashes: [][]byte{txHash, []byte("missing tx")}}
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{rewardHash}}
+	txMbHash := []byte(fmt.Sprintf("txMb%d", nonce))
+	rewardsMbHash := []byte(fmt.Sprintf("rewardsMb%d", nonce))
+	putMarshalized(t, store, dataRetriever.MiniBlockUnit, txMbHash, txMiniBlock)
+	putMarshalized(t, store, dataRetriever.MiniBlockUnit, rewardsMbHash, rewardsMiniBlock)
+
+	header := &block.Header{
+		Nonce: nonce,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: txMbHash, Type: block.TxBlock},
+			{Hash: rewardsMbHash, Type: block.RewardsBlock},
+		},
+	}
+	headerHash := []byte(fmt.Sprintf("header%d", nonce))
+	putMarshalized(t, store, dataRetriever.BlockHeaderUnit, headerHash, header)
+
+	err := store.Put(dataRetriever.ShardHdrNonceHashDataUnit, uint64Converter.ToByteSlice(nonce), headerHash)
+	require.Nil(t, err)
+}
+
+func createArgs(store dataRetriever.StorageService, blockWriter backfill.BlockWriter) backfill.ArgsBackfiller {
+	return backfill.ArgsBackfiller{
+		Store:            store,
+		BlockWriter:      blockWriter,
+		Marshalizer:      marshalizer,
+		Uint64Converter:  uint64Converter,
+		ShardCoordinator: mock.ShardCoordinatorMock{},
+	}
+}
+
+func TestNewBackfiller_NilArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs(nil, &mock.BlockWriterStub{})
+	_, err := backfill.NewBackfiller(args)
+	assert.Equal(t, backfill.ErrNilStorageService, err)
+
+	args = createArgs(createMemoryStore(), nil)
+	_, err = backfill.NewBackfiller(args)
+	assert.Equal(t, backfill.ErrNilBlockWriter, err)
+
+	args = createArgs(createMemoryStore(), &mock.BlockWriterStub{})
+	args.Marshalizer = nil
+	_, err = backfill.NewBackfiller(args)
+	assert.Equal(t, backfill.ErrNilMarshalizer, err)
+
+	args = createArgs(createMemoryStore(), &mock.BlockWriterStub{})
+	args.Uint64Converter = nil
+	_, err = backfill.NewBackfiller(args)
+	assert.Equal(t, backfill.ErrNilUint64ByteSliceConverter, err)
+
+	args = createArgs(createMemoryStore(), &mock.BlockWriterStub{})
+	args.ShardCoordinator = nil
+	_, err = backfill.NewBackfiller(args)
+	assert.Equal(t, backfill.ErrNilShardCoordinator, err)
+}
+
+func TestBackfiller_BackfillInvalidRangeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	b, _ := backfill.NewBackfiller(createArgs(createMemoryStore(), &mock.BlockWriterStub{}))
+
+	result, err := b.Backfill(5, 4)
+	assert.Nil(t, result)
+	assert.Equal(t, backfill.ErrInvalidNonceRange, err)
+}
+
+func TestBackfiller_BackfillShouldReplayShardBlocks(t *testing.T) {
+	t.Parallel()
+
+	store := createMemoryStore()
+	putShardBlock(t, store, 1)
+	putShardBlock(t, store, 2)
+
+	savedNonces := make([]uint64, 0)
+	blockWriter := &mock.BlockWriterStub{
+		WriteShardBlockCalled: func(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error {
+			savedNonces = append(savedNonces, header.GetNonce())
+			assert.Equal(t, 2, len(body.(block.Body)))
+			assert.Equal(t, 2, len(txPool))
+
+			tx, ok := txPool[fmt.Sprintf("tx%d", header.GetNonce())].(*transaction.Transaction)
+			require.True(t, ok)
+			assert.Equal(t, header.GetNonce(), tx.Nonce)
+
+			_, ok = txPool[fmt.Sprintf("reward%d", header.GetNonce())].(*rewardTx.RewardTx)
+			assert.True(t, ok)
+
+			return nil
+		},
+	}
+	b, _ := backfill.NewBackfiller(createArgs(store, blockWriter))
+
+	result, err := b.Backfill(0, 3)
+	require.Nil(t, err)
+
+	assert.Equal(t, []uint64{1, 2}, savedNonces)
+	assert.Equal(t, &backfill.Result{
+		NumIndexedBlocks: 2,
+		NumMissingBlocks: 2,
+		NumIndexedTxs:    4,
+		NumMissingTxs:    2,
+	}, result)
+}
+
+func TestBackfiller_BackfillShouldReplayMetaBlocks(t *testing.T) {
+	t.Parallel()
+
+	store := createMemoryStore()
+	metaBlock := &block.MetaBlock{Nonce: 7}
+	putMarshalized(t, store, dataRetriever.MetaBlockUnit, []byte("meta"), metaBlock)
+	_ = store.Put(dataRetriever.MetaHdrNonceHashDataUnit, uint64Converter.ToByteSlice(7), []byte("meta"))
+
+	savedNonces := make([]uint64, 0)
+	blockWriter := &mock.BlockWriterStub{
+		WriteMetaBlockCalled: func(header data.HeaderHandler) error {
+			savedNonces = append(savedNonces, header.GetNonce())
+			return nil
+		},
+	}
+	args := createArgs(store, blockWriter)
+	args.ShardCoordinator, _ = sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	b, _ := backfill.NewBackfiller(args)
+
+	result, err := b.Backfill(7, 7)
+	require.Nil(t, err)
+
+	assert.Equal(t, []uint64{7}, savedNonces)
+	assert.Equal(t, uint64(1), result.NumIndexedBlocks)
+}
+
+func TestBackfiller_BackfillShouldStopAtTheFirstFailedWrite(t *testing.T) {
+	t.Parallel()
+
+	store := createMemoryStore()
+	putShardBlock(t, store, 1)
+	putShardBlock(t, store, 2)
+
+	errWrite := errors.New("write failed")
+	blockWriter := &mock.BlockWriterStub{
+		WriteShardBlockCalled: func(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error {
+			if header.GetNonce() == 2 {
+				return errWrite
+			}
+			return nil
+		},
+	}
+	b, _ := backfill.NewBackfiller(createArgs(store, blockWriter))
+
+	result, err := b.Backfill(1, 2)
+
+	assert.True(t, errors.Is(err, errWrite))
+	assert.Equal(t, uint64(1), result.NumIndexedBlocks)
+}
+
+func TestBackfiller_BackfillShouldReturnAfterAllTheWritesAreDone(t *testing.T) {
+	t.Parallel()
+
+	store := createMemoryStore()
+	numBlocks := uint64(5)
+	for nonce := uint64(1); nonce <= numBlocks; nonce++ {
+		putShardBlock(t, store, nonce)
+	}
+
+	var numWrittenBlocks, numWrittenTxs int32
+	writer := &mock.DocumentsWriterStub{
+		WriteBlockCalled: func(block *indexer.Block) error {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&numWrittenBlocks, 1)
+			return nil
+		},
+		WriteTransactionsCalled: func(transactions []*indexer.Transaction) error {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&numWrittenTxs, int32(len(transactions)))
+			return nil
+		},
+	}
+	blockWriter, err := indexer.NewBlockWriter(indexer.ArgsBlockWriter{
+		Writer:           writer,
+		ShardCoordinator: mock.ShardCoordinatorMock{},
+		Marshalizer:      marshalizer,
+		Hasher:           mock.HasherMock{},
+		Options:          &indexer.Options{TxIndexingEnabled: true},
+	})
+	require.Nil(t, err)
+	b, _ := backfill.NewBackfiller(createArgs(store, blockWriter))
+
+	result, err := b.Backfill(1, numBlocks)
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(numBlocks), atomic.LoadInt32(&numWrittenBlocks))
+	assert.Equal(t, int32(result.NumIndexedTxs), atomic.LoadInt32(&numWrittenTxs))
+	assert.Equal(t, int32(2*numBlocks), atomic.LoadInt32(&numWrittenTxs))
+}
+
+func createPruningStore(t *testing.T, dbDir string, archiveDir string, epochNotifier pruning.EpochStartNotifier) dataRetriever.StorageService {
+	store := dataRetriever.NewChainStorer()
+	for _, unit := range []dataRetriever.UnitType{
+		dataRetriever.TransactionUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.RewardTransactionUnit,
+		dataRetriever.ShardHdrNonceHashDataUnit,
+	} {
+		identifier := fmt.Sprintf("unit%d", unit)
+		dbPathManager, err := pathmanager.NewPathManager(
+			filepath.Join(dbDir, "Epoch_[E]", "Shard_[S]", "[I]"),
+			filepath.Join(dbDir, "Static", "Shard_[S]", "[I]"))
+		require.Nil(t, err)
+		archivePathManager, err := pathmanager.NewPathManager(
+			filepath.Join(archiveDir, "Epoch_[E]", "Shard_[S]", "[I]"),
+			filepath.Join(archiveDir, "Static", "Shard_[S]", "[I]"))
+		require.Nil(t, err)
+
+		storer, err := pruning.NewPruningStorer(&pruning.StorerArgs{
+			Identifier:       identifier,
+			ShardCoordinator: mock.ShardCoordinatorMock{},
+			CacheConf:        storageUnit.CacheConfig{Type: storageUnit.LRUCache, Size: 10, Shards: 1},
+			PathManager:      dbPathManager,
+			PersisterFactory: factory.NewPersisterFactory(config.DBConfig{
+				Type:              string(storageUnit.LvlDbSerial),
+				BatchDelaySeconds: 2,
+				MaxBatchSize:      1,
+				MaxOpenFiles:      10,
+			}),
+			Notifier:              epochNotifier,
+			NumOfEpochsToKeep:     3,
+			NumOfActivePersisters: 1,
+			PruningEnabled:        true,
+			MaxBatchSize:          1,
+			ArchiveEnabled:        true,
+			ArchivePathManager:    archivePathManager,
+		})
+		require.Nil(t, err)
+		store.AddStorer(unit, storer)
+	}
+
+	return store
+}
+
+func TestBackfiller_BackfillShouldReadBlocksFromEpochsNoLongerActive(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "backfill-db")
+	archiveDir, _ := ioutil.TempDir("", "backfill-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	epochNotifier := notifier.NewEpochStartSubscriptionHandler()
+	store := createPruningStore(t, dbDir, archiveDir, epochNotifier)
+	defer func() {
+		_ = store.CloseAll()
+	}()
+
+	putShardBlock(t, store, 1)
+	epochNotifier.NotifyAll(&block.Header{Epoch: 1})
+	putShardBlock(t, store, 2)
+	for _, unit := range []dataRetriever.UnitType{
+		dataRetriever.TransactionUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.RewardTransactionUnit,
+		dataRetriever.ShardHdrNonceHashDataUnit,
+	} {
+		store.GetStorer(unit).ClearCache()
+	}
+
+	// the epoch of the first block is closed, so it can not be read with the lookups in the active epochs only
+	_, err := store.Get(dataRetriever.ShardHdrNonceHashDataUnit, uint64Converter.ToByteSlice(1))
+	require.NotNil(t, err)
+
+	savedNonces := make([]uint64, 0)
+	blockWriter := &mock.BlockWriterStub{
+		WriteShardBlockCalled: func(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error {
+			savedNonces = append(savedNonces, header.GetNonce())
+			assert.Equal(t, 2, len(body.(block.Body)))
+			assert.Equal(t, 2, len(txPool))
+
+			return nil
+		},
+	}
+	b, _ := backfill.NewBackfiller(createArgs(store, blockWriter))
+
+	result, err := b.Backfill(1, 2)
+	require.Nil(t, err)
+
+	assert.Equal(t, []uint64{1, 2}, savedNonces)
+	assert.Equal(t, &backfill.Result{
+		NumIndexedBlocks: 2,
+		NumIndexedTxs:    4,
+		NumMissingTxs:    2,
+	}, result)
+}
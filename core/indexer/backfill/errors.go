@@ -0,0 +1,29 @@
+package backfill
+
+import (
+	"errors"
+)
+
+// ErrNilStorageService signals that a nil storage service has been provided
+var ErrNilStorageService = errors.New("nil storage service")
+
+// ErrNilBlockWriter signals that a nil block writer has been provided
+var ErrNilBlockWriter = errors.New("nil block writer")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilUint64ByteSliceConverter signals that a nil uint64 byte slice converter has been provided
+var ErrNilUint64ByteSliceConverter = errors.New("nil uint64 byte slice converter")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator has been provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrInvalidNonceRange signals that the start nonce is greater than the end nonce
+var ErrInvalidNonceRange = errors.New("invalid nonce range")
+
+// ErrWrongTypeAssertion signals that a stored header has an unexpected type
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrNilStorer signals that the storage service holds no storer for a requested unit
+var ErrNilStorer = errors.New("nil storer")
@@ -0,0 +1,12 @@
+package backfill
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// BlockWriter writes the replayed blocks into the indexer. A call returns only after the block is written
+type BlockWriter interface {
+	WriteShardBlock(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error
+	WriteMetaBlock(header data.HeaderHandler) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// ArgsBlockWriter holds all the arguments needed to create a block writer
+type ArgsBlockWriter struct {
+	Writer           DocumentsWriter
+	ShardCoordinator sharding.Coordinator
+	Marshalizer      marshal.Marshalizer
+	Hasher           hashing.Hasher
+	Options          *Options
+}
+
+// blockWriter builds the same block and transactions documents as the indexer drivers but writes them inline through
+// a documents writer and returns the failures, so the caller knows the block is indexed when the call returns
+type blockWriter struct {
+	writer           DocumentsWriter
+	shardCoordinator sharding.Coordinator
+	marshalizer      marshal.Marshalizer
+	hasher           hashing.Hasher
+	options          *Options
+}
+
+// NewBlockWriter creates a new block writer instance
+func NewBlockWriter(args ArgsBlockWriter) (*blockWriter, error) {
+	if check.IfNil(args.Writer) {
+		return nil, ErrNilDocumentsWriter
+	}
+	err := checkIndexerParams(args.ShardCoordinator, args.Marshalizer, args.Hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockWriter{
+		writer:           args.Writer,
+		shardCoordinator: args.ShardCoordinator,
+		marshalizer:      args.Marshalizer,
+		hasher:           args.Hasher,
+		options:          args.Options,
+	}, nil
+}
+
+// WriteShardBlock writes the block header and, if enabled, the block's transactions
+func (bw *blockWriter) WriteShardBlock(
+	bodyHandler data.BodyHandler,
+	headerHandler data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+) error {
+	if check.IfNil(headerHandler) {
+		return ErrNoHeader
+	}
+	body, ok := bodyHandler.(block.Body)
+	if !ok {
+		return ErrBodyTypeAssertion
+	}
+
+	err := bw.writeHeader(headerHandler)
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 || bw.options == nil || !bw.options.TxIndexingEnabled {
+		return nil
+	}
+
+	transactions := createTransactionDocuments(body, headerHandler, txPool, bw.shardCoordinator.SelfId(), bw.marshalizer, bw.hasher)
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	return bw.writer.WriteTransactions(transactions)
+}
+
+// WriteMetaBlock writes a meta block header
+func (bw *blockWriter) WriteMetaBlock(headerHandler data.HeaderHandler) error {
+	if check.IfNil(headerHandler) {
+		return ErrNoHeader
+	}
+
+	return bw.writeHeader(headerHandler)
+}
+
+func (bw *blockWriter) writeHeader(header data.HeaderHandler) error {
+	blockDocument, _, err := createBlockDocument(header, nil, bw.marshalizer, bw.hasher)
+	if err != nil {
+		return err
+	}
+
+	return bw.writer.WriteBlock(blockDocument)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bw *blockWriter) IsInterfaceNil() bool {
+	return bw == nil
+}
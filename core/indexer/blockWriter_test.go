@@ -0,0 +1,108 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/mock"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBlockWriterArgs(writer indexer.DocumentsWriter) indexer.ArgsBlockWriter {
+	return indexer.ArgsBlockWriter{
+		Writer:           writer,
+		ShardCoordinator: shardCoordinator,
+		Marshalizer:      marshalizer,
+		Hasher:           hasher,
+		Options:          &indexer.Options{TxIndexingEnabled: true},
+	}
+}
+
+func TestNewBlockWriter_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	bw, err := indexer.NewBlockWriter(createBlockWriterArgs(nil))
+	assert.True(t, check.IfNil(bw))
+	assert.Equal(t, indexer.ErrNilDocumentsWriter, err)
+
+	args := createBlockWriterArgs(&mock.DocumentsWriterStub{})
+	args.Marshalizer = nil
+	bw, err = indexer.NewBlockWriter(args)
+	assert.True(t, check.IfNil(bw))
+	assert.Equal(t, core.ErrNilMarshalizer, err)
+}
+
+func TestBlockWriter_WriteShardBlockShouldWriteHeaderAndTransactions(t *testing.T) {
+	t.Parallel()
+
+	numBlocks, numTxs := 0, 0
+	writer := &mock.DocumentsWriterStub{
+		WriteBlockCalled: func(block *indexer.Block) error {
+			numBlocks++
+			return nil
+		},
+		WriteTransactionsCalled: func(transactions []*indexer.Transaction) error {
+			numTxs += len(transactions)
+			return nil
+		},
+	}
+	bw, _ := indexer.NewBlockWriter(createBlockWriterArgs(writer))
+
+	err := bw.WriteShardBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numBlocks)
+	assert.Equal(t, 3, numTxs)
+}
+
+func TestBlockWriter_WriteShardBlockShouldReturnTheWriteError(t *testing.T) {
+	t.Parallel()
+
+	numTxWrites := 0
+	writer := &mock.DocumentsWriterStub{
+		WriteBlockCalled: func(block *indexer.Block) error {
+			return errWriteFailed
+		},
+		WriteTransactionsCalled: func(transactions []*indexer.Transaction) error {
+			numTxWrites++
+			return nil
+		},
+	}
+	bw, _ := indexer.NewBlockWriter(createBlockWriterArgs(writer))
+
+	err := bw.WriteShardBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool())
+	assert.Equal(t, errWriteFailed, err)
+	assert.Equal(t, 0, numTxWrites)
+
+	writer.WriteBlockCalled = nil
+	writer.WriteTransactionsCalled = func(transactions []*indexer.Transaction) error {
+		return errWriteFailed
+	}
+	err = bw.WriteShardBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool())
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestBlockWriter_WriteMetaBlockShouldWriteTheHeader(t *testing.T) {
+	t.Parallel()
+
+	var written *indexer.Block
+	writer := &mock.DocumentsWriterStub{
+		WriteBlockCalled: func(block *indexer.Block) error {
+			written = block
+			return nil
+		},
+	}
+	bw, _ := indexer.NewBlockWriter(createBlockWriterArgs(writer))
+
+	err := bw.WriteMetaBlock(&block.MetaBlock{Nonce: 7})
+	require.Nil(t, err)
+	require.NotNil(t, written)
+	assert.Equal(t, uint64(7), written.Nonce)
+
+	err = bw.WriteMetaBlock(nil)
+	assert.Equal(t, indexer.ErrNoHeader, err)
+}
@@ -0,0 +1,155 @@
+package indexer
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// createBlockDocument builds the document saved for a block header, together with the header's hash.
+// All the indexer drivers store the same documents, only the storage differs
+func createBlockDocument(
+	header data.HeaderHandler,
+	signersIndexes []uint64,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*Block, []byte, error) {
+	h, err := marshalizer.Marshal(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headerHash := hasher.Compute(string(h))
+	proposer := uint64(0)
+	if len(signersIndexes) > 0 {
+		proposer = signersIndexes[0]
+	}
+
+	return &Block{
+		Nonce:         header.GetNonce(),
+		Round:         header.GetRound(),
+		ShardID:       header.GetShardID(),
+		Hash:          hex.EncodeToString(headerHash),
+		Proposer:      proposer,
+		Validators:    signersIndexes,
+		PubKeyBitmap:  hex.EncodeToString(header.GetPubKeysBitmap()),
+		Size:          int64(len(h)),
+		Timestamp:     time.Duration(header.GetTimeStamp()),
+		TxCount:       header.GetTxCount(),
+		StateRootHash: hex.EncodeToString(header.GetRootHash()),
+		PrevHash:      hex.EncodeToString(header.GetPrevHash()),
+	}, headerHash, nil
+}
+
+// createTransactionDocuments builds the documents of all the block's transactions that can be found in the pool
+func createTransactionDocuments(
+	body block.Body,
+	header data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+	selfShardID uint32,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) []*Transaction {
+	transactions := make([]*Transaction, 0, header.GetTxCount())
+	blockMarshal, _ := marshalizer.Marshal(header)
+	blockHash := hasher.Compute(string(blockMarshal))
+
+	for _, mb := range body {
+		mbMarshal, err := marshalizer.Marshal(mb)
+		if err != nil {
+			log.Debug("indexer: marshal", "error", "could not marshal miniblock")
+			continue
+		}
+		mbHash := hasher.Compute(string(mbMarshal))
+
+		mbTxStatus := "Pending"
+		if selfShardID == mb.ReceiverShardID {
+			mbTxStatus = "Success"
+		}
+
+		for _, txHash := range mb.TxHashes {
+			currentTxHandler, ok := txPool[string(txHash)]
+			if !ok {
+				log.Debug("indexer: could not find tx hash in pool")
+				continue
+			}
+
+			currentTx := getTransactionByType(currentTxHandler, txHash, mbHash, blockHash, mb, header, mbTxStatus)
+			if currentTx == nil {
+				log.Debug("indexer: found tx in pool but of wrong type")
+				continue
+			}
+
+			transactions = append(transactions, currentTx)
+		}
+	}
+
+	return transactions
+}
+
+// createGeneralTPSDocument builds the document holding the network wide statistics
+func createGeneralTPSDocument(tpsBenchmark statistics.TPSBenchmark) *TPS {
+	return &TPS{
+		LiveTPS:    tpsBenchmark.LiveTPS(),
+		PeakTPS:    tpsBenchmark.PeakTPS(),
+		NrOfShards: tpsBenchmark.NrOfShards(),
+		// TODO: This value is still mocked, it should be removed if we cannot populate it correctly
+		NrOfNodes:             100,
+		BlockNumber:           tpsBenchmark.BlockNumber(),
+		RoundNumber:           tpsBenchmark.RoundNumber(),
+		RoundTime:             tpsBenchmark.RoundTime(),
+		AverageBlockTxCount:   tpsBenchmark.AverageBlockTxCount(),
+		LastBlockTxCount:      tpsBenchmark.LastBlockTxCount(),
+		TotalProcessedTxCount: tpsBenchmark.TotalProcessedTxCount(),
+	}
+}
+
+// createShardTPSDocument builds the document holding the statistics of one shard
+func createShardTPSDocument(shardInfo statistics.ShardStatistic) *TPS {
+	bigTxCount := big.NewInt(int64(shardInfo.AverageBlockTxCount()))
+
+	return &TPS{
+		ShardID:               shardInfo.ShardID(),
+		LiveTPS:               shardInfo.LiveTPS(),
+		PeakTPS:               shardInfo.PeakTPS(),
+		AverageTPS:            shardInfo.AverageTPS(),
+		AverageBlockTxCount:   bigTxCount,
+		CurrentBlockNonce:     shardInfo.CurrentBlockNonce(),
+		LastBlockTxCount:      shardInfo.LastBlockTxCount(),
+		TotalProcessedTxCount: shardInfo.TotalProcessedTxCount(),
+	}
+}
+
+// createTPSDocuments builds the network wide statistics document and the documents of all shards, keyed by their IDs
+func createTPSDocuments(tpsBenchmark statistics.TPSBenchmark) map[string]*TPS {
+	tpsDocuments := map[string]*TPS{metachainTpsDocID: createGeneralTPSDocument(tpsBenchmark)}
+	for _, shardInfo := range tpsBenchmark.ShardStatistics() {
+		tpsDocuments[shardTPSDocumentID(shardInfo.ShardID())] = createShardTPSDocument(shardInfo)
+	}
+
+	return tpsDocuments
+}
+
+func shardTPSDocumentID(shardID uint32) string {
+	return shardTpsDocIDPrefix + strconv.FormatUint(uint64(shardID), 10)
+}
+
+func roundDocumentID(roundInfo RoundInfo) string {
+	return strconv.FormatUint(uint64(roundInfo.ShardId), 10) + "_" + strconv.FormatUint(roundInfo.Index, 10)
+}
+
+func hexEncodePubKeys(pubKeys [][]byte) []string {
+	encoded := make([]string, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		encoded = append(encoded, hex.EncodeToString(pubKey))
+	}
+
+	return encoded
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
@@ -135,6 +134,15 @@ func checkElasticSearchParams(
 	if url == "" {
 		return core.ErrNilUrl
 	}
+
+	return checkIndexerParams(coordinator, marshalizer, hasher)
+}
+
+func checkIndexerParams(
+	coordinator sharding.Coordinator,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) error {
 	if coordinator == nil || coordinator.IsInterfaceNil() {
 		return core.ErrNilCoordinator
 	}
@@ -244,41 +252,26 @@ func (ei *elasticIndexer) SaveMetaBlock(header data.HeaderHandler, signersIndexe
 	go ei.saveHeader(header, signersIndexes)
 }
 
-// SaveRoundInfo will save data about a round on elastic search
+// SaveRoundInfo will save data about a round on elastic search, without waiting for the request to complete
 func (ei *elasticIndexer) SaveRoundInfo(roundInfo RoundInfo) {
-	var buff bytes.Buffer
-
-	marshalizedRoundInfo, err := ei.marshalizer.Marshal(roundInfo)
-	if err != nil {
-		log.Debug("indexer: marshal", "error", "could not marshal signers indexes")
-		return
-	}
+	go ei.saveRoundInfo(roundInfo)
+}
 
-	buff.Grow(len(marshalizedRoundInfo))
-	_, err = buff.Write(marshalizedRoundInfo)
+func (ei *elasticIndexer) saveRoundInfo(roundInfo RoundInfo) {
+	err := ei.WriteRoundInfo(roundInfo)
 	if err != nil {
-		log.Warn("elastic search: save round info, write", "error", err.Error())
-		return
-	}
-
-	req := esapi.IndexRequest{
-		Index:      roundIndex,
-		DocumentID: strconv.FormatUint(uint64(roundInfo.ShardId), 10) + "_" + strconv.FormatUint(roundInfo.Index, 10),
-		Body:       bytes.NewReader(buff.Bytes()),
-		Refresh:    "true",
+		log.Warn("indexer: can not index round info", "error", err.Error())
 	}
+}
 
-	res, err := req.Do(context.Background(), ei.db)
+// WriteRoundInfo indexes the round info document
+func (ei *elasticIndexer) WriteRoundInfo(roundInfo RoundInfo) error {
+	marshalizedRoundInfo, err := ei.marshalizer.Marshal(roundInfo)
 	if err != nil {
-		log.Warn("indexer: can not index round info", "error", err.Error())
-		return
+		return err
 	}
 
-	defer closeESResponseBody(res)
-
-	if res.IsError() {
-		log.Warn("indexer", "error", res.String())
-	}
+	return ei.indexDocument(roundIndex, roundDocumentID(roundInfo), marshalizedRoundInfo)
 }
 
 //SaveValidatorsPubKeys will send all validators public keys to elastic search
@@ -300,65 +293,29 @@ func (ei *elasticIndexer) IsNilIndexer() bool {
 }
 
 func (ei *elasticIndexer) saveShardValidatorsPubKeys(shardId uint32, shardValidatorsPubKeys []string) {
-	var buff bytes.Buffer
-
-	shardValPubKeys := ValidatorsPublicKeys{PublicKeys: shardValidatorsPubKeys}
-	marshalizedValidatorPubKeys, err := ei.marshalizer.Marshal(shardValPubKeys)
+	err := ei.WriteValidatorsPubKeys(shardId, &ValidatorsPublicKeys{PublicKeys: shardValidatorsPubKeys})
 	if err != nil {
-		log.Debug("indexer: marshal", "error", "could not marshal validators public keys")
-		return
-	}
-
-	buff.Grow(len(marshalizedValidatorPubKeys))
-	_, err = buff.Write(marshalizedValidatorPubKeys)
-	if err != nil {
-		log.Warn("elastic search: save shard validators pub keys, write", "error", err.Error())
-	}
-
-	req := esapi.IndexRequest{
-		Index:      validatorsIndex,
-		DocumentID: strconv.FormatUint(uint64(shardId), 10),
-		Body:       bytes.NewReader(buff.Bytes()),
-		Refresh:    "true",
+		log.Warn("indexer: can not index validators pubkey", "error", err.Error())
 	}
+}
 
-	res, err := req.Do(context.Background(), ei.db)
+// WriteValidatorsPubKeys indexes the validators public keys document of a shard
+func (ei *elasticIndexer) WriteValidatorsPubKeys(shardID uint32, validatorsPubKeys *ValidatorsPublicKeys) error {
+	marshalizedValidatorPubKeys, err := ei.marshalizer.Marshal(validatorsPubKeys)
 	if err != nil {
-		log.Warn("indexer: can not index validators pubkey", "error", err.Error())
-		return
+		return err
 	}
-	log.Debug("indexer: response validators pubkey", "msg", res.String())
-
-	defer closeESResponseBody(res)
 
-	if res.IsError() {
-		log.Warn("indexer", "error", res.String())
-	}
+	return ei.indexDocument(validatorsIndex, strconv.FormatUint(uint64(shardID), 10), marshalizedValidatorPubKeys)
 }
 
 func (ei *elasticIndexer) getSerializedElasticBlockAndHeaderHash(header data.HeaderHandler, signersIndexes []uint64) ([]byte, []byte) {
-	h, err := ei.marshalizer.Marshal(header)
+	elasticBlock, headerHash, err := createBlockDocument(header, signersIndexes, ei.marshalizer, ei.hasher)
 	if err != nil {
 		log.Debug("indexer: marshal", "error", "could not marshal header")
 		return nil, nil
 	}
 
-	headerHash := ei.hasher.Compute(string(h))
-	elasticBlock := Block{
-		Nonce:         header.GetNonce(),
-		Round:         header.GetRound(),
-		ShardID:       header.GetShardID(),
-		Hash:          hex.EncodeToString(headerHash),
-		Proposer:      signersIndexes[0],
-		Validators:    signersIndexes,
-		PubKeyBitmap:  hex.EncodeToString(header.GetPubKeysBitmap()),
-		Size:          int64(len(h)),
-		Timestamp:     time.Duration(header.GetTimeStamp()),
-		TxCount:       header.GetTxCount(),
-		StateRootHash: hex.EncodeToString(header.GetRootHash()),
-		PrevHash:      hex.EncodeToString(header.GetPrevHash()),
-	}
-
 	serializedBlock, err := json.Marshal(elasticBlock)
 	if err != nil {
 		log.Debug("indexer: marshal", "error", "could not marshal elastic header")
@@ -369,34 +326,75 @@ func (ei *elasticIndexer) getSerializedElasticBlockAndHeaderHash(header data.Hea
 }
 
 func (ei *elasticIndexer) saveHeader(header data.HeaderHandler, signersIndexes []uint64) {
-	var buff bytes.Buffer
+	blockDocument, _, err := createBlockDocument(header, signersIndexes, ei.marshalizer, ei.hasher)
+	if err != nil {
+		log.Debug("indexer: marshal", "error", "could not marshal header")
+		return
+	}
 
-	serializedBlock, headerHash := ei.getSerializedElasticBlockAndHeaderHash(header, signersIndexes)
+	err = ei.WriteBlock(blockDocument)
+	if err != nil {
+		log.Warn("indexer: could not index block header", "error", err.Error())
+	}
+}
 
-	buff.Grow(len(serializedBlock))
-	_, err := buff.Write(serializedBlock)
+// WriteBlock indexes the block document
+func (ei *elasticIndexer) WriteBlock(blockDocument *Block) error {
+	serializedBlock, err := json.Marshal(blockDocument)
 	if err != nil {
-		log.Warn("elastic search: save header, write", "error", err.Error())
+		return err
 	}
 
+	return ei.indexDocument(blockIndex, blockDocument.Hash, serializedBlock)
+}
+
+func (ei *elasticIndexer) indexDocument(index string, documentID string, document []byte) error {
 	req := esapi.IndexRequest{
-		Index:      blockIndex,
-		DocumentID: hex.EncodeToString(headerHash),
-		Body:       bytes.NewReader(buff.Bytes()),
+		Index:      index,
+		DocumentID: documentID,
+		Body:       bytes.NewReader(document),
 		Refresh:    "true",
 	}
 
 	res, err := req.Do(context.Background(), ei.db)
 	if err != nil {
-		log.Warn("indexer: could not index block header", "error", err.Error())
-		return
+		return err
+	}
+
+	defer closeESResponseBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", ErrElasticSearchRequestFailed, res.String())
+	}
+
+	return nil
+}
+
+func (ei *elasticIndexer) bulkIndex(index string, buff *bytes.Buffer) error {
+	res, err := ei.db.Bulk(bytes.NewReader(buff.Bytes()), ei.db.Bulk.WithIndex(index))
+	if err != nil {
+		return err
 	}
 
 	defer closeESResponseBody(res)
 
 	if res.IsError() {
-		log.Warn("indexer", "error", res.String())
+		return fmt.Errorf("%w: %s", ErrElasticSearchRequestFailed, res.String())
+	}
+
+	// a bulk request succeeds even if some of its items were rejected
+	bulkResponse := struct {
+		Errors bool `json:"errors"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&bulkResponse)
+	if err != nil {
+		return err
+	}
+	if bulkResponse.Errors {
+		return fmt.Errorf("%w: some bulk items were rejected", ErrElasticSearchRequestFailed)
 	}
+
+	return nil
 }
 
 func (ei *elasticIndexer) serializeBulkTx(bulk []*Transaction) bytes.Buffer {
@@ -434,18 +432,52 @@ func (ei *elasticIndexer) saveTransactions(
 	bulks := ei.buildTransactionBulks(body, header, txPool)
 
 	for _, bulk := range bulks {
-		buff := ei.serializeBulkTx(bulk)
-		res, err := ei.db.Bulk(bytes.NewReader(buff.Bytes()), ei.db.Bulk.WithIndex(txIndex))
+		err := ei.WriteTransactions(bulk)
 		if err != nil {
-			log.Warn("indexer", "error", "indexing bulk of transactions")
-			continue
+			log.Warn("indexer", "error", "indexing bulk of transactions", "reason", err.Error())
 		}
-		if res.IsError() {
-			log.Warn("indexer", "error", res.String())
+	}
+}
+
+// WriteTransactions indexes the transaction documents using bulks of maximum txBulkSize transactions
+func (ei *elasticIndexer) WriteTransactions(transactions []*Transaction) error {
+	for i := 0; i < len(transactions); i += txBulkSize {
+		end := i + txBulkSize
+		if end > len(transactions) {
+			end = len(transactions)
 		}
 
-		closeESResponseBody(res)
+		buff := ei.serializeBulkTx(transactions[i:end])
+		err := ei.bulkIndex(txIndex, &buff)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTPS indexes the statistics documents in a single bulk
+func (ei *elasticIndexer) WriteTPS(tpsDocuments map[string]*TPS) error {
+	if len(tpsDocuments) == 0 {
+		return nil
 	}
+
+	var buff bytes.Buffer
+	for id, tps := range tpsDocuments {
+		meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s", "_type" : "%s" } }%s`, id, tpsIndex, "\n"))
+		serializedInfo, err := json.Marshal(tps)
+		if err != nil {
+			return err
+		}
+
+		buff.Grow(len(meta) + len(serializedInfo) + 1)
+		_, _ = buff.Write(meta)
+		_, _ = buff.Write(serializedInfo)
+		_, _ = buff.Write([]byte("\n"))
+	}
+
+	return ei.bulkIndex(tpsIndex, &buff)
 }
 
 // buildTransactionBulks creates bulks of maximum txBulkSize transactions to be indexed together
@@ -455,42 +487,11 @@ func (ei *elasticIndexer) buildTransactionBulks(
 	header data.HeaderHandler,
 	txPool map[string]data.TransactionHandler,
 ) [][]*Transaction {
-	processedTxCount := 0
-	bulks := make([][]*Transaction, (header.GetTxCount()/txBulkSize)+1)
-	blockMarshal, _ := ei.marshalizer.Marshal(header)
-	blockHash := ei.hasher.Compute(string(blockMarshal))
-
-	for _, mb := range body {
-		mbMarshal, err := ei.marshalizer.Marshal(mb)
-		if err != nil {
-			log.Debug("indexer: marshal", "error", "could not marshal miniblock")
-			continue
-		}
-		mbHash := ei.hasher.Compute(string(mbMarshal))
-
-		mbTxStatus := "Pending"
-		if ei.shardCoordinator.SelfId() == mb.ReceiverShardID {
-			mbTxStatus = "Success"
-		}
-
-		for _, txHash := range mb.TxHashes {
-			processedTxCount++
-
-			currentBulk := processedTxCount / txBulkSize
-			currentTxHandler, ok := txPool[string(txHash)]
-			if !ok {
-				log.Debug("indexer: elasticsearch could not find tx hash in pool")
-				continue
-			}
-
-			currentTx := getTransactionByType(currentTxHandler, txHash, mbHash, blockHash, mb, header, mbTxStatus)
-			if currentTx == nil {
-				log.Debug("indexer: elasticsearch found tx in pool but of wrong type")
-				continue
-			}
-
-			bulks[currentBulk] = append(bulks[currentBulk], currentTx)
-		}
+	transactions := createTransactionDocuments(body, header, txPool, ei.shardCoordinator.SelfId(), ei.marshalizer, ei.hasher)
+	bulks := make([][]*Transaction, (len(transactions)/txBulkSize)+1)
+	for i, tx := range transactions {
+		currentBulk := i / txBulkSize
+		bulks[currentBulk] = append(bulks[currentBulk], tx)
 	}
 
 	return bulks
@@ -500,17 +501,7 @@ func (ei *elasticIndexer) serializeShardInfo(shardInfo statistics.ShardStatistic
 	meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s%d", "_type" : "%s" } }%s`,
 		shardTpsDocIDPrefix, shardInfo.ShardID(), tpsIndex, "\n"))
 
-	bigTxCount := big.NewInt(int64(shardInfo.AverageBlockTxCount()))
-	shardTPS := TPS{
-		ShardID:               shardInfo.ShardID(),
-		LiveTPS:               shardInfo.LiveTPS(),
-		PeakTPS:               shardInfo.PeakTPS(),
-		AverageTPS:            shardInfo.AverageTPS(),
-		AverageBlockTxCount:   bigTxCount,
-		CurrentBlockNonce:     shardInfo.CurrentBlockNonce(),
-		LastBlockTxCount:      shardInfo.LastBlockTxCount(),
-		TotalProcessedTxCount: shardInfo.TotalProcessedTxCount(),
-	}
+	shardTPS := createShardTPSDocument(shardInfo)
 
 	serializedInfo, err := json.Marshal(shardTPS)
 	if err != nil {
@@ -523,29 +514,21 @@ func (ei *elasticIndexer) serializeShardInfo(shardInfo statistics.ShardStatistic
 	return serializedInfo, meta
 }
 
-// UpdateTPS updates the tps and statistics into elasticsearch index
+// UpdateTPS updates the tps and statistics into elasticsearch index, without waiting for the requests to complete
 func (ei *elasticIndexer) UpdateTPS(tpsBenchmark statistics.TPSBenchmark) {
 	if tpsBenchmark == nil {
 		log.Debug("indexer: update tps called, but the tpsBenchmark is nil")
 		return
 	}
 
+	go ei.updateTPS(tpsBenchmark)
+}
+
+func (ei *elasticIndexer) updateTPS(tpsBenchmark statistics.TPSBenchmark) {
 	var buff bytes.Buffer
 
 	meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s", "_type" : "%s" } }%s`, metachainTpsDocID, tpsIndex, "\n"))
-	generalInfo := TPS{
-		LiveTPS:    tpsBenchmark.LiveTPS(),
-		PeakTPS:    tpsBenchmark.PeakTPS(),
-		NrOfShards: tpsBenchmark.NrOfShards(),
-		// TODO: This value is still mocked, it should be removed if we cannot populate it correctly
-		NrOfNodes:             100,
-		BlockNumber:           tpsBenchmark.BlockNumber(),
-		RoundNumber:           tpsBenchmark.RoundNumber(),
-		RoundTime:             tpsBenchmark.RoundTime(),
-		AverageBlockTxCount:   tpsBenchmark.AverageBlockTxCount(),
-		LastBlockTxCount:      tpsBenchmark.LastBlockTxCount(),
-		TotalProcessedTxCount: tpsBenchmark.TotalProcessedTxCount(),
-	}
+	generalInfo := createGeneralTPSDocument(tpsBenchmark)
 
 	serializedInfo, err := json.Marshal(generalInfo)
 	if err != nil {
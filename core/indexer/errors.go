@@ -15,3 +15,49 @@ var ErrNoHeader = errors.New("elasticsearch - no header")
 
 // ErrNoMiniblocks signals that we could not create an elasticsearch index
 var ErrNoMiniblocks = errors.New("elasticsearch - no miniblocks")
+
+// ErrEmptyDataPath signals that an empty data path has been provided to a file based indexer driver
+var ErrEmptyDataPath = errors.New("empty indexer data path")
+
+// ErrUnknownDriver signals that the configured indexer driver is not supported
+var ErrUnknownDriver = errors.New("unknown indexer driver")
+
+// ErrNilStorer signals that a nil storer has been provided
+var ErrNilStorer = errors.New("nil storer")
+
+// ErrNilDocumentsWriter signals that a nil documents writer has been provided
+var ErrNilDocumentsWriter = errors.New("nil documents writer")
+
+// ErrInvalidMaxPendingItems signals that an invalid maximum number of pending items has been provided
+var ErrInvalidMaxPendingItems = errors.New("invalid maximum number of pending items")
+
+// ErrInvalidBackoff signals that invalid retry backoff durations have been provided
+var ErrInvalidBackoff = errors.New("invalid retry backoff")
+
+// ErrIndexingQueueClosed signals that an item was pushed after the indexing queue was closed
+var ErrIndexingQueueClosed = errors.New("indexing queue is closed")
+
+// ErrIndexingQueueFull signals that an item was dropped because the indexing queue holds the maximum number of
+// pending items
+var ErrIndexingQueueFull = errors.New("indexing queue is full")
+
+// ErrNilStatusHandler signals that a nil status handler has been provided
+var ErrNilStatusHandler = errors.New("nil status handler")
+
+// ErrUnknownWorkItemType signals that a work item of an unknown type was read from the indexing queue
+var ErrUnknownWorkItemType = errors.New("unknown work item type")
+
+// ErrElasticSearchRequestFailed signals that elastic search rejected an indexing request
+var ErrElasticSearchRequestFailed = errors.New("elastic search request failed")
+
+// ErrDriverNotQueueable signals that the selected indexer driver can not be used behind the indexing queue
+var ErrDriverNotQueueable = errors.New("indexer driver can not be used with the indexing queue")
+
+// ErrExportFileClosed signals that records were written after the export files were closed
+var ErrExportFileClosed = errors.New("export file is closed")
+
+// ErrDriverNotDocumentsWriter signals that the selected indexer driver can not write the documents inline
+var ErrDriverNotDocumentsWriter = errors.New("indexer driver can not write the documents inline")
+
+// ErrSqliteDriverNotCompiled signals that the sqlite driver was selected on a node built without the sqlite build tag
+var ErrSqliteDriverNotCompiled = errors.New("the sqlite indexer driver is not compiled in, build the node with -tags sqlite")
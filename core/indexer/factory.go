@@ -0,0 +1,115 @@
+package indexer
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+const (
+	// ElasticSearchDriver is the driver name of the elastic search indexer
+	ElasticSearchDriver = "elasticsearch"
+	// SqliteDriver is the driver name of the embedded SQLite indexer
+	SqliteDriver = "sqlite"
+	// NDJSONDriver is the driver name of the newline delimited JSON files exporter
+	NDJSONDriver = "ndjson"
+)
+
+const sqliteDbFileName = "indexer.db"
+
+// ArgsIndexerFactory holds all the arguments needed to create any of the indexer drivers
+type ArgsIndexerFactory struct {
+	Driver           string
+	Url              string
+	Username         string
+	Password         string
+	DataPath         string
+	ShardCoordinator sharding.Coordinator
+	Marshalizer      marshal.Marshalizer
+	Hasher           hashing.Hasher
+	Options          *Options
+	// Queue enables the persistent indexing queue when not nil. Its Writer is set to the created driver
+	Queue *ArgsIndexingQueue
+}
+
+// NewIndexer creates the indexer implementation selected by the provided driver name. An empty driver name
+// selects the elastic search indexer. Url, Username and Password are used by the elastic search driver while
+// DataPath is the directory the sqlite and ndjson drivers write into. If the queue arguments are provided, the
+// driver is wrapped in a queued indexer
+func NewIndexer(args ArgsIndexerFactory) (Indexer, error) {
+	driver, err := createDriver(args)
+	if err != nil {
+		return nil, err
+	}
+	if args.Queue == nil {
+		return driver, nil
+	}
+
+	writer, ok := driver.(DocumentsWriter)
+	if !ok {
+		closeDriver(driver)
+		return nil, fmt.Errorf("%w: %s", ErrDriverNotQueueable, args.Driver)
+	}
+
+	queueArgs := *args.Queue
+	queueArgs.Writer = writer
+
+	queuedIndexer, err := NewQueuedIndexer(ArgsQueuedIndexer{
+		Queue:            queueArgs,
+		ShardCoordinator: args.ShardCoordinator,
+		Marshalizer:      args.Marshalizer,
+		Hasher:           args.Hasher,
+		Options:          args.Options,
+	})
+	if err != nil {
+		closeDriver(driver)
+		return nil, err
+	}
+
+	return queuedIndexer, nil
+}
+
+// NewDocumentsWriter creates the driver selected by the provided driver name, without the indexing queue, so the
+// documents are written inline. The queue arguments are ignored
+func NewDocumentsWriter(args ArgsIndexerFactory) (DocumentsWriter, error) {
+	driver, err := createDriver(args)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, ok := driver.(DocumentsWriter)
+	if !ok {
+		closeDriver(driver)
+		return nil, fmt.Errorf("%w: %s", ErrDriverNotDocumentsWriter, args.Driver)
+	}
+
+	return writer, nil
+}
+
+func createDriver(args ArgsIndexerFactory) (Indexer, error) {
+	switch args.Driver {
+	case ElasticSearchDriver, "":
+		return NewElasticIndexer(args.Url, args.Username, args.Password, args.ShardCoordinator, args.Marshalizer, args.Hasher, args.Options)
+	case SqliteDriver:
+		if args.DataPath == "" {
+			return nil, ErrEmptyDataPath
+		}
+		dbPath := filepath.Join(args.DataPath, sqliteDbFileName)
+		return NewSqliteIndexer(dbPath, args.ShardCoordinator, args.Marshalizer, args.Hasher, args.Options)
+	case NDJSONDriver:
+		return NewNDJSONIndexer(args.DataPath, args.ShardCoordinator, args.Marshalizer, args.Hasher, args.Options)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, args.Driver)
+	}
+}
+
+func closeDriver(driver Indexer) {
+	closer, ok := driver.(io.Closer)
+	if ok {
+		_ = closer.Close()
+	}
+}
@@ -0,0 +1,131 @@
+package indexer_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/mock"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFactoryArgs(driver string, dataPath string) indexer.ArgsIndexerFactory {
+	return indexer.ArgsIndexerFactory{
+		Driver:           driver,
+		DataPath:         dataPath,
+		ShardCoordinator: shardCoordinator,
+		Marshalizer:      marshalizer,
+		Hasher:           hasher,
+		Options:          &indexer.Options{},
+	}
+}
+
+func TestNewIndexer_UnknownDriverShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ind, err := indexer.NewIndexer(createFactoryArgs("mysql", ""))
+
+	assert.Nil(t, ind)
+	assert.True(t, errors.Is(err, indexer.ErrUnknownDriver))
+}
+
+func TestNewIndexer_SqliteDriverEmptyPathShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ind, err := indexer.NewIndexer(createFactoryArgs(indexer.SqliteDriver, ""))
+
+	assert.Nil(t, ind)
+	assert.Equal(t, indexer.ErrEmptyDataPath, err)
+}
+
+func TestNewIndexer_FileDriversShouldWork(t *testing.T) {
+	t.Parallel()
+
+	for _, driver := range fileDrivers {
+		dir, err := ioutil.TempDir("", "indexer_factory")
+		require.Nil(t, err)
+
+		ind, err := indexer.NewIndexer(createFactoryArgs(driver, dir))
+		assert.Nil(t, err, driver)
+		assert.False(t, ind.IsNilIndexer(), driver)
+
+		_ = ind.(io.Closer).Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestNewIndexer_WithQueueShouldCreateQueuedIndexer(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "indexer_factory")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	args := createFactoryArgs(indexer.NDJSONDriver, dir)
+	args.Queue = &indexer.ArgsIndexingQueue{
+		Storer:          createTestQueueStorer(t, memorydb.New()),
+		StatusHandler:   &mock.AppStatusHandlerStub{},
+		MaxPendingItems: 10,
+		InitialBackoff:  time.Millisecond,
+		MaxBackoff:      time.Millisecond,
+	}
+	ind, err := indexer.NewIndexer(args)
+	require.Nil(t, err)
+
+	ind.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	waitForPendingItems(t, ind.(interface{ PendingItems() uint64 }), 0)
+	_ = ind.(io.Closer).Close()
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "rounds.ndjson"))
+	require.Nil(t, err)
+	assert.Contains(t, string(content), `"id":"0_1"`)
+}
+
+func TestNewIndexer_WithInvalidQueueArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "indexer_factory")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	args := createFactoryArgs(indexer.NDJSONDriver, dir)
+	args.Queue = &indexer.ArgsIndexingQueue{}
+	ind, err := indexer.NewIndexer(args)
+
+	assert.Nil(t, ind)
+	assert.Equal(t, indexer.ErrNilStorer, err)
+}
+
+func TestNewDocumentsWriter_ShouldIgnoreTheQueue(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "indexer_factory")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	args := createFactoryArgs(indexer.NDJSONDriver, dir)
+	args.Queue = &indexer.ArgsIndexingQueue{}
+	writer, err := indexer.NewDocumentsWriter(args)
+	require.Nil(t, err)
+
+	err = writer.WriteRoundInfo(indexer.RoundInfo{Index: 1})
+	assert.Nil(t, err)
+	_ = writer.(io.Closer).Close()
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "rounds.ndjson"))
+	require.Nil(t, err)
+	assert.Contains(t, string(content), `"id":"0_1"`)
+}
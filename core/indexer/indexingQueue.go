@@ -0,0 +1,320 @@
+package indexer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+var (
+	queueHeadKey       = []byte("indexingQueueHead")
+	queueTailKey       = []byte("indexingQueueTail")
+	queueItemKeyPrefix = []byte("indexingQueueItem_")
+)
+
+// ArgsIndexingQueue holds all the arguments needed to create an indexing queue
+type ArgsIndexingQueue struct {
+	Storer          storage.Storer
+	Writer          DocumentsWriter
+	StatusHandler   core.AppStatusHandler
+	MaxPendingItems uint64
+	InitialBackoff  time.Duration
+	MaxBackoff      time.Duration
+	// MaxAttempts is the number of times an item is written before being dropped, 0 meaning it is retried forever
+	MaxAttempts uint32
+}
+
+// indexingQueue is a persistent FIFO of work items. Items are written by a single worker go routine, in the order
+// they were pushed. A failed write is retried with an exponential backoff, blocking the following items, and the
+// items not yet written when the queue is closed are resumed the next time a queue is created on the same storer.
+// The storer is flushed on every push and every head advance, if it buffers its writes, so a crash loses no item.
+// Pushing never waits for the worker: when the queue holds the maximum number of pending items the new item is
+// dropped and counted in the MetricIndexerDroppedItems metric, so the callers on the commit path are never parked
+type indexingQueue struct {
+	storer          storage.Storer
+	writer          DocumentsWriter
+	statusHandler   core.AppStatusHandler
+	maxPendingItems uint64
+	initialBackoff  time.Duration
+	maxBackoff      time.Duration
+	maxAttempts     uint32
+
+	mutQueue     sync.Mutex
+	cond         *sync.Cond
+	head         uint64
+	tail         uint64
+	droppedItems uint64
+	closed       bool
+	chanClose    chan struct{}
+	chanDone     chan struct{}
+}
+
+// NewIndexingQueue creates a new indexing queue and starts writing the items already persisted in the storer
+func NewIndexingQueue(args ArgsIndexingQueue) (*indexingQueue, error) {
+	if check.IfNil(args.Storer) {
+		return nil, ErrNilStorer
+	}
+	if check.IfNil(args.Writer) {
+		return nil, ErrNilDocumentsWriter
+	}
+	if check.IfNil(args.StatusHandler) {
+		return nil, ErrNilStatusHandler
+	}
+	if args.MaxPendingItems == 0 {
+		return nil, ErrInvalidMaxPendingItems
+	}
+	if args.InitialBackoff <= 0 || args.MaxBackoff < args.InitialBackoff {
+		return nil, ErrInvalidBackoff
+	}
+
+	iq := &indexingQueue{
+		storer:          args.Storer,
+		writer:          args.Writer,
+		statusHandler:   args.StatusHandler,
+		maxPendingItems: args.MaxPendingItems,
+		initialBackoff:  args.InitialBackoff,
+		maxBackoff:      args.MaxBackoff,
+		maxAttempts:     args.MaxAttempts,
+		head:            loadCounter(args.Storer, queueHeadKey),
+		tail:            loadCounter(args.Storer, queueTailKey),
+		chanClose:       make(chan struct{}),
+		chanDone:        make(chan struct{}),
+	}
+	iq.cond = sync.NewCond(&iq.mutQueue)
+
+	if iq.tail < iq.head {
+		log.Warn("indexing queue: inconsistent persisted counters, discarding pending items",
+			"head", iq.head, "tail", iq.tail)
+		iq.tail = iq.head
+	}
+	if iq.tail > iq.head {
+		log.Debug("indexing queue: resuming pending items", "num items", iq.tail-iq.head)
+	}
+
+	go iq.processItems()
+
+	return iq, nil
+}
+
+// Push persists the provided item at the end of the queue. It never waits for the worker go routine: if the queue
+// holds the maximum number of pending items, the item is dropped and ErrIndexingQueueFull is returned. It returns
+// ErrIndexingQueueClosed if the queue is closed
+func (iq *indexingQueue) Push(item *workItem) error {
+	buff, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	iq.mutQueue.Lock()
+	defer iq.mutQueue.Unlock()
+
+	if iq.closed {
+		return ErrIndexingQueueClosed
+	}
+	if iq.tail-iq.head >= iq.maxPendingItems {
+		iq.droppedItems++
+		iq.statusHandler.Increment(core.MetricIndexerDroppedItems)
+		return ErrIndexingQueueFull
+	}
+
+	err = iq.storer.Put(queueItemKey(iq.tail), buff)
+	if err != nil {
+		return err
+	}
+	err = iq.storer.Put(queueTailKey, uint64ToBytes(iq.tail+1))
+	if err != nil {
+		return err
+	}
+	err = iq.flush()
+	if err != nil {
+		return err
+	}
+
+	iq.tail++
+	iq.cond.Broadcast()
+
+	return nil
+}
+
+// PendingItems returns the number of items not yet written
+func (iq *indexingQueue) PendingItems() uint64 {
+	iq.mutQueue.Lock()
+	defer iq.mutQueue.Unlock()
+
+	return iq.tail - iq.head
+}
+
+// DroppedItems returns the number of items dropped because the queue was full
+func (iq *indexingQueue) DroppedItems() uint64 {
+	iq.mutQueue.Lock()
+	defer iq.mutQueue.Unlock()
+
+	return iq.droppedItems
+}
+
+// Close stops the worker go routine, waiting for it to finish. The pending items are kept in the storer
+func (iq *indexingQueue) Close() error {
+	iq.mutQueue.Lock()
+	if iq.closed {
+		iq.mutQueue.Unlock()
+		return nil
+	}
+	iq.closed = true
+	close(iq.chanClose)
+	iq.cond.Broadcast()
+	iq.mutQueue.Unlock()
+
+	<-iq.chanDone
+
+	return nil
+}
+
+func (iq *indexingQueue) processItems() {
+	defer close(iq.chanDone)
+
+	for {
+		seq, ok := iq.waitForItem()
+		if !ok {
+			return
+		}
+
+		item, err := iq.loadItem(seq)
+		if err != nil {
+			log.Warn("indexing queue: could not load item, dropping it", "seq", seq, "error", err.Error())
+			iq.advanceHead(seq)
+			continue
+		}
+
+		isClosed := iq.writeWithRetries(seq, item)
+		if isClosed {
+			return
+		}
+
+		iq.advanceHead(seq)
+	}
+}
+
+func (iq *indexingQueue) waitForItem() (uint64, bool) {
+	iq.mutQueue.Lock()
+	defer iq.mutQueue.Unlock()
+
+	for !iq.closed && iq.head == iq.tail {
+		iq.cond.Wait()
+	}
+
+	return iq.head, !iq.closed
+}
+
+func (iq *indexingQueue) loadItem(seq uint64) (*workItem, error) {
+	buff, err := iq.storer.Get(queueItemKey(seq))
+	if err != nil {
+		return nil, err
+	}
+
+	item := &workItem{}
+	err = json.Unmarshal(buff, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// writeWithRetries returns true if the queue was closed while waiting to retry the write
+func (iq *indexingQueue) writeWithRetries(seq uint64, item *workItem) bool {
+	backoff := iq.initialBackoff
+	for attempt := uint32(1); ; attempt++ {
+		err := item.writeTo(iq.writer)
+		if err == nil {
+			return false
+		}
+
+		if iq.maxAttempts > 0 && attempt >= iq.maxAttempts {
+			log.Error("indexing queue: could not write item, dropping it",
+				"seq", seq, "attempts", attempt, "error", err.Error())
+			return false
+		}
+
+		log.Debug("indexing queue: could not write item, retrying",
+			"seq", seq, "attempt", attempt, "backoff", backoff, "error", err.Error())
+
+		select {
+		case <-time.After(backoff):
+		case <-iq.chanClose:
+			return true
+		}
+
+		backoff *= 2
+		if backoff > iq.maxBackoff {
+			backoff = iq.maxBackoff
+		}
+	}
+}
+
+// advanceHead persists the new head before removing the item so a crash in between can only leave behind an
+// orphan item, never make the queue write an item twice or lose one
+func (iq *indexingQueue) advanceHead(seq uint64) {
+	iq.mutQueue.Lock()
+	defer iq.mutQueue.Unlock()
+
+	err := iq.storer.Put(queueHeadKey, uint64ToBytes(seq+1))
+	if err != nil {
+		log.Warn("indexing queue: could not persist the queue head", "error", err.Error())
+	}
+
+	err = iq.storer.Remove(queueItemKey(seq))
+	if err != nil {
+		log.Debug("indexing queue: could not remove written item", "seq", seq, "error", err.Error())
+	}
+
+	err = iq.flush()
+	if err != nil {
+		log.Warn("indexing queue: could not flush the queue head", "error", err.Error())
+	}
+
+	iq.head = seq + 1
+	iq.cond.Broadcast()
+}
+
+// flush makes the buffered writes of the storer durable, if the storer buffers its writes
+func (iq *indexingQueue) flush() error {
+	flusher, ok := iq.storer.(storage.Flusher)
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (iq *indexingQueue) IsInterfaceNil() bool {
+	return iq == nil
+}
+
+func loadCounter(storer storage.Storer, key []byte) uint64 {
+	buff, err := storer.Get(key)
+	if err != nil || len(buff) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(buff)
+}
+
+func queueItemKey(seq uint64) []byte {
+	key := make([]byte, 0, len(queueItemKeyPrefix)+8)
+	key = append(key, queueItemKeyPrefix...)
+
+	return append(key, uint64ToBytes(seq)...)
+}
+
+func uint64ToBytes(value uint64) []byte {
+	buff := make([]byte, 8)
+	binary.BigEndian.PutUint64(buff, value)
+
+	return buff
+}
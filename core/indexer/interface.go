@@ -6,7 +6,9 @@ import (
 )
 
 // Indexer is an interface for saving node specific data to other storage.
-// This could be an elasticsearch index, a MySql database or any other external services.
+// This could be an elasticsearch index, an embedded SQLite database, NDJSON files or any other external services.
+// The available implementations are created by NewIndexer based on the configured driver. The save methods are called
+// inline, on the commit and consensus paths, so they must not wait for remote services
 type Indexer interface {
 	SaveBlock(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler, signersIndexes []uint64)
 	SaveMetaBlock(header data.HeaderHandler, signersIndexes []uint64)
@@ -16,3 +18,14 @@ type Indexer interface {
 	IsInterfaceNil() bool
 	IsNilIndexer() bool
 }
+
+// DocumentsWriter is implemented by the indexer drivers. It writes already built documents to the underlying storage
+// and reports the failures, so the indexing queue can retry them. All the writes are idempotent
+type DocumentsWriter interface {
+	WriteBlock(block *Block) error
+	WriteTransactions(transactions []*Transaction) error
+	WriteRoundInfo(roundInfo RoundInfo) error
+	WriteTPS(tpsDocuments map[string]*TPS) error
+	WriteValidatorsPubKeys(shardID uint32, validatorsPubKeys *ValidatorsPublicKeys) error
+	IsInterfaceNil() bool
+}
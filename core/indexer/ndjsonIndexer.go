@@ -0,0 +1,261 @@
+package indexer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+const ndjsonExtension = ".ndjson"
+
+// NDJSONRecord is a line written by the NDJSON exporter. ID has the same value as the elastic search document ID
+// so the records can be bulk imported later, the last record having an ID being the current one
+type NDJSONRecord struct {
+	ID       string      `json:"id"`
+	Document interface{} `json:"document"`
+}
+
+// ndjsonIndexer exports the same documents as the elastic search indexer as newline delimited JSON files,
+// one file for each elastic search index
+type ndjsonIndexer struct {
+	mutFiles         sync.Mutex
+	files            map[string]*os.File
+	shardCoordinator sharding.Coordinator
+	marshalizer      marshal.Marshalizer
+	hasher           hashing.Hasher
+	options          *Options
+}
+
+// NewNDJSONIndexer creates a new indexer that appends the indexed data to NDJSON files in the provided directory
+func NewNDJSONIndexer(
+	outputDirectory string,
+	shardCoordinator sharding.Coordinator,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	options *Options,
+) (Indexer, error) {
+	if outputDirectory == "" {
+		return nil, ErrEmptyDataPath
+	}
+	err := checkIndexerParams(shardCoordinator, marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(outputDirectory, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	ni := &ndjsonIndexer{
+		files:            make(map[string]*os.File),
+		shardCoordinator: shardCoordinator,
+		marshalizer:      marshalizer,
+		hasher:           hasher,
+		options:          options,
+	}
+
+	for _, index := range []string{blockIndex, txIndex, tpsIndex, validatorsIndex, roundIndex} {
+		fileName := filepath.Join(outputDirectory, index+ndjsonExtension)
+		file, errOpen := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if errOpen != nil {
+			_ = ni.Close()
+			return nil, errOpen
+		}
+
+		ni.files[index] = file
+	}
+
+	return ni, nil
+}
+
+// SaveBlock will export the block header and, if enabled, the block's transactions
+func (ni *ndjsonIndexer) SaveBlock(
+	bodyHandler data.BodyHandler,
+	headerHandler data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+	signersIndexes []uint64,
+) {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		log.Debug("indexer: no header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	body, ok := bodyHandler.(block.Body)
+	if !ok {
+		log.Debug("indexer", "error", ErrBodyTypeAssertion.Error())
+		return
+	}
+
+	ni.saveHeader(headerHandler, signersIndexes)
+
+	if len(body) == 0 {
+		log.Debug("indexer", "error", ErrNoMiniblocks.Error())
+		return
+	}
+
+	if ni.options != nil && ni.options.TxIndexingEnabled {
+		transactions := createTransactionDocuments(body, headerHandler, txPool, ni.shardCoordinator.SelfId(), ni.marshalizer, ni.hasher)
+		err := ni.WriteTransactions(transactions)
+		if err != nil {
+			log.Warn("indexer: could not export transactions", "error", err.Error())
+		}
+	}
+}
+
+// SaveMetaBlock will export a meta block header
+func (ni *ndjsonIndexer) SaveMetaBlock(header data.HeaderHandler, signersIndexes []uint64) {
+	if header == nil || header.IsInterfaceNil() {
+		log.Debug("indexer: nil header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	ni.saveHeader(header, signersIndexes)
+}
+
+func (ni *ndjsonIndexer) saveHeader(header data.HeaderHandler, signersIndexes []uint64) {
+	blockDocument, _, err := createBlockDocument(header, signersIndexes, ni.marshalizer, ni.hasher)
+	if err != nil {
+		log.Debug("indexer: marshal", "error", "could not marshal header")
+		return
+	}
+
+	err = ni.WriteBlock(blockDocument)
+	if err != nil {
+		log.Warn("indexer: could not export block header", "error", err.Error())
+	}
+}
+
+// WriteBlock exports the block document
+func (ni *ndjsonIndexer) WriteBlock(blockDocument *Block) error {
+	return ni.write(blockIndex, &NDJSONRecord{ID: blockDocument.Hash, Document: blockDocument})
+}
+
+// WriteTransactions exports the transaction documents
+func (ni *ndjsonIndexer) WriteTransactions(transactions []*Transaction) error {
+	records := make([]*NDJSONRecord, 0, len(transactions))
+	for _, tx := range transactions {
+		records = append(records, &NDJSONRecord{ID: tx.Hash, Document: tx})
+	}
+
+	return ni.write(txIndex, records...)
+}
+
+// SaveRoundInfo will export data about a round
+func (ni *ndjsonIndexer) SaveRoundInfo(roundInfo RoundInfo) {
+	err := ni.WriteRoundInfo(roundInfo)
+	if err != nil {
+		log.Warn("indexer: could not export round info", "error", err.Error())
+	}
+}
+
+// WriteRoundInfo exports the round info document
+func (ni *ndjsonIndexer) WriteRoundInfo(roundInfo RoundInfo) error {
+	return ni.write(roundIndex, &NDJSONRecord{ID: roundDocumentID(roundInfo), Document: roundInfo})
+}
+
+// UpdateTPS exports the network and the shards statistics
+func (ni *ndjsonIndexer) UpdateTPS(tpsBenchmark statistics.TPSBenchmark) {
+	if tpsBenchmark == nil {
+		log.Debug("indexer: update tps called, but the tpsBenchmark is nil")
+		return
+	}
+
+	err := ni.WriteTPS(createTPSDocuments(tpsBenchmark))
+	if err != nil {
+		log.Warn("indexer: could not export tps information", "error", err.Error())
+	}
+}
+
+// WriteTPS exports the statistics documents
+func (ni *ndjsonIndexer) WriteTPS(tpsDocuments map[string]*TPS) error {
+	records := make([]*NDJSONRecord, 0, len(tpsDocuments))
+	for id, tps := range tpsDocuments {
+		records = append(records, &NDJSONRecord{ID: id, Document: tps})
+	}
+
+	return ni.write(tpsIndex, records...)
+}
+
+// SaveValidatorsPubKeys will export all validators public keys
+func (ni *ndjsonIndexer) SaveValidatorsPubKeys(validatorsPubKeys map[uint32][][]byte) {
+	for shardID, shardPubKeys := range validatorsPubKeys {
+		err := ni.WriteValidatorsPubKeys(shardID, &ValidatorsPublicKeys{PublicKeys: hexEncodePubKeys(shardPubKeys)})
+		if err != nil {
+			log.Warn("indexer: could not export validators pubkey", "shard", shardID, "error", err.Error())
+		}
+	}
+}
+
+// WriteValidatorsPubKeys exports the validators public keys document of a shard
+func (ni *ndjsonIndexer) WriteValidatorsPubKeys(shardID uint32, validatorsPubKeys *ValidatorsPublicKeys) error {
+	return ni.write(validatorsIndex, &NDJSONRecord{
+		ID:       strconv.FormatUint(uint64(shardID), 10),
+		Document: validatorsPubKeys,
+	})
+}
+
+func (ni *ndjsonIndexer) write(index string, records ...*NDJSONRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	buff := make([]byte, 0)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		buff = append(buff, line...)
+		buff = append(buff, '\n')
+	}
+
+	ni.mutFiles.Lock()
+	defer ni.mutFiles.Unlock()
+
+	file, ok := ni.files[index]
+	if !ok {
+		return ErrExportFileClosed
+	}
+
+	_, err := file.Write(buff)
+
+	return err
+}
+
+// Close closes all the export files
+func (ni *ndjsonIndexer) Close() error {
+	ni.mutFiles.Lock()
+	defer ni.mutFiles.Unlock()
+
+	var lastErr error
+	for index, file := range ni.files {
+		err := file.Close()
+		if err != nil {
+			lastErr = err
+		}
+		delete(ni.files, index)
+	}
+
+	return lastErr
+}
+
+// IsNilIndexer returns false as this is a working indexer implementation
+func (ni *ndjsonIndexer) IsNilIndexer() bool {
+	return false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ni *ndjsonIndexer) IsInterfaceNil() bool {
+	return ni == nil
+}
@@ -0,0 +1,109 @@
+package indexer_test
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecords(t *testing.T, dir string, index string) []map[string]interface{} {
+	file, err := os.Open(filepath.Join(dir, index+".ndjson"))
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records := make([]map[string]interface{}, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := make(map[string]interface{})
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		require.Nil(t, err)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNewNDJSONIndexer_EmptyPathShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ni, err := indexer.NewNDJSONIndexer("", shardCoordinator, marshalizer, hasher, &indexer.Options{})
+
+	assert.Nil(t, ni)
+	assert.Equal(t, indexer.ErrEmptyDataPath, err)
+}
+
+func TestNewNDJSONIndexer_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ni, err := indexer.NewNDJSONIndexer("dir", shardCoordinator, marshalizer, nil, &indexer.Options{})
+
+	assert.Nil(t, ni)
+	assert.Equal(t, core.ErrNilHasher, err)
+}
+
+func TestNDJSONIndexer_ShouldExportAllDocuments(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "ndjson_indexer")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ni, err := indexer.NewNDJSONIndexer(dir, shardCoordinator, marshalizer, hasher, &indexer.Options{TxIndexingEnabled: true})
+	require.Nil(t, err)
+
+	header := newTestBlockHeader()
+	ni.SaveBlock(newTestBlockBody(), header, newTestTxPool(), []uint64{0, 1})
+	ni.SaveRoundInfo(indexer.RoundInfo{Index: 6, ShardId: 5})
+	ni.SaveValidatorsPubKeys(map[uint32][][]byte{0: {[]byte("pk")}})
+	err = ni.(io.Closer).Close()
+	require.Nil(t, err)
+
+	blocks := readRecords(t, dir, "blocks")
+	require.Equal(t, 1, len(blocks))
+	blockDocument := blocks[0]["document"].(map[string]interface{})
+	assert.Equal(t, float64(header.Nonce), blockDocument["nonce"])
+	assert.Equal(t, blocks[0]["id"], blockDocument["hash"])
+
+	transactions := readRecords(t, dir, "transactions")
+	assert.Equal(t, 3, len(transactions))
+
+	rounds := readRecords(t, dir, "rounds")
+	require.Equal(t, 1, len(rounds))
+	assert.Equal(t, "5_6", rounds[0]["id"])
+
+	validators := readRecords(t, dir, "validators")
+	require.Equal(t, 1, len(validators))
+	validatorsDocument := validators[0]["document"].(map[string]interface{})
+	assert.Equal(t, []interface{}{hex.EncodeToString([]byte("pk"))}, validatorsDocument["publicKeys"])
+}
+
+func TestNDJSONIndexer_WriteAfterCloseShouldNotPanic(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "ndjson_indexer")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ni, _ := indexer.NewNDJSONIndexer(dir, shardCoordinator, marshalizer, hasher, &indexer.Options{})
+	_ = ni.(io.Closer).Close()
+
+	assert.NotPanics(t, func() {
+		ni.SaveMetaBlock(newTestMetaBlock(), []uint64{0})
+	})
+}
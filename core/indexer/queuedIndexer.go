@@ -0,0 +1,184 @@
+package indexer
+
+import (
+	"io"
+
+	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// ArgsQueuedIndexer holds all the arguments needed to create a queued indexer
+type ArgsQueuedIndexer struct {
+	Queue            ArgsIndexingQueue
+	ShardCoordinator sharding.Coordinator
+	Marshalizer      marshal.Marshalizer
+	Hasher           hashing.Hasher
+	Options          *Options
+}
+
+// queuedIndexer builds the same documents as the indexer drivers but, instead of writing them inline, pushes them
+// in a persistent indexing queue which writes them through the driver, retrying the failed writes
+type queuedIndexer struct {
+	queue            *indexingQueue
+	storer           io.Closer
+	writer           DocumentsWriter
+	shardCoordinator sharding.Coordinator
+	marshalizer      marshal.Marshalizer
+	hasher           hashing.Hasher
+	options          *Options
+}
+
+// NewQueuedIndexer creates a new indexer that writes the documents through the queue's writer, asynchronously
+func NewQueuedIndexer(args ArgsQueuedIndexer) (*queuedIndexer, error) {
+	err := checkIndexerParams(args.ShardCoordinator, args.Marshalizer, args.Hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewIndexingQueue(args.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &queuedIndexer{
+		queue:            queue,
+		storer:           args.Queue.Storer,
+		writer:           args.Queue.Writer,
+		shardCoordinator: args.ShardCoordinator,
+		marshalizer:      args.Marshalizer,
+		hasher:           args.Hasher,
+		options:          args.Options,
+	}, nil
+}
+
+// SaveBlock will queue the block header and, if enabled, the block's transactions
+func (qi *queuedIndexer) SaveBlock(
+	bodyHandler data.BodyHandler,
+	headerHandler data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+	signersIndexes []uint64,
+) {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		log.Debug("indexer: no header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	body, ok := bodyHandler.(block.Body)
+	if !ok {
+		log.Debug("indexer", "error", ErrBodyTypeAssertion.Error())
+		return
+	}
+
+	qi.saveHeader(headerHandler, signersIndexes)
+
+	if len(body) == 0 {
+		log.Debug("indexer", "error", ErrNoMiniblocks.Error())
+		return
+	}
+
+	if qi.options != nil && qi.options.TxIndexingEnabled {
+		transactions := createTransactionDocuments(body, headerHandler, txPool, qi.shardCoordinator.SelfId(), qi.marshalizer, qi.hasher)
+		if len(transactions) == 0 {
+			return
+		}
+
+		qi.push(&workItem{Type: transactionsWorkItem, Transactions: transactions})
+	}
+}
+
+// SaveMetaBlock will queue a meta block header
+func (qi *queuedIndexer) SaveMetaBlock(header data.HeaderHandler, signersIndexes []uint64) {
+	if header == nil || header.IsInterfaceNil() {
+		log.Debug("indexer: nil header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	qi.saveHeader(header, signersIndexes)
+}
+
+func (qi *queuedIndexer) saveHeader(header data.HeaderHandler, signersIndexes []uint64) {
+	blockDocument, _, err := createBlockDocument(header, signersIndexes, qi.marshalizer, qi.hasher)
+	if err != nil {
+		log.Debug("indexer: marshal", "error", "could not marshal header")
+		return
+	}
+
+	qi.push(&workItem{Type: blockWorkItem, Block: blockDocument})
+}
+
+// SaveRoundInfo will queue data about a round
+func (qi *queuedIndexer) SaveRoundInfo(roundInfo RoundInfo) {
+	qi.push(newRoundInfoWorkItem(roundInfo))
+}
+
+// UpdateTPS queues the network and the shards statistics
+func (qi *queuedIndexer) UpdateTPS(tpsBenchmark statistics.TPSBenchmark) {
+	if tpsBenchmark == nil {
+		log.Debug("indexer: update tps called, but the tpsBenchmark is nil")
+		return
+	}
+
+	qi.push(&workItem{Type: tpsWorkItem, TPS: createTPSDocuments(tpsBenchmark)})
+}
+
+// SaveValidatorsPubKeys will queue all validators public keys
+func (qi *queuedIndexer) SaveValidatorsPubKeys(validatorsPubKeys map[uint32][][]byte) {
+	for shardID, shardPubKeys := range validatorsPubKeys {
+		qi.push(&workItem{
+			Type:       validatorsWorkItem,
+			ShardID:    shardID,
+			Validators: &ValidatorsPublicKeys{PublicKeys: hexEncodePubKeys(shardPubKeys)},
+		})
+	}
+}
+
+func (qi *queuedIndexer) push(item *workItem) {
+	err := qi.queue.Push(item)
+	if err != nil {
+		log.Warn("indexer: could not queue item", "type", item.Type, "error", err.Error())
+	}
+}
+
+// PendingItems returns the number of queued items not yet written
+func (qi *queuedIndexer) PendingItems() uint64 {
+	return qi.queue.PendingItems()
+}
+
+// DroppedItems returns the number of items dropped because the queue was full
+func (qi *queuedIndexer) DroppedItems() uint64 {
+	return qi.queue.DroppedItems()
+}
+
+// Close stops the indexing queue and closes its storer and, if it can be closed, the underlying driver
+func (qi *queuedIndexer) Close() error {
+	err := qi.queue.Close()
+	if err != nil {
+		return err
+	}
+
+	err = qi.storer.Close()
+	if err != nil {
+		return err
+	}
+
+	closer, ok := qi.writer.(io.Closer)
+	if ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// IsNilIndexer returns false as this is a working indexer implementation
+func (qi *queuedIndexer) IsNilIndexer() bool {
+	return false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (qi *queuedIndexer) IsInterfaceNil() bool {
+	return qi == nil
+}
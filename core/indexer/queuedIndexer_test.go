@@ -0,0 +1,316 @@
+package indexer_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/mock"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+func createTestQueueStorer(t *testing.T, persister storage.Persister) storage.Storer {
+	cacher, _ := lrucache.NewCache(10)
+	storer, err := storageUnit.NewStorageUnit(cacher, persister)
+	require.Nil(t, err)
+
+	return storer
+}
+
+func createQueuedIndexerArgs(storer storage.Storer, writer indexer.DocumentsWriter) indexer.ArgsQueuedIndexer {
+	return indexer.ArgsQueuedIndexer{
+		Queue: indexer.ArgsIndexingQueue{
+			Storer:          storer,
+			Writer:          writer,
+			StatusHandler:   &mock.AppStatusHandlerStub{IncrementHandler: func(key string) {}},
+			MaxPendingItems: 100,
+			InitialBackoff:  time.Millisecond,
+			MaxBackoff:      5 * time.Millisecond,
+		},
+		ShardCoordinator: shardCoordinator,
+		Marshalizer:      marshalizer,
+		Hasher:           hasher,
+		Options:          &indexer.Options{TxIndexingEnabled: true},
+	}
+}
+
+func waitForPendingItems(t *testing.T, qi interface{ PendingItems() uint64 }, expected uint64) {
+	for i := 0; i < 200; i++ {
+		if qi.PendingItems() == expected {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	assert.Fail(t, "timeout waiting for the pending items", "expected %d, got %d", expected, qi.PendingItems())
+}
+
+func TestNewQueuedIndexer_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	storer := createTestQueueStorer(t, memorydb.New())
+	writer := &mock.DocumentsWriterStub{}
+
+	args := createQueuedIndexerArgs(nil, writer)
+	_, err := indexer.NewQueuedIndexer(args)
+	assert.Equal(t, indexer.ErrNilStorer, err)
+
+	args = createQueuedIndexerArgs(storer, nil)
+	_, err = indexer.NewQueuedIndexer(args)
+	assert.Equal(t, indexer.ErrNilDocumentsWriter, err)
+
+	args = createQueuedIndexerArgs(storer, writer)
+	args.Queue.StatusHandler = nil
+	_, err = indexer.NewQueuedIndexer(args)
+	assert.Equal(t, indexer.ErrNilStatusHandler, err)
+
+	args = createQueuedIndexerArgs(storer, writer)
+	args.Queue.MaxPendingItems = 0
+	_, err = indexer.NewQueuedIndexer(args)
+	assert.Equal(t, indexer.ErrInvalidMaxPendingItems, err)
+
+	args = createQueuedIndexerArgs(storer, writer)
+	args.Queue.MaxBackoff = 0
+	_, err = indexer.NewQueuedIndexer(args)
+	assert.Equal(t, indexer.ErrInvalidBackoff, err)
+
+	args = createQueuedIndexerArgs(storer, writer)
+	args.Hasher = nil
+	_, err = indexer.NewQueuedIndexer(args)
+	assert.Equal(t, core.ErrNilHasher, err)
+}
+
+func TestQueuedIndexer_SaveBlockShouldWriteHeaderAndTransactions(t *testing.T) {
+	t.Parallel()
+
+	var numBlocks, numTxs int32
+	writer := &mock.DocumentsWriterStub{
+		WriteBlockCalled: func(block *indexer.Block) error {
+			atomic.AddInt32(&numBlocks, 1)
+			return nil
+		},
+		WriteTransactionsCalled: func(transactions []*indexer.Transaction) error {
+			atomic.AddInt32(&numTxs, int32(len(transactions)))
+			return nil
+		},
+	}
+	qi, err := indexer.NewQueuedIndexer(createQueuedIndexerArgs(createTestQueueStorer(t, memorydb.New()), writer))
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	qi.SaveBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool(), []uint64{0})
+	waitForPendingItems(t, qi, 0)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numBlocks))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&numTxs))
+}
+
+func TestQueuedIndexer_FailedWriteShouldBeRetried(t *testing.T) {
+	t.Parallel()
+
+	var numCalls int32
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			if atomic.AddInt32(&numCalls, 1) < 3 {
+				return errWriteFailed
+			}
+			return nil
+		},
+	}
+	qi, err := indexer.NewQueuedIndexer(createQueuedIndexerArgs(createTestQueueStorer(t, memorydb.New()), writer))
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	waitForPendingItems(t, qi, 0)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+}
+
+func TestQueuedIndexer_ItemShouldBeDroppedAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var numCalls int32
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			atomic.AddInt32(&numCalls, 1)
+			return errWriteFailed
+		},
+	}
+	args := createQueuedIndexerArgs(createTestQueueStorer(t, memorydb.New()), writer)
+	args.Queue.MaxAttempts = 2
+	qi, err := indexer.NewQueuedIndexer(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	waitForPendingItems(t, qi, 0)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&numCalls))
+}
+
+func TestQueuedIndexer_PendingItemsShouldBeResumedInOrder(t *testing.T) {
+	t.Parallel()
+
+	persister := memorydb.New()
+	failingWriter := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			return errWriteFailed
+		},
+	}
+	qi, err := indexer.NewQueuedIndexer(createQueuedIndexerArgs(createTestQueueStorer(t, persister), failingWriter))
+	require.Nil(t, err)
+
+	for i := uint64(1); i <= 3; i++ {
+		qi.SaveRoundInfo(indexer.RoundInfo{Index: i, ShardId: 1})
+	}
+	assert.Equal(t, uint64(3), qi.PendingItems())
+	_ = qi.Close()
+
+	mutRounds := sync.Mutex{}
+	rounds := make([]uint64, 0)
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			mutRounds.Lock()
+			rounds = append(rounds, roundInfo.Index)
+			mutRounds.Unlock()
+			return nil
+		},
+	}
+	qi, err = indexer.NewQueuedIndexer(createQueuedIndexerArgs(createTestQueueStorer(t, persister), writer))
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	waitForPendingItems(t, qi, 0)
+
+	mutRounds.Lock()
+	assert.Equal(t, []uint64{1, 2, 3}, rounds)
+	mutRounds.Unlock()
+}
+
+type flushCountingStorer struct {
+	storage.Storer
+	numFlushes int32
+}
+
+func (fcs *flushCountingStorer) Flush() error {
+	atomic.AddInt32(&fcs.numFlushes, 1)
+	return nil
+}
+
+func TestQueuedIndexer_StorerShouldBeFlushedOnPushAndOnWrite(t *testing.T) {
+	t.Parallel()
+
+	chanWrite := make(chan struct{})
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			<-chanWrite
+			return nil
+		},
+	}
+	storer := &flushCountingStorer{Storer: createTestQueueStorer(t, memorydb.New())}
+	qi, err := indexer.NewQueuedIndexer(createQueuedIndexerArgs(storer, writer))
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	assert.Equal(t, int32(1), atomic.LoadInt32(&storer.numFlushes))
+
+	close(chanWrite)
+	waitForPendingItems(t, qi, 0)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&storer.numFlushes))
+}
+
+func TestQueuedIndexer_FullQueueShouldDropAndCountWithoutBlocking(t *testing.T) {
+	t.Parallel()
+
+	chanRelease := make(chan struct{})
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			<-chanRelease
+			return nil
+		},
+	}
+	var numDropped int32
+	args := createQueuedIndexerArgs(createTestQueueStorer(t, memorydb.New()), writer)
+	args.Queue.MaxPendingItems = 2
+	args.Queue.StatusHandler = &mock.AppStatusHandlerStub{
+		IncrementHandler: func(key string) {
+			if key == core.MetricIndexerDroppedItems {
+				atomic.AddInt32(&numDropped, 1)
+			}
+		},
+	}
+	qi, err := indexer.NewQueuedIndexer(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = qi.Close()
+	}()
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 2})
+
+	chanThirdSaved := make(chan struct{})
+	go func() {
+		qi.SaveRoundInfo(indexer.RoundInfo{Index: 3})
+		close(chanThirdSaved)
+	}()
+
+	select {
+	case <-chanThirdSaved:
+	case <-time.After(time.Second):
+		assert.Fail(t, "caller should not have been blocked while the queue is full")
+	}
+	assert.Equal(t, uint64(2), qi.PendingItems())
+	assert.Equal(t, uint64(1), qi.DroppedItems())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numDropped))
+
+	close(chanRelease)
+	waitForPendingItems(t, qi, 0)
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 4})
+	waitForPendingItems(t, qi, 0)
+	assert.Equal(t, uint64(1), qi.DroppedItems())
+}
+
+func TestQueuedIndexer_SaveAfterCloseShouldNotQueue(t *testing.T) {
+	t.Parallel()
+
+	writer := &mock.DocumentsWriterStub{
+		WriteRoundInfoCalled: func(roundInfo indexer.RoundInfo) error {
+			return errWriteFailed
+		},
+	}
+	args := createQueuedIndexerArgs(createTestQueueStorer(t, memorydb.New()), writer)
+	qi, err := indexer.NewQueuedIndexer(args)
+	require.Nil(t, err)
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 1})
+	err = qi.Close()
+	assert.Nil(t, err)
+
+	qi.SaveRoundInfo(indexer.RoundInfo{Index: 2})
+	assert.Equal(t, uint64(1), qi.PendingItems())
+	assert.Equal(t, uint64(0), qi.DroppedItems())
+}
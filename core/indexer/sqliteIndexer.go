@@ -0,0 +1,385 @@
+//go:build sqlite
+// +build sqlite
+
+package indexer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	// registers the sqlite3 database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const sqliteDriverName = "sqlite3"
+
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		hash TEXT PRIMARY KEY,
+		nonce INTEGER,
+		round INTEGER,
+		shard_id INTEGER,
+		proposer INTEGER,
+		validators TEXT,
+		pub_key_bitmap TEXT,
+		size INTEGER,
+		timestamp INTEGER,
+		state_root_hash TEXT,
+		prev_hash TEXT,
+		tx_count INTEGER
+	)`,
+	`CREATE INDEX IF NOT EXISTS blocks_shard_nonce ON blocks (shard_id, nonce)`,
+	`CREATE TABLE IF NOT EXISTS transactions (
+		hash TEXT PRIMARY KEY,
+		mini_block_hash TEXT,
+		block_hash TEXT,
+		nonce INTEGER,
+		round INTEGER,
+		value TEXT,
+		receiver TEXT,
+		sender TEXT,
+		receiver_shard INTEGER,
+		sender_shard INTEGER,
+		gas_price INTEGER,
+		gas_limit INTEGER,
+		data BLOB,
+		signature TEXT,
+		timestamp INTEGER,
+		status TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS transactions_sender ON transactions (sender)`,
+	`CREATE INDEX IF NOT EXISTS transactions_receiver ON transactions (receiver)`,
+	`CREATE TABLE IF NOT EXISTS rounds (
+		id TEXT PRIMARY KEY,
+		shard_id INTEGER,
+		round INTEGER,
+		signers_indexes TEXT,
+		block_was_proposed INTEGER,
+		timestamp INTEGER
+	)`,
+	`CREATE TABLE IF NOT EXISTS tps (
+		id TEXT PRIMARY KEY,
+		document TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS validators (
+		shard_id INTEGER PRIMARY KEY,
+		public_keys TEXT
+	)`,
+}
+
+// sqliteIndexer saves the same documents as the elastic search indexer into an embedded SQLite database
+type sqliteIndexer struct {
+	mutDb            sync.Mutex
+	db               *sql.DB
+	shardCoordinator sharding.Coordinator
+	marshalizer      marshal.Marshalizer
+	hasher           hashing.Hasher
+	options          *Options
+}
+
+// NewSqliteIndexer creates a new indexer that stores the indexed data in the SQLite database found at the provided
+// path. The database file and its schema are created if they do not exist
+func NewSqliteIndexer(
+	dbPath string,
+	shardCoordinator sharding.Coordinator,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	options *Options,
+) (Indexer, error) {
+	if dbPath == "" {
+		return nil, ErrEmptyDataPath
+	}
+	err := checkIndexerParams(shardCoordinator, marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dbPath), os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(sqliteDriverName, dbPath)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer, the indexing calls are serialized on the same connection
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range sqliteSchema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return &sqliteIndexer{
+		db:               db,
+		shardCoordinator: shardCoordinator,
+		marshalizer:      marshalizer,
+		hasher:           hasher,
+		options:          options,
+	}, nil
+}
+
+// SaveBlock will save the block header and, if enabled, the block's transactions
+func (si *sqliteIndexer) SaveBlock(
+	bodyHandler data.BodyHandler,
+	headerHandler data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+	signersIndexes []uint64,
+) {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		log.Debug("indexer: no header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	body, ok := bodyHandler.(block.Body)
+	if !ok {
+		log.Debug("indexer", "error", ErrBodyTypeAssertion.Error())
+		return
+	}
+
+	si.saveHeader(headerHandler, signersIndexes)
+
+	if len(body) == 0 {
+		log.Debug("indexer", "error", ErrNoMiniblocks.Error())
+		return
+	}
+
+	if si.options != nil && si.options.TxIndexingEnabled {
+		si.saveTransactions(body, headerHandler, txPool)
+	}
+}
+
+// SaveMetaBlock will save a meta block header
+func (si *sqliteIndexer) SaveMetaBlock(header data.HeaderHandler, signersIndexes []uint64) {
+	if header == nil || header.IsInterfaceNil() {
+		log.Debug("indexer: nil header", "error", ErrNoHeader.Error())
+		return
+	}
+
+	si.saveHeader(header, signersIndexes)
+}
+
+func (si *sqliteIndexer) saveHeader(header data.HeaderHandler, signersIndexes []uint64) {
+	blockDocument, _, err := createBlockDocument(header, signersIndexes, si.marshalizer, si.hasher)
+	if err != nil {
+		log.Debug("indexer: marshal", "error", "could not marshal header")
+		return
+	}
+
+	err = si.WriteBlock(blockDocument)
+	if err != nil {
+		log.Warn("indexer: could not index block header", "error", err.Error())
+	}
+}
+
+// WriteBlock saves the block document
+func (si *sqliteIndexer) WriteBlock(blockDocument *Block) error {
+	validators, err := json.Marshal(blockDocument.Validators)
+	if err != nil {
+		return err
+	}
+
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	_, err = si.db.Exec(
+		`INSERT OR REPLACE INTO blocks (hash, nonce, round, shard_id, proposer, validators, pub_key_bitmap, size,
+			timestamp, state_root_hash, prev_hash, tx_count) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		blockDocument.Hash,
+		int64(blockDocument.Nonce),
+		int64(blockDocument.Round),
+		blockDocument.ShardID,
+		int64(blockDocument.Proposer),
+		string(validators),
+		blockDocument.PubKeyBitmap,
+		blockDocument.Size,
+		int64(blockDocument.Timestamp),
+		blockDocument.StateRootHash,
+		blockDocument.PrevHash,
+		blockDocument.TxCount,
+	)
+
+	return err
+}
+
+func (si *sqliteIndexer) saveTransactions(
+	body block.Body,
+	header data.HeaderHandler,
+	txPool map[string]data.TransactionHandler,
+) {
+	transactions := createTransactionDocuments(body, header, txPool, si.shardCoordinator.SelfId(), si.marshalizer, si.hasher)
+	err := si.WriteTransactions(transactions)
+	if err != nil {
+		log.Warn("indexer: could not index transactions", "error", err.Error())
+	}
+}
+
+// WriteTransactions saves the transaction documents in a single database transaction
+func (si *sqliteIndexer) WriteTransactions(transactions []*Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	dbTx, err := si.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range transactions {
+		_, err = dbTx.Exec(
+			`INSERT OR REPLACE INTO transactions (hash, mini_block_hash, block_hash, nonce, round, value, receiver,
+				sender, receiver_shard, sender_shard, gas_price, gas_limit, data, signature, timestamp, status)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			tx.Hash,
+			tx.MBHash,
+			tx.BlockHash,
+			int64(tx.Nonce),
+			int64(tx.Round),
+			tx.Value,
+			tx.Receiver,
+			tx.Sender,
+			tx.ReceiverShard,
+			tx.SenderShard,
+			int64(tx.GasPrice),
+			int64(tx.GasLimit),
+			tx.Data,
+			tx.Signature,
+			int64(tx.Timestamp),
+			tx.Status,
+		)
+		if err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+	}
+
+	return dbTx.Commit()
+}
+
+// SaveRoundInfo will save data about a round
+func (si *sqliteIndexer) SaveRoundInfo(roundInfo RoundInfo) {
+	err := si.WriteRoundInfo(roundInfo)
+	if err != nil {
+		log.Warn("indexer: can not index round info", "error", err.Error())
+	}
+}
+
+// WriteRoundInfo saves the round info document
+func (si *sqliteIndexer) WriteRoundInfo(roundInfo RoundInfo) error {
+	signersIndexes, err := json.Marshal(roundInfo.SignersIndexes)
+	if err != nil {
+		return err
+	}
+
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	_, err = si.db.Exec(
+		`INSERT OR REPLACE INTO rounds (id, shard_id, round, signers_indexes, block_was_proposed, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+		roundDocumentID(roundInfo),
+		roundInfo.ShardId,
+		int64(roundInfo.Index),
+		string(signersIndexes),
+		roundInfo.BlockWasProposed,
+		int64(roundInfo.Timestamp),
+	)
+
+	return err
+}
+
+// UpdateTPS updates the network and the shards statistics
+func (si *sqliteIndexer) UpdateTPS(tpsBenchmark statistics.TPSBenchmark) {
+	if tpsBenchmark == nil {
+		log.Debug("indexer: update tps called, but the tpsBenchmark is nil")
+		return
+	}
+
+	err := si.WriteTPS(createTPSDocuments(tpsBenchmark))
+	if err != nil {
+		log.Warn("indexer: error indexing tps information", "error", err.Error())
+	}
+}
+
+// WriteTPS saves the statistics documents
+func (si *sqliteIndexer) WriteTPS(tpsDocuments map[string]*TPS) error {
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	for id, tps := range tpsDocuments {
+		document, err := json.Marshal(tps)
+		if err != nil {
+			return err
+		}
+
+		_, err = si.db.Exec(`INSERT OR REPLACE INTO tps (id, document) VALUES (?, ?)`, id, string(document))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveValidatorsPubKeys will save all validators public keys
+func (si *sqliteIndexer) SaveValidatorsPubKeys(validatorsPubKeys map[uint32][][]byte) {
+	for shardID, shardPubKeys := range validatorsPubKeys {
+		err := si.WriteValidatorsPubKeys(shardID, &ValidatorsPublicKeys{PublicKeys: hexEncodePubKeys(shardPubKeys)})
+		if err != nil {
+			log.Warn("indexer: can not index validators pubkey", "shard", shardID, "error", err.Error())
+		}
+	}
+}
+
+// WriteValidatorsPubKeys saves the validators public keys document of a shard
+func (si *sqliteIndexer) WriteValidatorsPubKeys(shardID uint32, validatorsPubKeys *ValidatorsPublicKeys) error {
+	publicKeys, err := json.Marshal(validatorsPubKeys.PublicKeys)
+	if err != nil {
+		return err
+	}
+
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	_, err = si.db.Exec(
+		`INSERT OR REPLACE INTO validators (shard_id, public_keys) VALUES (?, ?)`,
+		shardID,
+		string(publicKeys),
+	)
+
+	return err
+}
+
+// Close closes the underlying database
+func (si *sqliteIndexer) Close() error {
+	si.mutDb.Lock()
+	defer si.mutDb.Unlock()
+
+	return si.db.Close()
+}
+
+// IsNilIndexer returns false as this is a working indexer implementation
+func (si *sqliteIndexer) IsNilIndexer() bool {
+	return false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (si *sqliteIndexer) IsInterfaceNil() bool {
+	return si == nil
+}
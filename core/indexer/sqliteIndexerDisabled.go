@@ -0,0 +1,22 @@
+//go:build !sqlite
+// +build !sqlite
+
+package indexer
+
+import (
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// NewSqliteIndexer returns ErrSqliteDriverNotCompiled, as the SQLite driver needs cgo and is only compiled in the
+// node built with the sqlite build tag
+func NewSqliteIndexer(
+	_ string,
+	_ sharding.Coordinator,
+	_ marshal.Marshalizer,
+	_ hashing.Hasher,
+	_ *Options,
+) (Indexer, error) {
+	return nil, ErrSqliteDriverNotCompiled
+}
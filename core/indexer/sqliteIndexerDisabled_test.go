@@ -0,0 +1,31 @@
+//go:build !sqlite
+// +build !sqlite
+
+package indexer_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fileDrivers = []string{indexer.NDJSONDriver}
+
+func TestNewIndexer_SqliteDriverNotCompiledShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "indexer_factory")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ind, err := indexer.NewIndexer(createFactoryArgs(indexer.SqliteDriver, dir))
+
+	assert.Nil(t, ind)
+	assert.Equal(t, indexer.ErrSqliteDriverNotCompiled, err)
+}
@@ -0,0 +1,161 @@
+//go:build sqlite
+// +build sqlite
+
+package indexer_test
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+	"github.com/ElrondNetwork/elrond-go/core/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fileDrivers = []string{indexer.SqliteDriver, indexer.NDJSONDriver}
+
+func createTestSqliteIndexer(t *testing.T, options *indexer.Options) (indexer.Indexer, *sql.DB, func()) {
+	dir, err := ioutil.TempDir("", "sqlite_indexer")
+	require.Nil(t, err)
+
+	dbPath := filepath.Join(dir, "indexer.db")
+	si, err := indexer.NewSqliteIndexer(dbPath, shardCoordinator, marshalizer, hasher, options)
+	require.Nil(t, err)
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.Nil(t, err)
+
+	return si, db, func() {
+		_ = db.Close()
+		_ = si.(io.Closer).Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+	require.Nil(t, err)
+
+	return count
+}
+
+func TestNewSqliteIndexer_EmptyPathShouldErr(t *testing.T) {
+	t.Parallel()
+
+	si, err := indexer.NewSqliteIndexer("", shardCoordinator, marshalizer, hasher, &indexer.Options{})
+
+	assert.Nil(t, si)
+	assert.Equal(t, indexer.ErrEmptyDataPath, err)
+}
+
+func TestNewSqliteIndexer_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	si, err := indexer.NewSqliteIndexer("indexer.db", shardCoordinator, nil, hasher, &indexer.Options{})
+
+	assert.Nil(t, si)
+	assert.Equal(t, core.ErrNilMarshalizer, err)
+}
+
+func TestSqliteIndexer_SaveBlockShouldSaveHeaderAndTransactions(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{TxIndexingEnabled: true})
+	defer cleanup()
+
+	header := newTestBlockHeader()
+	si.SaveBlock(newTestBlockBody(), header, newTestTxPool(), []uint64{2, 3})
+
+	var nonce uint64
+	var proposer uint64
+	var validators string
+	err := db.QueryRow("SELECT nonce, proposer, validators FROM blocks").Scan(&nonce, &proposer, &validators)
+	require.Nil(t, err)
+	assert.Equal(t, header.Nonce, nonce)
+	assert.Equal(t, uint64(2), proposer)
+	assert.Equal(t, "[2,3]", validators)
+
+	assert.Equal(t, 3, countRows(t, db, "transactions"))
+	var sender string
+	err = db.QueryRow("SELECT sender FROM transactions WHERE hash = ?", hex.EncodeToString([]byte("tx2"))).Scan(&sender)
+	require.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString([]byte("sender_address2")), sender)
+}
+
+func TestSqliteIndexer_SaveBlockTwiceShouldNotDuplicate(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{TxIndexingEnabled: true})
+	defer cleanup()
+
+	si.SaveBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool(), []uint64{0})
+	si.SaveBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool(), []uint64{0})
+
+	assert.Equal(t, 1, countRows(t, db, "blocks"))
+	assert.Equal(t, 3, countRows(t, db, "transactions"))
+}
+
+func TestSqliteIndexer_SaveBlockTxIndexingDisabledShouldSaveOnlyHeader(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{})
+	defer cleanup()
+
+	si.SaveBlock(newTestBlockBody(), newTestBlockHeader(), newTestTxPool(), []uint64{0})
+
+	assert.Equal(t, 1, countRows(t, db, "blocks"))
+	assert.Equal(t, 0, countRows(t, db, "transactions"))
+}
+
+func TestSqliteIndexer_SaveMetaBlock(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{})
+	defer cleanup()
+
+	si.SaveMetaBlock(newTestMetaBlock(), []uint64{0})
+
+	assert.Equal(t, 1, countRows(t, db, "blocks"))
+}
+
+func TestSqliteIndexer_SaveRoundInfoAndValidators(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{})
+	defer cleanup()
+
+	si.SaveRoundInfo(indexer.RoundInfo{Index: 5, ShardId: 1, SignersIndexes: []uint64{1, 2}, BlockWasProposed: true})
+	si.SaveValidatorsPubKeys(map[uint32][][]byte{
+		0: {[]byte("pk1"), []byte("pk2")},
+		1: {[]byte("pk3")},
+	})
+
+	var signers string
+	err := db.QueryRow("SELECT signers_indexes FROM rounds WHERE id = ?", "1_5").Scan(&signers)
+	require.Nil(t, err)
+	assert.Equal(t, "[1,2]", signers)
+
+	assert.Equal(t, 2, countRows(t, db, "validators"))
+}
+
+func TestSqliteIndexer_UpdateTPS(t *testing.T) {
+	t.Parallel()
+
+	si, db, cleanup := createTestSqliteIndexer(t, &indexer.Options{})
+	defer cleanup()
+
+	tpsBench := mock.TpsBenchmarkMock{}
+	tpsBench.Update(newTestMetaBlock())
+
+	si.UpdateTPS(&tpsBench)
+
+	assert.Equal(t, 1+len(tpsBench.ShardStatistics()), countRows(t, db, "tps"))
+}
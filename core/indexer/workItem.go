@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"fmt"
+)
+
+type workItemType uint8
+
+const (
+	blockWorkItem workItemType = iota + 1
+	transactionsWorkItem
+	roundInfoWorkItem
+	tpsWorkItem
+	validatorsWorkItem
+)
+
+// workItem is a unit of work persisted by the indexing queue. It holds already built documents so it can be
+// written again, unchanged, after a failure or after a node restart
+type workItem struct {
+	Type         workItemType          `json:"type"`
+	Block        *Block                `json:"block,omitempty"`
+	Transactions []*Transaction        `json:"transactions,omitempty"`
+	RoundInfo    *RoundInfo            `json:"roundInfo,omitempty"`
+	RoundIndex   uint64                `json:"roundIndex,omitempty"`
+	TPS          map[string]*TPS       `json:"tps,omitempty"`
+	ShardID      uint32                `json:"shardId,omitempty"`
+	Validators   *ValidatorsPublicKeys `json:"validators,omitempty"`
+}
+
+func newRoundInfoWorkItem(roundInfo RoundInfo) *workItem {
+	return &workItem{
+		Type:      roundInfoWorkItem,
+		RoundInfo: &roundInfo,
+		// the round index is not serialized together with the round info document
+		RoundIndex: roundInfo.Index,
+	}
+}
+
+func (wi *workItem) writeTo(writer DocumentsWriter) error {
+	switch wi.Type {
+	case blockWorkItem:
+		return writer.WriteBlock(wi.Block)
+	case transactionsWorkItem:
+		return writer.WriteTransactions(wi.Transactions)
+	case roundInfoWorkItem:
+		roundInfo := *wi.RoundInfo
+		roundInfo.Index = wi.RoundIndex
+		return writer.WriteRoundInfo(roundInfo)
+	case tpsWorkItem:
+		return writer.WriteTPS(wi.TPS)
+	case validatorsWorkItem:
+		return writer.WriteValidatorsPubKeys(wi.ShardID, wi.Validators)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownWorkItemType, wi.Type)
+	}
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// BlockWriterStub -
+type BlockWriterStub struct {
+	WriteShardBlockCalled func(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error
+	WriteMetaBlockCalled  func(header data.HeaderHandler) error
+}
+
+// WriteShardBlock -
+func (bws *BlockWriterStub) WriteShardBlock(body data.BodyHandler, header data.HeaderHandler, txPool map[string]data.TransactionHandler) error {
+	if bws.WriteShardBlockCalled != nil {
+		return bws.WriteShardBlockCalled(body, header, txPool)
+	}
+
+	return nil
+}
+
+// WriteMetaBlock -
+func (bws *BlockWriterStub) WriteMetaBlock(header data.HeaderHandler) error {
+	if bws.WriteMetaBlockCalled != nil {
+		return bws.WriteMetaBlockCalled(header)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (bws *BlockWriterStub) IsInterfaceNil() bool {
+	return bws == nil
+}
@@ -0,0 +1,64 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/indexer"
+)
+
+// DocumentsWriterStub -
+type DocumentsWriterStub struct {
+	WriteBlockCalled             func(block *indexer.Block) error
+	WriteTransactionsCalled      func(transactions []*indexer.Transaction) error
+	WriteRoundInfoCalled         func(roundInfo indexer.RoundInfo) error
+	WriteTPSCalled               func(tpsDocuments map[string]*indexer.TPS) error
+	WriteValidatorsPubKeysCalled func(shardID uint32, validatorsPubKeys *indexer.ValidatorsPublicKeys) error
+}
+
+// WriteBlock -
+func (dws *DocumentsWriterStub) WriteBlock(block *indexer.Block) error {
+	if dws.WriteBlockCalled != nil {
+		return dws.WriteBlockCalled(block)
+	}
+
+	return nil
+}
+
+// WriteTransactions -
+func (dws *DocumentsWriterStub) WriteTransactions(transactions []*indexer.Transaction) error {
+	if dws.WriteTransactionsCalled != nil {
+		return dws.WriteTransactionsCalled(transactions)
+	}
+
+	return nil
+}
+
+// WriteRoundInfo -
+func (dws *DocumentsWriterStub) WriteRoundInfo(roundInfo indexer.RoundInfo) error {
+	if dws.WriteRoundInfoCalled != nil {
+		return dws.WriteRoundInfoCalled(roundInfo)
+	}
+
+	return nil
+}
+
+// WriteTPS -
+func (dws *DocumentsWriterStub) WriteTPS(tpsDocuments map[string]*indexer.TPS) error {
+	if dws.WriteTPSCalled != nil {
+		return dws.WriteTPSCalled(tpsDocuments)
+	}
+
+	return nil
+}
+
+// WriteValidatorsPubKeys -
+func (dws *DocumentsWriterStub) WriteValidatorsPubKeys(shardID uint32, validatorsPubKeys *indexer.ValidatorsPublicKeys) error {
+	if dws.WriteValidatorsPubKeysCalled != nil {
+		return dws.WriteValidatorsPubKeysCalled(shardID, validatorsPubKeys)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (dws *DocumentsWriterStub) IsInterfaceNil() bool {
+	return dws == nil
+}
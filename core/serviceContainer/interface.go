@@ -1,6 +1,7 @@
 package serviceContainer
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 )
@@ -10,5 +11,6 @@ import (
 type Core interface {
 	Indexer() indexer.Indexer
 	TPSBenchmark() statistics.TPSBenchmark
+	EventsNotifier() eventsNotifier.EventsNotifier
 	IsInterfaceNil() bool
 }
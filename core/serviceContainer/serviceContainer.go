@@ -1,13 +1,15 @@
 package serviceContainer
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 )
 
 type serviceContainer struct {
-	indexer      indexer.Indexer
-	tpsBenchmark statistics.TPSBenchmark
+	indexer        indexer.Indexer
+	tpsBenchmark   statistics.TPSBenchmark
+	eventsNotifier eventsNotifier.EventsNotifier
 }
 
 // Option represents a functional configuration parameter that
@@ -37,6 +39,11 @@ func (sc *serviceContainer) TPSBenchmark() statistics.TPSBenchmark {
 	return sc.tpsBenchmark
 }
 
+// EventsNotifier returns the core package's chain events notifier
+func (sc *serviceContainer) EventsNotifier() eventsNotifier.EventsNotifier {
+	return sc.eventsNotifier
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (sc *serviceContainer) IsInterfaceNil() bool {
 	return sc == nil
@@ -57,3 +64,11 @@ func WithTPSBenchmark(tpsBenchmark statistics.TPSBenchmark) Option {
 		return nil
 	}
 }
+
+// WithEventsNotifier sets up the chain events notifier for the core serviceContainer
+func WithEventsNotifier(notifier eventsNotifier.EventsNotifier) Option {
+	return func(sc *serviceContainer) error {
+		sc.eventsNotifier = notifier
+		return nil
+	}
+}
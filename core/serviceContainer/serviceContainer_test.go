@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/mock"
 	"github.com/ElrondNetwork/elrond-go/core/serviceContainer"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,11 @@ func TestServiceContainer_NewServiceContainerWithNilTPSBenchmark(t *testing.T) {
 	assert.NotNil(t, sc)
 	assert.Nil(t, sc.TPSBenchmark())
 }
+
+func TestServiceContainer_NewServiceContainerWithEventsNotifier(t *testing.T) {
+	notifier, _ := eventsNotifier.NewEventsNotifier(1)
+	sc, err := serviceContainer.NewServiceContainer(serviceContainer.WithEventsNotifier(notifier))
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(sc))
+	assert.Equal(t, notifier, sc.EventsNotifier())
+}
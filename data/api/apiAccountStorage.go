@@ -0,0 +1,14 @@
+package api
+
+// KeyValuePair holds a hex encoded key and its hex encoded value, as stored in an account's data trie
+type KeyValuePair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AccountKeyValuePairs holds a page of an account's data trie entries, in ascending key order. ContinuationToken
+// is the opaque token the following page is requested with and is empty when there are no more entries
+type AccountKeyValuePairs struct {
+	Pairs             []*KeyValuePair `json:"pairs"`
+	ContinuationToken string          `json:"continuationToken,omitempty"`
+}
@@ -0,0 +1,35 @@
+package api
+
+// Block represents the structure of a shard block or a metablock, as it is returned by the api routes
+type Block struct {
+	Nonce           uint64            `json:"nonce"`
+	Round           uint64            `json:"round"`
+	Hash            string            `json:"hash"`
+	PrevBlockHash   string            `json:"prevBlockHash"`
+	Epoch           uint32            `json:"epoch"`
+	ShardID         uint32            `json:"shardId"`
+	NumTxs          uint32            `json:"numTxs"`
+	RootHash        string            `json:"rootHash"`
+	TimeStamp       uint64            `json:"timestamp"`
+	MiniBlocks      []*MiniBlock      `json:"miniBlocks,omitempty"`
+	NotarizedBlocks []*NotarizedBlock `json:"notarizedBlocks,omitempty"`
+}
+
+// MiniBlock represents the structure of a miniblock, as it is returned by the api routes
+type MiniBlock struct {
+	Hash            string   `json:"hash"`
+	Type            string   `json:"type,omitempty"`
+	SenderShardID   uint32   `json:"sourceShard"`
+	ReceiverShardID uint32   `json:"destinationShard"`
+	TxHashes        []string `json:"txHashes,omitempty"`
+}
+
+// NotarizedBlock represents a shard block notarized by a metablock, as it is returned by the api routes
+type NotarizedBlock struct {
+	Hash       string       `json:"hash"`
+	Nonce      uint64       `json:"nonce"`
+	Round      uint64       `json:"round"`
+	ShardID    uint32       `json:"shardId"`
+	NumTxs     uint32       `json:"numTxs"`
+	MiniBlocks []*MiniBlock `json:"miniBlocks,omitempty"`
+}
@@ -0,0 +1,26 @@
+package api
+
+// Types of the events pushed to the chain events subscribers
+const (
+	// EventTypeShardBlock is the type of the events describing a committed or notarized shard block
+	EventTypeShardBlock = "shardBlock"
+	// EventTypeMetaBlock is the type of the events describing a committed or notarized metablock
+	EventTypeMetaBlock = "metaBlock"
+	// EventTypeTransaction is the type of the events describing a committed transaction touching a watched address
+	EventTypeTransaction = "transaction"
+)
+
+// Event represents a chain event, as it is pushed to the events subscribers. For transaction events, the nonce,
+// the round and the shard ID are those of the block including the transaction
+type Event struct {
+	Type      string `json:"type"`
+	ShardID   uint32 `json:"shardId"`
+	Nonce     uint64 `json:"nonce"`
+	Round     uint64 `json:"round"`
+	Hash      string `json:"hash"`
+	RootHash  string `json:"rootHash,omitempty"`
+	BlockHash string `json:"blockHash,omitempty"`
+	Sender    string `json:"sender,omitempty"`
+	Receiver  string `json:"receiver,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
@@ -0,0 +1,22 @@
+package api
+
+// MerkleProof holds a Merkle proof for a key, together with the value the proof leads to. All fields are hex
+// encoded. Value is empty when the proof shows the key is missing from the trie
+type MerkleProof struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// AccountProof holds the proof of an account against a state root hash and, optionally, the proof of a key
+// against the account's data trie root hash. The account value is the marshalized account, while the data trie
+// value is the raw trie value, suffixed with the key and the account address. The header fields are set when the
+// proof was requested for a block header, whose root hash is the one the proof was built on
+type AccountProof struct {
+	HeaderHash       string       `json:"headerHash,omitempty"`
+	HeaderNonce      uint64       `json:"headerNonce,omitempty"`
+	RootHash         string       `json:"rootHash"`
+	Account          *MerkleProof `json:"account"`
+	DataTrieRootHash string       `json:"dataTrieRootHash,omitempty"`
+	DataTrieKey      *MerkleProof `json:"dataTrieKey,omitempty"`
+}
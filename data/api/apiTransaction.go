@@ -0,0 +1,73 @@
+package api
+
+// TransactionStatus is the status of a transaction, as it is seen by the node answering the api request
+type TransactionStatus string
+
+const (
+	// TxStatusPending signals that the transaction is in the node's pools, waiting to be included in a block
+	TxStatusPending TransactionStatus = "pending"
+	// TxStatusPartiallyExecuted signals that the transaction was executed in the source shard and was
+	// sent to the destination shard
+	TxStatusPartiallyExecuted TransactionStatus = "partially-executed"
+	// TxStatusExecuted signals that the transaction was executed in the destination shard
+	TxStatusExecuted TransactionStatus = "executed"
+	// TxStatusInvalid signals that the transaction was included in a block as invalid
+	TxStatusInvalid TransactionStatus = "invalid"
+	// TxStatusUnknown signals that the transaction was found in storage but its inclusion details are missing
+	TxStatusUnknown TransactionStatus = "unknown"
+)
+
+const (
+	// TxTypeNormal is the type of a signed transaction
+	TxTypeNormal = "normal"
+	// TxTypeUnsigned is the type of a smart contract result
+	TxTypeUnsigned = "unsigned"
+	// TxTypeReward is the type of a reward transaction
+	TxTypeReward = "reward"
+)
+
+// Transaction represents a transaction together with its processing status, as it is returned by the api routes
+type Transaction struct {
+	Type                 string                 `json:"type"`
+	Hash                 string                 `json:"hash"`
+	Nonce                uint64                 `json:"nonce"`
+	Value                string                 `json:"value"`
+	Receiver             string                 `json:"receiver"`
+	Sender               string                 `json:"sender"`
+	GasPrice             uint64                 `json:"gasPrice"`
+	GasLimit             uint64                 `json:"gasLimit"`
+	Data                 []byte                 `json:"data"`
+	Signature            string                 `json:"signature,omitempty"`
+	Status               TransactionStatus      `json:"status"`
+	SourceShard          uint32                 `json:"sourceShard"`
+	DestinationShard     uint32                 `json:"destinationShard"`
+	BlockNonce           uint64                 `json:"blockNonce,omitempty"`
+	BlockHash            string                 `json:"blockHash,omitempty"`
+	MiniBlockHash        string                 `json:"miniBlockHash,omitempty"`
+	MiniBlockType        string                 `json:"miniBlockType,omitempty"`
+	Epoch                uint32                 `json:"epoch,omitempty"`
+	SmartContractResults []*SmartContractResult `json:"smartContractResults,omitempty"`
+	Receipts             []*Receipt             `json:"receipts,omitempty"`
+}
+
+// SmartContractResult represents a smart contract result generated by a transaction, as it is returned by the api routes
+type SmartContractResult struct {
+	Hash           string `json:"hash"`
+	Nonce          uint64 `json:"nonce"`
+	Value          string `json:"value"`
+	Receiver       string `json:"receiver"`
+	Sender         string `json:"sender"`
+	Data           []byte `json:"data,omitempty"`
+	GasLimit       uint64 `json:"gasLimit"`
+	GasPrice       uint64 `json:"gasPrice"`
+	OriginalTxHash string `json:"originalTxHash"`
+}
+
+// Receipt represents a receipt generated by a transaction, as it is returned by the api routes
+type Receipt struct {
+	Hash   string `json:"hash"`
+	Value  string `json:"value"`
+	Sender string `json:"sender"`
+	Data   []byte `json:"data,omitempty"`
+	TxHash string `json:"txHash"`
+}
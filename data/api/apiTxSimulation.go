@@ -0,0 +1,11 @@
+package api
+
+// SimulationResults holds the outcome of a transaction executed against a throwaway copy of the state
+type SimulationResults struct {
+	Hash                 string                 `json:"hash"`
+	FailReason           string                 `json:"failReason,omitempty"`
+	GasUsed              uint64                 `json:"gasUsed"`
+	BalanceDeltas        map[string]string      `json:"balanceDeltas"`
+	SmartContractResults []*SmartContractResult `json:"smartContractResults,omitempty"`
+	Receipts             []*Receipt             `json:"receipts,omitempty"`
+}
@@ -367,3 +367,14 @@ func (mb *MiniBlock) Clone() *MiniBlock {
 
 	return newMb
 }
+
+// Size returns the number of bytes held by the miniblock, used when accounting the size of the caches
+func (mb *MiniBlock) Size() int {
+	// the two shard ids and the type
+	size := 4 + 4 + 1
+	for _, txHash := range mb.TxHashes {
+		size += len(txHash)
+	}
+
+	return size
+}
@@ -349,3 +349,16 @@ func TestMiniBlock_Clone(t *testing.T) {
 
 	assert.True(t, reflect.DeepEqual(miniBlock, clonedMB))
 }
+
+func TestMiniBlock_Size(t *testing.T) {
+	t.Parallel()
+
+	miniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{[]byte("something"), []byte("something2")},
+		ReceiverShardID: 1,
+		SenderShardID:   2,
+		Type:            0,
+	}
+
+	assert.Equal(t, 9+len("something")+len("something2"), miniBlock.Size())
+}
@@ -21,6 +21,27 @@ const (
 // ModifiedHashes is used to memorize all old hashes and new hashes from when a trie is committed
 type ModifiedHashes map[string]struct{}
 
+// LeafChangeType is the type for the kinds of changes of a leaf between two tries
+type LeafChangeType byte
+
+const (
+	// LeafAdded marks a key found only in the new trie
+	LeafAdded LeafChangeType = 0
+	// LeafModified marks a key found in both tries, with different values
+	LeafModified LeafChangeType = 1
+	// LeafRemoved marks a key found only in the old trie
+	LeafRemoved LeafChangeType = 2
+)
+
+// LeafChange holds a key which differs between two tries, with its old and new values. The old value is nil for an
+// added key and the new value is nil for a removed key
+type LeafChange struct {
+	Type     LeafChangeType
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
 // HeaderHandler defines getters and setters for header data holder
 type HeaderHandler interface {
 	GetShardID() uint32
@@ -134,6 +155,8 @@ type Trie interface {
 	Database() DBWriteCacher
 	GetSerializedNodes([]byte, uint64) ([][]byte, error)
 	GetAllLeaves() (map[string][]byte, error)
+	IterateLeaves(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error
+	Diff(newTrie Trie, handler func(change LeafChange) bool) error
 	IsPruningEnabled() bool
 	IsInterfaceNil() bool
 	ClosePersister() error
@@ -178,6 +201,12 @@ type StorageManager interface {
 	IsInterfaceNil() bool
 }
 
+// MerkleProofVerifier checks Merkle proofs against a root hash, without needing access to the trie
+type MerkleProofVerifier interface {
+	VerifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, bool, error)
+	IsInterfaceNil() bool
+}
+
 // TrieFactory creates new tries
 type TrieFactory interface {
 	Create(config.StorageConfig, bool) (Trie, error)
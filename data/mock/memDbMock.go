@@ -4,7 +4,10 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // MemDbMock represents the memory database storage. It holds a map of key value pairs
@@ -96,6 +99,29 @@ func (s *MemDbMock) DestroyClosed() error {
 	return nil
 }
 
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order
+func (s *MemDbMock) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	s.mutx.RLock()
+	keys := make([]string, 0, len(s.db))
+	pairs := make(map[string][]byte)
+	for key, val := range s.db {
+		if keyRange.Contains([]byte(key)) {
+			keys = append(keys, key)
+			pairs[key] = val
+		}
+	}
+	s.mutx.RUnlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !handler([]byte(key), pairs[key]) {
+			break
+		}
+	}
+
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (s *MemDbMock) IsInterfaceNil() bool {
 	if s == nil {
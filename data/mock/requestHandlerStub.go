@@ -0,0 +1,19 @@
+package mock
+
+// RequestHandlerStub -
+type RequestHandlerStub struct {
+	RequestTrieNodesCalled func(destShardID uint32, hash []byte, topic string)
+}
+
+// RequestTrieNodes -
+func (rhs *RequestHandlerStub) RequestTrieNodes(destShardID uint32, hash []byte, topic string) {
+	if rhs.RequestTrieNodesCalled == nil {
+		return
+	}
+	rhs.RequestTrieNodesCalled(destShardID, hash, topic)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rhs *RequestHandlerStub) IsInterfaceNil() bool {
+	return rhs == nil
+}
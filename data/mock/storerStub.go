@@ -1,5 +1,9 @@
 package mock
 
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
 // StorerStub -
 type StorerStub struct {
 	PutCalled          func(key, data []byte) error
@@ -8,10 +12,12 @@ type StorerStub struct {
 	HasCalled          func(key []byte) error
 	HasInEpochCalled   func(key []byte, epoch uint32) error
 	SearchFirstCalled  func(key []byte) ([]byte, error)
+	IterateCalled      func(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error
 	RemoveCalled       func(key []byte) error
 	ClearCacheCalled   func()
 	CloseCalled        func() error
 	DestroyUnitCalled  func() error
+	FlushCalled        func() error
 }
 
 // GetFromEpoch -
@@ -29,6 +35,24 @@ func (ss *StorerStub) SearchFirst(key []byte) ([]byte, error) {
 	return ss.SearchFirstCalled(key)
 }
 
+// Flush -
+func (ss *StorerStub) Flush() error {
+	if ss.FlushCalled != nil {
+		return ss.FlushCalled()
+	}
+
+	return nil
+}
+
+// Iterate -
+func (ss *StorerStub) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	if ss.IterateCalled != nil {
+		return ss.IterateCalled(keyRange, handler)
+	}
+
+	return nil
+}
+
 // Close -
 func (ss *StorerStub) Close() error {
 	if ss.CloseCalled != nil {
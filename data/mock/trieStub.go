@@ -28,6 +28,8 @@ type TrieStub struct {
 	GetSerializedNodesCalled func([]byte, uint64) ([][]byte, error)
 	DatabaseCalled           func() data.DBWriteCacher
 	GetAllLeavesCalled       func() (map[string][]byte, error)
+	IterateLeavesCalled      func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error
+	DiffCalled               func(newTrie data.Trie, handler func(change data.LeafChange) bool) error
 	IsPruningEnabledCalled   func() bool
 	ClosePersisterCalled     func() error
 }
@@ -132,6 +134,24 @@ func (ts *TrieStub) GetAllLeaves() (map[string][]byte, error) {
 	return nil, errNotImplemented
 }
 
+// IterateLeaves -
+func (ts *TrieStub) IterateLeaves(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+	if ts.IterateLeavesCalled != nil {
+		return ts.IterateLeavesCalled(startKey, endKey, handler)
+	}
+
+	return errNotImplemented
+}
+
+// Diff -
+func (ts *TrieStub) Diff(newTrie data.Trie, handler func(change data.LeafChange) bool) error {
+	if ts.DiffCalled != nil {
+		return ts.DiffCalled(newTrie, handler)
+	}
+
+	return errNotImplemented
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (ts *TrieStub) IsInterfaceNil() bool {
 	return ts == nil
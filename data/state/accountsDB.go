@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -493,17 +494,50 @@ func (adb *AccountsDB) Commit() ([]byte, error) {
 		return nil, err
 	}
 
+	//Step 3. make the committed nodes durable, if the trie storage is configured to be flushed on commit
+	err = adb.flushTrieStorage()
+	if err != nil {
+		return nil, err
+	}
+
 	root, err := adb.mainTrie.Root()
 	if err != nil {
 		log.Trace("accountsDB.Commit ended", "error", err.Error())
 		return nil, err
 	}
 
+	//Step 4. keep in memory the top levels of the new state, as every read of the state goes through them
+	adb.pinTopLevels(root)
+
 	log.Trace("accountsDB.Commit ended", "root hash", root)
 
 	return root, nil
 }
 
+// pinTopLevels asks the database of the main trie to keep the top levels of the given root in memory, if it is able
+// to. The state is already committed, so a failure only leaves the nodes to the cache's budget
+func (adb *AccountsDB) pinTopLevels(rootHash []byte) {
+	pinner, ok := adb.mainTrie.Database().(TopLevelsPinner)
+	if !ok {
+		return
+	}
+
+	err := pinner.PinTopLevels(rootHash)
+	if err != nil {
+		log.Debug("accountsDB.pinTopLevels", "root hash", rootHash, "error", err.Error())
+	}
+}
+
+// flushTrieStorage flushes the database of the main trie, which also holds the nodes of the data tries
+func (adb *AccountsDB) flushTrieStorage() error {
+	flusher, ok := adb.mainTrie.Database().(storage.Flusher)
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush()
+}
+
 // loadCode retrieves and saves the SC code inside AccountState object. Errors if something went wrong
 func (adb *AccountsDB) loadCode(accountHandler AccountHandler) error {
 	if accountHandler.GetCodeHash() == nil || len(accountHandler.GetCodeHash()) == 0 {
@@ -551,9 +585,126 @@ func (adb *AccountsDB) RecreateTrie(rootHash []byte) error {
 	}
 
 	adb.mainTrie = newTrie
+	//cached data tries might belong to the old state
+	adb.dataTries.Reset()
+
 	return nil
 }
 
+// StreamAccounts calls the handler for every account of the state having the given root hash, in ascending address
+// order, until the handler returns false. The trie nodes are read from the storage as the walk needs them and the
+// accounts are sorted with a bounded amount of memory, so the state is never loaded whole in memory. The accounts are
+// passed without their code and data tries. The current state of the accounts DB is not changed
+func (adb *AccountsDB) StreamAccounts(rootHash []byte, handler func(account AccountHandler) bool) error {
+	if handler == nil {
+		return ErrNilAccountsHandler
+	}
+
+	tr, err := adb.mainTrie.Recreate(rootHash)
+	if err != nil {
+		return err
+	}
+
+	var errAccount error
+	err = tr.IterateLeaves(nil, nil, func(key []byte, value []byte) bool {
+		if adb.isCodeEntry(key, value) {
+			return true
+		}
+
+		var acnt AccountHandler
+		acnt, errAccount = adb.decodeAccount(key, value)
+		if errAccount != nil {
+			return false
+		}
+
+		return handler(acnt)
+	})
+	if err != nil {
+		return err
+	}
+
+	return errAccount
+}
+
+// AccountChange holds an account which differs between two states. The old account is nil for an added account and
+// the new account is nil for a removed one
+type AccountChange struct {
+	Type       data.LeafChangeType
+	Address    AddressContainer
+	OldAccount AccountHandler
+	NewAccount AccountHandler
+}
+
+// DiffAccounts calls the handler for every account which was added, modified or removed between the states having the
+// given root hashes, until the handler returns false. Only the trie nodes which differ between the two states are
+// read from the storage. The accounts are passed without their code and data tries. The current state of the accounts
+// DB is not changed
+func (adb *AccountsDB) DiffAccounts(oldRootHash []byte, newRootHash []byte, handler func(change *AccountChange) bool) error {
+	if handler == nil {
+		return ErrNilAccountsHandler
+	}
+
+	oldTrie, err := adb.mainTrie.Recreate(oldRootHash)
+	if err != nil {
+		return err
+	}
+	newTrie, err := adb.mainTrie.Recreate(newRootHash)
+	if err != nil {
+		return err
+	}
+
+	var errAccount error
+	err = oldTrie.Diff(newTrie, func(change data.LeafChange) bool {
+		if adb.isCodeEntry(change.Key, change.OldValue) || adb.isCodeEntry(change.Key, change.NewValue) {
+			return true
+		}
+
+		accountChange := &AccountChange{
+			Type:    change.Type,
+			Address: NewAddress(change.Key),
+		}
+		if change.OldValue != nil {
+			accountChange.OldAccount, errAccount = adb.decodeAccount(change.Key, change.OldValue)
+			if errAccount != nil {
+				return false
+			}
+		}
+		if change.NewValue != nil {
+			accountChange.NewAccount, errAccount = adb.decodeAccount(change.Key, change.NewValue)
+			if errAccount != nil {
+				return false
+			}
+		}
+
+		return handler(accountChange)
+	})
+	if err != nil {
+		return err
+	}
+
+	return errAccount
+}
+
+// isCodeEntry returns true if the main trie entry holds a smart contract code, which is saved under its hash, next to
+// the accounts
+func (adb *AccountsDB) isCodeEntry(key []byte, value []byte) bool {
+	return value != nil && bytes.Equal(adb.hasher.Compute(string(value)), key)
+}
+
+func (adb *AccountsDB) decodeAccount(key []byte, value []byte) (AccountHandler, error) {
+	acnt, err := adb.accountFactory.CreateAccount(NewAddress(key), adb)
+	if err != nil {
+		return nil, err
+	}
+
+	err = adb.marshalizer.Unmarshal(acnt, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w for key %s", err, hex.EncodeToString(key))
+	}
+
+	return acnt, nil
+}
+
 // Journalize adds a new object to entries list. Concurrent safe.
 func (adb *AccountsDB) Journalize(entry JournalEntry) {
 	if check.IfNil(entry) {
@@ -12,6 +12,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func generateAccountDBFromTrie(trie data.Trie) *state.AccountsDB {
@@ -713,6 +714,41 @@ func TestAccountsDB_CommitShouldCallCommitFromTrie(t *testing.T) {
 	assert.Equal(t, 2, commitCalled)
 }
 
+type pinningDbMock struct {
+	*mock.MemDbMock
+	pinnedRootHash []byte
+}
+
+func (pdm *pinningDbMock) PinTopLevels(rootHash []byte) error {
+	pdm.pinnedRootHash = rootHash
+	return nil
+}
+
+func TestAccountsDB_CommitShouldPinTheTopLevelsOfTheNewRoot(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	db := &pinningDbMock{MemDbMock: mock.NewMemDbMock()}
+	trieStub := &mock.TrieStub{
+		CommitCalled: func() error {
+			return nil
+		},
+		RootCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+		DatabaseCalled: func() data.DBWriteCacher {
+			return db
+		},
+	}
+	adb := generateAccountDBFromTrie(trieStub)
+
+	root, err := adb.Commit()
+
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, root)
+	assert.Equal(t, rootHash, db.pinnedRootHash)
+}
+
 //------- RecreateTrie
 
 func TestAccountsDB_RecreateTrieMalfunctionTrieShouldErr(t *testing.T) {
@@ -946,3 +982,194 @@ func TestAccountsDB_RevertToSnapshotShouldWork(t *testing.T) {
 	err := adb.RevertToSnapshot(1)
 	assert.Nil(t, err)
 }
+
+func createTrieStubWithLeaves(keys [][]byte, values [][]byte) *mock.TrieStub {
+	return &mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			return &mock.TrieStub{
+				IterateLeavesCalled: func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+					for i := range keys {
+						if !handler(keys[i], values[i]) {
+							return nil
+						}
+					}
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestAccountsDB_StreamAccountsNilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+
+	err := adb.StreamAccounts([]byte("root hash"), nil)
+
+	assert.Equal(t, state.ErrNilAccountsHandler, err)
+}
+
+func TestAccountsDB_StreamAccountsRecreateErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("recreate error")
+	adb := generateAccountDBFromTrie(&mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			return nil, expectedErr
+		},
+	})
+
+	err := adb.StreamAccounts([]byte("root hash"), func(account state.AccountHandler) bool {
+		return true
+	})
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestAccountsDB_StreamAccountsShouldSkipTheCodeAndStopWhenRequested(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	code := []byte("smart contract code")
+	keys := [][]byte{[]byte("address1"), mock.HasherMock{}.Compute(string(code)), []byte("address2"), []byte("address3")}
+	values := [][]byte{nil, code, nil, nil}
+	for _, i := range []int{0, 2, 3} {
+		values[i], _ = marshalizer.Marshal(&mock.AccountWrapMock{MockValue: i})
+	}
+	adb := generateAccountDBFromTrie(createTrieStubWithLeaves(keys, values))
+
+	addresses := make([]string, 0)
+	mockValues := make([]int, 0)
+	err := adb.StreamAccounts([]byte("root hash"), func(account state.AccountHandler) bool {
+		addresses = append(addresses, string(account.AddressContainer().Bytes()))
+		mockValues = append(mockValues, account.(*mock.AccountWrapMock).MockValue)
+		return len(addresses) < 2
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"address1", "address2"}, addresses)
+	assert.Equal(t, []int{0, 2}, mockValues)
+}
+
+func TestAccountsDB_StreamAccountsInvalidAccountShouldErr(t *testing.T) {
+	t.Parallel()
+
+	keys := [][]byte{[]byte("address1")}
+	values := [][]byte{[]byte("not an account")}
+	adb := generateAccountDBFromTrie(createTrieStubWithLeaves(keys, values))
+
+	numAccounts := 0
+	err := adb.StreamAccounts([]byte("root hash"), func(account state.AccountHandler) bool {
+		numAccounts++
+		return true
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, numAccounts)
+}
+
+func createTrieStubWithChanges(changes []data.LeafChange) *mock.TrieStub {
+	return &mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			return &mock.TrieStub{
+				DiffCalled: func(newTrie data.Trie, handler func(change data.LeafChange) bool) error {
+					for _, change := range changes {
+						if !handler(change) {
+							return nil
+						}
+					}
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestAccountsDB_DiffAccountsNilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+
+	err := adb.DiffAccounts([]byte("old root hash"), []byte("new root hash"), nil)
+
+	assert.Equal(t, state.ErrNilAccountsHandler, err)
+}
+
+func TestAccountsDB_DiffAccountsRecreateErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("recreate error")
+	adb := generateAccountDBFromTrie(&mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			if bytes.Equal(root, []byte("new root hash")) {
+				return nil, expectedErr
+			}
+			return &mock.TrieStub{}, nil
+		},
+	})
+
+	err := adb.DiffAccounts([]byte("old root hash"), []byte("new root hash"), func(change *state.AccountChange) bool {
+		return true
+	})
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestAccountsDB_DiffAccountsShouldDecodeTheAccountsAndSkipTheCode(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	code := []byte("smart contract code")
+	oldValue, _ := marshalizer.Marshal(&mock.AccountWrapMock{MockValue: 1})
+	newValue, _ := marshalizer.Marshal(&mock.AccountWrapMock{MockValue: 2})
+	changes := []data.LeafChange{
+		{Type: data.LeafAdded, Key: []byte("address1"), NewValue: newValue},
+		{Type: data.LeafAdded, Key: mock.HasherMock{}.Compute(string(code)), NewValue: code},
+		{Type: data.LeafModified, Key: []byte("address2"), OldValue: oldValue, NewValue: newValue},
+		{Type: data.LeafRemoved, Key: []byte("address3"), OldValue: oldValue},
+	}
+	adb := generateAccountDBFromTrie(createTrieStubWithChanges(changes))
+
+	accountChanges := make([]*state.AccountChange, 0)
+	err := adb.DiffAccounts([]byte("old root hash"), []byte("new root hash"), func(change *state.AccountChange) bool {
+		accountChanges = append(accountChanges, change)
+		return true
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, 3, len(accountChanges))
+
+	assert.Equal(t, data.LeafAdded, accountChanges[0].Type)
+	assert.Equal(t, []byte("address1"), accountChanges[0].Address.Bytes())
+	assert.Nil(t, accountChanges[0].OldAccount)
+	assert.Equal(t, 2, accountChanges[0].NewAccount.(*mock.AccountWrapMock).MockValue)
+
+	assert.Equal(t, data.LeafModified, accountChanges[1].Type)
+	assert.Equal(t, []byte("address2"), accountChanges[1].Address.Bytes())
+	assert.Equal(t, 1, accountChanges[1].OldAccount.(*mock.AccountWrapMock).MockValue)
+	assert.Equal(t, 2, accountChanges[1].NewAccount.(*mock.AccountWrapMock).MockValue)
+
+	assert.Equal(t, data.LeafRemoved, accountChanges[2].Type)
+	assert.Equal(t, []byte("address3"), accountChanges[2].Address.Bytes())
+	assert.Equal(t, 1, accountChanges[2].OldAccount.(*mock.AccountWrapMock).MockValue)
+	assert.Nil(t, accountChanges[2].NewAccount)
+}
+
+func TestAccountsDB_DiffAccountsInvalidAccountShouldErr(t *testing.T) {
+	t.Parallel()
+
+	changes := []data.LeafChange{
+		{Type: data.LeafAdded, Key: []byte("address1"), NewValue: []byte("not an account")},
+	}
+	adb := generateAccountDBFromTrie(createTrieStubWithChanges(changes))
+
+	numChanges := 0
+	err := adb.DiffAccounts([]byte("old root hash"), []byte("new root hash"), func(change *state.AccountChange) bool {
+		numChanges++
+		return true
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, numChanges)
+}
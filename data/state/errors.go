@@ -139,3 +139,6 @@ var ErrUnknownAccountType = errors.New("account type is unknown")
 
 // ErrNilOrEmptyDataTrieUpdates signals that there are no data trie updates
 var ErrNilOrEmptyDataTrieUpdates = errors.New("no data trie updates")
+
+// ErrNilAccountsHandler signals that a nil handler has been provided for streaming the accounts
+var ErrNilAccountsHandler = errors.New("nil accounts handler")
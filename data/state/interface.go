@@ -121,6 +121,11 @@ type JournalEntry interface {
 	IsInterfaceNil() bool
 }
 
+// TopLevelsPinner is implemented by the trie databases able to keep in memory the top levels of a trie
+type TopLevelsPinner interface {
+	PinTopLevels(rootHash []byte) error
+}
+
 // TriesHolder is used to store multiple tries
 type TriesHolder interface {
 	Put([]byte, data.Trie)
@@ -0,0 +1,20 @@
+package transaction
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/block"
+)
+
+// Metadata holds the location of a committed transaction inside the chain, together with the hashes
+// of the smart contract results and receipts generated when it was executed
+type Metadata struct {
+	BlockNonce      uint64     `json:"blockNonce"`
+	BlockHash       []byte     `json:"blockHash"`
+	Round           uint64     `json:"round"`
+	Epoch           uint32     `json:"epoch"`
+	MiniBlockHash   []byte     `json:"miniBlockHash"`
+	MiniBlockType   block.Type `json:"miniBlockType"`
+	SenderShardID   uint32     `json:"senderShardId"`
+	ReceiverShardID uint32     `json:"receiverShardId"`
+	ScResultHashes  [][]byte   `json:"scResultHashes,omitempty"`
+	ReceiptHashes   [][]byte   `json:"receiptHashes,omitempty"`
+}
@@ -663,29 +663,18 @@ func (bn *branchNode) setDirty(dirty bool) {
 	bn.dirty = dirty
 }
 
-func (bn *branchNode) loadChildren(syncer *trieSyncer) error {
-	err := bn.isEmptyOrNil()
-	if err != nil {
-		return err
-	}
-
-	for i := range bn.EncodedChildren {
-		if len(bn.EncodedChildren[i]) == 0 {
+func (bn *branchNode) getChildrenHashes() [][]byte {
+	hashes := make([][]byte, 0)
+	for i := range bn.children {
+		hash := childHash(bn.children[i], bn.EncodedChildren[i])
+		if len(hash) == 0 {
 			continue
 		}
 
-		var child node
-		child, err = syncer.getNode(bn.EncodedChildren[i])
-		if err != nil {
-			return err
-		}
-
-		bn.children[i] = child
+		hashes = append(hashes, hash)
 	}
 
-	syncer.interceptedNodes.Remove(bn.hash)
-
-	return nil
+	return hashes
 }
 
 func (bn *branchNode) getAllLeaves(leaves map[string][]byte, key []byte, db data.DBWriteCacher, marshalizer marshal.Marshalizer) error {
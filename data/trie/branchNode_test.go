@@ -7,14 +7,12 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
-	"time"
 
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/mock"
 	"github.com/ElrondNetwork/elrond-go/hashing"
 	"github.com/ElrondNetwork/elrond-go/marshal"
-	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
@@ -1039,49 +1037,15 @@ func TestBranchNode_setDirty(t *testing.T) {
 	assert.True(t, bn.dirty)
 }
 
-func TestBranchNode_loadChildren(t *testing.T) {
+func TestBranchNode_getChildrenHashes(t *testing.T) {
 	t.Parallel()
 
-	marsh, hasher := getTestMarshAndHasher()
-	tr := initTrie()
-	_ = tr.root.setRootHash()
-	nodes, _ := getEncodedTrieNodesAndHashes(tr)
-	nodesCacher, _ := lrucache.NewCache(100)
-
-	resolver := &mock.TrieNodesResolverStub{
-		RequestDataFromHashCalled: func(hash []byte) error {
-			for i := range nodes {
-				node, _ := NewInterceptedTrieNode(nodes[i], marsh, hasher)
-				nodesCacher.Put(node.hash, node)
-			}
-			return nil
-		},
-	}
-	syncer, _ := NewTrieSyncer(resolver, nodesCacher, tr, time.Second)
-	syncer.interceptedNodes.RegisterHandler(func(key []byte) {
-		syncer.chRcvTrieNodes <- true
-	})
-
-	firstChildIndex := 5
-	secondChildIndex := 7
-
-	bn := getCollapsedBn(t, tr.root)
-
-	err := bn.loadChildren(syncer)
-	assert.Nil(t, err)
-	assert.NotNil(t, bn.children[firstChildIndex])
-	assert.NotNil(t, bn.children[secondChildIndex])
-
-	assert.Equal(t, 5, nodesCacher.Len())
-}
+	bn, collapsedBn := getBnAndCollapsedBn(getTestMarshAndHasher())
+	_ = bn.setHash()
+	expectedHashes := [][]byte{collapsedBn.EncodedChildren[2], collapsedBn.EncodedChildren[6], collapsedBn.EncodedChildren[13]}
 
-func getCollapsedBn(t *testing.T, n node) *branchNode {
-	bn, ok := n.(*branchNode)
-	assert.True(t, ok)
-	for i := 0; i < nrOfChildren; i++ {
-		bn.children[i] = nil
-	}
-	return bn
+	assert.Equal(t, expectedHashes, collapsedBn.getChildrenHashes())
+	assert.Equal(t, expectedHashes, bn.getChildrenHashes())
 }
 
 //------- deepClone
@@ -43,8 +43,8 @@ var ErrWrongTypeAssertion = errors.New("wrong type assertion")
 // ErrNilTrie is raised when the trie is nil
 var ErrNilTrie = errors.New("the trie is nil")
 
-// ErrNilResolver is raised when the given resolver is nil
-var ErrNilResolver = errors.New("the resolver is nil")
+// ErrNilRequestHandler is raised when the given request handler is nil
+var ErrNilRequestHandler = errors.New("the request handler is nil")
 
 // ErrInvalidHash is raised when the given hash is invalid
 var ErrInvalidHash = errors.New("the received hash is invalid")
@@ -66,3 +66,24 @@ var ErrNilSnapshotDbConfig = errors.New("nil snapshot db config provided")
 
 // ErrNilPathManager signals that a nil path manager has been provided
 var ErrNilPathManager = errors.New("nil path manager")
+
+// ErrInvalidProof signals that the provided Merkle proof is not valid for the given root hash and key
+var ErrInvalidProof = errors.New("invalid Merkle proof")
+
+// ErrNilNodeHandler is raised when a nil trie node handler is provided
+var ErrNilNodeHandler = errors.New("nil node handler provided")
+
+// ErrNilLeafChangeHandler is raised when a nil leaf change handler is provided
+var ErrNilLeafChangeHandler = errors.New("nil leaf change handler provided")
+
+// ErrNilAppStatusHandler is raised when a nil app status handler is provided
+var ErrNilAppStatusHandler = errors.New("nil app status handler provided")
+
+// ErrInvalidWaitTime is raised when the time to wait for a requested trie node is not positive
+var ErrInvalidWaitTime = errors.New("invalid wait time for the requested trie nodes")
+
+// ErrInvalidMaxInFlightRequests is raised when the number of trie nodes requested at once is lower than 1
+var ErrInvalidMaxInFlightRequests = errors.New("invalid maximum number of trie nodes requested at once")
+
+// ErrInvalidMaxRetries is raised when the number of times a trie node is requested again is negative
+var ErrInvalidMaxRetries = errors.New("invalid maximum number of retries for a requested trie node")
@@ -546,25 +546,13 @@ func (en *extensionNode) setDirty(dirty bool) {
 	en.dirty = dirty
 }
 
-func (en *extensionNode) loadChildren(syncer *trieSyncer) error {
-	err := en.isEmptyOrNil()
-	if err != nil {
-		return err
+func (en *extensionNode) getChildrenHashes() [][]byte {
+	hash := childHash(en.child, en.EncodedChild)
+	if len(hash) == 0 {
+		return make([][]byte, 0)
 	}
 
-	if en.EncodedChild == nil {
-		return ErrNilNode
-	}
-
-	child, err := syncer.getNode(en.EncodedChild)
-	if err != nil {
-		return err
-	}
-	en.child = child
-
-	syncer.interceptedNodes.Remove(en.hash)
-
-	return nil
+	return [][]byte{hash}
 }
 
 func (en *extensionNode) getAllLeaves(leaves map[string][]byte, key []byte, db data.DBWriteCacher, marshalizer marshal.Marshalizer) error {
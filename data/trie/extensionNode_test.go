@@ -5,11 +5,9 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/ElrondNetwork/elrond-go/data/mock"
 	protobuf "github.com/ElrondNetwork/elrond-go/data/trie/proto"
-	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -826,45 +824,15 @@ func TestExtensionNode_setDirty(t *testing.T) {
 	assert.True(t, en.dirty)
 }
 
-func TestExtensionNode_loadChildren(t *testing.T) {
+func TestExtensionNode_getChildrenHashes(t *testing.T) {
 	t.Parallel()
 
-	marsh, hasher := getTestMarshAndHasher()
-	tr, _, _ := newEmptyTrie()
-	_ = tr.Update([]byte("dog"), []byte("puppy"))
-	_ = tr.Update([]byte("ddog"), []byte("cat"))
-	_ = tr.root.setRootHash()
-	nodes, _ := getEncodedTrieNodesAndHashes(tr)
-	nodesCacher, _ := lrucache.NewCache(100)
-	resolver := &mock.TrieNodesResolverStub{
-		RequestDataFromHashCalled: func(hash []byte) error {
-			for i := range nodes {
-				node, _ := NewInterceptedTrieNode(nodes[i], marsh, hasher)
-				nodesCacher.Put(node.hash, node)
-			}
-			return nil
-		},
-	}
-	syncer, _ := NewTrieSyncer(resolver, nodesCacher, tr, time.Second)
-	syncer.interceptedNodes.RegisterHandler(func(key []byte) {
-		syncer.chRcvTrieNodes <- true
-	})
-
-	en := getCollapsedEn(t, tr.root)
-
-	err := en.loadChildren(syncer)
-	assert.Nil(t, err)
-	assert.NotNil(t, en.child)
-
-	assert.Equal(t, 3, nodesCacher.Len())
-}
-
-func getCollapsedEn(t *testing.T, n node) *extensionNode {
-	en, ok := n.(*extensionNode)
-	assert.True(t, ok)
-	en.child = nil
+	en, collapsedEn := getEnAndCollapsedEn()
+	_ = en.setHash()
+	expectedHashes := [][]byte{collapsedEn.EncodedChild}
 
-	return en
+	assert.Equal(t, expectedHashes, collapsedEn.getChildrenHashes())
+	assert.Equal(t, expectedHashes, en.getChildrenHashes())
 }
 
 func TestExtensionNode_deepCloneNilChildShouldWork(t *testing.T) {
@@ -20,10 +20,12 @@ import (
 type trieCreator struct {
 	evictionWaitingListCfg config.EvictionWaitingListConfig
 	snapshotDbCfg          config.DBConfig
+	trieNodesCacheCfg      config.TrieNodesCacheConfig
 	marshalizer            marshal.Marshalizer
 	hasher                 hashing.Hasher
 	pathManager            storage.PathManagerHandler
 	shardId                string
+	encryptionKey          []byte
 }
 
 var log = logger.GetOrCreate("trie")
@@ -45,10 +47,12 @@ func NewTrieFactory(
 	return &trieCreator{
 		evictionWaitingListCfg: args.EvictionWaitingListCfg,
 		snapshotDbCfg:          args.SnapshotDbCfg,
+		trieNodesCacheCfg:      args.TrieNodesCacheCfg,
 		marshalizer:            args.Marshalizer,
 		hasher:                 args.Hasher,
 		pathManager:            args.PathManager,
 		shardId:                args.ShardId,
+		encryptionKey:          args.EncryptionKey,
 	}, nil
 }
 
@@ -59,6 +63,7 @@ func (tc *trieCreator) Create(trieStorageCfg config.StorageConfig, pruningEnable
 
 	dbConfig := factory.GetDBFromConfig(trieStorageCfg.DB)
 	dbConfig.FilePath = path.Join(trieStoragePath, mainDb)
+	dbConfig.EncryptionKey = tc.encryptionKey
 	accountsTrieStorage, err := storageUnit.NewStorageUnitFromConf(
 		factory.GetCacherFromConfig(trieStorageCfg.Cache),
 		dbConfig,
@@ -68,9 +73,14 @@ func (tc *trieCreator) Create(trieStorageCfg config.StorageConfig, pruningEnable
 		return nil, err
 	}
 
+	trieDb, err := tc.createTrieDb(accountsTrieStorage)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Trace("trie pruning status", "enabled", pruningEnabled)
 	if !pruningEnabled {
-		trieStorage, errNewTrie := trie.NewTrieStorageManagerWithoutPruning(accountsTrieStorage)
+		trieStorage, errNewTrie := trie.NewTrieStorageManagerWithoutPruning(trieDb)
 		if errNewTrie != nil {
 			return nil, errNewTrie
 		}
@@ -78,13 +88,14 @@ func (tc *trieCreator) Create(trieStorageCfg config.StorageConfig, pruningEnable
 		return trie.NewTrie(trieStorage, tc.marshalizer, tc.hasher)
 	}
 
-	evictionDb, err := storageUnit.NewDB(
-		storageUnit.DBType(tc.evictionWaitingListCfg.DB.Type),
-		filepath.Join(trieStoragePath, tc.evictionWaitingListCfg.DB.FilePath),
-		tc.evictionWaitingListCfg.DB.MaxBatchSize,
-		tc.evictionWaitingListCfg.DB.BatchDelaySeconds,
-		tc.evictionWaitingListCfg.DB.MaxOpenFiles,
-	)
+	evictionDb, err := storageUnit.NewDBFromConf(storageUnit.DBConfig{
+		FilePath:          filepath.Join(trieStoragePath, tc.evictionWaitingListCfg.DB.FilePath),
+		Type:              storageUnit.DBType(tc.evictionWaitingListCfg.DB.Type),
+		BatchDelaySeconds: tc.evictionWaitingListCfg.DB.BatchDelaySeconds,
+		MaxBatchSize:      tc.evictionWaitingListCfg.DB.MaxBatchSize,
+		MaxOpenFiles:      tc.evictionWaitingListCfg.DB.MaxOpenFiles,
+		EncryptionKey:     tc.encryptionKey,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +107,7 @@ func (tc *trieCreator) Create(trieStorageCfg config.StorageConfig, pruningEnable
 
 	tc.snapshotDbCfg.FilePath = filepath.Join(trieStoragePath, tc.snapshotDbCfg.FilePath)
 
-	trieStorage, err := trie.NewTrieStorageManager(accountsTrieStorage, &tc.snapshotDbCfg, ewl)
+	trieStorage, err := trie.NewTrieStorageManagerWithEncryption(trieDb, &tc.snapshotDbCfg, ewl, tc.encryptionKey)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +115,21 @@ func (tc *trieCreator) Create(trieStorageCfg config.StorageConfig, pruningEnable
 	return trie.NewTrie(trieStorage, tc.marshalizer, tc.hasher)
 }
 
+// createTrieDb places a trie nodes cache in front of the trie storage, unless the cache is disabled
+func (tc *trieCreator) createTrieDb(trieStorage data.DBWriteCacher) (data.DBWriteCacher, error) {
+	if tc.trieNodesCacheCfg.SizeInBytes == 0 {
+		return trieStorage, nil
+	}
+
+	return trie.NewTrieNodesCache(
+		trieStorage,
+		tc.trieNodesCacheCfg.SizeInBytes,
+		tc.trieNodesCacheCfg.NumPinnedLevels,
+		tc.marshalizer,
+		tc.hasher,
+	)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (tc *trieCreator) IsInterfaceNil() bool {
 	return tc == nil
@@ -17,8 +17,10 @@ const PeerAccountTrie = "peerAccount"
 type TrieFactoryArgs struct {
 	EvictionWaitingListCfg config.EvictionWaitingListConfig
 	SnapshotDbCfg          config.DBConfig
+	TrieNodesCacheCfg      config.TrieNodesCacheConfig
 	Marshalizer            marshal.Marshalizer
 	Hasher                 hashing.Hasher
 	PathManager            storage.PathManagerHandler
 	ShardId                string
+	EncryptionKey          []byte
 }
@@ -81,6 +81,14 @@ func (inTn *InterceptedTrieNode) EncodedNode() []byte {
 	return inTn.encNode
 }
 
+// Size returns the number of bytes held by the encoded node and its hash, used when accounting the size of the caches
+func (inTn *InterceptedTrieNode) Size() int {
+	inTn.mutex.Lock()
+	defer inTn.mutex.Unlock()
+
+	return len(inTn.encNode) + len(inTn.hash)
+}
+
 // Type returns the type of this intercepted data
 func (inTn *InterceptedTrieNode) Type() string {
 	return "intercepted trie node"
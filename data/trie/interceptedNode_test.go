@@ -106,3 +106,13 @@ func TestInterceptedTrieNode_EncodedNode(t *testing.T) {
 	encNode := interceptedNode.EncodedNode()
 	assert.Equal(t, nodes[0], encNode)
 }
+
+func TestInterceptedTrieNode_Size(t *testing.T) {
+	t.Parallel()
+
+	interceptedNode, _ := trie.NewInterceptedTrieNode(getDefaultInterceptedTrieNodeParameters())
+	tr := initTrie()
+	nodes, hashes := getEncodedTrieNodesAndHashes(tr)
+
+	assert.Equal(t, len(nodes[0])+len(hashes[0]), interceptedNode.Size())
+}
@@ -36,7 +36,7 @@ type node interface {
 	getChildren(db data.DBWriteCacher) ([]node, error)
 	isValid() bool
 	setDirty(bool)
-	loadChildren(*trieSyncer) error
+	getChildrenHashes() [][]byte
 	getAllLeaves(map[string][]byte, []byte, data.DBWriteCacher, marshal.Marshalizer) error
 
 	getMarshalizer() marshal.Marshalizer
@@ -45,6 +45,12 @@ type node interface {
 	setHasher(hashing.Hasher)
 }
 
+// RequestHandler defines the methods through which the trie syncer asks for the missing trie nodes
+type RequestHandler interface {
+	RequestTrieNodes(destShardID uint32, hash []byte, topic string)
+	IsInterfaceNil() bool
+}
+
 type snapshotsBuffer interface {
 	add([]byte, bool)
 	len() int
@@ -0,0 +1,234 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// pendingNode is a node waiting to be visited by the trie walker or by the diff walker, either already in memory or
+// still collapsed
+type pendingNode struct {
+	node node
+	hash []byte
+	path []byte
+}
+
+// trieWalker walks the leaves of a trie in the trie's order, depth first. The nodes found collapsed are read from
+// the database and dropped once visited, without being attached to the trie, so the memory used is bounded by the
+// depth of the trie no matter how many leaves it holds.
+//
+// The trie's order is the order of the hex paths, and a path holds the nibbles of the key starting from the last one.
+// The leaves are therefore not sorted by their keys but by their reversed nibbles
+type trieWalker struct {
+	db          data.DBWriteCacher
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+	pending     []pendingNode
+	nextKey     []byte
+	nextValue   []byte
+}
+
+func newTrieWalker(pmt *patriciaMerkleTrie) *trieWalker {
+	pmt.mutOperation.RLock()
+	root := pmt.root
+	pmt.mutOperation.RUnlock()
+
+	tw := &trieWalker{
+		db:          pmt.Database(),
+		marshalizer: pmt.marshalizer,
+		hasher:      pmt.hasher,
+		pending:     make([]pendingNode, 0),
+	}
+	if root != nil {
+		tw.pending = append(tw.pending, pendingNode{node: root, path: make([]byte, 0)})
+	}
+
+	return tw
+}
+
+// next returns the key and the value of the next leaf in the trie's order, or a nil key when all the leaves were
+// visited
+func (tw *trieWalker) next() ([]byte, []byte, error) {
+	tw.nextKey, tw.nextValue = nil, nil
+
+	for len(tw.pending) > 0 {
+		pn := tw.pending[len(tw.pending)-1]
+		tw.pending = tw.pending[:len(tw.pending)-1]
+
+		n := pn.node
+		if n == nil {
+			var err error
+			n, err = getNodeFromDBAndDecode(pn.hash, tw.db, tw.marshalizer, tw.hasher)
+			if err != nil {
+				tw.pending = tw.pending[:0]
+				return nil, nil, err
+			}
+		}
+
+		found, err := tw.visit(n, pn.path)
+		if err != nil {
+			tw.pending = tw.pending[:0]
+			return nil, nil, err
+		}
+		if found {
+			return tw.nextKey, tw.nextValue, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// visit pushes the children of a branch or an extension node, in reverse order so they are popped in the trie's
+// order, and sets the next leaf if the node is a leaf
+func (tw *trieWalker) visit(n node, path []byte) (bool, error) {
+	switch nd := n.(type) {
+	case *branchNode:
+		for i := nrOfChildren - 1; i >= 0; i-- {
+			if nd.children[i] == nil && len(nd.EncodedChildren[i]) == 0 {
+				continue
+			}
+			tw.pending = append(tw.pending, pendingNode{
+				node: nd.children[i],
+				hash: nd.EncodedChildren[i],
+				path: concat(path, byte(i)),
+			})
+		}
+		return false, nil
+	case *extensionNode:
+		tw.pending = append(tw.pending, pendingNode{
+			node: nd.child,
+			hash: nd.EncodedChild,
+			path: concat(path, nd.Key...),
+		})
+		return false, nil
+	case *leafNode:
+		key, err := hexToKeyBytes(concat(path, nd.Key...))
+		if err != nil {
+			return false, err
+		}
+		tw.nextKey, tw.nextValue = key, nd.Value
+		return true, nil
+	default:
+		return false, ErrInvalidNode
+	}
+}
+
+// leafIterator returns the leaves of a trie in key order, between a start key, inclusive, and an end key, exclusive.
+// The trie's order is not the key order, so the keys of a range are spread over the whole trie: the iterator walks all
+// the leaves once, keeps the ones placed in range and sorts them with a leaf sorter. The memory used is bounded by the
+// sorter's buffer, the leaves exceeding it being sorted in temporary files. The temporary files are removed when the
+// iteration ends or when the iterator is closed
+type leafIterator struct {
+	merger    *leafMerger
+	nextKey   []byte
+	nextValue []byte
+	err       error
+}
+
+// NewLeafIterator creates an iterator over the leaves of the provided trie having the key greater than or equal to
+// startKey and less than endKey, in ascending key order. A nil key leaves that side of the range unbounded. All the
+// leaves of the trie are read, and the ones placed in range sorted, before the first leaf is returned
+func NewLeafIterator(trie data.Trie, startKey []byte, endKey []byte) (*leafIterator, error) {
+	if check.IfNil(trie) {
+		return nil, ErrNilTrie
+	}
+
+	pmt, ok := trie.(*patriciaMerkleTrie)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	it := &leafIterator{}
+	it.merger, it.err = sortLeavesInRange(newTrieWalker(pmt), startKey, endKey)
+	if it.err == nil {
+		it.advance()
+	}
+
+	return it, nil
+}
+
+func sortLeavesInRange(tw *trieWalker, startKey []byte, endKey []byte) (*leafMerger, error) {
+	sorter := newLeafSorter()
+	for {
+		key, value, err := tw.next()
+		if err != nil {
+			sorter.close()
+			return nil, err
+		}
+		if key == nil {
+			break
+		}
+		if !isKeyInRange(key, startKey, endKey) {
+			continue
+		}
+
+		err = sorter.add(key, value)
+		if err != nil {
+			sorter.close()
+			return nil, err
+		}
+	}
+
+	return sorter.merge()
+}
+
+func isKeyInRange(key []byte, startKey []byte, endKey []byte) bool {
+	if bytes.Compare(key, startKey) < 0 {
+		return false
+	}
+
+	return endKey == nil || bytes.Compare(key, endKey) < 0
+}
+
+// HasNext returns true if there is a next leaf, or an error to be returned by Next
+func (it *leafIterator) HasNext() bool {
+	return it.nextKey != nil || it.err != nil
+}
+
+// Next returns the key and the value of the next leaf and moves the iterator forward. An error ends the iteration
+func (it *leafIterator) Next() ([]byte, []byte, error) {
+	if it.err != nil {
+		err := it.err
+		it.err = nil
+		return nil, nil, err
+	}
+	if it.nextKey == nil {
+		return nil, nil, ErrNilNode
+	}
+
+	key, value := it.nextKey, it.nextValue
+	it.advance()
+
+	return key, value, nil
+}
+
+// Close removes the temporary files of the iterator. It has to be called if the iteration is stopped before the last
+// leaf was returned
+func (it *leafIterator) Close() {
+	it.nextKey, it.nextValue = nil, nil
+	if it.merger != nil {
+		it.merger.close()
+		it.merger = nil
+	}
+}
+
+func (it *leafIterator) advance() {
+	it.nextKey, it.nextValue = nil, nil
+
+	record, err := it.merger.next()
+	if err != nil {
+		it.err = err
+		it.Close()
+		return
+	}
+	if record == nil {
+		it.Close()
+		return
+	}
+
+	it.nextKey, it.nextValue = record.key, record.value
+}
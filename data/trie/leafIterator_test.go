@@ -0,0 +1,175 @@
+package trie_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectLeaves(t *testing.T, tr data.Trie, startKey []byte, endKey []byte) ([]string, map[string][]byte) {
+	it, err := trie.NewLeafIterator(tr, startKey, endKey)
+	require.Nil(t, err)
+
+	keys := make([]string, 0)
+	leaves := make(map[string][]byte)
+	for it.HasNext() {
+		key, value, errNext := it.Next()
+		require.Nil(t, errNext)
+		keys = append(keys, string(key))
+		leaves[string(key)] = value
+	}
+	it.Close()
+
+	return keys, leaves
+}
+
+func TestNewLeafIterator_NilTrieShouldErr(t *testing.T) {
+	t.Parallel()
+
+	it, err := trie.NewLeafIterator(nil, nil, nil)
+
+	assert.Nil(t, it)
+	assert.Equal(t, trie.ErrNilTrie, err)
+}
+
+func TestLeafIterator_EmptyTrieShouldNotHaveLeaves(t *testing.T) {
+	t.Parallel()
+
+	it, err := trie.NewLeafIterator(emptyTrie(), nil, nil)
+
+	assert.Nil(t, err)
+	assert.False(t, it.HasNext())
+}
+
+func TestLeafIterator_ShouldReturnAllTheLeaves(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(1000)
+	expectedLeaves, _ := tr.GetAllLeaves()
+
+	keys, leaves := collectLeaves(t, tr, nil, nil)
+
+	assert.Equal(t, len(expectedLeaves), len(keys))
+	assert.Equal(t, expectedLeaves, leaves)
+}
+
+func TestLeafIterator_CollapsedTrieShouldReturnTheSameLeavesInTheSameOrder(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(1000)
+	expectedKeys, expectedLeaves := collectLeaves(t, tr, nil, nil)
+
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	collapsedTrie, err := tr.Recreate(rootHash)
+	require.Nil(t, err)
+
+	keys, leaves := collectLeaves(t, collapsedTrie, nil, nil)
+
+	assert.Equal(t, expectedKeys, keys)
+	assert.Equal(t, expectedLeaves, leaves)
+}
+
+func TestLeafIterator_ShouldReturnTheLeavesInKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	tr, values := initTrieMultipleValues(1000)
+	expectedKeys := make([]string, 0, len(values))
+	for _, value := range values {
+		expectedKeys = append(expectedKeys, string(value))
+	}
+	sort.Strings(expectedKeys)
+
+	keys, _ := collectLeaves(t, tr, nil, nil)
+
+	assert.Equal(t, expectedKeys, keys)
+}
+
+func TestLeafIterator_BoundsShouldSelectARangeOfKeys(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(200)
+	allKeys, _ := collectLeaves(t, tr, nil, nil)
+
+	for _, bounds := range [][2]int{{0, 200}, {0, 1}, {50, 120}, {199, 200}, {73, 74}, {10, 10}} {
+		startIndex, endIndex := bounds[0], bounds[1]
+		t.Run(fmt.Sprintf("%d-%d", startIndex, endIndex), func(t *testing.T) {
+			startKey := []byte(allKeys[startIndex])
+			var endKey []byte
+			if endIndex < len(allKeys) {
+				endKey = []byte(allKeys[endIndex])
+			}
+
+			keys, _ := collectLeaves(t, tr, startKey, endKey)
+
+			assert.Equal(t, allKeys[startIndex:endIndex], keys)
+		})
+	}
+}
+
+func TestLeafIterator_BoundsNotInTheTrieShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	allKeys, _ := collectLeaves(t, tr, nil, nil)
+	require.Equal(t, []string{"ddog", "doe", "dog"}, allKeys)
+
+	keys, _ := collectLeaves(t, tr, []byte("dod"), []byte("dogg"))
+	assert.Equal(t, []string{"doe", "dog"}, keys)
+
+	keys, _ = collectLeaves(t, tr, []byte("zzz"), nil)
+	assert.Equal(t, 0, len(keys))
+
+	keys, _ = collectLeaves(t, tr, nil, []byte("d"))
+	assert.Equal(t, 0, len(keys))
+}
+
+func TestPatriciaMerkleTrie_IterateLeavesShouldStopWhenTheHandlerReturnsFalse(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(100)
+	allKeys, _ := collectLeaves(t, tr, nil, nil)
+
+	keys := make([]string, 0)
+	err := tr.IterateLeaves([]byte(allKeys[10]), nil, func(key []byte, value []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) < 5
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, allKeys[10:15], keys)
+}
+
+func TestLeafIterator_MissingNodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(100)
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+
+	nodesTrie, _ := tr.Recreate(rootHash)
+	nodesIterator, _ := trie.NewIterator(nodesTrie)
+	var lastHash []byte
+	for nodesIterator.HasNext() {
+		_ = nodesIterator.Next()
+		lastHash, _ = nodesIterator.GetHash()
+	}
+	_ = tr.Database().Remove(lastHash)
+
+	collapsedTrie, _ := tr.Recreate(rootHash)
+	it, err := trie.NewLeafIterator(collapsedTrie, nil, nil)
+	require.Nil(t, err)
+
+	var errNext error
+	for it.HasNext() && errNext == nil {
+		_, _, errNext = it.Next()
+	}
+
+	assert.NotNil(t, errNext)
+	assert.False(t, it.HasNext())
+}
@@ -334,9 +334,8 @@ func (ln *leafNode) setDirty(dirty bool) {
 	ln.dirty = dirty
 }
 
-func (ln *leafNode) loadChildren(syncer *trieSyncer) error {
-	syncer.interceptedNodes.Remove(ln.hash)
-	return nil
+func (ln *leafNode) getChildrenHashes() [][]byte {
+	return make([][]byte, 0)
 }
 
 func (ln *leafNode) getAllLeaves(leaves map[string][]byte, key []byte, _ data.DBWriteCacher, _ marshal.Marshalizer) error {
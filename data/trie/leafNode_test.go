@@ -6,13 +6,11 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/ElrondNetwork/elrond-go/data/mock"
 	protobuf "github.com/ElrondNetwork/elrond-go/data/trie/proto"
 	"github.com/ElrondNetwork/elrond-go/hashing"
 	"github.com/ElrondNetwork/elrond-go/marshal"
-	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -528,29 +526,12 @@ func TestLeafNode_setDirty(t *testing.T) {
 	assert.True(t, ln.dirty)
 }
 
-func TestLeafNode_loadChildren(t *testing.T) {
+func TestLeafNode_getChildrenHashes(t *testing.T) {
 	t.Parallel()
 
-	marsh, hasher := getTestMarshAndHasher()
-	tr := initTrie()
-	nodes, hashes := getEncodedTrieNodesAndHashes(tr)
-	nodesCacher, _ := lrucache.NewCache(100)
+	ln := getLn(getTestMarshAndHasher())
 
-	resolver := &mock.TrieNodesResolverStub{}
-	for i := range nodes {
-		node, _ := NewInterceptedTrieNode(nodes[i], marsh, hasher)
-		nodesCacher.Put(node.hash, node)
-	}
-	syncer, _ := NewTrieSyncer(resolver, nodesCacher, tr, time.Second)
-	syncer.interceptedNodes.RegisterHandler(func(key []byte) {
-		syncer.chRcvTrieNodes <- true
-	})
-
-	lnPosition := 5
-	ln := &leafNode{baseNode: &baseNode{hash: hashes[lnPosition]}}
-	err := ln.loadChildren(syncer)
-	assert.Nil(t, err)
-	assert.Equal(t, 5, nodesCacher.Len())
+	assert.Equal(t, 0, len(ln.getChildrenHashes()))
 }
 
 //------- deepClone
@@ -0,0 +1,310 @@
+package trie
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// maxLeafSortBufferSize is the size in bytes of the leaves kept in memory by a leaf sorter. When the buffer is full,
+// the leaves are sorted and written in a temporary file, so sorting a trie of any size uses a bounded amount of memory
+var maxLeafSortBufferSize = 16 * 1024 * 1024
+
+// leafRecord is a leaf held by the leaf sorter
+type leafRecord struct {
+	key   []byte
+	value []byte
+}
+
+// leafSorter sorts the leaves by key with an external merge sort: the leaves are gathered in a bounded buffer, which
+// is sorted and written as a run in a temporary file every time it gets full. The runs and the remaining buffer are
+// then merged. A trie holds every key only once so the sorter does not handle duplicated keys
+type leafSorter struct {
+	buffer     []leafRecord
+	bufferSize int
+	tempDir    string
+	runs       []string
+}
+
+func newLeafSorter() *leafSorter {
+	return &leafSorter{
+		buffer: make([]leafRecord, 0),
+		runs:   make([]string, 0),
+	}
+}
+
+// add places a leaf in the sorter, writing the buffered leaves in a new run if the buffer is full
+func (ls *leafSorter) add(key []byte, value []byte) error {
+	ls.buffer = append(ls.buffer, leafRecord{key: key, value: value})
+	ls.bufferSize += len(key) + len(value)
+	if ls.bufferSize < maxLeafSortBufferSize {
+		return nil
+	}
+
+	return ls.writeRun()
+}
+
+func (ls *leafSorter) writeRun() error {
+	if ls.tempDir == "" {
+		tempDir, err := ioutil.TempDir("", "trie-leaves")
+		if err != nil {
+			return err
+		}
+		ls.tempDir = tempDir
+	}
+
+	ls.sortBuffer()
+	runPath := filepath.Join(ls.tempDir, strconv.Itoa(len(ls.runs)))
+	file, err := os.Create(runPath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, record := range ls.buffer {
+		err = writeLeafRecord(writer, record)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+	err = writer.Flush()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	ls.runs = append(ls.runs, runPath)
+	ls.buffer = make([]leafRecord, 0)
+	ls.bufferSize = 0
+
+	return nil
+}
+
+func (ls *leafSorter) sortBuffer() {
+	sort.Slice(ls.buffer, func(i, j int) bool {
+		return bytes.Compare(ls.buffer[i].key, ls.buffer[j].key) < 0
+	})
+}
+
+// merge returns a source of all the added leaves, in key order
+func (ls *leafSorter) merge() (*leafMerger, error) {
+	ls.sortBuffer()
+
+	lm := &leafMerger{
+		sources: make(leafSources, 0, len(ls.runs)+1),
+		tempDir: ls.tempDir,
+	}
+	lm.sources = append(lm.sources, &leafSource{buffer: ls.buffer})
+	ls.buffer = nil
+
+	for _, runPath := range ls.runs {
+		file, err := os.Open(runPath)
+		if err != nil {
+			lm.close()
+			return nil, err
+		}
+		lm.sources = append(lm.sources, &leafSource{file: file, reader: bufio.NewReader(file)})
+	}
+
+	for i := len(lm.sources) - 1; i >= 0; i-- {
+		err := lm.sources[i].advance()
+		if err != nil {
+			lm.close()
+			return nil, err
+		}
+		if lm.sources[i].current == nil {
+			lm.removeSource(i)
+		}
+	}
+	heap.Init(&lm.sources)
+
+	return lm, nil
+}
+
+// close removes the runs written so far
+func (ls *leafSorter) close() {
+	if ls.tempDir != "" {
+		_ = os.RemoveAll(ls.tempDir)
+	}
+}
+
+// leafSource is a sorted run of leaves, either the in memory buffer or a run written in a temporary file
+type leafSource struct {
+	buffer  []leafRecord
+	file    *os.File
+	reader  *bufio.Reader
+	current *leafRecord
+}
+
+func (src *leafSource) advance() error {
+	src.current = nil
+	if src.reader == nil {
+		if len(src.buffer) > 0 {
+			src.current = &src.buffer[0]
+			src.buffer = src.buffer[1:]
+		}
+		return nil
+	}
+
+	record, err := readLeafRecord(src.reader)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	src.current = record
+
+	return nil
+}
+
+func (src *leafSource) close() {
+	if src.file != nil {
+		_ = src.file.Close()
+	}
+}
+
+// leafSources is a min heap of the sources, by the key of their current leaf
+type leafSources []*leafSource
+
+// Len returns the number of sources
+func (ls leafSources) Len() int {
+	return len(ls)
+}
+
+// Less returns true if the current key of the i-th source is smaller than the current key of the j-th source
+func (ls leafSources) Less(i, j int) bool {
+	return bytes.Compare(ls[i].current.key, ls[j].current.key) < 0
+}
+
+// Swap swaps the i-th and the j-th sources
+func (ls leafSources) Swap(i, j int) {
+	ls[i], ls[j] = ls[j], ls[i]
+}
+
+// Push adds a source to the heap
+func (ls *leafSources) Push(x interface{}) {
+	*ls = append(*ls, x.(*leafSource))
+}
+
+// Pop removes the last source of the heap
+func (ls *leafSources) Pop() interface{} {
+	old := *ls
+	last := old[len(old)-1]
+	*ls = old[:len(old)-1]
+
+	return last
+}
+
+// leafMerger merges the sorted sources, returning the leaves in key order. The memory used is the memory of the
+// buffered reader of every run
+type leafMerger struct {
+	sources leafSources
+	tempDir string
+}
+
+// next returns the leaf having the smallest key among the sources, or nil when all the sources are exhausted
+func (lm *leafMerger) next() (*leafRecord, error) {
+	if len(lm.sources) == 0 {
+		return nil, nil
+	}
+
+	src := lm.sources[0]
+	record := src.current
+	err := src.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	if src.current == nil {
+		src.close()
+		heap.Pop(&lm.sources)
+		return record, nil
+	}
+	heap.Fix(&lm.sources, 0)
+
+	return record, nil
+}
+
+func (lm *leafMerger) removeSource(index int) {
+	lm.sources[index].close()
+	lm.sources = append(lm.sources[:index], lm.sources[index+1:]...)
+}
+
+// close closes the sources and removes the temporary files of the runs
+func (lm *leafMerger) close() {
+	for _, src := range lm.sources {
+		src.close()
+	}
+	lm.sources = nil
+
+	if lm.tempDir != "" {
+		_ = os.RemoveAll(lm.tempDir)
+	}
+}
+
+func writeLeafRecord(writer *bufio.Writer, record leafRecord) error {
+	err := writeLengthPrefixed(writer, record.key)
+	if err != nil {
+		return err
+	}
+
+	return writeLengthPrefixed(writer, record.value)
+}
+
+func writeLengthPrefixed(writer *bufio.Writer, buff []byte) error {
+	lengthBuff := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lengthBuff, uint64(len(buff)))
+	_, err := writer.Write(lengthBuff[:n])
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(buff)
+
+	return err
+}
+
+func readLeafRecord(reader *bufio.Reader) (*leafRecord, error) {
+	key, err := readLengthPrefixed(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := readLengthPrefixed(reader)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &leafRecord{key: key, value: value}, nil
+}
+
+func readLengthPrefixed(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := make([]byte, length)
+	_, err = io.ReadFull(reader, buff)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return buff, err
+}
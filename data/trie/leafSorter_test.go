@@ -0,0 +1,96 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeAll(t *testing.T, lm *leafMerger) []string {
+	keys := make([]string, 0)
+	for {
+		record, err := lm.next()
+		require.Nil(t, err)
+		if record == nil {
+			return keys
+		}
+
+		assert.Equal(t, "value"+string(record.key), string(record.value))
+		keys = append(keys, string(record.key))
+	}
+}
+
+func TestLeafSorter_LeavesInMemoryShouldBeSorted(t *testing.T) {
+	t.Parallel()
+
+	ls := newLeafSorter()
+	for _, key := range []string{"dog", "ddog", "doe"} {
+		err := ls.add([]byte(key), []byte("value"+key))
+		require.Nil(t, err)
+	}
+
+	lm, err := ls.merge()
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"ddog", "doe", "dog"}, mergeAll(t, lm))
+	assert.Equal(t, "", ls.tempDir)
+}
+
+func TestLeafSorter_SpilledRunsShouldBeMergedInKeyOrder(t *testing.T) {
+	defer func(size int) {
+		maxLeafSortBufferSize = size
+	}(maxLeafSortBufferSize)
+	maxLeafSortBufferSize = 100
+
+	expectedKeys := make([]string, 0)
+	ls := newLeafSorter()
+	for _, i := range rand.Perm(500) {
+		key := fmt.Sprintf("key%04d", i)
+		expectedKeys = append(expectedKeys, key)
+		err := ls.add([]byte(key), []byte("value"+key))
+		require.Nil(t, err)
+	}
+	sort.Strings(expectedKeys)
+	assert.True(t, len(ls.runs) > 1)
+
+	lm, err := ls.merge()
+	require.Nil(t, err)
+
+	assert.Equal(t, expectedKeys, mergeAll(t, lm))
+
+	lm.close()
+	_, err = os.Stat(ls.tempDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLeafIterator_ClosingAnUnfinishedIterationShouldRemoveTheRuns(t *testing.T) {
+	defer func(size int) {
+		maxLeafSortBufferSize = size
+	}(maxLeafSortBufferSize)
+	maxLeafSortBufferSize = 1000
+
+	tr, _, _ := newEmptyTrie()
+	for i := 0; i < 200; i++ {
+		key := []byte(fmt.Sprintf("key%04d", i))
+		_ = tr.Update(key, key)
+	}
+
+	it, err := NewLeafIterator(tr, nil, nil)
+	require.Nil(t, err)
+	tempDir := it.merger.tempDir
+	require.NotEqual(t, "", tempDir)
+
+	key, _, err := it.Next()
+	require.Nil(t, err)
+	assert.Equal(t, []byte("key0000"), key)
+
+	it.Close()
+	assert.False(t, it.HasNext())
+	_, err = os.Stat(tempDir)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -182,7 +182,8 @@ func (tr *patriciaMerkleTrie) Root() ([]byte, error) {
 	return tr.root.getHash(), nil
 }
 
-// Prove returns the Merkle proof for the given key
+// Prove returns the Merkle proof for the given key. If the key is not in the trie, the returned proof ends with
+// the node where the key path diverges, proving the absence of the key
 func (tr *patriciaMerkleTrie) Prove(key []byte) ([][]byte, error) {
 	tr.mutOperation.Lock()
 	defer tr.mutOperation.Unlock()
@@ -209,6 +210,9 @@ func (tr *patriciaMerkleTrie) Prove(key []byte) ([][]byte, error) {
 		proof = append(proof, encNode)
 
 		n, hexKey, err = n.getNext(hexKey, tr.trieStorage.Database())
+		if err == ErrNodeNotFound {
+			return proof, nil
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -567,6 +571,37 @@ func (tr *patriciaMerkleTrie) GetAllLeaves() (map[string][]byte, error) {
 	return leaves, nil
 }
 
+// IterateLeaves calls the handler, in ascending key order, for the leaves having the key greater than or equal to
+// startKey and less than endKey, until the handler returns false. A nil key leaves that side of the range unbounded.
+// Unlike GetAllLeaves, the leaves are not gathered in memory and the collapsed nodes are not attached to the trie: see
+// NewLeafIterator for the memory used
+func (tr *patriciaMerkleTrie) IterateLeaves(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+	it, err := NewLeafIterator(tr, startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.HasNext() {
+		key, value, errNext := it.Next()
+		if errNext != nil {
+			return errNext
+		}
+
+		if !handler(key, value) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Diff calls the handler for every key which was added, modified or removed between this trie and the new trie, until
+// the handler returns false. See the Diff function for details
+func (tr *patriciaMerkleTrie) Diff(newTrie data.Trie, handler func(change data.LeafChange) bool) error {
+	return Diff(tr, newTrie, handler)
+}
+
 // IsPruningEnabled returns true if state pruning is enabled
 func (tr *patriciaMerkleTrie) IsPruningEnabled() bool {
 	return tr.trieStorage.IsPruningEnabled()
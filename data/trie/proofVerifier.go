@@ -0,0 +1,102 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+type merkleProofVerifier struct {
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+}
+
+// NewMerkleProofVerifier creates a verifier for the proofs generated by a patricia merkle trie. It does not need
+// access to any trie or storage, so it can be used to check proofs obtained from untrusted nodes
+func NewMerkleProofVerifier(marshalizer marshal.Marshalizer, hasher hashing.Hasher) (*merkleProofVerifier, error) {
+	if check.IfNil(marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &merkleProofVerifier{
+		marshalizer: marshalizer,
+		hasher:      hasher,
+	}, nil
+}
+
+// VerifyProof checks the given proof against the root hash. If the proof shows the key is in the trie, the value
+// stored under the key is returned together with true. If the proof shows the key is not in the trie, nil and false
+// are returned. ErrInvalidProof is returned if the proof proves neither
+func (mpv *merkleProofVerifier) VerifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, bool, error) {
+	if len(proof) == 0 {
+		if bytes.Equal(rootHash, emptyTrieHash) {
+			return nil, false, nil
+		}
+		return nil, false, ErrInvalidProof
+	}
+
+	wantHash := rootHash
+	hexKey := keyBytesToHex(key)
+	for i, encNode := range proof {
+		isLastNode := i == len(proof)-1
+
+		hash := mpv.hasher.Compute(string(encNode))
+		if !bytes.Equal(wantHash, hash) {
+			return nil, false, ErrInvalidProof
+		}
+
+		n, err := decodeNode(encNode, mpv.marshalizer, mpv.hasher)
+		if err != nil {
+			return nil, false, ErrInvalidProof
+		}
+
+		switch n := n.(type) {
+		case *extensionNode:
+			keyDiverges := len(hexKey) < len(n.Key) || !bytes.Equal(n.Key, hexKey[:len(n.Key)])
+			if keyDiverges {
+				return absenceIfLastNode(isLastNode)
+			}
+			hexKey = hexKey[len(n.Key):]
+			wantHash = n.EncodedChild
+		case *branchNode:
+			if len(hexKey) == 0 || childPosOutOfRange(hexKey[0]) {
+				return nil, false, ErrInvalidProof
+			}
+			wantHash = n.EncodedChildren[hexKey[0]]
+			hexKey = hexKey[1:]
+			if len(wantHash) == 0 {
+				return absenceIfLastNode(isLastNode)
+			}
+		case *leafNode:
+			if !isLastNode {
+				return nil, false, ErrInvalidProof
+			}
+			if bytes.Equal(hexKey, n.Key) {
+				return n.Value, true, nil
+			}
+			return nil, false, nil
+		default:
+			return nil, false, ErrInvalidProof
+		}
+	}
+
+	return nil, false, ErrInvalidProof
+}
+
+func absenceIfLastNode(isLastNode bool) ([]byte, bool, error) {
+	if !isLastNode {
+		return nil, false, ErrInvalidProof
+	}
+
+	return nil, false, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (mpv *merkleProofVerifier) IsInterfaceNil() bool {
+	return mpv == nil
+}
@@ -0,0 +1,163 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMerkleProofVerifier() data.MerkleProofVerifier {
+	verifier, _ := trie.NewMerkleProofVerifier(&mock.ProtobufMarshalizerMock{}, &mock.KeccakMock{})
+	return verifier
+}
+
+func TestNewMerkleProofVerifier_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := trie.NewMerkleProofVerifier(nil, &mock.KeccakMock{})
+
+	assert.True(t, verifier == nil)
+	assert.Equal(t, trie.ErrNilMarshalizer, err)
+}
+
+func TestNewMerkleProofVerifier_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := trie.NewMerkleProofVerifier(&mock.ProtobufMarshalizerMock{}, nil)
+
+	assert.True(t, verifier == nil)
+	assert.Equal(t, trie.ErrNilHasher, err)
+}
+
+func TestMerkleProofVerifier_VerifyProofExistingKeysShouldReturnValues(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	verifier := createMerkleProofVerifier()
+
+	values := map[string]string{
+		"doe":  "reindeer",
+		"dog":  "puppy",
+		"ddog": "cat",
+	}
+	for key, expectedValue := range values {
+		proof, err := tr.Prove([]byte(key))
+		require.Nil(t, err)
+
+		value, found, err := verifier.VerifyProof(rootHash, []byte(key), proof)
+		assert.Nil(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte(expectedValue), value)
+	}
+}
+
+func TestMerkleProofVerifier_VerifyProofMissingKeysShouldProveAbsence(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	rootHash, _ := tr.Root()
+	verifier := createMerkleProofVerifier()
+
+	for _, key := range []string{"dok", "zebra", "do", "doge"} {
+		proof, err := tr.Prove([]byte(key))
+		require.Nil(t, err)
+
+		value, found, err := verifier.VerifyProof(rootHash, []byte(key), proof)
+		assert.Nil(t, err)
+		assert.False(t, found)
+		assert.Nil(t, value)
+	}
+}
+
+func TestMerkleProofVerifier_VerifyProofEmptyTrieShouldProveAbsence(t *testing.T) {
+	t.Parallel()
+
+	rootHash, _ := emptyTrie().Root()
+	verifier := createMerkleProofVerifier()
+
+	value, found, err := verifier.VerifyProof(rootHash, []byte("dog"), nil)
+
+	assert.Nil(t, err)
+	assert.False(t, found)
+	assert.Nil(t, value)
+}
+
+func TestMerkleProofVerifier_VerifyProofEmptyProofForNonEmptyTrieShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rootHash, _ := initTrie().Root()
+	verifier := createMerkleProofVerifier()
+
+	_, _, err := verifier.VerifyProof(rootHash, []byte("dog"), nil)
+
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestMerkleProofVerifier_VerifyProofAgainstOtherRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	proof, _ := tr.Prove([]byte("dog"))
+	_ = tr.Update([]byte("dog"), []byte("wolf"))
+	newRootHash, _ := tr.Root()
+	verifier := createMerkleProofVerifier()
+
+	_, _, err := verifier.VerifyProof(newRootHash, []byte("dog"), proof)
+
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestMerkleProofVerifier_VerifyProofForOtherKeyShouldNotReturnValue(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	rootHash, _ := tr.Root()
+	proof, _ := tr.Prove([]byte("dog"))
+	verifier := createMerkleProofVerifier()
+
+	value, found, err := verifier.VerifyProof(rootHash, []byte("doe"), proof)
+
+	assert.False(t, found)
+	assert.Nil(t, value)
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestMerkleProofVerifier_VerifyProofTruncatedProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	rootHash, _ := tr.Root()
+	proof, _ := tr.Prove([]byte("dog"))
+	verifier := createMerkleProofVerifier()
+
+	_, _, err := verifier.VerifyProof(rootHash, []byte("dog"), proof[:len(proof)-1])
+
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestMerkleProofVerifier_VerifyProofAtHistoricalRootHash(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	oldRootHash, _ := tr.Root()
+
+	_ = tr.Update([]byte("dog"), []byte("wolf"))
+	_ = tr.Commit()
+
+	oldTrie, err := tr.Recreate(oldRootHash)
+	require.Nil(t, err)
+	proof, err := oldTrie.Prove([]byte("dog"))
+	require.Nil(t, err)
+
+	value, found, err := createMerkleProofVerifier().VerifyProof(oldRootHash, []byte("dog"), proof)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("puppy"), value)
+}
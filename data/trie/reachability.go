@@ -0,0 +1,165 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// ReachabilityReport holds the outcome of walking a trie directly from its storage
+type ReachabilityReport struct {
+	NumNodes        uint64
+	NumLeaves       uint64
+	MissingHashes   [][]byte
+	CorruptedHashes [][]byte
+}
+
+// IsComplete returns true if all the nodes reachable from the root were found and could be decoded
+func (rr *ReachabilityReport) IsComplete() bool {
+	return len(rr.MissingHashes) == 0 && len(rr.CorruptedHashes) == 0
+}
+
+// CheckReachability walks the trie having the given root hash, reading every node from the provided database.
+// Unlike the trie iterator, the walk does not stop at the first missing node: all the missing nodes and the nodes
+// which can not be decoded or do not match their hash are gathered in the report. The subtrees under such nodes
+// can not be walked so they are not counted
+func CheckReachability(
+	rootHash []byte,
+	db data.DBWriteCacher,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*ReachabilityReport, error) {
+	return CheckReachabilityUpToDepth(rootHash, math.MaxUint32, db, marshalizer, hasher)
+}
+
+// CheckReachabilityUpToDepth walks the trie like CheckReachability, but reads only the nodes placed on the first
+// maxDepth levels below the root, the root being on level 0. The nodes below maxDepth are neither read nor counted
+func CheckReachabilityUpToDepth(
+	rootHash []byte,
+	maxDepth uint32,
+	db data.DBWriteCacher,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*ReachabilityReport, error) {
+	if check.IfNil(db) {
+		return nil, ErrNilDatabase
+	}
+	if check.IfNil(marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+
+	report := &ReachabilityReport{
+		MissingHashes:   make([][]byte, 0),
+		CorruptedHashes: make([][]byte, 0),
+	}
+	if emptyTrie(rootHash) {
+		return report, nil
+	}
+
+	type pendingNode struct {
+		hash  []byte
+		depth uint32
+	}
+
+	// depth first, so the number of pending hashes is bounded by the trie depth times the branching factor
+	pending := []pendingNode{{hash: rootHash}}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		encNode, err := db.Get(current.hash)
+		if err != nil {
+			report.MissingHashes = append(report.MissingHashes, current.hash)
+			continue
+		}
+
+		n, err := decodeNode(encNode, marshalizer, hasher)
+		if err != nil || !bytes.Equal(hasher.Compute(string(encNode)), current.hash) {
+			report.CorruptedHashes = append(report.CorruptedHashes, current.hash)
+			continue
+		}
+
+		report.NumNodes++
+		if _, isLeaf := n.(*leafNode); isLeaf {
+			report.NumLeaves++
+		}
+		if current.depth == maxDepth {
+			continue
+		}
+		for _, childHash := range n.getChildrenHashes() {
+			pending = append(pending, pendingNode{hash: childHash, depth: current.depth + 1})
+		}
+	}
+
+	return report, nil
+}
+
+// NodeHandler is called for every node reached by WalkNodes, with the node's hash and encoding. The value is set
+// only for the leaf nodes
+type NodeHandler func(hash []byte, encNode []byte, leafValue []byte) error
+
+// WalkNodes reads from the provided database every node of the trie having the given root hash and passes it to the
+// handler. Unlike CheckReachability, the walk stops at the first node which is missing or can not be decoded, or at
+// the first error returned by the handler
+func WalkNodes(
+	rootHash []byte,
+	db data.DBWriteCacher,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	handler NodeHandler,
+) error {
+	if check.IfNil(db) {
+		return ErrNilDatabase
+	}
+	if check.IfNil(marshalizer) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return ErrNilHasher
+	}
+	if handler == nil {
+		return ErrNilNodeHandler
+	}
+	if emptyTrie(rootHash) {
+		return nil
+	}
+
+	pending := [][]byte{rootHash}
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		encNode, err := db.Get(hash)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrNodeNotFound, hex.EncodeToString(hash))
+		}
+
+		n, err := decodeNode(encNode, marshalizer, hasher)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, hex.EncodeToString(hash))
+		}
+
+		var leafValue []byte
+		if leaf, isLeaf := n.(*leafNode); isLeaf {
+			leafValue = leaf.Value
+		}
+
+		err = handler(hash, encNode, leafValue)
+		if err != nil {
+			return err
+		}
+
+		pending = append(pending, n.getChildrenHashes()...)
+	}
+
+	return nil
+}
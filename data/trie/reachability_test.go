@@ -0,0 +1,172 @@
+package trie_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReachability_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	db := mock.NewMemDbMock()
+
+	_, err := trie.CheckReachability(emptyTrieHash, nil, marshalizer, hasher)
+	assert.Equal(t, trie.ErrNilDatabase, err)
+
+	_, err = trie.CheckReachability(emptyTrieHash, db, nil, hasher)
+	assert.Equal(t, trie.ErrNilMarshalizer, err)
+
+	_, err = trie.CheckReachability(emptyTrieHash, db, marshalizer, nil)
+	assert.Equal(t, trie.ErrNilHasher, err)
+}
+
+func TestCheckReachability_EmptyTrieShouldBeComplete(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+
+	report, err := trie.CheckReachability(emptyTrieHash, mock.NewMemDbMock(), marshalizer, hasher)
+	assert.Nil(t, err)
+	assert.True(t, report.IsComplete())
+	assert.Equal(t, uint64(0), report.NumNodes)
+}
+
+func TestCheckReachability_ShouldReportMissingAndCorruptedNodes(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	db := tr.Database()
+
+	report, err := trie.CheckReachability(rootHash, db, marshalizer, hasher)
+	require.Nil(t, err)
+	assert.True(t, report.IsComplete())
+	assert.Equal(t, uint64(3), report.NumLeaves)
+	numNodes := report.NumNodes
+
+	encNodes, _ := tr.GetSerializedNodes(rootHash, 1<<20)
+	require.Equal(t, int(numNodes), len(encNodes))
+	missingHash := hasher.Compute(string(encNodes[len(encNodes)-1]))
+	corruptedHash := hasher.Compute(string(encNodes[len(encNodes)-2]))
+	_ = db.Remove(missingHash)
+	_ = db.Put(corruptedHash, encNodes[0])
+
+	report, err = trie.CheckReachability(rootHash, db, marshalizer, hasher)
+	require.Nil(t, err)
+	assert.False(t, report.IsComplete())
+	assert.Equal(t, [][]byte{missingHash}, report.MissingHashes)
+	assert.Equal(t, [][]byte{corruptedHash}, report.CorruptedHashes)
+	assert.True(t, report.NumNodes < numNodes)
+}
+
+func TestCheckReachabilityUpToDepth_ShouldReadOnlyTheTopLevels(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	db := tr.Database()
+
+	fullReport, _ := trie.CheckReachability(rootHash, db, marshalizer, hasher)
+	encNodes, _ := tr.GetSerializedNodes(rootHash, 1<<20)
+	_ = db.Remove(hasher.Compute(string(encNodes[len(encNodes)-1])))
+
+	report, err := trie.CheckReachabilityUpToDepth(rootHash, 0, db, marshalizer, hasher)
+	require.Nil(t, err)
+	assert.True(t, report.IsComplete())
+	assert.Equal(t, uint64(1), report.NumNodes)
+
+	report, err = trie.CheckReachabilityUpToDepth(rootHash, 1, db, marshalizer, hasher)
+	require.Nil(t, err)
+	assert.True(t, report.NumNodes > 1)
+	assert.True(t, report.NumNodes < fullReport.NumNodes)
+
+	report, err = trie.CheckReachabilityUpToDepth(rootHash, 100, db, marshalizer, hasher)
+	require.Nil(t, err)
+	assert.False(t, report.IsComplete())
+	assert.Equal(t, 1, len(report.MissingHashes))
+}
+
+func TestWalkNodes_NilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+
+	err := trie.WalkNodes(emptyTrieHash, mock.NewMemDbMock(), marshalizer, hasher, nil)
+	assert.Equal(t, trie.ErrNilNodeHandler, err)
+}
+
+func TestWalkNodes_ShouldReachAllNodesAndLeaves(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+
+	numNodes := 0
+	leafValues := make(map[string]struct{})
+	err := trie.WalkNodes(rootHash, tr.Database(), marshalizer, hasher, func(hash []byte, encNode []byte, leafValue []byte) error {
+		numNodes++
+		assert.Equal(t, hash, hasher.Compute(string(encNode)))
+		if leafValue != nil {
+			leafValues[string(leafValue)] = struct{}{}
+		}
+		return nil
+	})
+	require.Nil(t, err)
+
+	encNodes, _ := tr.GetSerializedNodes(rootHash, 1<<20)
+	assert.Equal(t, len(encNodes), numNodes)
+	assert.Equal(t, 3, len(leafValues))
+	for _, key := range []string{"doe", "dog", "ddog"} {
+		value, _ := tr.Get([]byte(key))
+		_, found := leafValues[string(value)]
+		assert.True(t, found)
+	}
+}
+
+func TestWalkNodes_MissingNodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	db := tr.Database()
+
+	encNodes, _ := tr.GetSerializedNodes(rootHash, 1<<20)
+	_ = db.Remove(hasher.Compute(string(encNodes[len(encNodes)-1])))
+
+	err := trie.WalkNodes(rootHash, db, marshalizer, hasher, func(hash []byte, encNode []byte, leafValue []byte) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, trie.ErrNodeNotFound))
+}
+
+func TestWalkNodes_HandlerErrorShouldStopTheWalk(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+
+	expectedErr := errors.New("expected error")
+	numCalls := 0
+	err := trie.WalkNodes(rootHash, tr.Database(), marshalizer, hasher, func(hash []byte, encNode []byte, leafValue []byte) error {
+		numCalls++
+		return expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, numCalls)
+}
@@ -1,184 +1,289 @@
 package trie
 
 import (
-	"bytes"
 	"sync"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
 	"github.com/ElrondNetwork/elrond-go/data"
-	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
+// ArgTrieSyncer is the argument for the trie syncer
+type ArgTrieSyncer struct {
+	RequestHandler   RequestHandler
+	InterceptedNodes storage.Cacher
+	Trie             data.Trie
+	StatusHandler    core.AppStatusHandler
+	ShardId          uint32
+	Topic            string
+	// WaitTime is the time after which an unanswered request is sent again. The request handler drops the requests
+	// for a hash which was requested recently, so it should not be shorter than the request handler's time span
+	WaitTime time.Duration
+	// MaxInFlightRequests is the number of trie nodes requested and not yet received at any moment
+	MaxInFlightRequests int
+	// MaxRetries is the number of times an unanswered request is sent again before the syncing fails
+	MaxRetries int
+}
+
+type requestedNode struct {
+	lastRequest time.Time
+	numRequests int
+}
+
 type trieSyncer struct {
 	trie             *patriciaMerkleTrie
-	resolver         dataRetriever.Resolver
+	requestHandler   RequestHandler
 	interceptedNodes storage.Cacher
-	chRcvTrieNodes   chan bool
+	statusHandler    core.AppStatusHandler
+	shardId          uint32
+	topic            string
 	waitTime         time.Duration
+	maxInFlight      int
+	maxRetries       int
+	chRcvTrieNodes   chan struct{}
 
-	requestedHashes      [][]byte
-	requestedHashesMutex sync.Mutex
+	mutSync         sync.Mutex
+	missingHashes   [][]byte
+	requestedHashes map[string]*requestedNode
+	knownHashes     map[string]struct{}
 }
 
 // NewTrieSyncer creates a new instance of trieSyncer
-func NewTrieSyncer(
-	resolver dataRetriever.Resolver,
-	interceptedNodes storage.Cacher,
-	trie data.Trie,
-	waitTime time.Duration,
-) (*trieSyncer, error) {
-	if check.IfNil(resolver) {
-		return nil, ErrNilResolver
-	}
-	if check.IfNil(interceptedNodes) {
+func NewTrieSyncer(arg ArgTrieSyncer) (*trieSyncer, error) {
+	if check.IfNil(arg.RequestHandler) {
+		return nil, ErrNilRequestHandler
+	}
+	if check.IfNil(arg.InterceptedNodes) {
 		return nil, data.ErrNilCacher
 	}
-	if check.IfNil(trie) {
+	if check.IfNil(arg.Trie) {
 		return nil, ErrNilTrie
 	}
+	if check.IfNil(arg.StatusHandler) {
+		return nil, ErrNilAppStatusHandler
+	}
+	if arg.WaitTime <= 0 {
+		return nil, ErrInvalidWaitTime
+	}
+	if arg.MaxInFlightRequests < 1 {
+		return nil, ErrInvalidMaxInFlightRequests
+	}
+	if arg.MaxRetries < 0 {
+		return nil, ErrInvalidMaxRetries
+	}
 
-	pmt, ok := trie.(*patriciaMerkleTrie)
+	pmt, ok := arg.Trie.(*patriciaMerkleTrie)
 	if !ok {
 		return nil, ErrWrongTypeAssertion
 	}
 
-	return &trieSyncer{
-		resolver:         resolver,
-		interceptedNodes: interceptedNodes,
+	ts := &trieSyncer{
 		trie:             pmt,
-		chRcvTrieNodes:   make(chan bool),
-		requestedHashes:  make([][]byte, 0),
-		waitTime:         waitTime,
-	}, nil
+		requestHandler:   arg.RequestHandler,
+		interceptedNodes: arg.InterceptedNodes,
+		statusHandler:    arg.StatusHandler,
+		shardId:          arg.ShardId,
+		topic:            arg.Topic,
+		waitTime:         arg.WaitTime,
+		maxInFlight:      arg.MaxInFlightRequests,
+		maxRetries:       arg.MaxRetries,
+		chRcvTrieNodes:   make(chan struct{}, 1),
+	}
+	ts.interceptedNodes.RegisterHandler(ts.trieNodeIntercepted)
+
+	return ts, nil
 }
 
-// StartSyncing completes the trie, asking for missing trie nodes on the network
+// StartSyncing completes the trie, asking for missing trie nodes on the network. Up to the maximum number of in flight
+// requests are sent at once, every hash is requested once and the requests left unanswered for the wait time are sent
+// again. The received nodes are saved in the trie's database as they arrive, so the nodes found there are not asked
+// for again if the syncing is restarted
 func (ts *trieSyncer) StartSyncing(rootHash []byte) error {
 	if len(rootHash) == 0 {
 		return ErrInvalidHash
 	}
-	ts.interceptedNodes.RegisterHandler(ts.trieNodeIntercepted)
 
-	currentNode, err := ts.getNode(rootHash)
-	if err != nil {
-		return err
-	}
+	ts.mutSync.Lock()
+	defer ts.mutSync.Unlock()
 
-	ts.trie.root = currentNode
-	err = ts.trie.root.loadChildren(ts)
-	if err != nil {
-		return err
-	}
+	ts.missingHashes = make([][]byte, 0)
+	ts.requestedHashes = make(map[string]*requestedNode)
+	ts.knownHashes = make(map[string]struct{})
 
-	nextNodes, err := ts.trie.root.getChildren(ts.trie.Database())
+	err := ts.addHash(rootHash)
 	if err != nil {
 		return err
 	}
 
-	for len(nextNodes) != 0 {
-		currentNode, err = ts.getNode(nextNodes[0].getHash())
+	for {
+		err = ts.checkRequestedHashes()
 		if err != nil {
 			return err
 		}
 
-		nextNodes = nextNodes[1:]
-
-		err = currentNode.loadChildren(ts)
-		if err != nil {
-			return err
+		if len(ts.missingHashes) == 0 && len(ts.requestedHashes) == 0 {
+			break
 		}
 
-		var children []node
-		children, err = currentNode.getChildren(ts.trie.Database())
+		err = ts.requestMissingHashes()
 		if err != nil {
 			return err
 		}
-		nextNodes = append(nextNodes, children...)
+
+		ts.waitForTrieNodes()
 	}
 
-	err = ts.trie.Commit()
+	root, err := getNodeFromDBAndDecode(rootHash, ts.trie.Database(), ts.trie.marshalizer, ts.trie.hasher)
 	if err != nil {
 		return err
 	}
 
+	ts.trie.mutOperation.Lock()
+	ts.trie.root = root
+	ts.trie.mutOperation.Unlock()
+
 	return nil
 }
 
-func (ts *trieSyncer) getNode(hash []byte) (node, error) {
-	n, ok := ts.interceptedNodes.Get(hash)
-	if ok {
-		return trieNode(n)
+// addHash marks the hash as known and saves its node if it is available locally. Otherwise, the hash is queued to be
+// requested
+func (ts *trieSyncer) addHash(hash []byte) error {
+	_, isKnown := ts.knownHashes[string(hash)]
+	if isKnown {
+		return nil
 	}
+	ts.knownHashes[string(hash)] = struct{}{}
 
-	err := ts.requestNode(hash)
-	if err != nil {
-		return nil, err
+	found, err := ts.tryToSaveLocalNode(hash)
+	if err != nil || found {
+		return err
 	}
 
-	n, _ = ts.interceptedNodes.Get(hash)
-	return trieNode(n)
+	ts.missingHashes = append(ts.missingHashes, hash)
+
+	return nil
 }
 
-func trieNode(data interface{}) (node, error) {
-	n, ok := data.(*InterceptedTrieNode)
-	if !ok {
-		return nil, ErrWrongTypeAssertion
+// tryToSaveLocalNode looks for the node in the trie's database and between the intercepted nodes, saves it in the
+// database and continues with its children. It returns false if the node was not found
+func (ts *trieSyncer) tryToSaveLocalNode(hash []byte) (bool, error) {
+	db := ts.trie.Database()
+
+	encodedNode, err := db.Get(hash)
+	if err == nil {
+		var n node
+		n, err = decodeNode(encodedNode, ts.trie.marshalizer, ts.trie.hasher)
+		if err != nil {
+			return false, err
+		}
+
+		return true, ts.addChildren(n)
 	}
 
-	return n.node, nil
-}
+	interceptedNode, ok := ts.interceptedNodes.Get(hash)
+	if !ok {
+		return false, nil
+	}
 
-func (ts *trieSyncer) requestNode(hash []byte) error {
-	receivedRequestedHashTrigger := append(hash, hash...)
-	ts.requestedHashesMutex.Lock()
-	ts.requestedHashes = append(ts.requestedHashes, receivedRequestedHashTrigger)
-	ts.requestedHashesMutex.Unlock()
+	n, ok := interceptedNode.(*InterceptedTrieNode)
+	if !ok {
+		return false, ErrWrongTypeAssertion
+	}
 
-	// epoch doesn't matter because that parameter is not used in trie's resolver
-	err := ts.resolver.RequestDataFromHash(hash, 0)
+	err = db.Put(hash, n.EncodedNode())
 	if err != nil {
-		return err
+		return false, err
 	}
+	ts.interceptedNodes.Remove(hash)
 
-	return ts.waitForTrieNode()
+	ts.statusHandler.AddUint64(core.MetricTrieSyncNumReceivedNodes, 1)
+	ts.statusHandler.AddUint64(core.MetricTrieSyncNumReceivedBytes, uint64(len(n.EncodedNode())))
+
+	return true, ts.addChildren(n.node)
 }
 
-func (ts *trieSyncer) waitForTrieNode() error {
-	select {
-	case <-ts.chRcvTrieNodes:
-		return nil
-	case <-time.After(ts.waitTime):
-		return ErrTimeIsOut
+func (ts *trieSyncer) addChildren(n node) error {
+	for _, hash := range n.getChildrenHashes() {
+		err := ts.addHash(hash)
+		if err != nil {
+			return err
+		}
 	}
-}
 
-func (ts *trieSyncer) trieNodeIntercepted(hash []byte) {
-	ts.requestedHashesMutex.Lock()
+	return nil
+}
 
-	if hashInSlice(hash, ts.requestedHashes) {
-		ts.chRcvTrieNodes <- true
-		ts.removeRequestedHash(hash)
+func (ts *trieSyncer) checkRequestedHashes() error {
+	for hash := range ts.requestedHashes {
+		found, err := ts.tryToSaveLocalNode([]byte(hash))
+		if err != nil {
+			return err
+		}
+		if found {
+			delete(ts.requestedHashes, hash)
+		}
 	}
-	ts.requestedHashesMutex.Unlock()
+
+	return nil
 }
 
-func (ts *trieSyncer) removeRequestedHash(hash []byte) {
-	for i := range ts.requestedHashes {
-		if bytes.Equal(ts.requestedHashes[i], hash) {
-			ts.requestedHashes = append(ts.requestedHashes[:i], ts.requestedHashes[i+1:]...)
+// requestMissingHashes sends again the timed out requests and fills the window of in flight requests with the
+// missing hashes. The missing hashes are taken last in first out, so the trie is walked depth first and the queue of
+// missing hashes stays small
+func (ts *trieSyncer) requestMissingHashes() error {
+	for hash, rn := range ts.requestedHashes {
+		if time.Since(rn.lastRequest) < ts.waitTime {
+			continue
+		}
+		if rn.numRequests > ts.maxRetries {
+			log.Debug("trie node was not received", "hash", []byte(hash), "num requests", rn.numRequests)
+			return ErrTimeIsOut
 		}
+
+		ts.request([]byte(hash), rn)
 	}
-}
 
-func hashInSlice(hash []byte, hashes [][]byte) bool {
-	for _, h := range hashes {
-		if bytes.Equal(h, hash) {
-			return true
+	for len(ts.requestedHashes) < ts.maxInFlight && len(ts.missingHashes) > 0 {
+		hash := ts.missingHashes[len(ts.missingHashes)-1]
+		ts.missingHashes = ts.missingHashes[:len(ts.missingHashes)-1]
+
+		found, err := ts.tryToSaveLocalNode(hash)
+		if err != nil {
+			return err
 		}
+		if found {
+			continue
+		}
+
+		rn := &requestedNode{}
+		ts.requestedHashes[string(hash)] = rn
+		ts.request(hash, rn)
+	}
+
+	return nil
+}
+
+func (ts *trieSyncer) request(hash []byte, rn *requestedNode) {
+	rn.lastRequest = time.Now()
+	rn.numRequests++
+
+	ts.requestHandler.RequestTrieNodes(ts.shardId, hash, ts.topic)
+}
+
+func (ts *trieSyncer) waitForTrieNodes() {
+	select {
+	case <-ts.chRcvTrieNodes:
+	case <-time.After(ts.waitTime):
+	}
+}
+
+func (ts *trieSyncer) trieNodeIntercepted(_ []byte) {
+	select {
+	case ts.chRcvTrieNodes <- struct{}{}:
+	default:
 	}
-	return false
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
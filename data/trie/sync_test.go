@@ -1,22 +1,22 @@
 package trie_test
 
 import (
-	"io/ioutil"
-	"math/rand"
-	"strconv"
+	"sync"
 	"testing"
 	"time"
 
-	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/mock"
 	"github.com/ElrondNetwork/elrond-go/data/trie"
 	"github.com/ElrondNetwork/elrond-go/hashing"
 	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/statusHandler"
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
-	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
-	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func getInterceptedNodes(tr data.Trie, marshalizer marshal.Marshalizer, hasher hashing.Hasher) []*trie.InterceptedTrieNode {
@@ -31,54 +31,282 @@ func getInterceptedNodes(tr data.Trie, marshalizer marshal.Marshalizer, hasher h
 	return interceptedNodes
 }
 
+func getInterceptedNodesByHash(tr data.Trie) map[string]*trie.InterceptedTrieNode {
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	interceptedNodes := make(map[string]*trie.InterceptedTrieNode)
+	for _, node := range getInterceptedNodes(tr, marshalizer, hasher) {
+		interceptedNodes[string(node.Hash())] = node
+	}
+
+	return interceptedNodes
+}
+
+func createArgTrieSyncer(tr data.Trie, interceptedNodes storage.Cacher) trie.ArgTrieSyncer {
+	return trie.ArgTrieSyncer{
+		RequestHandler:      &mock.RequestHandlerStub{},
+		InterceptedNodes:    interceptedNodes,
+		Trie:                tr,
+		StatusHandler:       statusHandler.NewNilStatusHandler(),
+		WaitTime:            time.Second,
+		MaxInFlightRequests: 10,
+		MaxRetries:          2,
+	}
+}
+
+func createSourceTrie(nrValues int) (data.Trie, []byte, map[string]*trie.InterceptedTrieNode) {
+	tr, _ := initTrieMultipleValues(nrValues)
+	rootHash, _ := tr.Root()
+
+	return tr, rootHash, getInterceptedNodesByHash(tr)
+}
+
+func TestNewTrieSyncer_InvalidArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setInvalid  func(arg *trie.ArgTrieSyncer)
+		expectedErr error
+	}{
+		{"request handler", func(arg *trie.ArgTrieSyncer) { arg.RequestHandler = nil }, trie.ErrNilRequestHandler},
+		{"intercepted nodes", func(arg *trie.ArgTrieSyncer) { arg.InterceptedNodes = nil }, data.ErrNilCacher},
+		{"trie", func(arg *trie.ArgTrieSyncer) { arg.Trie = nil }, trie.ErrNilTrie},
+		{"status handler", func(arg *trie.ArgTrieSyncer) { arg.StatusHandler = nil }, trie.ErrNilAppStatusHandler},
+		{"wait time", func(arg *trie.ArgTrieSyncer) { arg.WaitTime = 0 }, trie.ErrInvalidWaitTime},
+		{"max in flight requests", func(arg *trie.ArgTrieSyncer) { arg.MaxInFlightRequests = 0 }, trie.ErrInvalidMaxInFlightRequests},
+		{"max retries", func(arg *trie.ArgTrieSyncer) { arg.MaxRetries = -1 }, trie.ErrInvalidMaxRetries},
+		{"trie type", func(arg *trie.ArgTrieSyncer) { arg.Trie = &mock.TrieStub{} }, trie.ErrWrongTypeAssertion},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cacher, _ := lrucache.NewCache(10)
+			arg := createArgTrieSyncer(emptyTrie(), cacher)
+			tt.setInvalid(&arg)
+
+			ts, err := trie.NewTrieSyncer(arg)
+
+			assert.True(t, check.IfNil(ts))
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestNewTrieSyncer_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := lrucache.NewCache(10)
+	ts, err := trie.NewTrieSyncer(createArgTrieSyncer(emptyTrie(), cacher))
+
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(ts))
+}
+
+func TestTrieSyncer_StartSyncingEmptyRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := lrucache.NewCache(10)
+	ts, _ := trie.NewTrieSyncer(createArgTrieSyncer(emptyTrie(), cacher))
+
+	err := ts.StartSyncing(nil)
+
+	assert.Equal(t, trie.ErrInvalidHash, err)
+}
+
 func TestTrieSyncer_StartSyncing(t *testing.T) {
 	t.Parallel()
 
-	db := mock.NewMemDbMock()
-	marshalizer := &mock.ProtobufMarshalizerMock{}
-	hasher := &mock.KeccakMock{}
-	tempDir, _ := ioutil.TempDir("", strconv.Itoa(rand.Intn(100000)))
-	cfg := &config.DBConfig{
-		FilePath:          tempDir,
-		Type:              string(storageUnit.LvlDbSerial),
-		BatchDelaySeconds: 1,
-		MaxBatchSize:      1,
-		MaxOpenFiles:      10,
+	sourceTrie, rootHash, interceptedNodes := createSourceTrie(200)
+	cacher, _ := lrucache.NewCache(1000)
+	tr := emptyTrie()
+	arg := createArgTrieSyncer(tr, cacher)
+	numRequests := make(map[string]int)
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			numRequests[string(hash)]++
+			cacher.Put(hash, interceptedNodes[string(hash)])
+		},
 	}
+	metrics := statusHandler.NewStatusMetrics()
+	metrics.SetUInt64Value(core.MetricTrieSyncNumReceivedNodes, 0)
+	metrics.SetUInt64Value(core.MetricTrieSyncNumReceivedBytes, 0)
+	arg.StatusHandler = metrics
+	ts, _ := trie.NewTrieSyncer(arg)
+
+	err := ts.StartSyncing(rootHash)
+	require.Nil(t, err)
+
+	newRootHash, _ := tr.Root()
+	assert.Equal(t, rootHash, newRootHash)
+	expectedLeaves, _ := sourceTrie.GetAllLeaves()
+	leaves, _ := tr.GetAllLeaves()
+	assert.Equal(t, expectedLeaves, leaves)
+
+	assert.Equal(t, len(interceptedNodes), len(numRequests))
+	numBytes := 0
+	for hash, node := range interceptedNodes {
+		assert.Equal(t, 1, numRequests[hash])
+		numBytes += len(node.EncodedNode())
+	}
+	metricsMap, _ := metrics.StatusMetricsMap()
+	assert.Equal(t, uint64(len(interceptedNodes)), metricsMap[core.MetricTrieSyncNumReceivedNodes])
+	assert.Equal(t, uint64(numBytes), metricsMap[core.MetricTrieSyncNumReceivedBytes])
+	assert.Equal(t, 0, cacher.Len())
+}
+
+func TestTrieSyncer_StartSyncingShouldNotRequestTheReceivedNodes(t *testing.T) {
+	t.Parallel()
 
-	evictionWaitingList, _ := mock.NewEvictionWaitingList(100, memorydb.New(), marshalizer)
-	trieStorage, _ := trie.NewTrieStorageManager(db, cfg, evictionWaitingList)
-	tr, _ := trie.NewTrie(trieStorage, marshalizer, hasher)
-
-	syncTrie := initTrie()
-	interceptedNodesCacher, _ := lrucache.NewCache(100)
-	interceptedNodes := getInterceptedNodes(syncTrie, marshalizer, hasher)
-	nrNodesToSend := 2
-	nodesIndex := 0
-	nrRequests := 0
-	expectedRequests := 3
-
-	resolver := &mock.TrieNodesResolverStub{
-		RequestDataFromHashCalled: func(hash []byte) error {
-			requestedNode := interceptedNodes[nodesIndex]
-			for i := nodesIndex; i < nodesIndex+nrNodesToSend; i++ {
-				interceptedNodesCacher.Put(interceptedNodes[i].Hash(), interceptedNodes[i])
+	_, rootHash, interceptedNodes := createSourceTrie(100)
+	cacher, _ := lrucache.NewCache(1000)
+	tr := emptyTrie()
+	arg := createArgTrieSyncer(tr, cacher)
+	numRequests := 0
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			numRequests++
+			for nodeHash, node := range interceptedNodes {
+				cacher.Put([]byte(nodeHash), node)
 			}
+		},
+	}
+	ts, _ := trie.NewTrieSyncer(arg)
+
+	err := ts.StartSyncing(rootHash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numRequests)
+	newRootHash, _ := tr.Root()
+	assert.Equal(t, rootHash, newRootHash)
+}
+
+func TestTrieSyncer_StartSyncingShouldNotRequestTheNodesAlreadyInTheDatabase(t *testing.T) {
+	t.Parallel()
+
+	_, rootHash, interceptedNodes := createSourceTrie(100)
+	cacher, _ := lrucache.NewCache(1000)
+	tr := emptyTrie()
+	arg := createArgTrieSyncer(tr, cacher)
+	numRequests := 0
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			numRequests++
+			cacher.Put(hash, interceptedNodes[string(hash)])
+		},
+	}
+	ts, _ := trie.NewTrieSyncer(arg)
+	err := ts.StartSyncing(rootHash)
+	require.Nil(t, err)
+	require.Equal(t, len(interceptedNodes), numRequests)
 
-			requestedNode.CreateEndOfProcessingTriggerNode()
-			interceptedNodesCacher.Put(requestedNode.Hash(), requestedNode)
-			nodesIndex += nrNodesToSend
-			nrRequests++
+	numRequests = 0
+	err = ts.StartSyncing(rootHash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRequests)
+}
+
+func TestTrieSyncer_StartSyncingShouldKeepTheRequestsInTheWindow(t *testing.T) {
+	t.Parallel()
+
+	_, rootHash, interceptedNodes := createSourceTrie(200)
+	cacher, _ := lrucache.NewCache(1000)
+	tr := emptyTrie()
+	arg := createArgTrieSyncer(tr, cacher)
+	arg.MaxInFlightRequests = 5
+
+	mutPending := sync.Mutex{}
+	pending := make([][]byte, 0)
+	maxPending := 0
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			mutPending.Lock()
+			pending = append(pending, hash)
+			if len(pending) > maxPending {
+				maxPending = len(pending)
+			}
+			mutPending.Unlock()
+		},
+	}
+	chDone := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-chDone:
+				return
+			case <-time.After(time.Millisecond * 5):
+			}
+
+			mutPending.Lock()
+			for _, hash := range pending {
+				cacher.Put(hash, interceptedNodes[string(hash)])
+			}
+			pending = make([][]byte, 0)
+			mutPending.Unlock()
+		}
+	}()
+	ts, _ := trie.NewTrieSyncer(arg)
+
+	err := ts.StartSyncing(rootHash)
+	close(chDone)
+
+	assert.Nil(t, err)
+	newRootHash, _ := tr.Root()
+	assert.Equal(t, rootHash, newRootHash)
+	mutPending.Lock()
+	assert.True(t, maxPending > 1)
+	assert.True(t, maxPending <= arg.MaxInFlightRequests)
+	mutPending.Unlock()
+}
+
+func TestTrieSyncer_StartSyncingShouldRetryTheUnansweredRequests(t *testing.T) {
+	t.Parallel()
+
+	_, rootHash, interceptedNodes := createSourceTrie(50)
+	cacher, _ := lrucache.NewCache(1000)
+	tr := emptyTrie()
+	arg := createArgTrieSyncer(tr, cacher)
+	arg.WaitTime = time.Millisecond * 10
+	arg.MaxRetries = 1
+	numRequests := make(map[string]int)
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			numRequests[string(hash)]++
+			if numRequests[string(hash)] > 1 {
+				cacher.Put(hash, interceptedNodes[string(hash)])
+			}
+		},
+	}
+	ts, _ := trie.NewTrieSyncer(arg)
+
+	err := ts.StartSyncing(rootHash)
+
+	assert.Nil(t, err)
+	newRootHash, _ := tr.Root()
+	assert.Equal(t, rootHash, newRootHash)
+	for hash := range interceptedNodes {
+		assert.Equal(t, 2, numRequests[hash])
+	}
+}
+
+func TestTrieSyncer_StartSyncingUnansweredRequestsShouldErr(t *testing.T) {
+	t.Parallel()
 
-			return nil
+	_, rootHash, _ := createSourceTrie(50)
+	cacher, _ := lrucache.NewCache(1000)
+	arg := createArgTrieSyncer(emptyTrie(), cacher)
+	arg.WaitTime = time.Millisecond * 10
+	arg.MaxRetries = 2
+	numRequests := 0
+	arg.RequestHandler = &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			numRequests++
 		},
 	}
+	ts, _ := trie.NewTrieSyncer(arg)
 
-	rootHash, _ := syncTrie.Root()
-	sync, _ := trie.NewTrieSyncer(resolver, interceptedNodesCacher, tr, 10*time.Second)
+	err := ts.StartSyncing(rootHash)
 
-	_ = sync.StartSyncing(rootHash)
-	newTrieRootHash, _ := tr.Root()
-	assert.Equal(t, rootHash, newTrieRootHash)
-	assert.Equal(t, expectedRequests, nrRequests)
+	assert.Equal(t, trie.ErrTimeIsOut, err)
+	assert.Equal(t, arg.MaxRetries+1, numRequests)
 }
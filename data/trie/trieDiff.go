@@ -0,0 +1,241 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// diffWalker visits the nodes of a trie depth first, in the trie's order, and lets the caller decide for each node
+// whether its subtree is visited or skipped
+type diffWalker struct {
+	db          data.DBWriteCacher
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+	pending     []pendingNode
+	current     *pendingNode
+}
+
+func newDiffWalker(trie data.Trie) (*diffWalker, error) {
+	pmt, ok := trie.(*patriciaMerkleTrie)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	// computing the root hash sets the hashes of all the nodes kept in memory
+	_, err := pmt.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	pmt.mutOperation.RLock()
+	root := pmt.root
+	pmt.mutOperation.RUnlock()
+
+	dw := &diffWalker{
+		db:          pmt.Database(),
+		marshalizer: pmt.marshalizer,
+		hasher:      pmt.hasher,
+		pending:     make([]pendingNode, 0),
+	}
+	if root != nil {
+		dw.current = &pendingNode{node: root, hash: root.getHash(), path: make([]byte, 0)}
+	}
+
+	return dw, nil
+}
+
+// load reads the current node from the database if it is collapsed
+func (dw *diffWalker) load() error {
+	if dw.current.node != nil {
+		return nil
+	}
+
+	n, err := getNodeFromDBAndDecode(dw.current.hash, dw.db, dw.marshalizer, dw.hasher)
+	if err != nil {
+		return err
+	}
+	dw.current.node = n
+
+	return nil
+}
+
+// position returns the path the current node is sorted by: the full path of the key for a leaf and the path of the
+// node for the others. The current node must be loaded
+func (dw *diffWalker) position() []byte {
+	ln, ok := dw.current.node.(*leafNode)
+	if !ok {
+		return dw.current.path
+	}
+
+	return concat(dw.current.path, ln.Key...)
+}
+
+// skip moves to the node following the subtree of the current node
+func (dw *diffWalker) skip() {
+	dw.current = nil
+	if len(dw.pending) == 0 {
+		return
+	}
+
+	pn := dw.pending[len(dw.pending)-1]
+	dw.pending = dw.pending[:len(dw.pending)-1]
+	dw.current = &pn
+}
+
+// descend moves to the next node, entering the subtree of the current node. If the current node is a leaf, its key
+// and value are returned. The current node must be loaded
+func (dw *diffWalker) descend() ([]byte, []byte, error) {
+	switch nd := dw.current.node.(type) {
+	case *branchNode:
+		for i := nrOfChildren - 1; i >= 0; i-- {
+			if nd.children[i] == nil && len(nd.EncodedChildren[i]) == 0 {
+				continue
+			}
+			dw.pending = append(dw.pending, pendingNode{
+				node: nd.children[i],
+				hash: childHash(nd.children[i], nd.EncodedChildren[i]),
+				path: concat(dw.current.path, byte(i)),
+			})
+		}
+	case *extensionNode:
+		dw.pending = append(dw.pending, pendingNode{
+			node: nd.child,
+			hash: childHash(nd.child, nd.EncodedChild),
+			path: concat(dw.current.path, nd.Key...),
+		})
+	case *leafNode:
+		key, err := hexToKeyBytes(dw.position())
+		if err != nil {
+			return nil, nil, err
+		}
+		value := nd.Value
+		dw.skip()
+		return key, value, nil
+	default:
+		return nil, nil, ErrInvalidNode
+	}
+
+	dw.skip()
+	return nil, nil, nil
+}
+
+func childHash(child node, encodedChild []byte) []byte {
+	if child != nil {
+		return child.getHash()
+	}
+
+	return encodedChild
+}
+
+func isSameSubtree(first *pendingNode, second *pendingNode) bool {
+	return len(first.hash) > 0 && bytes.Equal(first.hash, second.hash) && bytes.Equal(first.path, second.path)
+}
+
+// Diff walks the two tries in lockstep and calls the handler for every key which was added, modified or removed
+// between the old and the new trie, until the handler returns false. Two nodes placed on the same path and having the
+// same hash hold the same leaves, so their subtrees are skipped without being read from the database. The changes are
+// passed in the trie's order, which is the order of the keys' reversed nibbles, not the key order
+func Diff(oldTrie data.Trie, newTrie data.Trie, handler func(change data.LeafChange) bool) error {
+	if check.IfNil(oldTrie) || check.IfNil(newTrie) {
+		return ErrNilTrie
+	}
+	if handler == nil {
+		return ErrNilLeafChangeHandler
+	}
+
+	oldWalker, err := newDiffWalker(oldTrie)
+	if err != nil {
+		return err
+	}
+	newWalker, err := newDiffWalker(newTrie)
+	if err != nil {
+		return err
+	}
+
+	for oldWalker.current != nil || newWalker.current != nil {
+		var change *data.LeafChange
+		change, err = diffStep(oldWalker, newWalker)
+		if err != nil {
+			return err
+		}
+
+		if change != nil && !handler(*change) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// diffStep moves forward the walker placed on the smallest position, or both walkers if they are on the same
+// position, and returns the leaf change found on the way, if any
+func diffStep(oldWalker *diffWalker, newWalker *diffWalker) (*data.LeafChange, error) {
+	if oldWalker.current == nil {
+		return descendToChange(newWalker, data.LeafAdded)
+	}
+	if newWalker.current == nil {
+		return descendToChange(oldWalker, data.LeafRemoved)
+	}
+
+	if isSameSubtree(oldWalker.current, newWalker.current) {
+		oldWalker.skip()
+		newWalker.skip()
+		return nil, nil
+	}
+
+	err := oldWalker.load()
+	if err != nil {
+		return nil, err
+	}
+	err = newWalker.load()
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := bytes.Compare(oldWalker.position(), newWalker.position())
+	if cmp < 0 {
+		return descendToChange(oldWalker, data.LeafRemoved)
+	}
+	if cmp > 0 {
+		return descendToChange(newWalker, data.LeafAdded)
+	}
+
+	key, oldValue, err := oldWalker.descend()
+	if err != nil {
+		return nil, err
+	}
+	_, newValue, err := newWalker.descend()
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || bytes.Equal(oldValue, newValue) {
+		return nil, nil
+	}
+
+	return &data.LeafChange{Type: data.LeafModified, Key: key, OldValue: oldValue, NewValue: newValue}, nil
+}
+
+func descendToChange(dw *diffWalker, changeType data.LeafChangeType) (*data.LeafChange, error) {
+	err := dw.load()
+	if err != nil {
+		return nil, err
+	}
+
+	key, value, err := dw.descend()
+	if err != nil || key == nil {
+		return nil, err
+	}
+
+	change := &data.LeafChange{Type: changeType, Key: key}
+	if changeType == data.LeafAdded {
+		change.NewValue = value
+	} else {
+		change.OldValue = value
+	}
+
+	return change, nil
+}
@@ -0,0 +1,239 @@
+package trie_test
+
+import (
+	"bytes"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingDbMock struct {
+	*mock.MemDbMock
+	numGets uint32
+}
+
+func (cdm *countingDbMock) Get(key []byte) ([]byte, error) {
+	atomic.AddUint32(&cdm.numGets, 1)
+	return cdm.MemDbMock.Get(key)
+}
+
+func collectChanges(t *testing.T, oldTrie data.Trie, newTrie data.Trie) map[string]data.LeafChange {
+	changes := make(map[string]data.LeafChange)
+	err := trie.Diff(oldTrie, newTrie, func(change data.LeafChange) bool {
+		_, found := changes[string(change.Key)]
+		require.False(t, found)
+		changes[string(change.Key)] = change
+		return true
+	})
+	require.Nil(t, err)
+
+	return changes
+}
+
+func computeExpectedChanges(t *testing.T, oldTrie data.Trie, newTrie data.Trie) map[string]data.LeafChange {
+	oldLeaves, err := oldTrie.GetAllLeaves()
+	require.Nil(t, err)
+	newLeaves, err := newTrie.GetAllLeaves()
+	require.Nil(t, err)
+
+	changes := make(map[string]data.LeafChange)
+	for key, oldValue := range oldLeaves {
+		newValue, found := newLeaves[key]
+		if !found {
+			changes[key] = data.LeafChange{Type: data.LeafRemoved, Key: []byte(key), OldValue: oldValue}
+			continue
+		}
+		if !bytes.Equal(oldValue, newValue) {
+			changes[key] = data.LeafChange{Type: data.LeafModified, Key: []byte(key), OldValue: oldValue, NewValue: newValue}
+		}
+	}
+	for key, newValue := range newLeaves {
+		if _, found := oldLeaves[key]; !found {
+			changes[key] = data.LeafChange{Type: data.LeafAdded, Key: []byte(key), NewValue: newValue}
+		}
+	}
+
+	return changes
+}
+
+func modifyTrie(tr data.Trie, values [][]byte) {
+	for i := 0; i < len(values); i += 7 {
+		_ = tr.Update(values[i], []byte("modified"))
+	}
+	for i := 3; i < len(values); i += 11 {
+		_ = tr.Delete(values[i])
+	}
+	for i := 0; i < 20; i++ {
+		key := []byte(fmt.Sprintf("added key %d", i))
+		_ = tr.Update(key, key)
+	}
+}
+
+func TestDiff_NilTriesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	err := trie.Diff(nil, emptyTrie(), func(change data.LeafChange) bool { return true })
+	assert.Equal(t, trie.ErrNilTrie, err)
+
+	err = trie.Diff(emptyTrie(), nil, func(change data.LeafChange) bool { return true })
+	assert.Equal(t, trie.ErrNilTrie, err)
+}
+
+func TestDiff_NilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	err := trie.Diff(emptyTrie(), emptyTrie(), nil)
+
+	assert.Equal(t, trie.ErrNilLeafChangeHandler, err)
+}
+
+func TestDiff_SameTrieShouldNotHaveChanges(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(100)
+
+	assert.Equal(t, 0, len(collectChanges(t, tr, tr)))
+	assert.Equal(t, 0, len(collectChanges(t, emptyTrie(), emptyTrie())))
+}
+
+func TestDiff_EmptyTrieShouldHaveAllTheKeysAddedOrRemoved(t *testing.T) {
+	t.Parallel()
+
+	tr, values := initTrieMultipleValues(100)
+
+	added := collectChanges(t, emptyTrie(), tr)
+	removed := collectChanges(t, tr, emptyTrie())
+
+	assert.Equal(t, len(values), len(added))
+	assert.Equal(t, len(values), len(removed))
+	for _, value := range values {
+		assert.Equal(t, data.LeafChange{Type: data.LeafAdded, Key: value, NewValue: value}, added[string(value)])
+		assert.Equal(t, data.LeafChange{Type: data.LeafRemoved, Key: value, OldValue: value}, removed[string(value)])
+	}
+}
+
+func TestDiff_InMemoryTriesShouldReturnTheChanges(t *testing.T) {
+	t.Parallel()
+
+	oldTrie, values := initTrieMultipleValues(500)
+	newTrie, _ := oldTrie.DeepClone()
+	modifyTrie(newTrie, values)
+
+	changes := collectChanges(t, oldTrie, newTrie)
+
+	assert.Equal(t, computeExpectedChanges(t, oldTrie, newTrie), changes)
+	assert.NotEqual(t, 0, len(changes))
+}
+
+func TestDiff_CollapsedTriesShouldReturnTheChanges(t *testing.T) {
+	t.Parallel()
+
+	tr, values := initTrieMultipleValues(500)
+	_ = tr.Commit()
+	oldRootHash, _ := tr.Root()
+	modifyTrie(tr, values)
+	_ = tr.Commit()
+	newRootHash, _ := tr.Root()
+
+	oldTrie, _ := tr.Recreate(oldRootHash)
+	newTrie, _ := tr.Recreate(newRootHash)
+	changes := collectChanges(t, oldTrie, newTrie)
+
+	expectedOldTrie, _ := tr.Recreate(oldRootHash)
+	expectedNewTrie, _ := tr.Recreate(newRootHash)
+	assert.Equal(t, computeExpectedChanges(t, expectedOldTrie, expectedNewTrie), changes)
+}
+
+func TestDiff_IdenticalSubtreesShouldNotBeRead(t *testing.T) {
+	t.Parallel()
+
+	db := &countingDbMock{MemDbMock: mock.NewMemDbMock()}
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	trieStorage, _ := trie.NewTrieStorageManagerWithoutPruning(db)
+	tr, _ := trie.NewTrie(trieStorage, marshalizer, hasher)
+
+	_, values := initTrieMultipleValues(1000)
+	for _, value := range values {
+		_ = tr.Update(value, value)
+	}
+	_ = tr.Commit()
+	oldRootHash, _ := tr.Root()
+	_ = tr.Update(values[500], []byte("modified"))
+	_ = tr.Commit()
+	newRootHash, _ := tr.Root()
+
+	oldTrie, _ := tr.Recreate(oldRootHash)
+	newTrie, _ := tr.Recreate(newRootHash)
+	atomic.StoreUint32(&db.numGets, 0)
+	changes := collectChanges(t, oldTrie, newTrie)
+	numGetsForDiff := atomic.LoadUint32(&db.numGets)
+
+	fullTrie, _ := tr.Recreate(oldRootHash)
+	atomic.StoreUint32(&db.numGets, 0)
+	_, _ = fullTrie.GetAllLeaves()
+	numGetsForAllNodes := atomic.LoadUint32(&db.numGets)
+
+	require.Equal(t, 1, len(changes))
+	assert.Equal(t, []byte("modified"), changes[string(values[500])].NewValue)
+	assert.True(t, numGetsForDiff < numGetsForAllNodes/10)
+}
+
+func TestDiff_HandlerReturningFalseShouldStop(t *testing.T) {
+	t.Parallel()
+
+	tr, _ := initTrieMultipleValues(100)
+
+	numChanges := 0
+	err := trie.Diff(emptyTrie(), tr, func(change data.LeafChange) bool {
+		numChanges++
+		return numChanges < 5
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, numChanges)
+}
+
+func TestDiff_MissingNodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, values := initTrieMultipleValues(100)
+	_ = tr.Commit()
+	oldRootHash, _ := tr.Root()
+	_ = tr.Update(values[0], []byte("modified"))
+	_ = tr.Commit()
+	newRootHash, _ := tr.Root()
+
+	oldNodes := make(map[string]struct{})
+	oldNodesTrie, _ := tr.Recreate(oldRootHash)
+	oldNodesIterator, _ := trie.NewIterator(oldNodesTrie)
+	for oldNodesIterator.HasNext() {
+		_ = oldNodesIterator.Next()
+		hash, _ := oldNodesIterator.GetHash()
+		oldNodes[string(hash)] = struct{}{}
+	}
+	newNodesTrie, _ := tr.Recreate(newRootHash)
+	newNodesIterator, _ := trie.NewIterator(newNodesTrie)
+	var newNodeHash []byte
+	for newNodesIterator.HasNext() {
+		_ = newNodesIterator.Next()
+		hash, _ := newNodesIterator.GetHash()
+		if _, found := oldNodes[string(hash)]; !found {
+			newNodeHash = hash
+		}
+	}
+	require.NotNil(t, newNodeHash)
+
+	oldTrie, _ := tr.Recreate(oldRootHash)
+	newTrie, _ := tr.Recreate(newRootHash)
+	_ = tr.Database().Remove(newNodeHash)
+
+	err := trie.Diff(oldTrie, newTrie, func(change data.LeafChange) bool { return true })
+
+	assert.NotNil(t, err)
+}
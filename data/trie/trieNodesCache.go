@@ -0,0 +1,220 @@
+package trie
+
+import (
+	"bytes"
+	"math"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+)
+
+// trieNodesCache keeps in memory the encoded trie nodes read from or written to the trie database. The recently used
+// nodes are held within a byte budget, while the top levels of the last pinned root are kept on top of the budget,
+// as every read of the state goes through them. The nodes are addressed by their hash, so a cached node is never
+// stale; a node removed from the database is removed from the cache as well
+type trieNodesCache struct {
+	db              data.DBWriteCacher
+	cache           *lrucache.LRUCache
+	numPinnedLevels uint32
+	marshalizer     marshal.Marshalizer
+	hasher          hashing.Hasher
+	counters        storage.CacheCounters
+
+	mutPinned   sync.RWMutex
+	pinnedNodes map[string][]byte
+}
+
+// NewTrieNodesCache creates a trie nodes cache in front of the given trie database
+func NewTrieNodesCache(
+	db data.DBWriteCacher,
+	sizeInBytes uint64,
+	numPinnedLevels uint32,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*trieNodesCache, error) {
+	if check.IfNil(db) {
+		return nil, ErrNilDatabase
+	}
+	if check.IfNil(marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+	if sizeInBytes == 0 || sizeInBytes > math.MaxInt64 {
+		return nil, storage.ErrInvalidCacheSizeInBytes
+	}
+
+	cache, err := lrucache.NewCacheWithSizeInBytes(math.MaxInt32, int64(sizeInBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trieNodesCache{
+		db:              db,
+		cache:           cache,
+		numPinnedLevels: numPinnedLevels,
+		marshalizer:     marshalizer,
+		hasher:          hasher,
+		pinnedNodes:     make(map[string][]byte),
+	}, nil
+}
+
+// Put writes the encoded node in the database and keeps it in the cache
+func (tnc *trieNodesCache) Put(key, val []byte) error {
+	err := tnc.db.Put(key, val)
+	if err != nil {
+		return err
+	}
+
+	tnc.cache.Put(key, val)
+
+	return nil
+}
+
+// Get returns the encoded node from the cache or, if it is not cached, from the database
+func (tnc *trieNodesCache) Get(key []byte) ([]byte, error) {
+	val, ok := tnc.getFromCache(key)
+	tnc.counters.RecordGet(ok)
+	if ok {
+		return val, nil
+	}
+
+	return tnc.getFromDb(key)
+}
+
+func (tnc *trieNodesCache) getFromCache(key []byte) ([]byte, bool) {
+	tnc.mutPinned.RLock()
+	val, ok := tnc.pinnedNodes[string(key)]
+	tnc.mutPinned.RUnlock()
+	if ok {
+		return val, true
+	}
+
+	cachedVal, ok := tnc.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	val, ok = cachedVal.([]byte)
+	return val, ok
+}
+
+func (tnc *trieNodesCache) getFromDb(key []byte) ([]byte, error) {
+	val, err := tnc.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	tnc.cache.Put(key, val)
+
+	return val, nil
+}
+
+// Remove removes the node from the cache and from the database
+func (tnc *trieNodesCache) Remove(key []byte) error {
+	tnc.mutPinned.Lock()
+	delete(tnc.pinnedNodes, string(key))
+	tnc.mutPinned.Unlock()
+
+	tnc.cache.Remove(key)
+
+	return tnc.db.Remove(key)
+}
+
+// PinTopLevels keeps in memory, outside of the byte budget, the nodes placed on the top levels of the trie having the
+// given root hash. The nodes pinned for the previous root are moved to the budgeted part of the cache
+func (tnc *trieNodesCache) PinTopLevels(rootHash []byte) error {
+	newPinnedNodes := make(map[string][]byte)
+	if len(rootHash) == 0 || bytes.Equal(rootHash, emptyTrieHash) {
+		tnc.replacePinnedNodes(newPinnedNodes)
+		return nil
+	}
+
+	hashes := [][]byte{rootHash}
+	for level := uint32(0); level < tnc.numPinnedLevels && len(hashes) > 0; level++ {
+		nextHashes := make([][]byte, 0)
+		for _, hash := range hashes {
+			encodedNode, ok := tnc.getFromCache(hash)
+			if !ok {
+				var err error
+				encodedNode, err = tnc.db.Get(hash)
+				if err != nil {
+					return err
+				}
+			}
+
+			n, err := decodeNode(encodedNode, tnc.marshalizer, tnc.hasher)
+			if err != nil {
+				return err
+			}
+
+			newPinnedNodes[string(hash)] = encodedNode
+			nextHashes = append(nextHashes, n.getChildrenHashes()...)
+		}
+		hashes = nextHashes
+	}
+
+	tnc.replacePinnedNodes(newPinnedNodes)
+
+	return nil
+}
+
+func (tnc *trieNodesCache) replacePinnedNodes(newPinnedNodes map[string][]byte) {
+	tnc.mutPinned.Lock()
+	oldPinnedNodes := tnc.pinnedNodes
+	tnc.pinnedNodes = newPinnedNodes
+	tnc.mutPinned.Unlock()
+
+	for hash, encodedNode := range oldPinnedNodes {
+		if _, ok := newPinnedNodes[hash]; ok {
+			continue
+		}
+
+		tnc.cache.Put([]byte(hash), encodedNode)
+	}
+}
+
+// NumPinnedNodes returns the number of nodes kept outside of the byte budget
+func (tnc *trieNodesCache) NumPinnedNodes() int {
+	tnc.mutPinned.RLock()
+	defer tnc.mutPinned.RUnlock()
+
+	return len(tnc.pinnedNodes)
+}
+
+// Stats returns the counters of the cache. The lookups answered by the pinned nodes are counted as hits
+func (tnc *trieNodesCache) Stats() storage.CacheStats {
+	stats := tnc.cache.Stats()
+	lookups := tnc.counters.Stats()
+	stats.NumGets = lookups.NumGets
+	stats.NumHits = lookups.NumHits
+	stats.NumMisses = lookups.NumMisses
+
+	return stats
+}
+
+// Flush makes the writes buffered by the database durable, if the database supports it
+func (tnc *trieNodesCache) Flush() error {
+	flusher, ok := tnc.db.(storage.Flusher)
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush()
+}
+
+// Close closes the database
+func (tnc *trieNodesCache) Close() error {
+	return tnc.db.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tnc *trieNodesCache) IsInterfaceNil() bool {
+	return tnc == nil
+}
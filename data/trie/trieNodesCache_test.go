@@ -0,0 +1,216 @@
+package trie_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCacheSizeInBytes = 1024 * 1024
+
+type trieNodesCacheHandler interface {
+	data.DBWriteCacher
+	PinTopLevels(rootHash []byte) error
+	NumPinnedNodes() int
+	Stats() storage.CacheStats
+}
+
+func createTrieNodesCache(t *testing.T, sizeInBytes uint64, numPinnedLevels uint32) (trieNodesCacheHandler, *countingDbMock) {
+	db := &countingDbMock{MemDbMock: mock.NewMemDbMock()}
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	cache, err := trie.NewTrieNodesCache(db, sizeInBytes, numPinnedLevels, marshalizer, hasher)
+	require.Nil(t, err)
+
+	return cache, db
+}
+
+func createTrieOverCache(t *testing.T, cache data.DBWriteCacher, nrValues int) (data.Trie, []byte) {
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	trieStorage, _ := trie.NewTrieStorageManagerWithoutPruning(cache)
+	tr, _ := trie.NewTrie(trieStorage, marshalizer, hasher)
+
+	_, values := initTrieMultipleValues(nrValues)
+	for _, value := range values {
+		_ = tr.Update(value, value)
+	}
+	err := tr.Commit()
+	require.Nil(t, err)
+	rootHash, _ := tr.Root()
+
+	return tr, rootHash
+}
+
+func TestNewTrieNodesCache_InvalidArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, marshalizer, hasher := getDefaultTrieParameters()
+	db := mock.NewMemDbMock()
+
+	cache, err := trie.NewTrieNodesCache(nil, testCacheSizeInBytes, 2, marshalizer, hasher)
+	assert.True(t, check.IfNil(cache))
+	assert.Equal(t, trie.ErrNilDatabase, err)
+
+	cache, err = trie.NewTrieNodesCache(db, testCacheSizeInBytes, 2, nil, hasher)
+	assert.True(t, check.IfNil(cache))
+	assert.Equal(t, trie.ErrNilMarshalizer, err)
+
+	cache, err = trie.NewTrieNodesCache(db, testCacheSizeInBytes, 2, marshalizer, nil)
+	assert.True(t, check.IfNil(cache))
+	assert.Equal(t, trie.ErrNilHasher, err)
+
+	cache, err = trie.NewTrieNodesCache(db, 0, 2, marshalizer, hasher)
+	assert.True(t, check.IfNil(cache))
+	assert.Equal(t, storage.ErrInvalidCacheSizeInBytes, err)
+}
+
+func TestTrieNodesCache_GetShouldReadTheDatabaseOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	cache, db := createTrieNodesCache(t, testCacheSizeInBytes, 0)
+	_ = db.MemDbMock.Put([]byte("key"), []byte("value"))
+
+	for i := 0; i < 3; i++ {
+		val, err := cache.Get([]byte("key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), val)
+	}
+
+	_, err := cache.Get([]byte("missing key"))
+	assert.NotNil(t, err)
+
+	assert.Equal(t, uint32(2), atomic.LoadUint32(&db.numGets))
+	stats := cache.Stats()
+	assert.Equal(t, uint64(4), stats.NumGets)
+	assert.Equal(t, uint64(2), stats.NumHits)
+	assert.Equal(t, uint64(2), stats.NumMisses)
+}
+
+func TestTrieNodesCache_PutAndRemoveShouldUpdateTheDatabaseAndTheCache(t *testing.T) {
+	t.Parallel()
+
+	cache, db := createTrieNodesCache(t, testCacheSizeInBytes, 0)
+
+	err := cache.Put([]byte("key"), []byte("value"))
+	assert.Nil(t, err)
+	val, _ := db.MemDbMock.Get([]byte("key"))
+	assert.Equal(t, []byte("value"), val)
+	val, _ = cache.Get([]byte("key"))
+	assert.Equal(t, []byte("value"), val)
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&db.numGets))
+
+	err = cache.Remove([]byte("key"))
+	assert.Nil(t, err)
+	_, err = db.MemDbMock.Get([]byte("key"))
+	assert.NotNil(t, err)
+	_, err = cache.Get([]byte("key"))
+	assert.NotNil(t, err)
+}
+
+func TestTrieNodesCache_ShouldKeepWithinTheBudget(t *testing.T) {
+	t.Parallel()
+
+	cache, db := createTrieNodesCache(t, 1000, 0)
+	value := make([]byte, 90)
+	for i := 0; i < 100; i++ {
+		_ = cache.Put([]byte(fmt.Sprintf("key%03d", i)), value)
+	}
+
+	_, _ = cache.Get([]byte("key099"))
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&db.numGets))
+	_, _ = cache.Get([]byte("key000"))
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&db.numGets))
+	assert.True(t, cache.Stats().NumEvictions >= 90)
+}
+
+func TestTrieNodesCache_PinTopLevelsShouldKeepTheTopNodesOutsideTheBudget(t *testing.T) {
+	t.Parallel()
+
+	cache, db := createTrieNodesCache(t, 2000, 2)
+	tr, rootHash := createTrieOverCache(t, cache, 1000)
+
+	err := cache.PinTopLevels(rootHash)
+	require.Nil(t, err)
+
+	collapsedTrie, _ := tr.Recreate(rootHash)
+	it, _ := trie.NewIterator(collapsedTrie)
+	expectedPinnedHashes := make([][]byte, 0)
+	rootNodeHash, _ := it.GetHash()
+	expectedPinnedHashes = append(expectedPinnedHashes, rootNodeHash)
+	numRootChildren := 16
+	for i := 0; i < numRootChildren && it.HasNext(); i++ {
+		_ = it.Next()
+		hash, _ := it.GetHash()
+		expectedPinnedHashes = append(expectedPinnedHashes, hash)
+	}
+	require.Equal(t, len(expectedPinnedHashes), cache.NumPinnedNodes())
+
+	for i := 0; i < 100; i++ {
+		_ = cache.Put([]byte(fmt.Sprintf("key%03d", i)), make([]byte, 100))
+	}
+	atomic.StoreUint32(&db.numGets, 0)
+	for _, hash := range expectedPinnedHashes {
+		_, err = cache.Get(hash)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&db.numGets))
+}
+
+func TestTrieNodesCache_PinTopLevelsShouldReplaceThePinnedNodes(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := createTrieNodesCache(t, testCacheSizeInBytes, 3)
+	tr, rootHash := createTrieOverCache(t, cache, 100)
+
+	err := cache.PinTopLevels(rootHash)
+	require.Nil(t, err)
+	numPinnedNodes := cache.NumPinnedNodes()
+	assert.True(t, numPinnedNodes > 1)
+
+	_ = tr.Update([]byte("new key"), []byte("new value"))
+	_ = tr.Commit()
+	newRootHash, _ := tr.Root()
+	err = cache.PinTopLevels(newRootHash)
+	require.Nil(t, err)
+	assert.True(t, cache.NumPinnedNodes() >= numPinnedNodes)
+
+	err = cache.PinTopLevels(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.NumPinnedNodes())
+}
+
+func TestTrieNodesCache_PinTopLevelsMissingRootShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := createTrieNodesCache(t, testCacheSizeInBytes, 2)
+
+	err := cache.PinTopLevels([]byte("missing root hash"))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, cache.NumPinnedNodes())
+}
+
+func TestTrieNodesCache_TrieOverTheCacheShouldWork(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := createTrieNodesCache(t, 5000, 2)
+	tr, rootHash := createTrieOverCache(t, cache, 500)
+	_ = cache.PinTopLevels(rootHash)
+
+	expectedTrie, _ := initTrieMultipleValues(500)
+	expectedLeaves, _ := expectedTrie.GetAllLeaves()
+	collapsedTrie, err := tr.Recreate(rootHash)
+	require.Nil(t, err)
+	leaves, err := collapsedTrie.GetAllLeaves()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLeaves, leaves)
+	assert.True(t, cache.Stats().NumHits > 0)
+}
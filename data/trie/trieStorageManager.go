@@ -29,6 +29,7 @@ type trieStorageManager struct {
 	snapshotId      int
 	snapshotDbCfg   *config.DBConfig
 	snapshotsBuffer snapshotsBuffer
+	encryptionKey   []byte
 
 	dbEvictionWaitingList data.DBRemoveCacher
 	storageOperationMutex sync.RWMutex
@@ -36,6 +37,17 @@ type trieStorageManager struct {
 
 // NewTrieStorageManager creates a new instance of trieStorageManager
 func NewTrieStorageManager(db data.DBWriteCacher, snapshotDbCfg *config.DBConfig, ewl data.DBRemoveCacher) (*trieStorageManager, error) {
+	return NewTrieStorageManagerWithEncryption(db, snapshotDbCfg, ewl, nil)
+}
+
+// NewTrieStorageManagerWithEncryption creates a new instance of trieStorageManager which encrypts the values written
+// in the snapshot databases with the provided key. A nil key leaves the snapshots unencrypted
+func NewTrieStorageManagerWithEncryption(
+	db data.DBWriteCacher,
+	snapshotDbCfg *config.DBConfig,
+	ewl data.DBRemoveCacher,
+	encryptionKey []byte,
+) (*trieStorageManager, error) {
 	if check.IfNil(db) {
 		return nil, ErrNilDatabase
 	}
@@ -46,7 +58,7 @@ func NewTrieStorageManager(db data.DBWriteCacher, snapshotDbCfg *config.DBConfig
 		return nil, ErrNilSnapshotDbConfig
 	}
 
-	snapshots, snapshotId, err := getSnapshotsAndSnapshotId(snapshotDbCfg)
+	snapshots, snapshotId, err := getSnapshotsAndSnapshotId(snapshotDbCfg, encryptionKey)
 	if err != nil {
 		log.Debug("get snapshot", "error", err.Error())
 	}
@@ -58,11 +70,12 @@ func NewTrieStorageManager(db data.DBWriteCacher, snapshotDbCfg *config.DBConfig
 		snapshotId:            snapshotId,
 		snapshotDbCfg:         snapshotDbCfg,
 		snapshotsBuffer:       newSnapshotsQueue(),
+		encryptionKey:         encryptionKey,
 		dbEvictionWaitingList: ewl,
 	}, nil
 }
 
-func getSnapshotsAndSnapshotId(snapshotDbCfg *config.DBConfig) ([]storage.Persister, int, error) {
+func getSnapshotsAndSnapshotId(snapshotDbCfg *config.DBConfig, encryptionKey []byte) ([]storage.Persister, int, error) {
 	snapshots := make([]storage.Persister, 0)
 	snapshotId := 0
 
@@ -87,13 +100,7 @@ func getSnapshotsAndSnapshotId(snapshotDbCfg *config.DBConfig) ([]storage.Persis
 		}
 
 		var db storage.Persister
-		db, err = storageUnit.NewDB(
-			storageUnit.DBType(snapshotDbCfg.Type),
-			path.Join(snapshotDbCfg.FilePath, f.Name()),
-			snapshotDbCfg.BatchDelaySeconds,
-			snapshotDbCfg.MaxBatchSize,
-			snapshotDbCfg.MaxOpenFiles,
-		)
+		db, err = newSnapshotPersister(snapshotDbCfg, path.Join(snapshotDbCfg.FilePath, f.Name()), encryptionKey)
 		if err != nil {
 			return snapshots, snapshotId, err
 		}
@@ -139,6 +146,7 @@ func (tsm *trieStorageManager) Clone() data.StorageManager {
 		snapshotId:            tsm.snapshotId,
 		snapshotDbCfg:         tsm.snapshotDbCfg,
 		snapshotsBuffer:       tsm.snapshotsBuffer.clone(),
+		encryptionKey:         tsm.encryptionKey,
 		dbEvictionWaitingList: tsm.dbEvictionWaitingList,
 	}
 }
@@ -410,13 +418,7 @@ func (tsm *trieStorageManager) newSnapshotDb() (storage.Persister, error) {
 		snapshotPath = path.Join(tsm.snapshotDbCfg.FilePath, strconv.Itoa(tsm.snapshotId))
 	}
 
-	db, err := storageUnit.NewDB(
-		storageUnit.DBType(tsm.snapshotDbCfg.Type),
-		snapshotPath,
-		tsm.snapshotDbCfg.BatchDelaySeconds,
-		tsm.snapshotDbCfg.MaxBatchSize,
-		tsm.snapshotDbCfg.MaxOpenFiles,
-	)
+	db, err := newSnapshotPersister(tsm.snapshotDbCfg, snapshotPath, tsm.encryptionKey)
 	if err != nil {
 		return nil, err
 	}
@@ -427,6 +429,17 @@ func (tsm *trieStorageManager) newSnapshotDb() (storage.Persister, error) {
 	return db, nil
 }
 
+func newSnapshotPersister(snapshotDbCfg *config.DBConfig, snapshotPath string, encryptionKey []byte) (storage.Persister, error) {
+	return storageUnit.NewDBFromConf(storageUnit.DBConfig{
+		FilePath:          snapshotPath,
+		Type:              storageUnit.DBType(snapshotDbCfg.Type),
+		BatchDelaySeconds: snapshotDbCfg.BatchDelaySeconds,
+		MaxBatchSize:      snapshotDbCfg.MaxBatchSize,
+		MaxOpenFiles:      snapshotDbCfg.MaxOpenFiles,
+		EncryptionKey:     encryptionKey,
+	})
+}
+
 func directoryExists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
@@ -15,6 +15,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/mock"
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +91,55 @@ func TestNewTrieStorageManagerWithExistingSnapshot(t *testing.T) {
 	assert.Equal(t, 1, newTrieStorage.snapshotId)
 }
 
+func TestNewTrieStorageManagerWithEncryptionShouldEncryptTheSnapshots(t *testing.T) {
+	t.Parallel()
+
+	tempDir, _ := ioutil.TempDir("", "leveldb_temp")
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	cfg := &config.DBConfig{
+		FilePath:          tempDir,
+		Type:              string(storageUnit.LvlDbSerial),
+		BatchDelaySeconds: 1,
+		MaxBatchSize:      1,
+		MaxOpenFiles:      10,
+	}
+	encryptionKey := make([]byte, encryption.KeySize)
+
+	db := mock.NewMemDbMock()
+	msh, hsh := getTestMarshAndHasher()
+	evictionWaitList, _ := mock.NewEvictionWaitingList(100, mock.NewMemDbMock(), msh)
+	trieStorage, _ := NewTrieStorageManagerWithEncryption(db, cfg, evictionWaitList, encryptionKey)
+	tr, _ := NewTrie(trieStorage, msh, hsh)
+
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	_ = tr.Update([]byte("dog"), []byte("puppy"))
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	encodedRoot, _ := db.Get(rootHash)
+	tr.TakeSnapshot(rootHash)
+
+	for trieStorage.snapshotsBuffer.len() != 0 {
+		time.Sleep(snapshotDelay)
+	}
+	_ = trieStorage.snapshots[0].Close()
+
+	rawSnapshot, err := storageUnit.NewDB(storageUnit.LvlDbSerial, path.Join(tempDir, "0"), 1, 1, 10)
+	require.Nil(t, err)
+	storedRoot, err := rawSnapshot.Get(rootHash)
+	assert.Nil(t, err)
+	assert.NotEqual(t, encodedRoot, storedRoot)
+	_ = rawSnapshot.Close()
+
+	newTrieStorage, _ := NewTrieStorageManagerWithEncryption(memorydb.New(), cfg, evictionWaitList, encryptionKey)
+	snapshot := newTrieStorage.GetDbThatContainsHash(rootHash)
+	require.NotNil(t, snapshot)
+	readRoot, err := snapshot.Get(rootHash)
+	assert.Nil(t, err)
+	assert.Equal(t, encodedRoot, readRoot)
+}
+
 func TestTrieStorageManager_Clone(t *testing.T) {
 	t.Parallel()
 
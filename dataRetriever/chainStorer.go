@@ -1,6 +1,7 @@
 package dataRetriever
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/storage"
@@ -145,6 +146,28 @@ func (bc *ChainStorer) CloseAll() error {
 	return storage.ErrClosingPersisters
 }
 
+// FlushAll makes durable the buffered writes of the units configured to be flushed when a block is committed.
+// All the units are flushed even if some of them fail, and the first error is returned
+func (bc *ChainStorer) FlushAll() error {
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+
+	var firstErr error
+	for unitType, unit := range bc.chain {
+		flusher, ok := unit.(storage.Flusher)
+		if !ok {
+			continue
+		}
+
+		err := flusher.Flush()
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%w for unit %d", err, unitType)
+		}
+	}
+
+	return firstErr
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (bc *ChainStorer) IsInterfaceNil() bool {
 	return bc == nil
@@ -212,3 +212,40 @@ func TestCloseAll_Ok(t *testing.T) {
 	err := b.CloseAll()
 	require.Nil(t, err)
 }
+
+func TestFlushAll_ShouldFlushAllTheUnitsAndReturnTheError(t *testing.T) {
+	t.Parallel()
+
+	flushErr := errors.New("error")
+	numFlushed := 0
+	failing := &mock.StorerStub{
+		FlushCalled: func() error {
+			numFlushed++
+			return flushErr
+		},
+	}
+	working := &mock.StorerStub{
+		FlushCalled: func() error {
+			numFlushed++
+			return nil
+		},
+	}
+
+	b := dataRetriever.NewChainStorer()
+	b.AddStorer(1, failing)
+	b.AddStorer(2, working)
+
+	err := b.FlushAll()
+	require.True(t, errors.Is(err, flushErr))
+	require.Equal(t, 2, numFlushed)
+}
+
+func TestFlushAll_Ok(t *testing.T) {
+	t.Parallel()
+
+	b := dataRetriever.NewChainStorer()
+	b.AddStorer(1, &mock.StorerStub{})
+
+	err := b.FlushAll()
+	require.Nil(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 type headersCache struct {
@@ -17,6 +18,8 @@ type headersCache struct {
 
 	numHeadersToRemove int
 	maxHeadersPerShard int
+
+	counters storage.CacheCounters
 }
 
 func newHeadersCache(numMaxHeaderPerShard int, numHeadersToRemove int) *headersCache {
@@ -49,6 +52,7 @@ func (cache *headersCache) addHeader(headerHash []byte, header data.HeaderHandle
 	shard.appendHeaderToList(headerHash, header)
 
 	cache.headersCounter.increment(headerShardId)
+	cache.counters.RecordPut(storage.EstimateSize(header))
 
 	return true
 }
@@ -78,6 +82,8 @@ func (cache *headersCache) lruEviction(shardId uint32) {
 			break
 		}
 	}
+
+	cache.counters.RecordEvictions(numHashes)
 }
 
 func (cache *headersCache) getShardMap(shardId uint32) listOfHeadersByNonces {
@@ -7,6 +7,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 var log = logger.GetOrCreate("dataRetriever/headersCache")
@@ -93,6 +94,7 @@ func (pool *headersPool) GetHeadersByNonceAndShardId(hdrNonce uint64, shardId ui
 	defer pool.mutHeadersPool.Unlock()
 
 	headers, hashes, ok := pool.cache.getHeadersAndHashesByNonceAndShardId(hdrNonce, shardId)
+	pool.cache.counters.RecordGet(ok)
 	if !ok {
 		return nil, nil, ErrHeaderNotFound
 	}
@@ -105,7 +107,10 @@ func (pool *headersPool) GetHeaderByHash(hash []byte) (data.HeaderHandler, error
 	pool.mutHeadersPool.Lock()
 	defer pool.mutHeadersPool.Unlock()
 
-	return pool.cache.getHeaderByHash(hash)
+	header, err := pool.cache.getHeaderByHash(hash)
+	pool.cache.counters.RecordGet(err == nil)
+
+	return header, err
 }
 
 // GetNumHeaders will return how many header are in pool for a specific shard
@@ -160,6 +165,11 @@ func (pool *headersPool) MaxSize() int {
 	return pool.cache.maxHeadersPerShard
 }
 
+// Stats returns the counters of the operations done on the pool
+func (pool *headersPool) Stats() storage.CacheStats {
+	return pool.cache.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (pool *headersPool) IsInterfaceNil() bool {
 	return pool == nil
@@ -624,3 +624,27 @@ func createASliceOfHeadersNonce0(numHeaders int, shardId uint32) ([]block.Header
 
 	return headers, headersHashes
 }
+
+func TestHeadersPool_StatsShouldCountOperations(t *testing.T) {
+	t.Parallel()
+
+	headersCacher, _ := headersCache.NewHeadersPool(
+		config.HeadersPoolConfig{
+			MaxHeadersPerShard:            2,
+			NumElementsToRemoveOnEviction: 1},
+	)
+
+	for i := uint64(0); i < 3; i++ {
+		headersCacher.AddHeader([]byte(fmt.Sprintf("hash%d", i)), &block.Header{Nonce: i})
+	}
+	_, _ = headersCacher.GetHeaderByHash([]byte("hash0"))
+	_, _ = headersCacher.GetHeaderByHash([]byte("hash2"))
+	_, _, _ = headersCacher.GetHeadersByNonceAndShardId(1, 0)
+
+	stats := headersCacher.Stats()
+	assert.Equal(t, uint64(3), stats.NumGets)
+	assert.Equal(t, uint64(2), stats.NumHits)
+	assert.Equal(t, uint64(1), stats.NumMisses)
+	assert.Equal(t, uint64(3), stats.NumPuts)
+	assert.Equal(t, uint64(1), stats.NumEvictions)
+}
@@ -37,6 +37,8 @@ const (
 	BootstrapUnit UnitType = 10
 	//StatusMetricsUnit is the status metrics storage unit identifier
 	StatusMetricsUnit UnitType = 11
+	// TransactionMetadataUnit is the transaction metadata (inclusion location and generated results) unit identifier
+	TransactionMetadataUnit UnitType = 12
 
 	// ShardHdrNonceHashDataUnit is the header nonce-hash pair data unit identifier
 	//TODO: Add only unit types lower than 100
@@ -257,6 +259,8 @@ type StorageService interface {
 	Destroy() error
 	//CloseAll will close all the units
 	CloseAll() error
+	// FlushAll makes durable the buffered writes of the units configured to be flushed when a block is committed
+	FlushAll() error
 	// IsInterfaceNil returns true if there is no value under the interface
 	IsInterfaceNil() bool
 }
@@ -1,5 +1,9 @@
 package mock
 
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
 // StorerStub -
 type StorerStub struct {
 	PutCalled          func(key, data []byte) error
@@ -8,6 +12,7 @@ type StorerStub struct {
 	HasCalled          func(key []byte) error
 	HasInEpochCalled   func(key []byte, epoch uint32) error
 	SearchFirstCalled  func(key []byte) ([]byte, error)
+	IterateCalled      func(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error
 	RemoveCalled       func(key []byte) error
 	ClearCacheCalled   func()
 	DestroyUnitCalled  func() error
@@ -28,6 +33,15 @@ func (ss *StorerStub) SearchFirst(key []byte) ([]byte, error) {
 	return ss.SearchFirstCalled(key)
 }
 
+// Iterate -
+func (ss *StorerStub) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	if ss.IterateCalled != nil {
+		return ss.IterateCalled(keyRange, handler)
+	}
+
+	return nil
+}
+
 // Close -
 func (ss *StorerStub) Close() error {
 	return nil
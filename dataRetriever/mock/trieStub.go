@@ -41,6 +41,16 @@ func (ts *TrieStub) GetAllLeaves() (map[string][]byte, error) {
 	return make(map[string][]byte), nil
 }
 
+// IterateLeaves -
+func (ts *TrieStub) IterateLeaves(_ []byte, _ []byte, _ func(key []byte, value []byte) bool) error {
+	return nil
+}
+
+// Diff -
+func (ts *TrieStub) Diff(_ data.Trie, _ func(change data.LeafChange) bool) error {
+	return nil
+}
+
 // IsPruningEnabled -
 func (ts *TrieStub) IsPruningEnabled() bool {
 	return false
@@ -56,7 +56,7 @@ func verifyCacherConfig(cacherConfig storageUnit.CacheConfig) error {
 
 // newShardStore is responsible for creating an empty shardStore
 func newShardStore(cacheId string, cacherConfig storageUnit.CacheConfig) (*shardStore, error) {
-	cacher, err := storageUnit.NewCache(cacherConfig.Type, cacherConfig.Size, cacherConfig.Shards)
+	cacher, err := storageUnit.NewCacheFromConfig(cacherConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -224,6 +224,26 @@ func (sd *shardedData) RegisterHandler(handler func(key []byte)) {
 	sd.mutAddedDataHandlers.Unlock()
 }
 
+// Stats returns the sum of the counters of the shard stores currently in the pool
+func (sd *shardedData) Stats() storage.CacheStats {
+	sd.mutShardedDataStore.RLock()
+	defer sd.mutShardedDataStore.RUnlock()
+
+	stats := storage.CacheStats{}
+	for _, store := range sd.shardedDataStore {
+		if store == nil {
+			continue
+		}
+
+		statsHandler, ok := store.DataStore.(storage.CacheStatsHandler)
+		if ok {
+			stats = stats.Add(statsHandler.Stats())
+		}
+	}
+
+	return stats
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (sd *shardedData) IsInterfaceNil() bool {
 	return sd == nil
@@ -241,6 +241,19 @@ func (txPool *shardedTxPool) RegisterHandler(handler func(key []byte)) {
 	txPool.mutexAddCallbacks.Unlock()
 }
 
+// Stats returns the sum of the counters of the transaction caches currently in the pool
+func (txPool *shardedTxPool) Stats() storage.CacheStats {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+
+	stats := storage.CacheStats{}
+	for _, shard := range txPool.backingMap {
+		stats = stats.Add(shard.Cache.Stats())
+	}
+
+	return stats
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (txPool *shardedTxPool) IsInterfaceNil() bool {
 	return txPool == nil
@@ -16,6 +16,7 @@ type ChainStorerStub struct {
 	GetAllCalled    func(unitType dataRetriever.UnitType, keys [][]byte) (map[string][]byte, error)
 	DestroyCalled   func() error
 	CloseAllCalled  func() error
+	FlushAllCalled  func() error
 }
 
 // CloseAll -
@@ -26,6 +27,14 @@ func (bc *ChainStorerStub) CloseAll() error {
 	return nil
 }
 
+// FlushAll -
+func (bc *ChainStorerStub) FlushAll() error {
+	if bc.FlushAllCalled != nil {
+		return bc.FlushAllCalled()
+	}
+	return nil
+}
+
 // AddStorer will add a new storer to the chain map
 func (bc *ChainStorerStub) AddStorer(key dataRetriever.UnitType, s storage.Storer) {
 	if bc.AddStorerCalled != nil {
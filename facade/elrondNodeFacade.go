@@ -5,7 +5,9 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/api"
 	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	apiData "github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/logger"
@@ -173,17 +175,93 @@ func (ef *ElrondNodeFacade) SendBulkTransactions(txs []*transaction.Transaction)
 	return ef.node.SendBulkTransactions(txs)
 }
 
-// GetTransaction gets the transaction with a specified hash
-func (ef *ElrondNodeFacade) GetTransaction(hash string) (*transaction.Transaction, error) {
+// GetTransaction gets the transaction with a specified hash, together with its status
+func (ef *ElrondNodeFacade) GetTransaction(hash string) (*apiData.Transaction, error) {
 	return ef.node.GetTransaction(hash)
 }
 
+// SimulateTransactionExecution executes the provided transaction on a copy of the current state and returns
+// its outcome, without broadcasting it
+func (ef *ElrondNodeFacade) SimulateTransactionExecution(tx *transaction.Transaction) (*apiData.SimulationResults, error) {
+	return ef.apiResolver.SimulateTransactionExecution(tx)
+}
+
+// ComputeTransactionGasLimit estimates the gas limit needed by the provided transaction
+func (ef *ElrondNodeFacade) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	return ef.apiResolver.ComputeTransactionGasLimit(tx)
+}
+
+// GetProof returns the Merkle proof of an account against the given state root hash and, if a data trie key is
+// provided, the Merkle proof of that key against the account's data trie root hash
+func (ef *ElrondNodeFacade) GetProof(rootHash string, address string, key string) (*apiData.AccountProof, error) {
+	return ef.apiResolver.GetProof(rootHash, address, key)
+}
+
+// GetProofByHeaderNonce returns the Merkle proof of an account against the root hash of the header having the given
+// nonce, together with the header hash
+func (ef *ElrondNodeFacade) GetProofByHeaderNonce(nonce uint64, address string, key string) (*apiData.AccountProof, error) {
+	return ef.apiResolver.GetProofByHeaderNonce(nonce, address, key)
+}
+
+// GetProofByHeaderHash returns the Merkle proof of an account against the root hash of the header having the given
+// hash
+func (ef *ElrondNodeFacade) GetProofByHeaderHash(headerHash string, address string, key string) (*apiData.AccountProof, error) {
+	return ef.apiResolver.GetProofByHeaderHash(headerHash, address, key)
+}
+
 // GetAccount returns an accountResponse containing information
 // about the account correlated with provided address
 func (ef *ElrondNodeFacade) GetAccount(address string) (*state.Account, error) {
 	return ef.node.GetAccount(address)
 }
 
+// GetAccountAtBlockNonce returns the account correlated with the provided address, as it was after the block having
+// the provided nonce
+func (ef *ElrondNodeFacade) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return ef.apiResolver.GetAccountAtBlockNonce(address, blockNonce)
+}
+
+// GetValueForKey returns the hex encoded value stored under the provided hex encoded key in the account's data trie
+func (ef *ElrondNodeFacade) GetValueForKey(address string, key string) (string, error) {
+	return ef.node.GetValueForKey(address, key)
+}
+
+// GetKeyValuePairs returns at most maxNumKeys entries of the account's data trie, resuming from the continuation token
+func (ef *ElrondNodeFacade) GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*apiData.AccountKeyValuePairs, error) {
+	return ef.node.GetKeyValuePairs(address, continuationToken, maxNumKeys)
+}
+
+// SubscribeToEvents registers a new subscriber for the committed blocks and the transactions touching the
+// provided addresses
+func (ef *ElrondNodeFacade) SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error) {
+	return ef.node.SubscribeToEvents(addresses)
+}
+
+// UnsubscribeFromEvents removes the provided chain events subscriber
+func (ef *ElrondNodeFacade) UnsubscribeFromEvents(subscription *eventsNotifier.Subscription) {
+	ef.node.UnsubscribeFromEvents(subscription)
+}
+
+// GetBlockByNonce returns the block of the node's shard having the provided nonce
+func (ef *ElrondNodeFacade) GetBlockByNonce(nonce uint64) (*apiData.Block, error) {
+	return ef.node.GetBlockByNonce(nonce)
+}
+
+// GetBlockByHash returns the block of the node's shard having the provided hex encoded hash
+func (ef *ElrondNodeFacade) GetBlockByHash(hash string) (*apiData.Block, error) {
+	return ef.node.GetBlockByHash(hash)
+}
+
+// GetHyperBlockByNonce returns the metablock having the provided nonce, together with the notarized shard blocks
+func (ef *ElrondNodeFacade) GetHyperBlockByNonce(nonce uint64) (*apiData.Block, error) {
+	return ef.node.GetHyperBlockByNonce(nonce)
+}
+
+// GetHyperBlockByHash returns the metablock having the provided hex encoded hash, together with the notarized shard blocks
+func (ef *ElrondNodeFacade) GetHyperBlockByHash(hash string) (*apiData.Block, error) {
+	return ef.node.GetHyperBlockByHash(hash)
+}
+
 // GetHeartbeats returns the heartbeat status for each public key from initial list or later joined to the network
 func (ef *ElrondNodeFacade) GetHeartbeats() ([]heartbeat.PubKeyHeartbeat, error) {
 	hbStatus := ef.node.GetHeartbeats()
@@ -204,6 +282,11 @@ func (ef *ElrondNodeFacade) ExecuteSCQuery(query *process.SCQuery) (*vmcommon.VM
 	return ef.apiResolver.ExecuteSCQuery(query)
 }
 
+// ExecuteSCQueryAtBlockNonce retrieves data from existing SC trie, on the state of the block having the provided nonce
+func (ef *ElrondNodeFacade) ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	return ef.apiResolver.ExecuteSCQueryAtBlockNonce(query, blockNonce)
+}
+
 // PprofEnabled returns if profiling mode should be active or not on the application
 func (ef *ElrondNodeFacade) PprofEnabled() bool {
 	return ef.config.PprofEnabled
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	apiData "github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/facade/mock"
@@ -178,10 +180,9 @@ func TestElrondFacade_GetBalanceWithErrorOnNodeShouldReturnZeroBalanceAndError(t
 
 func TestElrondFacade_GetTransactionWithValidInputsShouldNotReturnError(t *testing.T) {
 	testHash := "testHash"
-	testTx := &transaction.Transaction{}
-	//testTx.
+	testTx := &apiData.Transaction{}
 	node := &mock.NodeMock{
-		GetTransactionHandler: func(hash string) (*transaction.Transaction, error) {
+		GetTransactionHandler: func(hash string) (*apiData.Transaction, error) {
 			if hash == testHash {
 				return testTx, nil
 			}
@@ -209,9 +210,9 @@ func TestElrondNodeFacade_SetAndGetTpsBenchmark(t *testing.T) {
 
 func TestElrondFacade_GetTransactionWithUnknowHashShouldReturnNilAndNoError(t *testing.T) {
 	testHash := "testHash"
-	testTx := &transaction.Transaction{}
+	testTx := &apiData.Transaction{}
 	node := &mock.NodeMock{
-		GetTransactionHandler: func(hash string) (*transaction.Transaction, error) {
+		GetTransactionHandler: func(hash string) (*apiData.Transaction, error) {
 			if hash == testHash {
 				return testTx, nil
 			}
@@ -407,6 +408,198 @@ func TestElrondNodeFacade_StatusMetrics(t *testing.T) {
 	assert.True(t, apiResolverMetricsRequested)
 }
 
+func TestElrondNodeFacade_SimulateTransactionExecution(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := &apiData.SimulationResults{GasUsed: 37}
+	nodeMock := &mock.NodeMock{}
+	apiResStub := &mock.ApiResolverStub{
+		SimulateTransactionExecutionHandler: func(tx *transaction.Transaction) (*apiData.SimulationResults, error) {
+			return expectedResults, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, apiResStub, false)
+
+	results, err := ef.SimulateTransactionExecution(&transaction.Transaction{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResults, results)
+}
+
+func TestElrondNodeFacade_GetValueForKey(t *testing.T) {
+	t.Parallel()
+
+	expectedValue := "0a0b"
+	nodeMock := &mock.NodeMock{
+		GetValueForKeyCalled: func(address string, key string) (string, error) {
+			return expectedValue, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, &mock.ApiResolverStub{}, false)
+
+	value, err := ef.GetValueForKey("address", "key")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, value)
+}
+
+func TestElrondNodeFacade_GetKeyValuePairs(t *testing.T) {
+	t.Parallel()
+
+	expectedPairs := &apiData.AccountKeyValuePairs{ContinuationToken: "0a"}
+	nodeMock := &mock.NodeMock{
+		GetKeyValuePairsCalled: func(address string, continuationToken string, maxNumKeys int) (*apiData.AccountKeyValuePairs, error) {
+			return expectedPairs, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, &mock.ApiResolverStub{}, false)
+
+	pairs, err := ef.GetKeyValuePairs("address", "", 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPairs, pairs)
+}
+
+func TestElrondNodeFacade_SubscribeToEvents(t *testing.T) {
+	t.Parallel()
+
+	expectedSubscription := &eventsNotifier.Subscription{}
+	nodeMock := &mock.NodeMock{
+		SubscribeToEventsCalled: func(addresses []string) (*eventsNotifier.Subscription, error) {
+			return expectedSubscription, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, &mock.ApiResolverStub{}, false)
+
+	subscription, err := ef.SubscribeToEvents([]string{"address"})
+
+	assert.Nil(t, err)
+	assert.True(t, subscription == expectedSubscription)
+}
+
+func TestElrondNodeFacade_UnsubscribeFromEvents(t *testing.T) {
+	t.Parallel()
+
+	subscription := &eventsNotifier.Subscription{}
+	var unsubscribed *eventsNotifier.Subscription
+	nodeMock := &mock.NodeMock{
+		UnsubscribeFromEventsCalled: func(s *eventsNotifier.Subscription) {
+			unsubscribed = s
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, &mock.ApiResolverStub{}, false)
+
+	ef.UnsubscribeFromEvents(subscription)
+
+	assert.True(t, unsubscribed == subscription)
+}
+
+func TestElrondNodeFacade_ComputeTransactionGasLimit(t *testing.T) {
+	t.Parallel()
+
+	expectedGasLimit := uint64(37)
+	nodeMock := &mock.NodeMock{}
+	apiResStub := &mock.ApiResolverStub{
+		ComputeTransactionGasLimitHandler: func(tx *transaction.Transaction) (uint64, error) {
+			return expectedGasLimit, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(nodeMock, apiResStub, false)
+
+	gasLimit, err := ef.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedGasLimit, gasLimit)
+}
+
+func TestElrondNodeFacade_GetProof(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &apiData.AccountProof{RootHash: "aa"}
+	apiResStub := &mock.ApiResolverStub{
+		GetProofHandler: func(rootHash string, address string, key string) (*apiData.AccountProof, error) {
+			return expectedProof, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(&mock.NodeMock{}, apiResStub, false)
+
+	proof, err := ef.GetProof("aa", "bb", "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+}
+
+func TestElrondNodeFacade_GetProofByHeader(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &apiData.AccountProof{HeaderHash: "cc", RootHash: "aa"}
+	apiResStub := &mock.ApiResolverStub{
+		GetProofByHeaderNonceHandler: func(nonce uint64, address string, key string) (*apiData.AccountProof, error) {
+			assert.Equal(t, uint64(10), nonce)
+			return expectedProof, nil
+		},
+		GetProofByHeaderHashHandler: func(headerHash string, address string, key string) (*apiData.AccountProof, error) {
+			assert.Equal(t, "cc", headerHash)
+			return expectedProof, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(&mock.NodeMock{}, apiResStub, false)
+
+	proof, err := ef.GetProofByHeaderNonce(10, "bb", "")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+
+	proof, err = ef.GetProofByHeaderHash("cc", "bb", "")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+}
+
+func TestElrondNodeFacade_GetAccountAtBlockNonce(t *testing.T) {
+	t.Parallel()
+
+	expectedAccount := &state.Account{Nonce: 7}
+	apiResStub := &mock.ApiResolverStub{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			assert.Equal(t, uint64(10), blockNonce)
+			return expectedAccount, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(&mock.NodeMock{}, apiResStub, false)
+
+	account, err := ef.GetAccountAtBlockNonce("aa", 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAccount, account)
+}
+
+func TestElrondNodeFacade_ExecuteSCQueryAtBlockNonce(t *testing.T) {
+	t.Parallel()
+
+	expectedVmOutput := &vmcommon.VMOutput{GasRemaining: 3}
+	apiResStub := &mock.ApiResolverStub{
+		ExecuteSCQueryAtBlockNonceHandler: func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+			assert.Equal(t, uint64(10), blockNonce)
+			return expectedVmOutput, nil
+		},
+	}
+
+	ef := NewElrondNodeFacade(&mock.NodeMock{}, apiResStub, false)
+
+	vmOutput, err := ef.ExecuteSCQueryAtBlockNonce(&process.SCQuery{}, 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedVmOutput, vmOutput)
+}
+
 func TestElrondNodeFacade_PprofEnabled(t *testing.T) {
 	t.Parallel()
 
@@ -444,3 +637,35 @@ func TestElrondNodeFacade_CreateTransaction(t *testing.T) {
 
 	assert.True(t, nodeCreateTxWasCalled)
 }
+
+func TestElrondNodeFacade_GetBlockByNonce(t *testing.T) {
+	t.Parallel()
+
+	expectedBlock := &apiData.Block{Nonce: 37}
+	nodeMock := &mock.NodeMock{
+		GetBlockByNonceCalled: func(nonce uint64) (*apiData.Block, error) {
+			return expectedBlock, nil
+		},
+	}
+	ef := createElrondNodeFacadeWithMockResolver(nodeMock)
+	apiBlock, err := ef.GetBlockByNonce(37)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, apiBlock)
+}
+
+func TestElrondNodeFacade_GetHyperBlockByHash(t *testing.T) {
+	t.Parallel()
+
+	expectedBlock := &apiData.Block{Hash: "aabb"}
+	nodeMock := &mock.NodeMock{
+		GetHyperBlockByHashCalled: func(hash string) (*apiData.Block, error) {
+			return expectedBlock, nil
+		},
+	}
+	ef := createElrondNodeFacadeWithMockResolver(nodeMock)
+	apiBlock, err := ef.GetHyperBlockByHash("aabb")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedBlock, apiBlock)
+}
@@ -3,6 +3,8 @@ package facade
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/external"
@@ -38,13 +40,37 @@ type NodeWrapper interface {
 	//SendBulkTransactions will send a bulk of transactions on the 'send transactions pipe' channel
 	SendBulkTransactions(txs []*transaction.Transaction) (uint64, error)
 
-	//GetTransaction gets the transaction
-	GetTransaction(hash string) (*transaction.Transaction, error)
+	//GetTransaction gets the transaction together with its status
+	GetTransaction(hash string) (*api.Transaction, error)
 
 	// GetAccount returns an accountResponse containing information
 	//  about the account corelated with provided address
 	GetAccount(address string) (*state.Account, error)
 
+	// GetValueForKey returns the hex encoded value stored under the hex encoded key in the account's data trie
+	GetValueForKey(address string, key string) (string, error)
+
+	// GetKeyValuePairs returns a page of the account's data trie entries, in ascending key order
+	GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error)
+
+	// SubscribeToEvents registers a new chain events subscriber watching the provided addresses
+	SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error)
+
+	// UnsubscribeFromEvents removes a chain events subscriber
+	UnsubscribeFromEvents(subscription *eventsNotifier.Subscription)
+
+	// GetBlockByNonce returns the block of the node's shard having the provided nonce
+	GetBlockByNonce(nonce uint64) (*api.Block, error)
+
+	// GetBlockByHash returns the block of the node's shard having the provided hex encoded hash
+	GetBlockByHash(hash string) (*api.Block, error)
+
+	// GetHyperBlockByNonce returns the metablock having the provided nonce, together with the notarized shard blocks
+	GetHyperBlockByNonce(nonce uint64) (*api.Block, error)
+
+	// GetHyperBlockByHash returns the metablock having the provided hex encoded hash, together with the notarized shard blocks
+	GetHyperBlockByHash(hash string) (*api.Block, error)
+
 	// GetHeartbeats returns the heartbeat status for each public key defined in genesis.json
 	GetHeartbeats() []heartbeat.PubKeyHeartbeat
 
@@ -59,5 +85,12 @@ type NodeWrapper interface {
 type ApiResolver interface {
 	ExecuteSCQuery(query *process.SCQuery) (*vmcommon.VMOutput, error)
 	StatusMetrics() external.StatusMetricsHandler
+	SimulateTransactionExecution(tx *transaction.Transaction) (*api.SimulationResults, error)
+	ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error)
+	GetProof(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error)
+	GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error)
+	ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
 	IsInterfaceNil() bool
 }
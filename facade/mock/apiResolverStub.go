@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/external"
 	"github.com/ElrondNetwork/elrond-go/process"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
@@ -8,8 +11,15 @@ import (
 
 // ApiResolverStub -
 type ApiResolverStub struct {
-	ExecuteSCQueryHandler func(query *process.SCQuery) (*vmcommon.VMOutput, error)
-	StatusMetricsHandler  func() external.StatusMetricsHandler
+	ExecuteSCQueryHandler               func(query *process.SCQuery) (*vmcommon.VMOutput, error)
+	StatusMetricsHandler                func() external.StatusMetricsHandler
+	SimulateTransactionExecutionHandler func(tx *transaction.Transaction) (*api.SimulationResults, error)
+	ComputeTransactionGasLimitHandler   func(tx *transaction.Transaction) (uint64, error)
+	GetProofHandler                     func(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonceHandler        func(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHashHandler         func(headerHash string, address string, key string) (*api.AccountProof, error)
+	GetAccountAtBlockNonceHandler       func(address string, blockNonce uint64) (*state.Account, error)
+	ExecuteSCQueryAtBlockNonceHandler   func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
 }
 
 // ExecuteSCQuery -
@@ -22,6 +32,41 @@ func (ars *ApiResolverStub) StatusMetrics() external.StatusMetricsHandler {
 	return ars.StatusMetricsHandler()
 }
 
+// SimulateTransactionExecution -
+func (ars *ApiResolverStub) SimulateTransactionExecution(tx *transaction.Transaction) (*api.SimulationResults, error) {
+	return ars.SimulateTransactionExecutionHandler(tx)
+}
+
+// ComputeTransactionGasLimit -
+func (ars *ApiResolverStub) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	return ars.ComputeTransactionGasLimitHandler(tx)
+}
+
+// GetProof -
+func (ars *ApiResolverStub) GetProof(rootHash string, address string, key string) (*api.AccountProof, error) {
+	return ars.GetProofHandler(rootHash, address, key)
+}
+
+// GetProofByHeaderNonce -
+func (ars *ApiResolverStub) GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error) {
+	return ars.GetProofByHeaderNonceHandler(nonce, address, key)
+}
+
+// GetProofByHeaderHash -
+func (ars *ApiResolverStub) GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error) {
+	return ars.GetProofByHeaderHashHandler(headerHash, address, key)
+}
+
+// GetAccountAtBlockNonce -
+func (ars *ApiResolverStub) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return ars.GetAccountAtBlockNonceHandler(address, blockNonce)
+}
+
+// ExecuteSCQueryAtBlockNonce -
+func (ars *ApiResolverStub) ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	return ars.ExecuteSCQueryAtBlockNonceHandler(query, blockNonce)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (ars *ApiResolverStub) IsInterfaceNil() bool {
 	return ars == nil
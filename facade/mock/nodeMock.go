@@ -3,6 +3,8 @@ package mock
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/data/api"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
@@ -21,7 +23,7 @@ type NodeMock struct {
 	GenerateTransactionHandler func(sender string, receiver string, amount string, code string) (*transaction.Transaction, error)
 	CreateTransactionHandler   func(nonce uint64, value string, receiverHex string, senderHex string, gasPrice uint64,
 		gasLimit uint64, data []byte, signatureHex string) (*transaction.Transaction, error)
-	GetTransactionHandler                          func(hash string) (*transaction.Transaction, error)
+	GetTransactionHandler                          func(hash string) (*api.Transaction, error)
 	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, amount string, txData []byte, signature []byte) (string, error)
 	SendBulkTransactionsHandler                    func(txs []*transaction.Transaction) (uint64, error)
 	GetAccountHandler                              func(address string) (*state.Account, error)
@@ -30,6 +32,14 @@ type NodeMock struct {
 	GenerateAndSendBulkTransactionsOneByOneHandler func(destination string, value *big.Int, nrTransactions uint64) error
 	GetHeartbeatsHandler                           func() []heartbeat.PubKeyHeartbeat
 	ValidatorStatisticsApiCalled                   func() (map[string]*state.ValidatorApiResponse, error)
+	GetBlockByNonceCalled                          func(nonce uint64) (*api.Block, error)
+	GetBlockByHashCalled                           func(hash string) (*api.Block, error)
+	GetHyperBlockByNonceCalled                     func(nonce uint64) (*api.Block, error)
+	GetHyperBlockByHashCalled                      func(hash string) (*api.Block, error)
+	GetValueForKeyCalled                           func(address string, key string) (string, error)
+	GetKeyValuePairsCalled                         func(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error)
+	SubscribeToEventsCalled                        func(addresses []string) (*eventsNotifier.Subscription, error)
+	UnsubscribeFromEventsCalled                    func(subscription *eventsNotifier.Subscription)
 }
 
 // Address -
@@ -80,7 +90,7 @@ func (nm *NodeMock) CreateTransaction(nonce uint64, value string, receiverHex st
 }
 
 // GetTransaction -
-func (nm *NodeMock) GetTransaction(hash string) (*transaction.Transaction, error) {
+func (nm *NodeMock) GetTransaction(hash string) (*api.Transaction, error) {
 	return nm.GetTransactionHandler(hash)
 }
 
@@ -114,6 +124,26 @@ func (nm *NodeMock) GetAccount(address string) (*state.Account, error) {
 	return nm.GetAccountHandler(address)
 }
 
+// GetValueForKey -
+func (nm *NodeMock) GetValueForKey(address string, key string) (string, error) {
+	return nm.GetValueForKeyCalled(address, key)
+}
+
+// GetKeyValuePairs -
+func (nm *NodeMock) GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error) {
+	return nm.GetKeyValuePairsCalled(address, continuationToken, maxNumKeys)
+}
+
+// SubscribeToEvents -
+func (nm *NodeMock) SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error) {
+	return nm.SubscribeToEventsCalled(addresses)
+}
+
+// UnsubscribeFromEvents -
+func (nm *NodeMock) UnsubscribeFromEvents(subscription *eventsNotifier.Subscription) {
+	nm.UnsubscribeFromEventsCalled(subscription)
+}
+
 // GetHeartbeats -
 func (nm *NodeMock) GetHeartbeats() []heartbeat.PubKeyHeartbeat {
 	return nm.GetHeartbeatsHandler()
@@ -124,6 +154,26 @@ func (nm *NodeMock) ValidatorStatisticsApi() (map[string]*state.ValidatorApiResp
 	return nm.ValidatorStatisticsApiCalled()
 }
 
+// GetBlockByNonce -
+func (nm *NodeMock) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+	return nm.GetBlockByNonceCalled(nonce)
+}
+
+// GetBlockByHash -
+func (nm *NodeMock) GetBlockByHash(hash string) (*api.Block, error) {
+	return nm.GetBlockByHashCalled(hash)
+}
+
+// GetHyperBlockByNonce -
+func (nm *NodeMock) GetHyperBlockByNonce(nonce uint64) (*api.Block, error) {
+	return nm.GetHyperBlockByNonceCalled(nonce)
+}
+
+// GetHyperBlockByHash -
+func (nm *NodeMock) GetHyperBlockByHash(hash string) (*api.Block, error) {
+	return nm.GetHyperBlockByHashCalled(hash)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (nm *NodeMock) IsInterfaceNil() bool {
 	if nm == nil {
@@ -8,7 +8,6 @@ require (
 	github.com/ElrondNetwork/elrond-vm v0.0.25
 	github.com/ElrondNetwork/elrond-vm-common v0.1.9
 	github.com/beevik/ntp v0.2.0
-	github.com/boltdb/bolt v1.3.1 // indirect
 	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btcutil v0.0.0-20190425235716-9e5f4b9a998d
 	github.com/cornelk/hashmap v1.0.1-0.20190121140111-33e58823eb9d
@@ -32,6 +31,7 @@ require (
 	github.com/libp2p/go-libp2p-kad-dht v0.2.1
 	github.com/libp2p/go-libp2p-kbucket v0.2.1
 	github.com/libp2p/go-libp2p-pubsub v0.1.1
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/mr-tron/base58 v1.1.3
 	github.com/multiformats/go-multiaddr v0.0.4
 	github.com/pelletier/go-toml v1.2.0
@@ -44,6 +44,8 @@ require (
 	github.com/whyrusleeping/go-logging v0.0.1
 	github.com/whyrusleeping/timecache v0.0.0-20160911033111-cfcb2f1abfee
 	go.dedis.ch/kyber/v3 v3.0.7
+	go.etcd.io/bbolt v1.3.6
 	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550
+	golang.org/x/sys v0.10.0 // indirect
 	gopkg.in/go-playground/validator.v8 v8.18.2
 )
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 )
 
@@ -56,6 +57,11 @@ func (cdb *countingDB) DestroyClosed() error {
 	return cdb.Destroy()
 }
 
+// Iterate will walk the pairs of the underlying db
+func (cdb *countingDB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	return cdb.db.Iterate(keyRange, handler)
+}
+
 // Reset will reset the number of time the Put method was called
 func (cdb *countingDB) Reset() {
 	cdb.nrOfPut = 0
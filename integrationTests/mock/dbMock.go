@@ -1,5 +1,9 @@
 package mock
 
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
 // MockDB -
 type MockDB struct {
 }
@@ -44,6 +48,11 @@ func (MockDB) DestroyClosed() error {
 	return nil
 }
 
+// Iterate -
+func (MockDB) Iterate(_ *storage.KeyRange, _ func(key []byte, val []byte) bool) error {
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (s MockDB) IsInterfaceNil() bool {
 	return false
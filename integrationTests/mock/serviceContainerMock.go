@@ -1,14 +1,16 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 )
 
 // ServiceContainerMock is a mock implementation of the Core interface
 type ServiceContainerMock struct {
-	IndexerCalled      func() indexer.Indexer
-	TPSBenchmarkCalled func() statistics.TPSBenchmark
+	IndexerCalled        func() indexer.Indexer
+	TPSBenchmarkCalled   func() statistics.TPSBenchmark
+	EventsNotifierCalled func() eventsNotifier.EventsNotifier
 }
 
 // Indexer returns a mock implementation for core.Indexer
@@ -27,6 +29,14 @@ func (scm *ServiceContainerMock) TPSBenchmark() statistics.TPSBenchmark {
 	return nil
 }
 
+// EventsNotifier returns a mock implementation for the chain events notifier
+func (scm *ServiceContainerMock) EventsNotifier() eventsNotifier.EventsNotifier {
+	if scm.EventsNotifierCalled != nil {
+		return scm.EventsNotifierCalled()
+	}
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (scm *ServiceContainerMock) IsInterfaceNil() bool {
 	if scm == nil {
@@ -8,8 +8,10 @@ import (
 	"github.com/ElrondNetwork/elrond-go/data/trie"
 	factory2 "github.com/ElrondNetwork/elrond-go/data/trie/factory"
 	"github.com/ElrondNetwork/elrond-go/integrationTests"
+	"github.com/ElrondNetwork/elrond-go/integrationTests/mock"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/statusHandler"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,8 +55,23 @@ func TestNode_RequestInterceptTrieNodesWithMessenger(t *testing.T) {
 	nilRootHash, _ := requesterTrie.Root()
 	trieNodesResolver, _ := nRequester.ResolverFinder.CrossShardResolver(factory.AccountTrieNodesTopic, sharding.MetachainShardId)
 
-	waitTime := 5 * time.Second
-	trieSyncer, _ := trie.NewTrieSyncer(trieNodesResolver, nRequester.DataPool.TrieNodes(), requesterTrie, waitTime)
+	requestHandler := &mock.RequestHandlerStub{
+		RequestTrieNodesCalled: func(destShardID uint32, hash []byte, topic string) {
+			_ = trieNodesResolver.RequestDataFromHash(hash, 0)
+		},
+	}
+	arg := trie.ArgTrieSyncer{
+		RequestHandler:      requestHandler,
+		InterceptedNodes:    nRequester.DataPool.TrieNodes(),
+		Trie:                requesterTrie,
+		StatusHandler:       statusHandler.NewNilStatusHandler(),
+		ShardId:             sharding.MetachainShardId,
+		Topic:               factory.AccountTrieNodesTopic,
+		WaitTime:            5 * time.Second,
+		MaxInFlightRequests: 10,
+		MaxRetries:          2,
+	}
+	trieSyncer, _ := trie.NewTrieSyncer(arg)
 	err = trieSyncer.StartSyncing(rootHash)
 	assert.Nil(t, err)
 
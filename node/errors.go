@@ -150,3 +150,15 @@ var ErrNilPendingMiniBlocksHandler = errors.New("trying to set nil pending minib
 
 // ErrNilRequestHandler signals that a nil request handler has been provided
 var ErrNilRequestHandler = errors.New("trying to set nil request handler")
+
+// ErrInvalidMaxNumKeys signals that an invalid maximum number of keys has been requested
+var ErrInvalidMaxNumKeys = errors.New("invalid maximum number of keys")
+
+// ErrInvalidContinuationToken signals that the continuation token of a paged request is not a hex encoded position
+var ErrInvalidContinuationToken = errors.New("invalid continuation token")
+
+// ErrInvalidDataTrieValue signals that a value read from an account's data trie is shorter than its key suffix
+var ErrInvalidDataTrieValue = errors.New("invalid data trie value")
+
+// ErrNilEventsNotifier signals that a nil events notifier has been provided
+var ErrNilEventsNotifier = errors.New("trying to set nil events notifier")
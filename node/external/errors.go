@@ -7,3 +7,15 @@ var ErrNilSCQueryService = errors.New("nil SC query service")
 
 // ErrNilStatusMetrics signals that a nil status metrics was provided
 var ErrNilStatusMetrics = errors.New("nil status metrics handler")
+
+// ErrNilTransactionSimulator signals that a nil transaction simulator was provided
+var ErrNilTransactionSimulator = errors.New("nil transaction simulator")
+
+// ErrNilTransactionCostHandler signals that a nil transaction cost handler was provided
+var ErrNilTransactionCostHandler = errors.New("nil transaction cost handler")
+
+// ErrNilAccountProofProvider signals that a nil account proof provider was provided
+var ErrNilAccountProofProvider = errors.New("nil account proof provider")
+
+// ErrNilHistoricalStateProvider signals that a nil historical state provider was provided
+var ErrNilHistoricalStateProvider = errors.New("nil historical state provider")
@@ -1,6 +1,9 @@
 package external
 
 import (
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/process"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 )
@@ -16,3 +19,31 @@ type StatusMetricsHandler interface {
 	StatusMetricsMap() (map[string]interface{}, error)
 	IsInterfaceNil() bool
 }
+
+// TransactionSimulator defines how a transaction can be executed without altering the state
+type TransactionSimulator interface {
+	ProcessTx(tx *transaction.Transaction) (*api.SimulationResults, error)
+	IsInterfaceNil() bool
+}
+
+// TransactionCostHandler defines how the gas limit needed by a transaction can be estimated
+type TransactionCostHandler interface {
+	ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error)
+	IsInterfaceNil() bool
+}
+
+// AccountProofProvider defines how the Merkle proofs of an account and of its data trie keys can be fetched
+type AccountProofProvider interface {
+	GetProof(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error)
+	IsInterfaceNil() bool
+}
+
+// HistoricalStateProvider defines how the accounts can be read and the smart contracts queried on the state of a
+// past block
+type HistoricalStateProvider interface {
+	GetAccount(address string, blockNonce uint64) (*state.Account, error)
+	ExecuteQuery(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
+	IsInterfaceNil() bool
+}
@@ -2,6 +2,9 @@ package external
 
 import (
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/process"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 )
@@ -10,20 +13,47 @@ import (
 type NodeApiResolver struct {
 	scQueryService       SCQueryService
 	statusMetricsHandler StatusMetricsHandler
+	txSimulator          TransactionSimulator
+	txCostHandler        TransactionCostHandler
+	accountProofProvider AccountProofProvider
+	historicalState      HistoricalStateProvider
 }
 
 // NewNodeApiResolver creates a new NodeApiResolver instance
-func NewNodeApiResolver(scQueryService SCQueryService, statusMetricsHandler StatusMetricsHandler) (*NodeApiResolver, error) {
+func NewNodeApiResolver(
+	scQueryService SCQueryService,
+	statusMetricsHandler StatusMetricsHandler,
+	txSimulator TransactionSimulator,
+	txCostHandler TransactionCostHandler,
+	accountProofProvider AccountProofProvider,
+	historicalState HistoricalStateProvider,
+) (*NodeApiResolver, error) {
 	if check.IfNil(scQueryService) {
 		return nil, ErrNilSCQueryService
 	}
 	if check.IfNil(statusMetricsHandler) {
 		return nil, ErrNilStatusMetrics
 	}
+	if check.IfNil(txSimulator) {
+		return nil, ErrNilTransactionSimulator
+	}
+	if check.IfNil(txCostHandler) {
+		return nil, ErrNilTransactionCostHandler
+	}
+	if check.IfNil(accountProofProvider) {
+		return nil, ErrNilAccountProofProvider
+	}
+	if check.IfNil(historicalState) {
+		return nil, ErrNilHistoricalStateProvider
+	}
 
 	return &NodeApiResolver{
 		scQueryService:       scQueryService,
 		statusMetricsHandler: statusMetricsHandler,
+		txSimulator:          txSimulator,
+		txCostHandler:        txCostHandler,
+		accountProofProvider: accountProofProvider,
+		historicalState:      historicalState,
 	}, nil
 }
 
@@ -32,11 +62,49 @@ func (nar *NodeApiResolver) ExecuteSCQuery(query *process.SCQuery) (*vmcommon.VM
 	return nar.scQueryService.ExecuteQuery(query)
 }
 
+// ExecuteSCQueryAtBlockNonce retrieves data stored in a SC account through a VM, on the state of the block having the
+// given nonce
+func (nar *NodeApiResolver) ExecuteSCQueryAtBlockNonce(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	return nar.historicalState.ExecuteQuery(query, blockNonce)
+}
+
 // StatusMetrics returns an implementation of the StatusMetricsHandler interface
 func (nar *NodeApiResolver) StatusMetrics() StatusMetricsHandler {
 	return nar.statusMetricsHandler
 }
 
+// SimulateTransactionExecution executes the provided transaction without altering the state
+func (nar *NodeApiResolver) SimulateTransactionExecution(tx *transaction.Transaction) (*api.SimulationResults, error) {
+	return nar.txSimulator.ProcessTx(tx)
+}
+
+// ComputeTransactionGasLimit estimates the gas limit needed by the provided transaction
+func (nar *NodeApiResolver) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	return nar.txCostHandler.ComputeTransactionGasLimit(tx)
+}
+
+// GetProof returns the Merkle proof of an account, and optionally of one of its data trie keys, at the given root hash
+func (nar *NodeApiResolver) GetProof(rootHash string, address string, key string) (*api.AccountProof, error) {
+	return nar.accountProofProvider.GetProof(rootHash, address, key)
+}
+
+// GetProofByHeaderNonce returns the Merkle proof of an account, and optionally of one of its data trie keys, at the
+// root hash of the header having the given nonce
+func (nar *NodeApiResolver) GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error) {
+	return nar.accountProofProvider.GetProofByHeaderNonce(nonce, address, key)
+}
+
+// GetProofByHeaderHash returns the Merkle proof of an account, and optionally of one of its data trie keys, at the
+// root hash of the header having the given hash
+func (nar *NodeApiResolver) GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error) {
+	return nar.accountProofProvider.GetProofByHeaderHash(headerHash, address, key)
+}
+
+// GetAccountAtBlockNonce returns the account having the given address, as it was after the block having the given nonce
+func (nar *NodeApiResolver) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return nar.historicalState.GetAccount(address, blockNonce)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (nar *NodeApiResolver) IsInterfaceNil() bool {
 	return nar == nil
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/external"
 	"github.com/ElrondNetwork/elrond-go/node/mock"
 	"github.com/ElrondNetwork/elrond-go/process"
@@ -14,7 +17,7 @@ import (
 func TestNewNodeApiResolver_NilSCQueryServiceShouldErr(t *testing.T) {
 	t.Parallel()
 
-	nar, err := external.NewNodeApiResolver(nil, &mock.StatusMetricsStub{})
+	nar, err := external.NewNodeApiResolver(nil, &mock.StatusMetricsStub{}, &mock.TxSimulatorStub{}, &mock.TxCostHandlerStub{}, &mock.AccountProofProviderStub{}, &mock.HistoricalStateProviderStub{})
 
 	assert.Nil(t, nar)
 	assert.Equal(t, external.ErrNilSCQueryService, err)
@@ -23,16 +26,52 @@ func TestNewNodeApiResolver_NilSCQueryServiceShouldErr(t *testing.T) {
 func TestNewNodeApiResolver_NilStatusMetricsShouldErr(t *testing.T) {
 	t.Parallel()
 
-	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, nil)
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, nil, &mock.TxSimulatorStub{}, &mock.TxCostHandlerStub{}, &mock.AccountProofProviderStub{}, &mock.HistoricalStateProviderStub{})
 
 	assert.Nil(t, nar)
 	assert.Equal(t, external.ErrNilStatusMetrics, err)
 }
 
+func TestNewNodeApiResolver_NilTxSimulatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{}, nil, &mock.TxCostHandlerStub{}, &mock.AccountProofProviderStub{}, &mock.HistoricalStateProviderStub{})
+
+	assert.Nil(t, nar)
+	assert.Equal(t, external.ErrNilTransactionSimulator, err)
+}
+
+func TestNewNodeApiResolver_NilTxCostHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{}, &mock.TxSimulatorStub{}, nil, &mock.AccountProofProviderStub{}, &mock.HistoricalStateProviderStub{})
+
+	assert.Nil(t, nar)
+	assert.Equal(t, external.ErrNilTransactionCostHandler, err)
+}
+
+func TestNewNodeApiResolver_NilAccountProofProviderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{}, &mock.TxSimulatorStub{}, &mock.TxCostHandlerStub{}, nil, &mock.HistoricalStateProviderStub{})
+
+	assert.Nil(t, nar)
+	assert.Equal(t, external.ErrNilAccountProofProvider, err)
+}
+
+func TestNewNodeApiResolver_NilHistoricalStateProviderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{}, &mock.TxSimulatorStub{}, &mock.TxCostHandlerStub{}, &mock.AccountProofProviderStub{}, nil)
+
+	assert.Nil(t, nar)
+	assert.Equal(t, external.ErrNilHistoricalStateProvider, err)
+}
+
 func TestNewNodeApiResolver_ShouldWork(t *testing.T) {
 	t.Parallel()
 
-	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{})
+	nar, err := external.NewNodeApiResolver(&mock.SCQueryServiceStub{}, &mock.StatusMetricsStub{}, &mock.TxSimulatorStub{}, &mock.TxCostHandlerStub{}, &mock.AccountProofProviderStub{}, &mock.HistoricalStateProviderStub{})
 
 	assert.Nil(t, err)
 	assert.False(t, check.IfNil(nar))
@@ -48,7 +87,12 @@ func TestNodeApiResolver_GetDataValueShouldCall(t *testing.T) {
 			return &vmcommon.VMOutput{}, nil
 		},
 	},
-		&mock.StatusMetricsStub{})
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{},
+		&mock.HistoricalStateProviderStub{},
+	)
 
 	_, _ = nar.ExecuteSCQuery(&process.SCQuery{
 		ScAddress: []byte{0},
@@ -69,8 +113,145 @@ func TestNodeApiResolver_StatusMetricsMapShouldBeCalled(t *testing.T) {
 				wasCalled = true
 				return nil, nil
 			},
-		})
+		},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{},
+		&mock.HistoricalStateProviderStub{},
+	)
 	_, _ = nar.StatusMetrics().StatusMetricsMap()
 
 	assert.True(t, wasCalled)
 }
+
+func TestNodeApiResolver_SimulateTransactionExecutionShouldCall(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	nar, _ := external.NewNodeApiResolver(
+		&mock.SCQueryServiceStub{},
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{
+			ProcessTxCalled: func(tx *transaction.Transaction) (*api.SimulationResults, error) {
+				wasCalled = true
+				return &api.SimulationResults{}, nil
+			},
+		},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{},
+		&mock.HistoricalStateProviderStub{},
+	)
+
+	_, _ = nar.SimulateTransactionExecution(&transaction.Transaction{})
+
+	assert.True(t, wasCalled)
+}
+
+func TestNodeApiResolver_ComputeTransactionGasLimitShouldCall(t *testing.T) {
+	t.Parallel()
+
+	expectedGasLimit := uint64(37)
+	nar, _ := external.NewNodeApiResolver(
+		&mock.SCQueryServiceStub{},
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{
+			ComputeTransactionGasLimitCalled: func(tx *transaction.Transaction) (uint64, error) {
+				return expectedGasLimit, nil
+			},
+		},
+		&mock.AccountProofProviderStub{},
+		&mock.HistoricalStateProviderStub{},
+	)
+
+	gasLimit, err := nar.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedGasLimit, gasLimit)
+}
+
+func TestNodeApiResolver_GetProofShouldCall(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &api.AccountProof{RootHash: "aa"}
+	nar, _ := external.NewNodeApiResolver(
+		&mock.SCQueryServiceStub{},
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{
+			GetProofCalled: func(rootHash string, address string, key string) (*api.AccountProof, error) {
+				return expectedProof, nil
+			},
+		},
+		&mock.HistoricalStateProviderStub{},
+	)
+
+	proof, err := nar.GetProof("aa", "bb", "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+}
+
+func TestNodeApiResolver_GetProofByHeaderShouldCall(t *testing.T) {
+	t.Parallel()
+
+	expectedProof := &api.AccountProof{HeaderHash: "cc", RootHash: "aa"}
+	nar, _ := external.NewNodeApiResolver(
+		&mock.SCQueryServiceStub{},
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{
+			GetProofByHeaderNonceCalled: func(nonce uint64, address string, key string) (*api.AccountProof, error) {
+				assert.Equal(t, uint64(10), nonce)
+				return expectedProof, nil
+			},
+			GetProofByHeaderHashCalled: func(headerHash string, address string, key string) (*api.AccountProof, error) {
+				assert.Equal(t, "cc", headerHash)
+				return expectedProof, nil
+			},
+		},
+		&mock.HistoricalStateProviderStub{},
+	)
+
+	proof, err := nar.GetProofByHeaderNonce(10, "bb", "")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+
+	proof, err = nar.GetProofByHeaderHash("cc", "bb", "")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedProof, proof)
+}
+
+func TestNodeApiResolver_AtBlockNonceShouldCallTheHistoricalState(t *testing.T) {
+	t.Parallel()
+
+	expectedAccount := &state.Account{Nonce: 7}
+	expectedVmOutput := &vmcommon.VMOutput{GasRemaining: 3}
+	nar, _ := external.NewNodeApiResolver(
+		&mock.SCQueryServiceStub{},
+		&mock.StatusMetricsStub{},
+		&mock.TxSimulatorStub{},
+		&mock.TxCostHandlerStub{},
+		&mock.AccountProofProviderStub{},
+		&mock.HistoricalStateProviderStub{
+			GetAccountCalled: func(address string, blockNonce uint64) (*state.Account, error) {
+				assert.Equal(t, uint64(10), blockNonce)
+				return expectedAccount, nil
+			},
+			ExecuteQueryCalled: func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+				assert.Equal(t, uint64(11), blockNonce)
+				return expectedVmOutput, nil
+			},
+		},
+	)
+
+	account, err := nar.GetAccountAtBlockNonce("aa", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAccount, account)
+
+	vmOutput, err := nar.ExecuteSCQueryAtBlockNonce(&process.SCQuery{}, 11)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedVmOutput, vmOutput)
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/api"
+)
+
+// AccountProofProviderStub -
+type AccountProofProviderStub struct {
+	GetProofCalled              func(rootHash string, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderNonceCalled func(nonce uint64, address string, key string) (*api.AccountProof, error)
+	GetProofByHeaderHashCalled  func(headerHash string, address string, key string) (*api.AccountProof, error)
+}
+
+// GetProof -
+func (apps *AccountProofProviderStub) GetProof(rootHash string, address string, key string) (*api.AccountProof, error) {
+	if apps.GetProofCalled != nil {
+		return apps.GetProofCalled(rootHash, address, key)
+	}
+
+	return &api.AccountProof{}, nil
+}
+
+// GetProofByHeaderNonce -
+func (apps *AccountProofProviderStub) GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error) {
+	if apps.GetProofByHeaderNonceCalled != nil {
+		return apps.GetProofByHeaderNonceCalled(nonce, address, key)
+	}
+
+	return &api.AccountProof{}, nil
+}
+
+// GetProofByHeaderHash -
+func (apps *AccountProofProviderStub) GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error) {
+	if apps.GetProofByHeaderHashCalled != nil {
+		return apps.GetProofByHeaderHashCalled(headerHash, address, key)
+	}
+
+	return &api.AccountProof{}, nil
+}
+
+// IsInterfaceNil -
+func (apps *AccountProofProviderStub) IsInterfaceNil() bool {
+	return apps == nil
+}
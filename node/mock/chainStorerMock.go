@@ -16,6 +16,7 @@ type ChainStorerMock struct {
 	GetAllCalled    func(unitType dataRetriever.UnitType, keys [][]byte) (map[string][]byte, error)
 	DestroyCalled   func() error
 	CloseAllCalled  func() error
+	FlushAllCalled  func() error
 }
 
 // CloseAll -
@@ -27,6 +28,15 @@ func (bc *ChainStorerMock) CloseAll() error {
 	return nil
 }
 
+// FlushAll -
+func (bc *ChainStorerMock) FlushAll() error {
+	if bc.FlushAllCalled != nil {
+		return bc.FlushAllCalled()
+	}
+
+	return nil
+}
+
 // AddStorer will add a new storer to the chain map
 func (bc *ChainStorerMock) AddStorer(key dataRetriever.UnitType, s storage.Storer) {
 	if bc.AddStorerCalled != nil {
@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// EventsNotifierStub -
+type EventsNotifierStub struct {
+	SubscribeCalled               func(addresses [][]byte) *eventsNotifier.Subscription
+	UnsubscribeCalled             func(subscription *eventsNotifier.Subscription)
+	NotifyCommittedBlockCalled    func(header data.HeaderHandler, headerHash []byte, txs map[string]data.TransactionHandler)
+	NotarizedHeadersHandlerCalled func(shardID uint32, headers []data.HeaderHandler, headersHashes [][]byte)
+}
+
+// Subscribe -
+func (ens *EventsNotifierStub) Subscribe(addresses [][]byte) *eventsNotifier.Subscription {
+	if ens.SubscribeCalled != nil {
+		return ens.SubscribeCalled(addresses)
+	}
+
+	return nil
+}
+
+// Unsubscribe -
+func (ens *EventsNotifierStub) Unsubscribe(subscription *eventsNotifier.Subscription) {
+	if ens.UnsubscribeCalled != nil {
+		ens.UnsubscribeCalled(subscription)
+	}
+}
+
+// NotifyCommittedBlock -
+func (ens *EventsNotifierStub) NotifyCommittedBlock(header data.HeaderHandler, headerHash []byte, txs map[string]data.TransactionHandler) {
+	if ens.NotifyCommittedBlockCalled != nil {
+		ens.NotifyCommittedBlockCalled(header, headerHash, txs)
+	}
+}
+
+// NotarizedHeadersHandler -
+func (ens *EventsNotifierStub) NotarizedHeadersHandler(shardID uint32, headers []data.HeaderHandler, headersHashes [][]byte) {
+	if ens.NotarizedHeadersHandlerCalled != nil {
+		ens.NotarizedHeadersHandlerCalled(shardID, headers, headersHashes)
+	}
+}
+
+// IsInterfaceNil -
+func (ens *EventsNotifierStub) IsInterfaceNil() bool {
+	return ens == nil
+}
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// HistoricalStateProviderStub -
+type HistoricalStateProviderStub struct {
+	GetAccountCalled   func(address string, blockNonce uint64) (*state.Account, error)
+	ExecuteQueryCalled func(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error)
+}
+
+// GetAccount -
+func (hsps *HistoricalStateProviderStub) GetAccount(address string, blockNonce uint64) (*state.Account, error) {
+	if hsps.GetAccountCalled != nil {
+		return hsps.GetAccountCalled(address, blockNonce)
+	}
+
+	return &state.Account{Balance: big.NewInt(0)}, nil
+}
+
+// ExecuteQuery -
+func (hsps *HistoricalStateProviderStub) ExecuteQuery(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	if hsps.ExecuteQueryCalled != nil {
+		return hsps.ExecuteQueryCalled(query, blockNonce)
+	}
+
+	return &vmcommon.VMOutput{}, nil
+}
+
+// IsInterfaceNil -
+func (hsps *HistoricalStateProviderStub) IsInterfaceNil() bool {
+	return hsps == nil
+}
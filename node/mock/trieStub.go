@@ -21,6 +21,9 @@ type TrieStub struct {
 	AppendToOldHashesCalled  func([][]byte)
 	GetSerializedNodesCalled func([]byte, uint64) ([][]byte, error)
 	DatabaseCalled           func() data.DBWriteCacher
+	GetAllLeavesCalled       func() (map[string][]byte, error)
+	IterateLeavesCalled      func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error
+	DiffCalled               func(newTrie data.Trie, handler func(change data.LeafChange) bool) error
 }
 
 // ClosePersister -
@@ -38,9 +41,31 @@ func (ts *TrieStub) SetCheckpoint(_ []byte) {
 
 // GetAllLeaves -
 func (ts *TrieStub) GetAllLeaves() (map[string][]byte, error) {
+	if ts.GetAllLeavesCalled != nil {
+		return ts.GetAllLeavesCalled()
+	}
+
 	return make(map[string][]byte), nil
 }
 
+// IterateLeaves -
+func (ts *TrieStub) IterateLeaves(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+	if ts.IterateLeavesCalled != nil {
+		return ts.IterateLeavesCalled(startKey, endKey, handler)
+	}
+
+	return nil
+}
+
+// Diff -
+func (ts *TrieStub) Diff(newTrie data.Trie, handler func(change data.LeafChange) bool) error {
+	if ts.DiffCalled != nil {
+		return ts.DiffCalled(newTrie, handler)
+	}
+
+	return nil
+}
+
 // IsPruningEnabled -
 func (ts *TrieStub) IsPruningEnabled() bool {
 	return false
@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+)
+
+// TxCostHandlerStub -
+type TxCostHandlerStub struct {
+	ComputeTransactionGasLimitCalled func(tx *transaction.Transaction) (uint64, error)
+}
+
+// ComputeTransactionGasLimit -
+func (tchs *TxCostHandlerStub) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	if tchs.ComputeTransactionGasLimitCalled != nil {
+		return tchs.ComputeTransactionGasLimitCalled(tx)
+	}
+
+	return 0, nil
+}
+
+// IsInterfaceNil -
+func (tchs *TxCostHandlerStub) IsInterfaceNil() bool {
+	return tchs == nil
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+)
+
+// TxSimulatorStub -
+type TxSimulatorStub struct {
+	ProcessTxCalled func(tx *transaction.Transaction) (*api.SimulationResults, error)
+}
+
+// ProcessTx -
+func (tss *TxSimulatorStub) ProcessTx(tx *transaction.Transaction) (*api.SimulationResults, error) {
+	if tss.ProcessTxCalled != nil {
+		return tss.ProcessTxCalled(tx)
+	}
+
+	return &api.SimulationResults{}, nil
+}
+
+// IsInterfaceNil -
+func (tss *TxSimulatorStub) IsInterfaceNil() bool {
+	return tss == nil
+}
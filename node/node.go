@@ -18,6 +18,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/consensus/spos/sposFactory"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/partitioning"
 	"github.com/ElrondNetwork/elrond-go/crypto"
@@ -121,6 +122,7 @@ type Node struct {
 	sizeCheckDelta uint32
 
 	requestHandler process.RequestHandler
+	eventsNotifier eventsNotifier.EventsNotifier
 }
 
 // ApplyOptions can set up different configurable options of a Node instance
@@ -842,11 +844,6 @@ func (n *Node) CreateTransaction(
 	}, nil
 }
 
-//GetTransaction gets the transaction
-func (n *Node) GetTransaction(_ string) (*transaction.Transaction, error) {
-	return nil, fmt.Errorf("not yet implemented")
-}
-
 // GetCurrentPublicKey will return the current node's public key
 func (n *Node) GetCurrentPublicKey() string {
 	if n.txSignPubKey != nil {
@@ -0,0 +1,134 @@
+package node
+
+import (
+	"encoding/hex"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+)
+
+// GetValueForKey returns the hex encoded value stored under the provided hex encoded key in the data trie
+// of the given account. An empty string is returned if the account or the key do not exist
+func (n *Node) GetValueForKey(address string, key string) (string, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	dataTrie, addressBytes, err := n.getAccountDataTrie(address)
+	if err != nil {
+		return "", err
+	}
+	if check.IfNil(dataTrie) {
+		return "", nil
+	}
+
+	value, err := dataTrie.Get(keyBytes)
+	if err != nil {
+		return "", err
+	}
+	if len(value) == 0 {
+		return "", nil
+	}
+
+	value, err = trimDataTrieValue(value, keyBytes, addressBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(value), nil
+}
+
+// GetKeyValuePairs returns at most maxNumKeys entries from the data trie of the given account, in ascending key order.
+// An empty continuation token starts with the smallest key of the data trie, and the token returned with a page
+// resumes the walk right after it
+func (n *Node) GetKeyValuePairs(address string, continuationToken string, maxNumKeys int) (*api.AccountKeyValuePairs, error) {
+	if maxNumKeys <= 0 {
+		return nil, ErrInvalidMaxNumKeys
+	}
+
+	startKey, err := hex.DecodeString(continuationToken)
+	if err != nil {
+		return nil, ErrInvalidContinuationToken
+	}
+	if len(startKey) == 0 {
+		startKey = nil
+	}
+
+	result := &api.AccountKeyValuePairs{
+		Pairs: make([]*api.KeyValuePair, 0),
+	}
+
+	dataTrie, addressBytes, err := n.getAccountDataTrie(address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(dataTrie) {
+		return result, nil
+	}
+
+	var errTrim error
+	err = dataTrie.IterateLeaves(startKey, nil, func(key []byte, value []byte) bool {
+		if len(result.Pairs) == maxNumKeys {
+			result.ContinuationToken = hex.EncodeToString(key)
+			return false
+		}
+
+		var trimmedValue []byte
+		trimmedValue, errTrim = trimDataTrieValue(value, key, addressBytes)
+		if errTrim != nil {
+			return false
+		}
+
+		result.Pairs = append(result.Pairs, &api.KeyValuePair{
+			Key:   hex.EncodeToString(key),
+			Value: hex.EncodeToString(trimmedValue),
+		})
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errTrim != nil {
+		return nil, errTrim
+	}
+
+	return result, nil
+}
+
+func (n *Node) getAccountDataTrie(address string) (data.Trie, []byte, error) {
+	if check.IfNil(n.addrConverter) {
+		return nil, nil, ErrNilAddressConverter
+	}
+	if check.IfNil(n.accounts) {
+		return nil, nil, ErrNilAccountsAdapter
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accWrp, err := n.accounts.GetExistingAccount(addr)
+	if err == state.ErrAccNotFound {
+		return nil, addr.Bytes(), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return accWrp.DataTrie(), addr.Bytes(), nil
+}
+
+// trimDataTrieValue removes the key and address suffix the data trie tracker appends to every saved value
+func trimDataTrieValue(value []byte, key []byte, addressBytes []byte) ([]byte, error) {
+	dataLength := len(value) - len(key) - len(addressBytes)
+	if dataLength < 0 {
+		return nil, ErrInvalidDataTrieValue
+	}
+
+	return value[:dataLength], nil
+}
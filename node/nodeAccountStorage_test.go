@@ -0,0 +1,262 @@
+package node_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/node"
+	"github.com/ElrondNetwork/elrond-go/node/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createNodeWithDataTrie(t *testing.T, leaves map[string][]byte) (*node.Node, string) {
+	addressHex := createDummyHexAddress(64)
+	addressBytes, _ := hex.DecodeString(addressHex)
+
+	dataTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return leaves[string(key)], nil
+		},
+		IterateLeavesCalled: func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+			keys := make([]string, 0, len(leaves))
+			for key := range leaves {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				if key < string(startKey) {
+					continue
+				}
+				if !handler([]byte(key), leaves[key]) {
+					return nil
+				}
+			}
+
+			return nil
+		},
+	}
+
+	accDB := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acc, err := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			require.Nil(t, err)
+			acc.SetDataTrie(dataTrie)
+
+			return acc, nil
+		},
+	}
+
+	for key, value := range leaves {
+		suffix := append([]byte(key), addressBytes...)
+		leaves[key] = append(value, suffix...)
+	}
+
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(accDB),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	return n, addressHex
+}
+
+func TestNode_GetValueForKeyInvalidKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, addressHex := createNodeWithDataTrie(t, map[string][]byte{})
+
+	value, err := n.GetValueForKey(addressHex, "not hex")
+
+	assert.NotNil(t, err)
+	assert.Empty(t, value)
+}
+
+func TestNode_GetValueForKeyNilAccountsAdapterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	value, err := n.GetValueForKey(createDummyHexAddress(64), "aa")
+
+	assert.Equal(t, node.ErrNilAccountsAdapter, err)
+	assert.Empty(t, value)
+}
+
+func TestNode_GetValueForKeyAccountNotFoundShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+
+	accDB := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(accDB),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	value, err := n.GetValueForKey(createDummyHexAddress(64), "aa")
+
+	assert.Nil(t, err)
+	assert.Empty(t, value)
+}
+
+func TestNode_GetValueForKeyAccountsAdapterErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accDB := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(accDB),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	value, err := n.GetValueForKey(createDummyHexAddress(64), "aa")
+
+	assert.Equal(t, errExpected, err)
+	assert.Empty(t, value)
+}
+
+func TestNode_GetValueForKeyShouldWork(t *testing.T) {
+	t.Parallel()
+
+	n, addressHex := createNodeWithDataTrie(t, map[string][]byte{
+		"key1": []byte("value1"),
+	})
+
+	value, err := n.GetValueForKey(addressHex, hex.EncodeToString([]byte("key1")))
+	assert.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString([]byte("value1")), value)
+
+	value, err = n.GetValueForKey(addressHex, hex.EncodeToString([]byte("missing")))
+	assert.Nil(t, err)
+	assert.Empty(t, value)
+}
+
+func TestNode_GetKeyValuePairsInvalidMaxNumKeysShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, addressHex := createNodeWithDataTrie(t, map[string][]byte{})
+
+	pairs, err := n.GetKeyValuePairs(addressHex, "", 0)
+
+	assert.Equal(t, node.ErrInvalidMaxNumKeys, err)
+	assert.Nil(t, pairs)
+}
+
+func TestNode_GetKeyValuePairsShouldPaginate(t *testing.T) {
+	t.Parallel()
+
+	n, addressHex := createNodeWithDataTrie(t, map[string][]byte{
+		"c": []byte("3"),
+		"a": []byte("1"),
+		"d": []byte("4"),
+		"b": []byte("2"),
+	})
+
+	pairs, err := n.GetKeyValuePairs(addressHex, "", 3)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(pairs.Pairs))
+	assert.Equal(t, hex.EncodeToString([]byte("a")), pairs.Pairs[0].Key)
+	assert.Equal(t, hex.EncodeToString([]byte("1")), pairs.Pairs[0].Value)
+	assert.Equal(t, hex.EncodeToString([]byte("c")), pairs.Pairs[2].Key)
+	assert.NotEmpty(t, pairs.ContinuationToken)
+
+	pairs, err = n.GetKeyValuePairs(addressHex, pairs.ContinuationToken, 3)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(pairs.Pairs))
+	assert.Equal(t, hex.EncodeToString([]byte("d")), pairs.Pairs[0].Key)
+	assert.Equal(t, hex.EncodeToString([]byte("4")), pairs.Pairs[0].Value)
+	assert.Empty(t, pairs.ContinuationToken)
+}
+
+func TestNode_GetKeyValuePairsInvalidContinuationTokenShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, addressHex := createNodeWithDataTrie(t, map[string][]byte{})
+
+	pairs, err := n.GetKeyValuePairs(addressHex, "not hex", 10)
+
+	assert.Equal(t, node.ErrInvalidContinuationToken, err)
+	assert.Nil(t, pairs)
+}
+
+func TestNode_GetKeyValuePairsShouldNotReadTheWholeDataTrie(t *testing.T) {
+	t.Parallel()
+
+	numReadLeaves := 0
+	accDB := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acc, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			acc.SetDataTrie(&mock.TrieStub{
+				GetAllLeavesCalled: func() (map[string][]byte, error) {
+					assert.Fail(t, "the data trie should have been walked page by page")
+					return nil, nil
+				},
+				IterateLeavesCalled: func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+					for i := 0; i < 100; i++ {
+						numReadLeaves++
+						key := []byte{byte(i)}
+						value := append([]byte("value"), key...)
+						value = append(value, addressContainer.Bytes()...)
+						if !handler(key, value) {
+							return nil
+						}
+					}
+
+					return nil
+				},
+			})
+
+			return acc, nil
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(accDB),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	pairs, err := n.GetKeyValuePairs(createDummyHexAddress(64), "", 10)
+
+	require.Nil(t, err)
+	assert.Equal(t, 10, len(pairs.Pairs))
+	assert.Equal(t, "0a", pairs.ContinuationToken)
+	assert.Equal(t, 11, numReadLeaves)
+}
+
+func TestNode_GetKeyValuePairsCorruptedValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	accDB := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acc, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			acc.SetDataTrie(&mock.TrieStub{
+				IterateLeavesCalled: func(startKey []byte, endKey []byte, handler func(key []byte, value []byte) bool) error {
+					handler([]byte("key"), []byte("short"))
+					return nil
+				},
+			})
+
+			return acc, nil
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(accDB),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	pairs, err := n.GetKeyValuePairs(createDummyHexAddress(64), "", 10)
+
+	assert.Equal(t, node.ErrInvalidDataTrieValue, err)
+	assert.Nil(t, pairs)
+}
@@ -0,0 +1,254 @@
+package node
+
+import (
+	"encoding/hex"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// GetBlockByNonce returns the block having the provided nonce from the node's own shard.
+// On a metachain node the metablock is returned. It returns nil if the block is not in the storage
+func (n *Node) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+	err := n.checkBlockQueryComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	hdrUnit, nonceUnit := n.selfShardHeaderUnits()
+	headerHash, err := n.getHeaderHashByNonce(nonceUnit, nonce)
+	if err != nil || headerHash == nil {
+		return nil, err
+	}
+
+	return n.getBlock(hdrUnit, headerHash, false)
+}
+
+// GetBlockByHash returns the block having the provided hex encoded hash from the node's own shard.
+// On a metachain node the metablock is returned. It returns nil if the block is not in the storage
+func (n *Node) GetBlockByHash(hash string) (*api.Block, error) {
+	err := n.checkBlockQueryComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	hdrUnit, _ := n.selfShardHeaderUnits()
+
+	return n.getBlock(hdrUnit, headerHash, false)
+}
+
+// GetHyperBlockByNonce returns the metablock having the provided nonce, together with all the
+// shard blocks it notarized, their miniblocks and the included transaction hashes.
+// It returns nil if the metablock is not in the storage
+func (n *Node) GetHyperBlockByNonce(nonce uint64) (*api.Block, error) {
+	err := n.checkBlockQueryComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash, err := n.getHeaderHashByNonce(dataRetriever.MetaHdrNonceHashDataUnit, nonce)
+	if err != nil || headerHash == nil {
+		return nil, err
+	}
+
+	return n.getBlock(dataRetriever.MetaBlockUnit, headerHash, true)
+}
+
+// GetHyperBlockByHash returns the metablock having the provided hex encoded hash, together with all the
+// shard blocks it notarized, their miniblocks and the included transaction hashes.
+// It returns nil if the metablock is not in the storage
+func (n *Node) GetHyperBlockByHash(hash string) (*api.Block, error) {
+	err := n.checkBlockQueryComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.getBlock(dataRetriever.MetaBlockUnit, headerHash, true)
+}
+
+func (n *Node) checkBlockQueryComponents() error {
+	if check.IfNil(n.store) {
+		return ErrNilStore
+	}
+	if check.IfNil(n.marshalizer) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(n.shardCoordinator) {
+		return ErrNilShardCoordinator
+	}
+	if check.IfNil(n.uint64ByteSliceConverter) {
+		return ErrNilUint64ByteSliceConverter
+	}
+
+	return nil
+}
+
+func (n *Node) selfShardHeaderUnits() (dataRetriever.UnitType, dataRetriever.UnitType) {
+	selfShardId := n.shardCoordinator.SelfId()
+	if selfShardId == sharding.MetachainShardId {
+		return dataRetriever.MetaBlockUnit, dataRetriever.MetaHdrNonceHashDataUnit
+	}
+
+	return dataRetriever.BlockHeaderUnit, dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(selfShardId)
+}
+
+// getHeaderHashByNonce returns nil, without error, if the nonce is not in the storage
+func (n *Node) getHeaderHashByNonce(nonceUnit dataRetriever.UnitType, nonce uint64) ([]byte, error) {
+	nonceToByteSlice := n.uint64ByteSliceConverter.ToByteSlice(nonce)
+	if n.store.Has(nonceUnit, nonceToByteSlice) != nil {
+		return nil, nil
+	}
+
+	return n.store.Get(nonceUnit, nonceToByteSlice)
+}
+
+// getBlock returns nil, without error, if the header is not in the storage
+func (n *Node) getBlock(hdrUnit dataRetriever.UnitType, headerHash []byte, withNotarizedMiniBlocks bool) (*api.Block, error) {
+	if n.store.Has(hdrUnit, headerHash) != nil {
+		return nil, nil
+	}
+
+	buff, err := n.store.Get(hdrUnit, headerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if hdrUnit == dataRetriever.MetaBlockUnit {
+		metaBlock := &block.MetaBlock{}
+		err = n.marshalizer.Unmarshal(metaBlock, buff)
+		if err != nil {
+			return nil, err
+		}
+
+		return n.convertMetaBlock(metaBlock, headerHash, withNotarizedMiniBlocks), nil
+	}
+
+	header := &block.Header{}
+	err = n.marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.convertShardBlock(header, headerHash), nil
+}
+
+func (n *Node) convertShardBlock(header *block.Header, headerHash []byte) *api.Block {
+	apiBlock := &api.Block{
+		Nonce:         header.Nonce,
+		Round:         header.Round,
+		Hash:          hex.EncodeToString(headerHash),
+		PrevBlockHash: hex.EncodeToString(header.PrevHash),
+		Epoch:         header.Epoch,
+		ShardID:       header.ShardId,
+		NumTxs:        header.TxCount,
+		RootHash:      hex.EncodeToString(header.RootHash),
+		TimeStamp:     header.TimeStamp,
+		MiniBlocks:    make([]*api.MiniBlock, 0, len(header.MiniBlockHeaders)),
+	}
+
+	for _, mbHeader := range header.MiniBlockHeaders {
+		apiMiniBlock := &api.MiniBlock{
+			Hash:            hex.EncodeToString(mbHeader.Hash),
+			Type:            mbHeader.Type.String(),
+			SenderShardID:   mbHeader.SenderShardID,
+			ReceiverShardID: mbHeader.ReceiverShardID,
+		}
+		n.fillMiniBlockTxHashes(apiMiniBlock, mbHeader.Hash)
+
+		apiBlock.MiniBlocks = append(apiBlock.MiniBlocks, apiMiniBlock)
+	}
+
+	return apiBlock
+}
+
+func (n *Node) convertMetaBlock(metaBlock *block.MetaBlock, headerHash []byte, withNotarizedMiniBlocks bool) *api.Block {
+	apiBlock := &api.Block{
+		Nonce:           metaBlock.Nonce,
+		Round:           metaBlock.Round,
+		Hash:            hex.EncodeToString(headerHash),
+		PrevBlockHash:   hex.EncodeToString(metaBlock.PrevHash),
+		Epoch:           metaBlock.Epoch,
+		ShardID:         sharding.MetachainShardId,
+		NumTxs:          metaBlock.TxCount,
+		RootHash:        hex.EncodeToString(metaBlock.RootHash),
+		TimeStamp:       metaBlock.TimeStamp,
+		MiniBlocks:      make([]*api.MiniBlock, 0, len(metaBlock.MiniBlockHeaders)),
+		NotarizedBlocks: make([]*api.NotarizedBlock, 0, len(metaBlock.ShardInfo)),
+	}
+
+	for _, mbHeader := range metaBlock.MiniBlockHeaders {
+		apiMiniBlock := &api.MiniBlock{
+			Hash:            hex.EncodeToString(mbHeader.Hash),
+			Type:            mbHeader.Type.String(),
+			SenderShardID:   mbHeader.SenderShardID,
+			ReceiverShardID: mbHeader.ReceiverShardID,
+		}
+		n.fillMiniBlockTxHashes(apiMiniBlock, mbHeader.Hash)
+
+		apiBlock.MiniBlocks = append(apiBlock.MiniBlocks, apiMiniBlock)
+	}
+
+	for _, shardData := range metaBlock.ShardInfo {
+		notarizedBlock := &api.NotarizedBlock{
+			Hash:    hex.EncodeToString(shardData.HeaderHash),
+			Nonce:   shardData.Nonce,
+			Round:   shardData.Round,
+			ShardID: shardData.ShardID,
+			NumTxs:  shardData.TxCount,
+		}
+
+		if withNotarizedMiniBlocks {
+			notarizedBlock.MiniBlocks = make([]*api.MiniBlock, 0, len(shardData.ShardMiniBlockHeaders))
+			for _, shardMbHeader := range shardData.ShardMiniBlockHeaders {
+				apiMiniBlock := &api.MiniBlock{
+					Hash:            hex.EncodeToString(shardMbHeader.Hash),
+					SenderShardID:   shardMbHeader.SenderShardID,
+					ReceiverShardID: shardMbHeader.ReceiverShardID,
+				}
+				n.fillMiniBlockTxHashes(apiMiniBlock, shardMbHeader.Hash)
+
+				notarizedBlock.MiniBlocks = append(notarizedBlock.MiniBlocks, apiMiniBlock)
+			}
+		}
+
+		apiBlock.NotarizedBlocks = append(apiBlock.NotarizedBlocks, notarizedBlock)
+	}
+
+	return apiBlock
+}
+
+// fillMiniBlockTxHashes completes the provided api miniblock with the transaction hashes read from the
+// miniblocks storage unit. Miniblocks which are not present in the local storage are left untouched
+func (n *Node) fillMiniBlockTxHashes(apiMiniBlock *api.MiniBlock, miniBlockHash []byte) {
+	buff, err := n.store.Get(dataRetriever.MiniBlockUnit, miniBlockHash)
+	if err != nil {
+		log.Trace("miniblock not found in storage", "hash", miniBlockHash)
+		return
+	}
+
+	miniBlock := &block.MiniBlock{}
+	err = n.marshalizer.Unmarshal(miniBlock, buff)
+	if err != nil {
+		log.Debug("fillMiniBlockTxHashes.Unmarshal", "hash", miniBlockHash, "error", err.Error())
+		return
+	}
+
+	apiMiniBlock.Type = miniBlock.Type.String()
+	apiMiniBlock.TxHashes = make([]string, 0, len(miniBlock.TxHashes))
+	for _, txHash := range miniBlock.TxHashes {
+		apiMiniBlock.TxHashes = append(apiMiniBlock.TxHashes, hex.EncodeToString(txHash))
+	}
+}
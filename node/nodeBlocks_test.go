@@ -0,0 +1,252 @@
+package node_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/node"
+	"github.com/ElrondNetwork/elrond-go/node/mock"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errKeyNotFound = errors.New("key not found")
+
+func createStoreForBlocks(units map[dataRetriever.UnitType]map[string][]byte) *mock.ChainStorerMock {
+	get := func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+		unit, ok := units[unitType]
+		if !ok {
+			return nil, errKeyNotFound
+		}
+		value, ok := unit[string(key)]
+		if !ok {
+			return nil, errKeyNotFound
+		}
+
+		return value, nil
+	}
+
+	return &mock.ChainStorerMock{
+		GetCalled: get,
+		HasCalled: func(unitType dataRetriever.UnitType, key []byte) error {
+			_, err := get(unitType, key)
+			return err
+		},
+	}
+}
+
+func createNodeForBlocks(t *testing.T, selfShardId uint32, units map[dataRetriever.UnitType]map[string][]byte) *node.Node {
+	n, err := node.NewNode(
+		node.WithDataStore(createStoreForBlocks(units)),
+		node.WithMarshalizer(&mock.MarshalizerFake{}, 0),
+		node.WithShardCoordinator(&mock.ShardCoordinatorMock{SelfShardId: selfShardId}),
+		node.WithUint64ByteSliceConverter(mock.NewNonceHashConverterMock()),
+	)
+	require.Nil(t, err)
+
+	return n
+}
+
+func TestNode_GetBlockByNonceNilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	apiBlock, err := n.GetBlockByNonce(1)
+
+	assert.Nil(t, apiBlock)
+	assert.Equal(t, node.ErrNilStore, err)
+}
+
+func TestNode_GetBlockByNonceMissingBlockShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	n := createNodeForBlocks(t, 0, make(map[dataRetriever.UnitType]map[string][]byte))
+	apiBlock, err := n.GetBlockByNonce(1)
+
+	assert.Nil(t, apiBlock)
+	assert.Nil(t, err)
+
+	apiBlock, err = n.GetHyperBlockByHash("aabb")
+
+	assert.Nil(t, apiBlock)
+	assert.Nil(t, err)
+}
+
+func TestNode_GetBlockByNonceStorageErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithDataStore(&mock.ChainStorerMock{
+			HasCalled: func(unitType dataRetriever.UnitType, key []byte) error {
+				return nil
+			},
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, errKeyNotFound
+			},
+		}),
+		node.WithMarshalizer(&mock.MarshalizerFake{}, 0),
+		node.WithShardCoordinator(&mock.ShardCoordinatorMock{SelfShardId: 0}),
+		node.WithUint64ByteSliceConverter(mock.NewNonceHashConverterMock()),
+	)
+	apiBlock, err := n.GetBlockByNonce(1)
+
+	assert.Nil(t, apiBlock)
+	assert.Equal(t, errKeyNotFound, err)
+}
+
+func TestNode_GetBlockByNonceShardBlockShouldWork(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	nonce := uint64(37)
+	headerHash := []byte("header hash")
+	mbHash := []byte("mb hash")
+	missingMbHash := []byte("missing mb hash")
+	txHash := []byte("tx hash")
+	header := &block.Header{
+		Nonce:    nonce,
+		Round:    39,
+		ShardId:  1,
+		TxCount:  1,
+		RootHash: []byte("root hash"),
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: mbHash, SenderShardID: 1, ReceiverShardID: 0, Type: block.TxBlock},
+			{Hash: missingMbHash, SenderShardID: 1, ReceiverShardID: 1, Type: block.SmartContractResultBlock},
+		},
+	}
+	miniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{txHash},
+		SenderShardID:   1,
+		ReceiverShardID: 0,
+		Type:            block.TxBlock,
+	}
+	marshalizedHeader, _ := marshalizer.Marshal(header)
+	marshalizedMiniBlock, _ := marshalizer.Marshal(miniBlock)
+
+	units := map[dataRetriever.UnitType]map[string][]byte{
+		dataRetriever.ShardHdrNonceHashDataUnit + 1: {
+			string(mock.NewNonceHashConverterMock().ToByteSlice(nonce)): headerHash,
+		},
+		dataRetriever.BlockHeaderUnit: {
+			string(headerHash): marshalizedHeader,
+		},
+		dataRetriever.MiniBlockUnit: {
+			string(mbHash): marshalizedMiniBlock,
+		},
+	}
+	n := createNodeForBlocks(t, 1, units)
+
+	apiBlock, err := n.GetBlockByNonce(nonce)
+	require.Nil(t, err)
+
+	assert.Equal(t, nonce, apiBlock.Nonce)
+	assert.Equal(t, header.Round, apiBlock.Round)
+	assert.Equal(t, hex.EncodeToString(headerHash), apiBlock.Hash)
+	assert.Equal(t, hex.EncodeToString(header.RootHash), apiBlock.RootHash)
+	assert.Equal(t, uint32(1), apiBlock.ShardID)
+	require.Equal(t, 2, len(apiBlock.MiniBlocks))
+	assert.Equal(t, []string{hex.EncodeToString(txHash)}, apiBlock.MiniBlocks[0].TxHashes)
+	assert.Equal(t, block.TxBlock.String(), apiBlock.MiniBlocks[0].Type)
+	assert.Nil(t, apiBlock.MiniBlocks[1].TxHashes)
+	assert.Equal(t, block.SmartContractResultBlock.String(), apiBlock.MiniBlocks[1].Type)
+}
+
+func TestNode_GetBlockByHashInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n := createNodeForBlocks(t, 0, make(map[dataRetriever.UnitType]map[string][]byte))
+	apiBlock, err := n.GetBlockByHash("not a hex string")
+
+	assert.Nil(t, apiBlock)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetBlockByHashOnMetachainShouldReturnMetaBlock(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	headerHash := []byte("meta hash")
+	metaBlock := &block.MetaBlock{
+		Nonce: 5,
+		ShardInfo: []block.ShardData{
+			{
+				HeaderHash: []byte("shard hash"),
+				Nonce:      7,
+				ShardID:    0,
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("mb hash")},
+				},
+			},
+		},
+	}
+	marshalizedMetaBlock, _ := marshalizer.Marshal(metaBlock)
+	units := map[dataRetriever.UnitType]map[string][]byte{
+		dataRetriever.MetaBlockUnit: {
+			string(headerHash): marshalizedMetaBlock,
+		},
+	}
+	n := createNodeForBlocks(t, sharding.MetachainShardId, units)
+
+	apiBlock, err := n.GetBlockByHash(hex.EncodeToString(headerHash))
+	require.Nil(t, err)
+
+	assert.Equal(t, metaBlock.Nonce, apiBlock.Nonce)
+	assert.Equal(t, sharding.MetachainShardId, apiBlock.ShardID)
+	require.Equal(t, 1, len(apiBlock.NotarizedBlocks))
+	assert.Equal(t, hex.EncodeToString([]byte("shard hash")), apiBlock.NotarizedBlocks[0].Hash)
+	assert.Nil(t, apiBlock.NotarizedBlocks[0].MiniBlocks)
+}
+
+func TestNode_GetHyperBlockByNonceShouldReturnNotarizedMiniBlocks(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	nonce := uint64(5)
+	headerHash := []byte("meta hash")
+	mbHash := []byte("mb hash")
+	txHash := []byte("tx hash")
+	metaBlock := &block.MetaBlock{
+		Nonce: nonce,
+		ShardInfo: []block.ShardData{
+			{
+				HeaderHash: []byte("shard hash"),
+				Nonce:      7,
+				ShardID:    0,
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: mbHash},
+				},
+			},
+		},
+	}
+	miniBlock := &block.MiniBlock{
+		TxHashes: [][]byte{txHash},
+		Type:     block.TxBlock,
+	}
+	marshalizedMetaBlock, _ := marshalizer.Marshal(metaBlock)
+	marshalizedMiniBlock, _ := marshalizer.Marshal(miniBlock)
+	units := map[dataRetriever.UnitType]map[string][]byte{
+		dataRetriever.MetaHdrNonceHashDataUnit: {
+			string(mock.NewNonceHashConverterMock().ToByteSlice(nonce)): headerHash,
+		},
+		dataRetriever.MetaBlockUnit: {
+			string(headerHash): marshalizedMetaBlock,
+		},
+		dataRetriever.MiniBlockUnit: {
+			string(mbHash): marshalizedMiniBlock,
+		},
+	}
+	n := createNodeForBlocks(t, 0, units)
+
+	apiBlock, err := n.GetHyperBlockByNonce(nonce)
+	require.Nil(t, err)
+
+	require.Equal(t, 1, len(apiBlock.NotarizedBlocks))
+	require.Equal(t, 1, len(apiBlock.NotarizedBlocks[0].MiniBlocks))
+	notarizedMiniBlock := apiBlock.NotarizedBlocks[0].MiniBlocks[0]
+	assert.Equal(t, block.TxBlock.String(), notarizedMiniBlock.Type)
+	assert.Equal(t, []string{hex.EncodeToString(txHash)}, notarizedMiniBlock.TxHashes)
+}
@@ -0,0 +1,38 @@
+package node
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+)
+
+// SubscribeToEvents registers a new chain events subscriber that will receive all committed and notarized
+// blocks and the transactions having one of the provided hex encoded addresses as sender or receiver
+func (n *Node) SubscribeToEvents(addresses []string) (*eventsNotifier.Subscription, error) {
+	if check.IfNil(n.eventsNotifier) {
+		return nil, ErrNilEventsNotifier
+	}
+	if check.IfNil(n.addrConverter) {
+		return nil, ErrNilAddressConverter
+	}
+
+	watchedAddresses := make([][]byte, 0, len(addresses))
+	for _, address := range addresses {
+		addr, err := n.addrConverter.CreateAddressFromHex(address)
+		if err != nil {
+			return nil, err
+		}
+
+		watchedAddresses = append(watchedAddresses, addr.Bytes())
+	}
+
+	return n.eventsNotifier.Subscribe(watchedAddresses), nil
+}
+
+// UnsubscribeFromEvents removes the provided chain events subscriber
+func (n *Node) UnsubscribeFromEvents(subscription *eventsNotifier.Subscription) {
+	if check.IfNil(n.eventsNotifier) {
+		return
+	}
+
+	n.eventsNotifier.Unsubscribe(subscription)
+}
@@ -0,0 +1,87 @@
+package node_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
+	"github.com/ElrondNetwork/elrond-go/node"
+	"github.com/ElrondNetwork/elrond-go/node/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_SubscribeToEventsNilEventsNotifierShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	subscription, err := n.SubscribeToEvents([]string{createDummyHexAddress(64)})
+
+	assert.Nil(t, subscription)
+	assert.Equal(t, node.ErrNilEventsNotifier, err)
+}
+
+func TestNode_SubscribeToEventsInvalidAddressShouldErr(t *testing.T) {
+	t.Parallel()
+
+	subscribeCalled := false
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithEventsNotifier(&mock.EventsNotifierStub{
+			SubscribeCalled: func(addresses [][]byte) *eventsNotifier.Subscription {
+				subscribeCalled = true
+				return nil
+			},
+		}),
+	)
+
+	subscription, err := n.SubscribeToEvents([]string{"not hex"})
+
+	assert.Nil(t, subscription)
+	assert.NotNil(t, err)
+	assert.False(t, subscribeCalled)
+}
+
+func TestNode_SubscribeToEventsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	addressHex := createDummyHexAddress(64)
+	addressBytes, _ := hex.DecodeString(addressHex)
+	expectedSubscription := &eventsNotifier.Subscription{}
+	var watchedAddresses [][]byte
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithEventsNotifier(&mock.EventsNotifierStub{
+			SubscribeCalled: func(addresses [][]byte) *eventsNotifier.Subscription {
+				watchedAddresses = addresses
+				return expectedSubscription
+			},
+		}),
+	)
+
+	subscription, err := n.SubscribeToEvents([]string{addressHex})
+
+	assert.Nil(t, err)
+	assert.True(t, subscription == expectedSubscription)
+	assert.Equal(t, [][]byte{addressBytes}, watchedAddresses)
+}
+
+func TestNode_UnsubscribeFromEventsShouldCallNotifier(t *testing.T) {
+	t.Parallel()
+
+	subscription := &eventsNotifier.Subscription{}
+	var unsubscribed *eventsNotifier.Subscription
+	n, _ := node.NewNode(
+		node.WithEventsNotifier(&mock.EventsNotifierStub{
+			UnsubscribeCalled: func(s *eventsNotifier.Subscription) {
+				unsubscribed = s
+			},
+		}),
+	)
+
+	n.UnsubscribeFromEvents(subscription)
+
+	assert.True(t, unsubscribed == subscription)
+}
@@ -0,0 +1,270 @@
+package node
+
+import (
+	"encoding/hex"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+)
+
+// GetTransaction returns the transaction having the provided hex encoded hash, together with its status and,
+// if it was executed, the smart contract results and the receipts it generated.
+// It returns nil if the transaction is neither in the pools nor in the storage
+func (n *Node) GetTransaction(hash string) (*api.Transaction, error) {
+	err := n.checkTransactionQueryComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txType, found := n.getTransactionFromPools(txHash)
+	if found {
+		apiTx := n.convertTransaction(tx, txType, txHash)
+		apiTx.Status = api.TxStatusPending
+
+		return apiTx, nil
+	}
+
+	tx, txType, found = n.getTransactionFromStorage(txHash)
+	if !found {
+		return nil, nil
+	}
+
+	apiTx := n.convertTransaction(tx, txType, txHash)
+	apiTx.Status = api.TxStatusUnknown
+	n.fillTransactionMetadata(apiTx, txHash)
+
+	return apiTx, nil
+}
+
+func (n *Node) checkTransactionQueryComponents() error {
+	if check.IfNil(n.store) {
+		return ErrNilStore
+	}
+	if check.IfNil(n.dataPool) {
+		return ErrNilDataPool
+	}
+	if check.IfNil(n.marshalizer) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(n.shardCoordinator) {
+		return ErrNilShardCoordinator
+	}
+
+	return nil
+}
+
+func (n *Node) getTransactionFromPools(txHash []byte) (data.TransactionHandler, string, bool) {
+	pools := map[string]dataRetriever.ShardedDataCacherNotifier{
+		api.TxTypeNormal:   n.dataPool.Transactions(),
+		api.TxTypeUnsigned: n.dataPool.UnsignedTransactions(),
+		api.TxTypeReward:   n.dataPool.RewardTransactions(),
+	}
+
+	for txType, pool := range pools {
+		if check.IfNil(pool) {
+			continue
+		}
+
+		value, ok := pool.SearchFirstData(txHash)
+		if !ok {
+			continue
+		}
+
+		tx, ok := value.(data.TransactionHandler)
+		if !ok {
+			continue
+		}
+
+		return tx, txType, true
+	}
+
+	return nil, "", false
+}
+
+func (n *Node) getTransactionFromStorage(txHash []byte) (data.TransactionHandler, string, bool) {
+	buff, err := n.store.Get(dataRetriever.TransactionUnit, txHash)
+	if err == nil {
+		tx := &transaction.Transaction{}
+		err = n.marshalizer.Unmarshal(tx, buff)
+
+		return tx, api.TxTypeNormal, err == nil
+	}
+
+	buff, err = n.store.Get(dataRetriever.UnsignedTransactionUnit, txHash)
+	if err == nil {
+		scr := &smartContractResult.SmartContractResult{}
+		err = n.marshalizer.Unmarshal(scr, buff)
+
+		return scr, api.TxTypeUnsigned, err == nil
+	}
+
+	buff, err = n.store.Get(dataRetriever.RewardTransactionUnit, txHash)
+	if err == nil {
+		rtx := &rewardTx.RewardTx{}
+		err = n.marshalizer.Unmarshal(rtx, buff)
+
+		return rtx, api.TxTypeReward, err == nil
+	}
+
+	return nil, "", false
+}
+
+func (n *Node) convertTransaction(tx data.TransactionHandler, txType string, txHash []byte) *api.Transaction {
+	apiTx := &api.Transaction{
+		Type:     txType,
+		Hash:     hex.EncodeToString(txHash),
+		Nonce:    tx.GetNonce(),
+		Receiver: hex.EncodeToString(tx.GetRecvAddress()),
+		Sender:   hex.EncodeToString(tx.GetSndAddress()),
+		GasPrice: tx.GetGasPrice(),
+		GasLimit: tx.GetGasLimit(),
+		Data:     tx.GetData(),
+	}
+	if tx.GetValue() != nil {
+		apiTx.Value = tx.GetValue().String()
+	}
+
+	signedTx, ok := tx.(*transaction.Transaction)
+	if ok {
+		apiTx.Signature = hex.EncodeToString(signedTx.Signature)
+	}
+
+	if !check.IfNil(n.addrConverter) {
+		apiTx.SourceShard = n.computeShardOfAddress(tx.GetSndAddress())
+		apiTx.DestinationShard = n.computeShardOfAddress(tx.GetRecvAddress())
+	}
+
+	return apiTx
+}
+
+func (n *Node) computeShardOfAddress(address []byte) uint32 {
+	addr, err := n.addrConverter.CreateAddressFromPublicKeyBytes(address)
+	if err != nil {
+		return n.shardCoordinator.SelfId()
+	}
+
+	return n.shardCoordinator.ComputeId(addr)
+}
+
+// fillTransactionMetadata completes the provided api transaction with the inclusion details, the status and the
+// results read from the transaction metadata storage unit
+func (n *Node) fillTransactionMetadata(apiTx *api.Transaction, txHash []byte) {
+	buff, err := n.store.Get(dataRetriever.TransactionMetadataUnit, txHash)
+	if err != nil {
+		log.Trace("transaction metadata not found in storage", "hash", txHash)
+		return
+	}
+
+	metadata := &transaction.Metadata{}
+	err = n.marshalizer.Unmarshal(metadata, buff)
+	if err != nil {
+		log.Debug("fillTransactionMetadata.Unmarshal", "hash", txHash, "error", err.Error())
+		return
+	}
+
+	apiTx.BlockNonce = metadata.BlockNonce
+	apiTx.BlockHash = hex.EncodeToString(metadata.BlockHash)
+	apiTx.MiniBlockHash = hex.EncodeToString(metadata.MiniBlockHash)
+	apiTx.MiniBlockType = metadata.MiniBlockType.String()
+	apiTx.Epoch = metadata.Epoch
+	apiTx.SourceShard = metadata.SenderShardID
+	apiTx.DestinationShard = metadata.ReceiverShardID
+	apiTx.Status = n.computeTransactionStatus(metadata)
+
+	for _, scrHash := range metadata.ScResultHashes {
+		apiScr, errGet := n.getSmartContractResult(scrHash)
+		if errGet != nil {
+			log.Debug("fillTransactionMetadata.getSmartContractResult", "hash", scrHash, "error", errGet.Error())
+			continue
+		}
+
+		apiTx.SmartContractResults = append(apiTx.SmartContractResults, apiScr)
+	}
+
+	for _, receiptHash := range metadata.ReceiptHashes {
+		apiReceipt, errGet := n.getReceipt(receiptHash)
+		if errGet != nil {
+			log.Debug("fillTransactionMetadata.getReceipt", "hash", receiptHash, "error", errGet.Error())
+			continue
+		}
+
+		apiTx.Receipts = append(apiTx.Receipts, apiReceipt)
+	}
+}
+
+func (n *Node) computeTransactionStatus(metadata *transaction.Metadata) api.TransactionStatus {
+	if metadata.MiniBlockType == block.InvalidBlock {
+		return api.TxStatusInvalid
+	}
+	if metadata.ReceiverShardID != n.shardCoordinator.SelfId() {
+		return api.TxStatusPartiallyExecuted
+	}
+
+	return api.TxStatusExecuted
+}
+
+func (n *Node) getSmartContractResult(scrHash []byte) (*api.SmartContractResult, error) {
+	buff, err := n.store.Get(dataRetriever.UnsignedTransactionUnit, scrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	scr := &smartContractResult.SmartContractResult{}
+	err = n.marshalizer.Unmarshal(scr, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	apiScr := &api.SmartContractResult{
+		Hash:           hex.EncodeToString(scrHash),
+		Nonce:          scr.Nonce,
+		Receiver:       hex.EncodeToString(scr.RcvAddr),
+		Sender:         hex.EncodeToString(scr.SndAddr),
+		Data:           scr.Data,
+		GasLimit:       scr.GasLimit,
+		GasPrice:       scr.GasPrice,
+		OriginalTxHash: hex.EncodeToString(scr.TxHash),
+	}
+	if scr.Value != nil {
+		apiScr.Value = scr.Value.String()
+	}
+
+	return apiScr, nil
+}
+
+func (n *Node) getReceipt(receiptHash []byte) (*api.Receipt, error) {
+	buff, err := n.store.Get(dataRetriever.UnsignedTransactionUnit, receiptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt := &receipt.Receipt{}
+	err = n.marshalizer.Unmarshal(rpt, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReceipt := &api.Receipt{
+		Hash:   hex.EncodeToString(receiptHash),
+		Sender: hex.EncodeToString(rpt.SndAddr),
+		Data:   rpt.Data,
+		TxHash: hex.EncodeToString(rpt.TxHash),
+	}
+	if rpt.Value != nil {
+		apiReceipt.Value = rpt.Value.String()
+	}
+
+	return apiReceipt, nil
+}
@@ -0,0 +1,213 @@
+package node_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/node"
+	"github.com/ElrondNetwork/elrond-go/node/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEmptyShardedDataStub() *mock.ShardedDataStub {
+	return &mock.ShardedDataStub{
+		SearchFirstDataCalled: func(key []byte) (value interface{}, ok bool) {
+			return nil, false
+		},
+	}
+}
+
+func createPoolsHolderForTransactions(txPool *mock.ShardedDataStub) *mock.PoolsHolderStub {
+	return &mock.PoolsHolderStub{
+		TransactionsCalled: func() dataRetriever.ShardedDataCacherNotifier {
+			return txPool
+		},
+		UnsignedTransactionsCalled: func() dataRetriever.ShardedDataCacherNotifier {
+			return createEmptyShardedDataStub()
+		},
+		RewardTransactionsCalled: func() dataRetriever.ShardedDataCacherNotifier {
+			return createEmptyShardedDataStub()
+		},
+	}
+}
+
+func createNodeForTransactions(
+	t *testing.T,
+	txPool *mock.ShardedDataStub,
+	units map[dataRetriever.UnitType]map[string][]byte,
+) *node.Node {
+	n, err := node.NewNode(
+		node.WithDataStore(createStoreForBlocks(units)),
+		node.WithDataPool(createPoolsHolderForTransactions(txPool)),
+		node.WithMarshalizer(&mock.MarshalizerFake{}, 0),
+		node.WithShardCoordinator(&mock.ShardCoordinatorMock{SelfShardId: 0}),
+	)
+	require.Nil(t, err)
+
+	return n
+}
+
+func TestNode_GetTransactionNilDataPoolShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithDataStore(&mock.ChainStorerMock{}),
+	)
+	tx, err := n.GetTransaction("aabb")
+
+	assert.Nil(t, tx)
+	assert.Equal(t, node.ErrNilDataPool, err)
+}
+
+func TestNode_GetTransactionInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n := createNodeForTransactions(t, createEmptyShardedDataStub(), make(map[dataRetriever.UnitType]map[string][]byte))
+	tx, err := n.GetTransaction("not a hex string")
+
+	assert.Nil(t, tx)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetTransactionNotFoundShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	n := createNodeForTransactions(t, createEmptyShardedDataStub(), make(map[dataRetriever.UnitType]map[string][]byte))
+	tx, err := n.GetTransaction("aabb")
+
+	assert.Nil(t, tx)
+	assert.Nil(t, err)
+}
+
+func TestNode_GetTransactionFromPoolShouldBePending(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	txPool := &mock.ShardedDataStub{
+		SearchFirstDataCalled: func(key []byte) (value interface{}, ok bool) {
+			if string(key) == string(txHash) {
+				return &transaction.Transaction{Nonce: 7, Value: big.NewInt(10)}, true
+			}
+			return nil, false
+		},
+	}
+	n := createNodeForTransactions(t, txPool, make(map[dataRetriever.UnitType]map[string][]byte))
+
+	tx, err := n.GetTransaction(hex.EncodeToString(txHash))
+	require.Nil(t, err)
+
+	assert.Equal(t, api.TxStatusPending, tx.Status)
+	assert.Equal(t, api.TxTypeNormal, tx.Type)
+	assert.Equal(t, uint64(7), tx.Nonce)
+	assert.Equal(t, "10", tx.Value)
+}
+
+func TestNode_GetTransactionFromStorageWithoutMetadataShouldBeUnknown(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	txHash := []byte("tx hash")
+	marshalizedTx, _ := marshalizer.Marshal(&transaction.Transaction{Nonce: 7, Value: big.NewInt(10)})
+	units := map[dataRetriever.UnitType]map[string][]byte{
+		dataRetriever.TransactionUnit: {
+			string(txHash): marshalizedTx,
+		},
+	}
+	n := createNodeForTransactions(t, createEmptyShardedDataStub(), units)
+
+	tx, err := n.GetTransaction(hex.EncodeToString(txHash))
+	require.Nil(t, err)
+
+	assert.Equal(t, api.TxStatusUnknown, tx.Status)
+	assert.Equal(t, uint64(7), tx.Nonce)
+}
+
+func TestNode_GetTransactionExecutedShouldReturnResults(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	txHash := []byte("tx hash")
+	scrHash := []byte("scr hash")
+	receiptHash := []byte("receipt hash")
+	blockHash := []byte("block hash")
+	marshalizedTx, _ := marshalizer.Marshal(&transaction.Transaction{Nonce: 7, Value: big.NewInt(10)})
+	marshalizedMetadata, _ := marshalizer.Marshal(&transaction.Metadata{
+		BlockNonce:      37,
+		BlockHash:       blockHash,
+		MiniBlockType:   block.TxBlock,
+		SenderShardID:   0,
+		ReceiverShardID: 0,
+		ScResultHashes:  [][]byte{scrHash},
+		ReceiptHashes:   [][]byte{receiptHash},
+	})
+	marshalizedScr, _ := marshalizer.Marshal(&smartContractResult.SmartContractResult{
+		Value:  big.NewInt(3),
+		TxHash: txHash,
+	})
+	marshalizedReceipt, _ := marshalizer.Marshal(&receipt.Receipt{
+		Value:  big.NewInt(2),
+		TxHash: txHash,
+	})
+	units := map[dataRetriever.UnitType]map[string][]byte{
+		dataRetriever.TransactionUnit: {
+			string(txHash): marshalizedTx,
+		},
+		dataRetriever.TransactionMetadataUnit: {
+			string(txHash): marshalizedMetadata,
+		},
+		dataRetriever.UnsignedTransactionUnit: {
+			string(scrHash):     marshalizedScr,
+			string(receiptHash): marshalizedReceipt,
+		},
+	}
+	n := createNodeForTransactions(t, createEmptyShardedDataStub(), units)
+
+	tx, err := n.GetTransaction(hex.EncodeToString(txHash))
+	require.Nil(t, err)
+
+	assert.Equal(t, api.TxStatusExecuted, tx.Status)
+	assert.Equal(t, uint64(37), tx.BlockNonce)
+	assert.Equal(t, hex.EncodeToString(blockHash), tx.BlockHash)
+	require.Equal(t, 1, len(tx.SmartContractResults))
+	assert.Equal(t, "3", tx.SmartContractResults[0].Value)
+	assert.Equal(t, hex.EncodeToString(txHash), tx.SmartContractResults[0].OriginalTxHash)
+	require.Equal(t, 1, len(tx.Receipts))
+	assert.Equal(t, "2", tx.Receipts[0].Value)
+}
+
+func TestNode_GetTransactionStatusFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	txHash := []byte("tx hash")
+	marshalizedTx, _ := marshalizer.Marshal(&transaction.Transaction{Nonce: 7, Value: big.NewInt(10)})
+
+	testStatus := func(metadata *transaction.Metadata, expectedStatus api.TransactionStatus) {
+		marshalizedMetadata, _ := marshalizer.Marshal(metadata)
+		units := map[dataRetriever.UnitType]map[string][]byte{
+			dataRetriever.TransactionUnit: {
+				string(txHash): marshalizedTx,
+			},
+			dataRetriever.TransactionMetadataUnit: {
+				string(txHash): marshalizedMetadata,
+			},
+		}
+		n := createNodeForTransactions(t, createEmptyShardedDataStub(), units)
+
+		tx, err := n.GetTransaction(hex.EncodeToString(txHash))
+		require.Nil(t, err)
+		assert.Equal(t, expectedStatus, tx.Status)
+	}
+
+	testStatus(&transaction.Metadata{MiniBlockType: block.InvalidBlock}, api.TxStatusInvalid)
+	testStatus(&transaction.Metadata{MiniBlockType: block.TxBlock, ReceiverShardID: 1}, api.TxStatusPartiallyExecuted)
+	testStatus(&transaction.Metadata{MiniBlockType: block.TxBlock, SenderShardID: 1}, api.TxStatusExecuted)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/consensus/spos"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/ElrondNetwork/elrond-go/data"
@@ -524,3 +525,14 @@ func WithRequestHandler(requestHandler process.RequestHandler) Option {
 		return nil
 	}
 }
+
+// WithEventsNotifier sets up the chain events notifier for the Node
+func WithEventsNotifier(notifier eventsNotifier.EventsNotifier) Option {
+	return func(n *Node) error {
+		if check.IfNil(notifier) {
+			return ErrNilEventsNotifier
+		}
+		n.eventsNotifier = notifier
+		return nil
+	}
+}
@@ -1082,3 +1082,25 @@ func TestWithPubKey_NilPublicKey(t *testing.T) {
 	err := opt(node)
 	assert.Equal(t, ErrNilPublicKey, err)
 }
+
+func TestWithEventsNotifier_NilEventsNotifierShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+	opt := WithEventsNotifier(nil)
+
+	err := opt(node)
+	assert.Equal(t, ErrNilEventsNotifier, err)
+}
+
+func TestWithEventsNotifier_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+	notifier := &mock.EventsNotifierStub{}
+	opt := WithEventsNotifier(notifier)
+
+	err := opt(node)
+	assert.Nil(t, err)
+	assert.True(t, node.eventsNotifier == notifier)
+}
@@ -0,0 +1,223 @@
+package accountProof
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// ArgsAccountProofProvider holds the components needed to create a new account proof provider
+type ArgsAccountProofProvider struct {
+	StateTrie        data.Trie
+	AddressConverter state.AddressConverter
+	Marshalizer      marshal.Marshalizer
+	Store            dataRetriever.StorageService
+	Uint64Converter  typeConverters.Uint64ByteSliceConverter
+	ShardCoordinator sharding.Coordinator
+}
+
+type accountProofProvider struct {
+	stateTrie        data.Trie
+	addressConverter state.AddressConverter
+	marshalizer      marshal.Marshalizer
+	store            dataRetriever.StorageService
+	uint64Converter  typeConverters.Uint64ByteSliceConverter
+	shardCoordinator sharding.Coordinator
+}
+
+// NewAccountProofProvider creates a component able to generate Merkle proofs for accounts and for the keys
+// of their data tries, at any root hash still available in the trie storage
+func NewAccountProofProvider(args ArgsAccountProofProvider) (*accountProofProvider, error) {
+	if check.IfNil(args.StateTrie) {
+		return nil, ErrNilStateTrie
+	}
+	if check.IfNil(args.AddressConverter) {
+		return nil, process.ErrNilAddressConverter
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, process.ErrNilMarshalizer
+	}
+	if check.IfNil(args.Store) {
+		return nil, process.ErrNilStore
+	}
+	if check.IfNil(args.Uint64Converter) {
+		return nil, process.ErrNilUint64Converter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, process.ErrNilShardCoordinator
+	}
+
+	return &accountProofProvider{
+		stateTrie:        args.StateTrie,
+		addressConverter: args.AddressConverter,
+		marshalizer:      args.Marshalizer,
+		store:            args.Store,
+		uint64Converter:  args.Uint64Converter,
+		shardCoordinator: args.ShardCoordinator,
+	}, nil
+}
+
+// GetProofByHeaderNonce returns the proof of the given account against the root hash of the node's shard header
+// having the given nonce. The hash of the header the proof was built on is returned with the proof
+func (app *accountProofProvider) GetProofByHeaderNonce(nonce uint64, address string, key string) (*api.AccountProof, error) {
+	header, headerHash, err := process.GetHeaderFromStorageWithNonce(
+		nonce,
+		app.shardCoordinator.SelfId(),
+		app.store,
+		app.uint64Converter,
+		app.marshalizer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w for nonce %d", err, nonce)
+	}
+
+	return app.getProofAtHeader(header, headerHash, address, key)
+}
+
+// GetProofByHeaderHash returns the proof of the given account against the root hash of the node's shard header
+// having the given hex encoded hash
+func (app *accountProofProvider) GetProofByHeaderHash(headerHash string, address string, key string) (*api.AccountProof, error) {
+	headerHashBytes, err := hex.DecodeString(headerHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(headerHashBytes) == 0 {
+		return nil, ErrEmptyHeaderHash
+	}
+
+	var header data.HeaderHandler
+	if app.shardCoordinator.SelfId() == sharding.MetachainShardId {
+		header, err = process.GetMetaHeaderFromStorage(headerHashBytes, app.marshalizer, app.store)
+	} else {
+		header, err = process.GetShardHeaderFromStorage(headerHashBytes, app.marshalizer, app.store)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w for hash %s", err, headerHash)
+	}
+
+	return app.getProofAtHeader(header, headerHashBytes, address, key)
+}
+
+func (app *accountProofProvider) getProofAtHeader(
+	header data.HeaderHandler,
+	headerHash []byte,
+	address string,
+	key string,
+) (*api.AccountProof, error) {
+	proof, err := app.GetProof(hex.EncodeToString(header.GetRootHash()), address, key)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.HeaderHash = hex.EncodeToString(headerHash)
+	proof.HeaderNonce = header.GetNonce()
+
+	return proof, nil
+}
+
+// GetProof returns the proof of the given account against the hex encoded root hash. If a hex encoded key is
+// provided, the proof of the key against the account's data trie is also returned
+func (app *accountProofProvider) GetProof(rootHash string, address string, key string) (*api.AccountProof, error) {
+	rootHashBytes, err := hex.DecodeString(rootHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(rootHashBytes) == 0 {
+		return nil, ErrEmptyRootHash
+	}
+
+	addr, err := app.addressConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof, accountValue, err := app.getProofAtRootHash(rootHashBytes, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.AccountProof{
+		RootHash: rootHash,
+		Account:  accountProof,
+	}
+	if len(key) == 0 {
+		return result, nil
+	}
+
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result.DataTrieKey = &api.MerkleProof{
+		Key:   key,
+		Proof: make([]string, 0),
+	}
+	if len(accountValue) == 0 {
+		return result, nil
+	}
+
+	account := &state.Account{}
+	err = app.marshalizer.Unmarshal(account, accountValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(account.RootHash) == 0 {
+		return result, nil
+	}
+
+	result.DataTrieRootHash = hex.EncodeToString(account.RootHash)
+	result.DataTrieKey, _, err = app.getProofAtRootHash(account.RootHash, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (app *accountProofProvider) getProofAtRootHash(rootHash []byte, key []byte) (*api.MerkleProof, []byte, error) {
+	tr, err := app.stateTrie.Recreate(rootHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := tr.Prove(key)
+	if err == trie.ErrNilNode {
+		proof = make([][]byte, 0)
+		err = nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value, err := tr.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encodedProof := make([]string, 0, len(proof))
+	for _, encodedNode := range proof {
+		encodedProof = append(encodedProof, hex.EncodeToString(encodedNode))
+	}
+
+	return &api.MerkleProof{
+		Key:   hex.EncodeToString(key),
+		Value: hex.EncodeToString(value),
+		Proof: encodedProof,
+	}, value, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (app *accountProofProvider) IsInterfaceNil() bool {
+	return app == nil
+}
@@ -0,0 +1,329 @@
+package accountProof_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/addressConverters"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/node/external"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/accountProof"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const addressLen = 32
+
+func createStateTrie() data.Trie {
+	trieStorage, _ := trie.NewTrieStorageManagerWithoutPruning(memorydb.New())
+	tr, _ := trie.NewTrie(trieStorage, &mock.MarshalizerMock{}, mock.HasherMock{})
+
+	return tr
+}
+
+func createAddress(b byte) []byte {
+	address := make([]byte, addressLen)
+	address[addressLen-1] = b
+
+	return address
+}
+
+func saveAccount(t *testing.T, stateTrie data.Trie, address []byte, balance int64, dataTrieRootHash []byte) []byte {
+	account := &state.Account{
+		Balance:  big.NewInt(balance),
+		RootHash: dataTrieRootHash,
+		Address:  address,
+	}
+	buff, err := (&mock.MarshalizerMock{}).Marshal(account)
+	require.Nil(t, err)
+
+	err = stateTrie.Update(address, buff)
+	require.Nil(t, err)
+	err = stateTrie.Commit()
+	require.Nil(t, err)
+
+	rootHash, _ := stateTrie.Root()
+	return rootHash
+}
+
+func createMemUnit() storage.Storer {
+	cache, _ := lrucache.NewCache(10)
+	unit, _ := storageUnit.NewStorageUnit(cache, memorydb.New())
+
+	return unit
+}
+
+func createArgs(stateTrie data.Trie) accountProof.ArgsAccountProofProvider {
+	store := dataRetriever.NewChainStorer()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnit, createMemUnit())
+	addrConv, _ := addressConverters.NewPlainAddressConverter(addressLen, "")
+
+	return accountProof.ArgsAccountProofProvider{
+		StateTrie:        stateTrie,
+		AddressConverter: addrConv,
+		Marshalizer:      &mock.MarshalizerMock{},
+		Store:            store,
+		Uint64Converter:  uint64ByteSlice.NewBigEndianConverter(),
+		ShardCoordinator: mock.NewOneShardCoordinatorMock(),
+	}
+}
+
+func createProvider(stateTrie data.Trie) external.AccountProofProvider {
+	provider, _ := accountProof.NewAccountProofProvider(createArgs(stateTrie))
+
+	return provider
+}
+
+func saveHeader(t *testing.T, args accountProof.ArgsAccountProofProvider, nonce uint64, rootHash []byte) []byte {
+	header := &block.Header{Nonce: nonce, RootHash: rootHash}
+	headerBytes, err := args.Marshalizer.Marshal(header)
+	require.Nil(t, err)
+	headerHash := mock.HasherMock{}.Compute(string(headerBytes))
+
+	err = args.Store.Put(dataRetriever.BlockHeaderUnit, headerHash, headerBytes)
+	require.Nil(t, err)
+	err = args.Store.Put(dataRetriever.ShardHdrNonceHashDataUnit, args.Uint64Converter.ToByteSlice(nonce), headerHash)
+	require.Nil(t, err)
+
+	return headerHash
+}
+
+func verifyProof(t *testing.T, rootHash string, proof []string, key string) ([]byte, bool) {
+	verifier, _ := trie.NewMerkleProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{})
+
+	rootHashBytes, _ := hex.DecodeString(rootHash)
+	keyBytes, _ := hex.DecodeString(key)
+	proofBytes := make([][]byte, 0, len(proof))
+	for _, encodedNode := range proof {
+		nodeBytes, _ := hex.DecodeString(encodedNode)
+		proofBytes = append(proofBytes, nodeBytes)
+	}
+
+	value, found, err := verifier.VerifyProof(rootHashBytes, keyBytes, proofBytes)
+	require.Nil(t, err)
+
+	return value, found
+}
+
+func TestNewAccountProofProvider_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setNil      func(args *accountProof.ArgsAccountProofProvider)
+		expectedErr error
+	}{
+		{"state trie", func(args *accountProof.ArgsAccountProofProvider) { args.StateTrie = nil }, accountProof.ErrNilStateTrie},
+		{"address converter", func(args *accountProof.ArgsAccountProofProvider) { args.AddressConverter = nil }, process.ErrNilAddressConverter},
+		{"marshalizer", func(args *accountProof.ArgsAccountProofProvider) { args.Marshalizer = nil }, process.ErrNilMarshalizer},
+		{"store", func(args *accountProof.ArgsAccountProofProvider) { args.Store = nil }, process.ErrNilStore},
+		{"uint64 converter", func(args *accountProof.ArgsAccountProofProvider) { args.Uint64Converter = nil }, process.ErrNilUint64Converter},
+		{"shard coordinator", func(args *accountProof.ArgsAccountProofProvider) { args.ShardCoordinator = nil }, process.ErrNilShardCoordinator},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			args := createArgs(createStateTrie())
+			tt.setNil(&args)
+
+			provider, err := accountProof.NewAccountProofProvider(args)
+
+			assert.True(t, check.IfNil(provider))
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestAccountProofProvider_GetProofInvalidRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	provider := createProvider(createStateTrie())
+	address := hex.EncodeToString(createAddress(1))
+
+	_, err := provider.GetProof("not hex", address, "")
+	assert.NotNil(t, err)
+
+	_, err = provider.GetProof("", address, "")
+	assert.Equal(t, accountProof.ErrEmptyRootHash, err)
+}
+
+func TestAccountProofProvider_GetProofExistingAccountShouldWork(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	_ = saveAccount(t, stateTrie, createAddress(1), 10, nil)
+	rootHash := saveAccount(t, stateTrie, createAddress(2), 20, nil)
+	provider := createProvider(stateTrie)
+
+	address := hex.EncodeToString(createAddress(2))
+	result, err := provider.GetProof(hex.EncodeToString(rootHash), address, "")
+	require.Nil(t, err)
+	assert.Nil(t, result.DataTrieKey)
+
+	value, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	require.True(t, found)
+	assert.Equal(t, result.Account.Value, hex.EncodeToString(value))
+
+	account := &state.Account{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(account, value)
+	assert.Equal(t, big.NewInt(20), account.Balance)
+}
+
+func TestAccountProofProvider_GetProofMissingAccountShouldProveAbsence(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	_ = saveAccount(t, stateTrie, createAddress(1), 10, nil)
+	rootHash := saveAccount(t, stateTrie, createAddress(2), 20, nil)
+	provider := createProvider(stateTrie)
+
+	address := hex.EncodeToString(createAddress(3))
+	result, err := provider.GetProof(hex.EncodeToString(rootHash), address, "aa")
+	require.Nil(t, err)
+	assert.Empty(t, result.Account.Value)
+	assert.Empty(t, result.DataTrieKey.Proof)
+
+	_, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	assert.False(t, found)
+}
+
+func TestAccountProofProvider_GetProofAtHistoricalRootHash(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	oldRootHash := saveAccount(t, stateTrie, createAddress(1), 10, nil)
+	_ = saveAccount(t, stateTrie, createAddress(1), 99, nil)
+	provider := createProvider(stateTrie)
+
+	address := hex.EncodeToString(createAddress(1))
+	result, err := provider.GetProof(hex.EncodeToString(oldRootHash), address, "")
+	require.Nil(t, err)
+
+	value, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	require.True(t, found)
+
+	account := &state.Account{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(account, value)
+	assert.Equal(t, big.NewInt(10), account.Balance)
+}
+
+func TestAccountProofProvider_GetProofWithDataTrieKeyShouldWork(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	dataTrie, _ := stateTrie.Recreate(make([]byte, 32))
+	_ = dataTrie.Update([]byte("key1"), []byte("value1"))
+	_ = dataTrie.Update([]byte("key2"), []byte("value2"))
+	_ = dataTrie.Commit()
+	dataTrieRootHash, _ := dataTrie.Root()
+
+	rootHash := saveAccount(t, stateTrie, createAddress(1), 10, dataTrieRootHash)
+	provider := createProvider(stateTrie)
+
+	address := hex.EncodeToString(createAddress(1))
+	key := hex.EncodeToString([]byte("key2"))
+	result, err := provider.GetProof(hex.EncodeToString(rootHash), address, key)
+	require.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(dataTrieRootHash), result.DataTrieRootHash)
+
+	_, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	require.True(t, found)
+
+	value, found := verifyProof(t, result.DataTrieRootHash, result.DataTrieKey.Proof, key)
+	require.True(t, found)
+	assert.Equal(t, []byte("value2"), value)
+
+	missingKey := hex.EncodeToString([]byte("key3"))
+	result, err = provider.GetProof(hex.EncodeToString(rootHash), address, missingKey)
+	require.Nil(t, err)
+	_, found = verifyProof(t, result.DataTrieRootHash, result.DataTrieKey.Proof, missingKey)
+	assert.False(t, found)
+}
+
+func TestAccountProofProvider_GetProofByHeaderNonceShouldProveAgainstTheHeaderRootHash(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	args := createArgs(stateTrie)
+	oldRootHash := saveAccount(t, stateTrie, createAddress(1), 10, nil)
+	oldHeaderHash := saveHeader(t, args, 5, oldRootHash)
+	newRootHash := saveAccount(t, stateTrie, createAddress(1), 99, nil)
+	_ = saveHeader(t, args, 6, newRootHash)
+	provider, _ := accountProof.NewAccountProofProvider(args)
+
+	address := hex.EncodeToString(createAddress(1))
+	result, err := provider.GetProofByHeaderNonce(5, address, "")
+	require.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(oldHeaderHash), result.HeaderHash)
+	assert.Equal(t, uint64(5), result.HeaderNonce)
+	assert.Equal(t, hex.EncodeToString(oldRootHash), result.RootHash)
+
+	value, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	require.True(t, found)
+
+	account := &state.Account{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(account, value)
+	assert.Equal(t, big.NewInt(10), account.Balance)
+}
+
+func TestAccountProofProvider_GetProofByHeaderNonceMissingHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	provider := createProvider(createStateTrie())
+
+	result, err := provider.GetProofByHeaderNonce(5, hex.EncodeToString(createAddress(1)), "")
+
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+}
+
+func TestAccountProofProvider_GetProofByHeaderHashShouldProveAgainstTheHeaderRootHash(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := createStateTrie()
+	args := createArgs(stateTrie)
+	rootHash := saveAccount(t, stateTrie, createAddress(1), 10, nil)
+	headerHash := saveHeader(t, args, 5, rootHash)
+	_ = saveAccount(t, stateTrie, createAddress(1), 99, nil)
+	provider, _ := accountProof.NewAccountProofProvider(args)
+
+	address := hex.EncodeToString(createAddress(1))
+	result, err := provider.GetProofByHeaderHash(hex.EncodeToString(headerHash), address, "")
+	require.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(headerHash), result.HeaderHash)
+	assert.Equal(t, uint64(5), result.HeaderNonce)
+	assert.Equal(t, hex.EncodeToString(rootHash), result.RootHash)
+
+	_, found := verifyProof(t, result.RootHash, result.Account.Proof, address)
+	assert.True(t, found)
+}
+
+func TestAccountProofProvider_GetProofByHeaderHashInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	provider := createProvider(createStateTrie())
+	address := hex.EncodeToString(createAddress(1))
+
+	_, err := provider.GetProofByHeaderHash("not hex", address, "")
+	assert.NotNil(t, err)
+
+	_, err = provider.GetProofByHeaderHash("", address, "")
+	assert.Equal(t, accountProof.ErrEmptyHeaderHash, err)
+
+	_, err = provider.GetProofByHeaderHash("aabb", address, "")
+	assert.NotNil(t, err)
+}
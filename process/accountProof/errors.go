@@ -0,0 +1,12 @@
+package accountProof
+
+import "errors"
+
+// ErrNilStateTrie signals that a nil state trie has been provided
+var ErrNilStateTrie = errors.New("nil state trie")
+
+// ErrEmptyRootHash signals that an empty root hash has been provided
+var ErrEmptyRootHash = errors.New("empty root hash")
+
+// ErrEmptyHeaderHash signals that an empty header hash has been provided
+var ErrEmptyHeaderHash = errors.New("empty header hash")
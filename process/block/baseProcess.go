@@ -9,6 +9,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/consensus"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/core/serviceContainer"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/data/state"
@@ -796,6 +797,20 @@ func (bp *baseProcessor) prepareDataForBootStorer(
 	}
 }
 
+// flushStorage makes durable the pending writes of the units configured with FlushOnCommit. It is called when the
+// committed block is fully written and before its bootstrap record is saved, so the record never points to a block
+// whose data is still buffered. If the flush fails, the bootstrap record of the round must not be saved
+func (bp *baseProcessor) flushStorage(round uint64) error {
+	err := bp.store.FlushAll()
+	if err != nil {
+		log.Warn("cannot flush storage units, the bootstrap record of the round will not be saved",
+			"round", round,
+			"error", err.Error())
+	}
+
+	return err
+}
+
 func (bp *baseProcessor) getLastCrossNotarizedHeaders() []bootstrapStorage.BootstrapHeaderInfo {
 	lastCrossNotarizedHeaders := make([]bootstrapStorage.BootstrapHeaderInfo, 0, bp.shardCoordinator.NumberOfShards()+1)
 
@@ -986,3 +1001,19 @@ func getLastSelfNotarizedHeaderByItself(chainHandler data.ChainHandler) (data.He
 
 	return chainHandler.GetCurrentBlockHeader(), chainHandler.GetCurrentBlockHeaderHash()
 }
+
+// notifyCommittedBlock pushes the committed block and the transactions it used to the chain events subscribers
+func (bp *baseProcessor) notifyCommittedBlock(coreService serviceContainer.Core, header data.HeaderHandler, headerHash []byte) {
+	if check.IfNil(coreService) || check.IfNil(coreService.EventsNotifier()) {
+		return
+	}
+
+	txPool := make(map[string]data.TransactionHandler)
+	for _, blockType := range []block.Type{block.TxBlock, block.SmartContractResultBlock, block.RewardsBlock} {
+		for hash, tx := range bp.txCoordinator.GetAllCurrentUsedTxs(blockType) {
+			txPool[hash] = tx
+		}
+	}
+
+	coreService.EventsNotifier().NotifyCommittedBlock(header, headerHash, txPool)
+}
@@ -11,7 +11,7 @@ import (
 )
 
 // HighestRoundFromBootStorage is the key for the highest round that is saved in storage
-const highestRoundFromBootStorage = "highestRoundFromBootStorage"
+const HighestRoundFromBootStorage = "highestRoundFromBootStorage"
 
 // ErrNilMarshalizer signals that an operation has been attempted to or with a nil Marshalizer implementation
 var ErrNilMarshalizer = errors.New("nil Marshalizer")
@@ -98,7 +98,7 @@ func (bs *bootstrapStorer) Put(round int64, bootData BootstrapData) error {
 		return err
 	}
 
-	err = bs.store.Put([]byte(highestRoundFromBootStorage), roundBytes)
+	err = bs.store.Put([]byte(HighestRoundFromBootStorage), roundBytes)
 	if err != nil {
 		return err
 	}
@@ -127,7 +127,7 @@ func (bs *bootstrapStorer) Get(round int64) (BootstrapData, error) {
 
 // GetHighestRound will return highest round saved in storage
 func (bs *bootstrapStorer) GetHighestRound() int64 {
-	roundBytes, err := bs.store.Get([]byte(highestRoundFromBootStorage))
+	roundBytes, err := bs.store.Get([]byte(HighestRoundFromBootStorage))
 	if err != nil {
 		return 0
 	}
@@ -151,7 +151,7 @@ func (bs *bootstrapStorer) SaveLastRound(round int64) error {
 		return err
 	}
 
-	err = bs.store.Put([]byte(highestRoundFromBootStorage), roundBytes)
+	err = bs.store.Put([]byte(HighestRoundFromBootStorage), roundBytes)
 	if err != nil {
 		return err
 	}
@@ -438,7 +438,7 @@ func (mp *metaProcessor) indexBlock(
 	// Update tps benchmarks in the DB
 	tpsBenchmark := mp.core.TPSBenchmark()
 	if tpsBenchmark != nil {
-		go mp.core.Indexer().UpdateTPS(tpsBenchmark)
+		mp.core.Indexer().UpdateTPS(tpsBenchmark)
 	}
 
 	txPool := mp.txCoordinator.GetAllCurrentUsedTxs(block.TxBlock)
@@ -454,7 +454,7 @@ func (mp *metaProcessor) indexBlock(
 	}
 
 	signersIndexes := mp.nodesCoordinator.GetValidatorsIndexes(publicKeys)
-	go mp.core.Indexer().SaveBlock(body, metaBlock, txPool, signersIndexes)
+	mp.core.Indexer().SaveBlock(body, metaBlock, txPool, signersIndexes)
 
 	saveRoundInfoInElastic(mp.core.Indexer(), mp.nodesCoordinator, sharding.MetachainShardId, metaBlock, lastMetaBlock, signersIndexes)
 }
@@ -973,6 +973,7 @@ func (mp *metaProcessor) CommitBlock(
 	}
 
 	mp.indexBlock(header, body, lastMetaBlock)
+	mp.notifyCommittedBlock(mp.core, header, headerHash)
 
 	saveMetachainCommitBlockMetrics(mp.appStatusHandler, header, headerHash, mp.nodesCoordinator)
 
@@ -990,14 +991,17 @@ func (mp *metaProcessor) CommitBlock(
 		Hash:    headerHash,
 	}
 
-	go mp.prepareDataForBootStorer(
-		headerInfo,
-		header.Round,
-		nil,
-		mp.getPendingMiniBlocks(),
-		mp.forkDetector.GetHighestFinalBlockNonce(),
-		nil,
-	)
+	errFlush := mp.flushStorage(header.Round)
+	if errFlush == nil {
+		go mp.prepareDataForBootStorer(
+			headerInfo,
+			header.Round,
+			nil,
+			mp.getPendingMiniBlocks(),
+			mp.forkDetector.GetHighestFinalBlockNonce(),
+			nil,
+		)
+	}
 
 	mp.blockSizeThrottler.Succeed(header.Round)
 
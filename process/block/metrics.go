@@ -148,7 +148,7 @@ func saveRoundInfoInElastic(
 		Timestamp:        time.Duration(header.GetTimeStamp()),
 	}
 
-	go elasticIndexer.SaveRoundInfo(roundInfo)
+	elasticIndexer.SaveRoundInfo(roundInfo)
 
 	if lastHeader == nil {
 		return
@@ -171,7 +171,7 @@ func saveRoundInfoInElastic(
 			Timestamp:        time.Duration(header.GetTimeStamp() - ((currentBlockRound - i) * roundDuration)),
 		}
 
-		go elasticIndexer.SaveRoundInfo(roundInfo)
+		elasticIndexer.SaveRoundInfo(roundInfo)
 	}
 }
 
@@ -11,6 +11,9 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core/sliceUtil"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/display"
 	"github.com/ElrondNetwork/elrond-go/process"
@@ -507,11 +510,108 @@ func (sp *shardProcessor) indexBlockIfNeeded(
 	}
 
 	signersIndexes := sp.nodesCoordinator.GetValidatorsIndexes(pubKeys)
-	go sp.core.Indexer().SaveBlock(body, header, txPool, signersIndexes)
+	sp.core.Indexer().SaveBlock(body, header, txPool, signersIndexes)
 
 	saveRoundInfoInElastic(sp.core.Indexer(), sp.nodesCoordinator, shardId, header, lastBlockHeader, signersIndexes)
 }
 
+// saveTransactionsMetadata stores, for each transaction included in the committed body, the block and miniblock
+// it was included in, and links the generated smart contract results and receipts to their originating transactions.
+// The metadata is read, updated and written back on the commit path, so the links of consecutive blocks never
+// overwrite each other
+func (sp *shardProcessor) saveTransactionsMetadata(header *block.Header, headerHash []byte, body block.Body) {
+	scResults := sp.txCoordinator.GetAllCurrentUsedTxs(block.SmartContractResultBlock)
+	receipts := sp.txCoordinator.GetAllCurrentUsedTxs(block.ReceiptBlock)
+
+	metadataByTxHash := make(map[string]*transaction.Metadata)
+	for _, miniBlock := range body {
+		marshalizedMiniBlock, errNotCritical := sp.marshalizer.Marshal(miniBlock)
+		if errNotCritical != nil {
+			log.Debug("saveTransactionsMetadata.Marshal", "error", errNotCritical.Error())
+			continue
+		}
+
+		miniBlockHash := sp.hasher.Compute(string(marshalizedMiniBlock))
+		for _, txHash := range miniBlock.TxHashes {
+			metadataByTxHash[string(txHash)] = &transaction.Metadata{
+				BlockNonce:      header.Nonce,
+				BlockHash:       headerHash,
+				Round:           header.Round,
+				Epoch:           header.Epoch,
+				MiniBlockHash:   miniBlockHash,
+				MiniBlockType:   miniBlock.Type,
+				SenderShardID:   miniBlock.SenderShardID,
+				ReceiverShardID: miniBlock.ReceiverShardID,
+			}
+		}
+	}
+
+	for scrHash, tx := range scResults {
+		scr, ok := tx.(*smartContractResult.SmartContractResult)
+		if !ok {
+			continue
+		}
+
+		metadata := sp.getTransactionMetadata(metadataByTxHash, scr.TxHash)
+		if metadata != nil {
+			metadata.ScResultHashes = append(metadata.ScResultHashes, []byte(scrHash))
+		}
+	}
+
+	for receiptHash, tx := range receipts {
+		rpt, ok := tx.(*receipt.Receipt)
+		if !ok {
+			continue
+		}
+
+		metadata := sp.getTransactionMetadata(metadataByTxHash, rpt.TxHash)
+		if metadata != nil {
+			metadata.ReceiptHashes = append(metadata.ReceiptHashes, []byte(receiptHash))
+		}
+	}
+
+	for txHash, metadata := range metadataByTxHash {
+		buff, errNotCritical := sp.marshalizer.Marshal(metadata)
+		if errNotCritical != nil {
+			log.Debug("saveTransactionsMetadata.Marshal", "error", errNotCritical.Error())
+			continue
+		}
+
+		errNotCritical = sp.store.Put(dataRetriever.TransactionMetadataUnit, []byte(txHash), buff)
+		if errNotCritical != nil {
+			log.Debug("saveTransactionsMetadata.Put -> TransactionMetadataUnit", "error", errNotCritical.Error())
+		}
+	}
+}
+
+// getTransactionMetadata returns the metadata of the provided transaction, searching first in the metadata created
+// for the current block and then in storage. The metadata read from storage is added to the provided map
+func (sp *shardProcessor) getTransactionMetadata(
+	metadataByTxHash map[string]*transaction.Metadata,
+	txHash []byte,
+) *transaction.Metadata {
+	metadata, ok := metadataByTxHash[string(txHash)]
+	if ok {
+		return metadata
+	}
+
+	buff, err := sp.store.Get(dataRetriever.TransactionMetadataUnit, txHash)
+	if err != nil {
+		return nil
+	}
+
+	metadata = &transaction.Metadata{}
+	err = sp.marshalizer.Unmarshal(metadata, buff)
+	if err != nil {
+		log.Debug("getTransactionMetadata.Unmarshal", "error", err.Error())
+		return nil
+	}
+
+	metadataByTxHash[string(txHash)] = metadata
+
+	return metadata
+}
+
 // RestoreBlockIntoPools restores the TxBlock and MetaBlock into associated pools
 func (sp *shardProcessor) RestoreBlockIntoPools(headerHandler data.HeaderHandler, bodyHandler data.BodyHandler) error {
 	if check.IfNil(headerHandler) {
@@ -697,6 +797,8 @@ func (sp *shardProcessor) CommitBlock(
 
 	go sp.saveBody(body)
 
+	sp.saveTransactionsMetadata(header, headerHash, body)
+
 	processedMetaHdrs, err := sp.getOrderedProcessedMetaBlocksFromHeader(header)
 	if err != nil {
 		return err
@@ -780,6 +882,7 @@ func (sp *shardProcessor) CommitBlock(
 
 	chainHandler.SetCurrentBlockHeaderHash(headerHash)
 	sp.indexBlockIfNeeded(bodyHandler, headerHandler, lastBlockHeader)
+	sp.notifyCommittedBlock(sp.core, header, headerHash)
 
 	lastCrossNotarizedHeader, _, err := sp.blockTracker.GetLastCrossNotarizedHeader(sharding.MetachainShardId)
 	if err != nil {
@@ -804,14 +907,17 @@ func (sp *shardProcessor) CommitBlock(
 		sp.lowestNonceInSelfNotarizedHeaders = selfNotarizedHeaders[0].GetNonce()
 	}
 
-	go sp.prepareDataForBootStorer(
-		headerInfo,
-		header.Round,
-		sp.getBootstrapHeadersInfo(selfNotarizedHeaders, selfNotarizedHeadersHashes),
-		nil,
-		sp.lowestNonceInSelfNotarizedHeaders,
-		sp.processedMiniBlocks.ConvertProcessedMiniBlocksMapToSlice(),
-	)
+	errFlush := sp.flushStorage(header.Round)
+	if errFlush == nil {
+		go sp.prepareDataForBootStorer(
+			headerInfo,
+			header.Round,
+			sp.getBootstrapHeadersInfo(selfNotarizedHeaders, selfNotarizedHeadersHashes),
+			nil,
+			sp.lowestNonceInSelfNotarizedHeaders,
+			sp.processedMiniBlocks.ConvertProcessedMiniBlocksMapToSlice(),
+		)
+	}
 
 	go sp.cleanTxsPools()
 
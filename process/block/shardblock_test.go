@@ -13,6 +13,7 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/eventsNotifier"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
@@ -28,6 +29,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/marshal"
 	"github.com/ElrondNetwork/elrond-go/process"
 	blproc "github.com/ElrondNetwork/elrond-go/process/block"
+	"github.com/ElrondNetwork/elrond-go/process/block/bootstrapStorage"
 	"github.com/ElrondNetwork/elrond-go/process/coordinator"
 	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
@@ -1763,6 +1765,14 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 		return hdrHash
 	}
 	store := initStore()
+	store.AddStorer(dataRetriever.TransactionMetadataUnit, generateTestUnit())
+	storageFlushed := false
+	store.AddStorer(dataRetriever.PeerChangesUnit, &mock.StorerStub{
+		FlushCalled: func() error {
+			storageFlushed = true
+			return nil
+		},
+	})
 
 	arguments := CreateMockArgumentsMultiShard()
 	arguments.DataPool = tdp
@@ -1789,11 +1799,127 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	err = sp.CommitBlock(blkc, hdr, body)
 	assert.Nil(t, err)
 	assert.True(t, forkDetectorAddCalled)
+	assert.True(t, storageFlushed)
 	assert.Equal(t, hdrHash, blkc.GetCurrentBlockHeaderHash())
+	_, err = store.Get(dataRetriever.TransactionMetadataUnit, txHash)
+	assert.Nil(t, err, "the transactions metadata should be written when the commit returns")
 	//this should sleep as there is an async call to display current hdr and block in CommitBlock
 	time.Sleep(time.Second)
 }
 
+func TestShardProcessor_CommitBlockWithFailingFlushShouldNotSaveTheBootstrapRecord(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	txHash := []byte("tx_hash1")
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	randSeed := []byte("rand seed")
+
+	prevHdr := &block.Header{
+		Nonce:         0,
+		Round:         0,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+		RandSeed:      randSeed,
+	}
+
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+		PrevRandSeed:  randSeed,
+	}
+	mb := block.MiniBlock{
+		TxHashes: [][]byte{txHash},
+	}
+	body := block.Body{&mb}
+
+	mbHdr := block.MiniBlockHeader{
+		TxCount: uint32(len(mb.TxHashes)),
+		Hash:    hdrHash,
+	}
+	mbHdrs := make([]block.MiniBlockHeader, 0)
+	mbHdrs = append(mbHdrs, mbHdr)
+	hdr.MiniBlockHeaders = mbHdrs
+
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, selfNotarizedHeaders []data.HeaderHandler, selfNotarizedHeadersHashes [][]byte) error {
+			if header == hdr {
+				return nil
+			}
+
+			return errors.New("should have not got here")
+		},
+		GetHighestFinalBlockNonceCalled: func() uint64 {
+			return 0
+		},
+		GetHighestFinalBlockHashCalled: func() []byte {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+	store.AddStorer(dataRetriever.TransactionMetadataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.PeerChangesUnit, &mock.StorerStub{
+		FlushCalled: func() error {
+			return errors.New("flush error")
+		},
+	})
+
+	arguments := CreateMockArgumentsMultiShard()
+	arguments.DataPool = tdp
+	arguments.Store = store
+	arguments.Hasher = hasher
+	arguments.Accounts = accounts
+	arguments.ForkDetector = fd
+	blockTrackerMock := mock.NewBlockTrackerMock(mock.NewOneShardCoordinatorMock(), createGenesisBlocks(mock.NewOneShardCoordinatorMock()))
+	blockTrackerMock.GetCrossNotarizedHeaderCalled = func(shardID uint32, offset uint64) (data.HeaderHandler, []byte, error) {
+		return &block.MetaBlock{}, []byte("hash"), nil
+	}
+	arguments.BlockTracker = blockTrackerMock
+	bootRecordsPut := uint32(0)
+	arguments.BootStorer = &mock.BoostrapStorerMock{
+		PutCalled: func(round int64, bootData bootstrapStorage.BootstrapData) error {
+			atomic.AddUint32(&bootRecordsPut, 1)
+			return nil
+		},
+	}
+	sp, _ := blproc.NewShardProcessor(arguments)
+
+	blkc := createTestBlockchain()
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return prevHdr
+	}
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	err := sp.ProcessBlock(blkc, hdr, body, haveTime)
+	assert.Nil(t, err)
+	err = sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.Equal(t, hdrHash, blkc.GetCurrentBlockHeaderHash())
+	//this should sleep as the bootstrap record would be saved by an async call in CommitBlock
+	time.Sleep(time.Second)
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&bootRecordsPut))
+}
+
 func TestShardProcessor_CommitBlockCallsIndexerMethods(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
@@ -1928,6 +2054,136 @@ func TestShardProcessor_CommitBlockCallsIndexerMethods(t *testing.T) {
 	assert.Equal(t, 4, len(wasCalled))
 }
 
+func TestShardProcessor_CommitBlockShouldNotifyEvents(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	txHash := []byte("tx_hash1")
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	randSeed := []byte("rand seed")
+
+	prevHdr := &block.Header{
+		Nonce:         0,
+		Round:         0,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+		RandSeed:      randSeed,
+	}
+
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+		PrevRandSeed:  randSeed,
+	}
+	mb := block.MiniBlock{
+		TxHashes: [][]byte{txHash},
+	}
+	body := block.Body{&mb}
+
+	mbHdr := block.MiniBlockHeader{
+		TxCount: uint32(len(mb.TxHashes)),
+		Hash:    hdrHash,
+	}
+	mbHdrs := make([]block.MiniBlockHeader, 0)
+	mbHdrs = append(mbHdrs, mbHdr)
+	hdr.MiniBlockHeaders = mbHdrs
+
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, selfNotarizedHeaders []data.HeaderHandler, selfNotarizedHeadersHashes [][]byte) error {
+			return nil
+		},
+		GetHighestFinalBlockNonceCalled: func() uint64 {
+			return 0
+		},
+		GetHighestFinalBlockHashCalled: func() []byte {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+
+	var notifiedHeader data.HeaderHandler
+	var notifiedHeaderHash []byte
+	var notifiedTxs map[string]data.TransactionHandler
+
+	arguments := CreateMockArgumentsMultiShard()
+	arguments.Core = &mock.ServiceContainerMock{
+		EventsNotifierCalled: func() eventsNotifier.EventsNotifier {
+			return &mock.EventsNotifierStub{
+				NotifyCommittedBlockCalled: func(header data.HeaderHandler, headerHash []byte, txs map[string]data.TransactionHandler) {
+					notifiedHeader = header
+					notifiedHeaderHash = headerHash
+					notifiedTxs = txs
+				},
+			}
+		},
+	}
+	arguments.DataPool = tdp
+	arguments.Store = store
+	arguments.Hasher = hasher
+	arguments.Accounts = accounts
+	arguments.ForkDetector = fd
+	arguments.TxCoordinator = &mock.TransactionCoordinatorMock{
+		GetAllCurrentUsedTxsCalled: func(blockType block.Type) map[string]data.TransactionHandler {
+			switch blockType {
+			case block.TxBlock:
+				return map[string]data.TransactionHandler{
+					"tx_1": &transaction.Transaction{Nonce: 1},
+					"tx_2": &transaction.Transaction{Nonce: 2},
+				}
+			case block.SmartContractResultBlock:
+				return map[string]data.TransactionHandler{
+					"utx_1": &smartContractResult.SmartContractResult{Nonce: 1},
+					"utx_2": &smartContractResult.SmartContractResult{Nonce: 2},
+				}
+			default:
+				return nil
+			}
+		},
+	}
+	blockTrackerMock := mock.NewBlockTrackerMock(mock.NewOneShardCoordinatorMock(), createGenesisBlocks(mock.NewOneShardCoordinatorMock()))
+	blockTrackerMock.GetCrossNotarizedHeaderCalled = func(shardID uint32, offset uint64) (data.HeaderHandler, []byte, error) {
+		return &block.MetaBlock{}, []byte("hash"), nil
+	}
+	arguments.BlockTracker = blockTrackerMock
+
+	sp, _ := blproc.NewShardProcessor(arguments)
+
+	blkc := createTestBlockchain()
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return prevHdr
+	}
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	err := sp.ProcessBlock(blkc, hdr, body, haveTime)
+	assert.Nil(t, err)
+	err = sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+
+	assert.True(t, notifiedHeader == hdr)
+	assert.Equal(t, hdrHash, notifiedHeaderHash)
+	assert.Equal(t, 4, len(notifiedTxs))
+}
+
 func TestShardProcessor_CreateTxBlockBodyWithDirtyAccStateShouldErr(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
@@ -632,3 +632,9 @@ var ErrNilMiniBlocksResolver = errors.New("nil miniblocks resolver")
 
 // ErrMiniBlocksInWrongOrder signals the miniblocks are in wrong order
 var ErrMiniBlocksInWrongOrder = errors.New("miniblocks in wrong order, should have been only from me")
+
+// ErrHigherGasRemainingThanProvided signals that the VM reported more remaining gas than it was provided with
+var ErrHigherGasRemainingThanProvided = errors.New("higher gas remaining than provided")
+
+// ErrGasEstimationNotSupported signals that the gas needed by the provided transaction type can not be estimated
+var ErrGasEstimationNotSupported = errors.New("gas estimation is not supported for this transaction type")
@@ -0,0 +1,15 @@
+package historicalState
+
+import "errors"
+
+// ErrNilSCQueryService signals that a nil smart contract query service has been provided
+var ErrNilSCQueryService = errors.New("nil smart contract query service")
+
+// ErrBlockNotFound signals that the node's storage holds no block having the requested nonce
+var ErrBlockNotFound = errors.New("the requested block was not found")
+
+// ErrStatePruned signals that the state of the requested block is no longer available in the trie storage
+var ErrStatePruned = errors.New("the state of the requested block was pruned")
+
+// ErrWrongAccountType signals that the account found in the state is not a user account
+var ErrWrongAccountType = errors.New("account is not of type with balance and nonce")
@@ -0,0 +1,186 @@
+package historicalState
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// SCQueryService defines how a smart contract query is executed
+type SCQueryService interface {
+	ExecuteQuery(query *process.SCQuery) (*vmcommon.VMOutput, error)
+	IsInterfaceNil() bool
+}
+
+// ArgsHistoricalStateProvider holds the components needed to create a new historical state provider
+type ArgsHistoricalStateProvider struct {
+	Accounts         state.AccountsAdapter
+	BlockChain       data.ChainHandler
+	SCQueryService   SCQueryService
+	AddressConverter state.AddressConverter
+	Store            dataRetriever.StorageService
+	Marshalizer      marshal.Marshalizer
+	Uint64Converter  typeConverters.Uint64ByteSliceConverter
+	ShardCoordinator sharding.Coordinator
+}
+
+// historicalStateProvider reads the accounts and runs smart contract queries on the state of a past block. It owns
+// an accounts adapter and a chain handler, distinct from the node's ones, which are moved to the root hash and to the
+// header of the requested block before each read, so the queries never touch the state the node is processing on and
+// the contracts see the nonce, round, epoch and random seed of that block. The smart contract query service must work
+// on the same accounts adapter and chain handler
+type historicalStateProvider struct {
+	accounts         state.AccountsAdapter
+	blockChain       data.ChainHandler
+	scQueryService   SCQueryService
+	addressConverter state.AddressConverter
+	store            dataRetriever.StorageService
+	marshalizer      marshal.Marshalizer
+	uint64Converter  typeConverters.Uint64ByteSliceConverter
+	shardCoordinator sharding.Coordinator
+
+	mutState sync.Mutex
+}
+
+// NewHistoricalStateProvider creates a new historical state provider
+func NewHistoricalStateProvider(args ArgsHistoricalStateProvider) (*historicalStateProvider, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.BlockChain) {
+		return nil, process.ErrNilBlockChain
+	}
+	if check.IfNil(args.SCQueryService) {
+		return nil, ErrNilSCQueryService
+	}
+	if check.IfNil(args.AddressConverter) {
+		return nil, process.ErrNilAddressConverter
+	}
+	if check.IfNil(args.Store) {
+		return nil, process.ErrNilStore
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, process.ErrNilMarshalizer
+	}
+	if check.IfNil(args.Uint64Converter) {
+		return nil, process.ErrNilUint64Converter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, process.ErrNilShardCoordinator
+	}
+
+	return &historicalStateProvider{
+		accounts:         args.Accounts,
+		blockChain:       args.BlockChain,
+		scQueryService:   args.SCQueryService,
+		addressConverter: args.AddressConverter,
+		store:            args.Store,
+		marshalizer:      args.Marshalizer,
+		uint64Converter:  args.Uint64Converter,
+		shardCoordinator: args.ShardCoordinator,
+	}, nil
+}
+
+// GetAccount returns the account having the given hex encoded address, as it was after the block of the node's shard
+// having the given nonce. An account missing from that state is returned with zero balance and nonce. The trie nodes
+// leading to the account are read only now, so failing to read them means the state was partially pruned
+func (hsp *historicalStateProvider) GetAccount(address string, blockNonce uint64) (*state.Account, error) {
+	addr, err := hsp.addressConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, err
+	}
+
+	hsp.mutState.Lock()
+	defer hsp.mutState.Unlock()
+
+	err = hsp.recreateState(blockNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	accountHandler, err := hsp.accounts.GetExistingAccount(addr)
+	if err == state.ErrAccNotFound {
+		return &state.Account{Balance: big.NewInt(0)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: block nonce %d, %s", ErrStatePruned, blockNonce, err.Error())
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return nil, ErrWrongAccountType
+	}
+
+	return account, nil
+}
+
+// ExecuteQuery runs the smart contract query on the state left by the block of the node's shard having the given
+// nonce. The contract's account is read first, so a state pruned on the path to the contract is reported as such
+// instead of as a failed query
+func (hsp *historicalStateProvider) ExecuteQuery(query *process.SCQuery, blockNonce uint64) (*vmcommon.VMOutput, error) {
+	scAddress, err := hsp.addressConverter.CreateAddressFromPublicKeyBytes(query.ScAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	hsp.mutState.Lock()
+	defer hsp.mutState.Unlock()
+
+	err = hsp.recreateState(blockNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hsp.accounts.GetExistingAccount(scAddress)
+	if err != nil && err != state.ErrAccNotFound {
+		return nil, fmt.Errorf("%w: block nonce %d, %s", ErrStatePruned, blockNonce, err.Error())
+	}
+
+	return hsp.scQueryService.ExecuteQuery(query)
+}
+
+// recreateState moves the accounts adapter to the root hash of the block having the given nonce and the chain handler
+// to its header. The root hash is looked up in the trie database and in the snapshots, so a root hash found in neither
+// means the state of the block was pruned
+func (hsp *historicalStateProvider) recreateState(blockNonce uint64) error {
+	header, headerHash, err := process.GetHeaderFromStorageWithNonce(
+		blockNonce,
+		hsp.shardCoordinator.SelfId(),
+		hsp.store,
+		hsp.uint64Converter,
+		hsp.marshalizer,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: block nonce %d, %s", ErrBlockNotFound, blockNonce, err.Error())
+	}
+
+	err = hsp.accounts.RecreateTrie(header.GetRootHash())
+	if err != nil {
+		return fmt.Errorf("%w: block nonce %d, root hash %s, %s",
+			ErrStatePruned, blockNonce, hex.EncodeToString(header.GetRootHash()), err.Error())
+	}
+
+	err = hsp.blockChain.SetCurrentBlockHeader(header)
+	if err != nil {
+		return err
+	}
+	hsp.blockChain.SetCurrentBlockHeaderHash(headerHash)
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hsp *historicalStateProvider) IsInterfaceNil() bool {
+	return hsp == nil
+}
@@ -0,0 +1,309 @@
+package historicalState_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/addressConverters"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/historicalState"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const addressLen = 32
+
+var testAddress = hex.EncodeToString(make([]byte, addressLen))
+
+func createMemUnit() storage.Storer {
+	cache, _ := lrucache.NewCache(10)
+	unit, _ := storageUnit.NewStorageUnit(cache, memorydb.New())
+
+	return unit
+}
+
+func createArgs() historicalState.ArgsHistoricalStateProvider {
+	store := dataRetriever.NewChainStorer()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnit, createMemUnit())
+	addrConv, _ := addressConverters.NewPlainAddressConverter(addressLen, "")
+
+	return historicalState.ArgsHistoricalStateProvider{
+		Accounts:         &mock.AccountsStub{},
+		BlockChain:       &mock.BlockChainMock{},
+		SCQueryService:   &mock.ScQueryMock{},
+		AddressConverter: addrConv,
+		Store:            store,
+		Marshalizer:      &mock.MarshalizerMock{},
+		Uint64Converter:  uint64ByteSlice.NewBigEndianConverter(),
+		ShardCoordinator: mock.NewOneShardCoordinatorMock(),
+	}
+}
+
+func saveHeader(t *testing.T, args historicalState.ArgsHistoricalStateProvider, nonce uint64, rootHash []byte) {
+	header := &block.Header{Nonce: nonce, RootHash: rootHash}
+	headerBytes, err := args.Marshalizer.Marshal(header)
+	require.Nil(t, err)
+	headerHash := mock.HasherMock{}.Compute(string(headerBytes))
+
+	err = args.Store.Put(dataRetriever.BlockHeaderUnit, headerHash, headerBytes)
+	require.Nil(t, err)
+	err = args.Store.Put(dataRetriever.ShardHdrNonceHashDataUnit, args.Uint64Converter.ToByteSlice(nonce), headerHash)
+	require.Nil(t, err)
+}
+
+// createAccountsWithStates returns an accounts adapter holding, for each root hash, the balance of the test account
+func createAccountsWithStates(balances map[string]int64) *mock.AccountsStub {
+	currentRootHash := ""
+	return &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			if _, ok := balances[string(rootHash)]; !ok {
+				return errors.New("hash not found")
+			}
+			currentRootHash = string(rootHash)
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			balance := balances[currentRootHash]
+			if balance == 0 {
+				return nil, state.ErrAccNotFound
+			}
+			return &state.Account{Balance: big.NewInt(balance)}, nil
+		},
+	}
+}
+
+func TestNewHistoricalStateProvider_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setNil      func(args *historicalState.ArgsHistoricalStateProvider)
+		expectedErr error
+	}{
+		{"accounts", func(args *historicalState.ArgsHistoricalStateProvider) { args.Accounts = nil }, process.ErrNilAccountsAdapter},
+		{"block chain", func(args *historicalState.ArgsHistoricalStateProvider) { args.BlockChain = nil }, process.ErrNilBlockChain},
+		{"sc query service", func(args *historicalState.ArgsHistoricalStateProvider) { args.SCQueryService = nil }, historicalState.ErrNilSCQueryService},
+		{"address converter", func(args *historicalState.ArgsHistoricalStateProvider) { args.AddressConverter = nil }, process.ErrNilAddressConverter},
+		{"store", func(args *historicalState.ArgsHistoricalStateProvider) { args.Store = nil }, process.ErrNilStore},
+		{"marshalizer", func(args *historicalState.ArgsHistoricalStateProvider) { args.Marshalizer = nil }, process.ErrNilMarshalizer},
+		{"uint64 converter", func(args *historicalState.ArgsHistoricalStateProvider) { args.Uint64Converter = nil }, process.ErrNilUint64Converter},
+		{"shard coordinator", func(args *historicalState.ArgsHistoricalStateProvider) { args.ShardCoordinator = nil }, process.ErrNilShardCoordinator},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			args := createArgs()
+			tt.setNil(&args)
+
+			hsp, err := historicalState.NewHistoricalStateProvider(args)
+
+			assert.True(t, check.IfNil(hsp))
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestNewHistoricalStateProvider_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	hsp, err := historicalState.NewHistoricalStateProvider(createArgs())
+
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(hsp))
+}
+
+func TestHistoricalStateProvider_GetAccountShouldReadTheStateOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.Accounts = createAccountsWithStates(map[string]int64{"root1": 100, "root2": 200, "root3": 0})
+	saveHeader(t, args, 1, []byte("root1"))
+	saveHeader(t, args, 2, []byte("root2"))
+	saveHeader(t, args, 3, []byte("root3"))
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	account, err := hsp.GetAccount(testAddress, 2)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(200), account.Balance)
+
+	account, err = hsp.GetAccount(testAddress, 1)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+
+	account, err = hsp.GetAccount(testAddress, 3)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), account.Balance)
+}
+
+func TestHistoricalStateProvider_GetAccountInvalidAddressShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hsp, _ := historicalState.NewHistoricalStateProvider(createArgs())
+
+	account, err := hsp.GetAccount("not an address", 1)
+
+	assert.Nil(t, account)
+	assert.NotNil(t, err)
+}
+
+func TestHistoricalStateProvider_MissingBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	recreateCalled := false
+	args.Accounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreateCalled = true
+			return nil
+		},
+	}
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	account, err := hsp.GetAccount(testAddress, 5)
+
+	assert.Nil(t, account)
+	assert.True(t, errors.Is(err, historicalState.ErrBlockNotFound))
+	assert.False(t, errors.Is(err, historicalState.ErrStatePruned))
+	assert.False(t, recreateCalled)
+}
+
+func TestHistoricalStateProvider_PrunedStateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.Accounts = createAccountsWithStates(map[string]int64{"root2": 200})
+	saveHeader(t, args, 1, []byte("root1"))
+	queryCalled := false
+	args.SCQueryService = &mock.ScQueryMock{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, error) {
+			queryCalled = true
+			return &vmcommon.VMOutput{}, nil
+		},
+	}
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	account, err := hsp.GetAccount(testAddress, 1)
+	assert.Nil(t, account)
+	assert.True(t, errors.Is(err, historicalState.ErrStatePruned))
+
+	vmOutput, err := hsp.ExecuteQuery(&process.SCQuery{ScAddress: make([]byte, addressLen)}, 1)
+	assert.Nil(t, vmOutput)
+	assert.True(t, errors.Is(err, historicalState.ErrStatePruned))
+	assert.False(t, queryCalled)
+}
+
+func TestHistoricalStateProvider_ExecuteQueryShouldRunOnTheStateOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	recreatedRootHash := ""
+	args.Accounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRootHash = string(rootHash)
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+	expectedVmOutput := &vmcommon.VMOutput{GasRemaining: 3}
+	args.SCQueryService = &mock.ScQueryMock{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, error) {
+			assert.Equal(t, "root4", recreatedRootHash)
+			return expectedVmOutput, nil
+		},
+	}
+	saveHeader(t, args, 4, []byte("root4"))
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	vmOutput, err := hsp.ExecuteQuery(&process.SCQuery{ScAddress: make([]byte, addressLen), FuncName: "get"}, 4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedVmOutput, vmOutput)
+}
+
+func TestHistoricalStateProvider_PartiallyPrunedStateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.Accounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errors.New("key not found")
+		},
+	}
+	queryCalled := false
+	args.SCQueryService = &mock.ScQueryMock{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, error) {
+			queryCalled = true
+			return &vmcommon.VMOutput{}, nil
+		},
+	}
+	saveHeader(t, args, 1, []byte("root1"))
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	account, err := hsp.GetAccount(testAddress, 1)
+	assert.Nil(t, account)
+	assert.True(t, errors.Is(err, historicalState.ErrStatePruned))
+
+	vmOutput, err := hsp.ExecuteQuery(&process.SCQuery{ScAddress: make([]byte, addressLen)}, 1)
+	assert.Nil(t, vmOutput)
+	assert.True(t, errors.Is(err, historicalState.ErrStatePruned))
+	assert.False(t, queryCalled)
+}
+
+func TestHistoricalStateProvider_ExecuteQueryShouldSeeTheHeaderOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.Accounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return &state.Account{Balance: big.NewInt(0)}, nil
+		},
+	}
+	var currentHeader data.HeaderHandler
+	var currentHeaderHash []byte
+	args.BlockChain = &mock.BlockChainMock{
+		SetCurrentBlockHeaderCalled: func(header data.HeaderHandler) error {
+			currentHeader = header
+			return nil
+		},
+		SetCurrentBlockHeaderHashCalled: func(hash []byte) {
+			currentHeaderHash = hash
+		},
+	}
+	args.SCQueryService = &mock.ScQueryMock{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, error) {
+			require.False(t, check.IfNil(currentHeader))
+			assert.Equal(t, uint64(7), currentHeader.GetNonce())
+			assert.NotEqual(t, 0, len(currentHeaderHash))
+			return &vmcommon.VMOutput{}, nil
+		},
+	}
+	saveHeader(t, args, 7, []byte("root7"))
+	hsp, _ := historicalState.NewHistoricalStateProvider(args)
+
+	_, err := hsp.ExecuteQuery(&process.SCQuery{ScAddress: make([]byte, addressLen)}, 7)
+
+	assert.Nil(t, err)
+}
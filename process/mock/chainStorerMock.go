@@ -16,6 +16,7 @@ type ChainStorerMock struct {
 	GetAllCalled    func(unitType dataRetriever.UnitType, keys [][]byte) (map[string][]byte, error)
 	DestroyCalled   func() error
 	CloseAllCalled  func() error
+	FlushAllCalled  func() error
 }
 
 // CloseAll -
@@ -27,6 +28,15 @@ func (csm *ChainStorerMock) CloseAll() error {
 	return nil
 }
 
+// FlushAll -
+func (csm *ChainStorerMock) FlushAll() error {
+	if csm.FlushAllCalled != nil {
+		return csm.FlushAllCalled()
+	}
+
+	return nil
+}
+
 // AddStorer will add a new storer to the chain map
 func (csm *ChainStorerMock) AddStorer(key dataRetriever.UnitType, s storage.Storer) {
 	if csm.AddStorerCalled != nil {
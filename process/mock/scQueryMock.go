@@ -1,13 +1,15 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/process"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 )
 
 // ScQueryMock -
 type ScQueryMock struct {
-	ExecuteQueryCalled func(query *process.SCQuery) (*vmcommon.VMOutput, error)
+	ExecuteQueryCalled          func(query *process.SCQuery) (*vmcommon.VMOutput, error)
+	ComputeScCallGasLimitCalled func(tx *transaction.Transaction) (uint64, error)
 }
 
 // ExecuteQuery -
@@ -18,6 +20,14 @@ func (s *ScQueryMock) ExecuteQuery(query *process.SCQuery) (*vmcommon.VMOutput,
 	return &vmcommon.VMOutput{}, nil
 }
 
+// ComputeScCallGasLimit -
+func (s *ScQueryMock) ComputeScCallGasLimit(tx *transaction.Transaction) (uint64, error) {
+	if s.ComputeScCallGasLimitCalled != nil {
+		return s.ComputeScCallGasLimitCalled(tx)
+	}
+	return 0, nil
+}
+
 // IsInterfaceNil -
 func (s *ScQueryMock) IsInterfaceNil() bool {
 	return s == nil
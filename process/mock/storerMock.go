@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // StorerMock -
@@ -62,6 +64,11 @@ func (sm *StorerMock) SearchFirst(key []byte) ([]byte, error) {
 	return nil, errors.New("not implemented")
 }
 
+// Iterate -
+func (sm *StorerMock) Iterate(_ *storage.KeyRange, _ func(key []byte, val []byte) bool) error {
+	return errors.New("not implemented")
+}
+
 // Has -
 func (sm *StorerMock) Has(key []byte) error {
 	return errors.New("not implemented")
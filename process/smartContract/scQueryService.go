@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/process"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/pkg/errors"
@@ -53,15 +55,72 @@ func (service *SCQueryService) ExecuteQuery(query *process.SCQuery) (*vmcommon.V
 		return nil, process.ErrEmptyFunctionName
 	}
 
+	vmInput := service.createVMCallInput(query)
+
+	return service.runSmartContractCall(vmInput)
+}
+
+// ComputeScCallGasLimit returns the gas consumed by the VM when running, on the current state, the smart contract
+// call held by the provided transaction. The gas needed to move the transaction data is not included
+func (service *SCQueryService) ComputeScCallGasLimit(tx *transaction.Transaction) (uint64, error) {
+	if check.IfNil(tx) {
+		return 0, process.ErrNilTransaction
+	}
+	if tx.RcvAddr == nil {
+		return 0, process.ErrNilScAddress
+	}
+
+	argsParser, err := vmcommon.NewAtArgumentParser()
+	if err != nil {
+		return 0, err
+	}
+
+	err = argsParser.ParseData(string(tx.Data))
+	if err != nil {
+		return 0, err
+	}
+
+	funcName, err := argsParser.GetFunction()
+	if err != nil {
+		return 0, err
+	}
+
+	arguments, err := argsParser.GetArguments()
+	if err != nil {
+		return 0, err
+	}
+
+	vmInput := service.createVMCallInput(&process.SCQuery{
+		ScAddress: tx.RcvAddr,
+		FuncName:  funcName,
+		Arguments: arguments,
+	})
+	vmInput.CallerAddr = tx.SndAddr
+	vmInput.GasPrice = tx.GasPrice
+	if tx.Value != nil {
+		vmInput.CallValue = big.NewInt(0).Set(tx.Value)
+	}
+
+	vmOutput, err := service.runSmartContractCall(vmInput)
+	if err != nil {
+		return 0, err
+	}
+	if vmOutput.GasRemaining > vmInput.GasProvided {
+		return 0, process.ErrHigherGasRemainingThanProvided
+	}
+
+	return vmInput.GasProvided - vmOutput.GasRemaining, nil
+}
+
+func (service *SCQueryService) runSmartContractCall(vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
 	service.mutRunSc.Lock()
 	defer service.mutRunSc.Unlock()
 
-	vm, err := service.getVMFromAddress(query.ScAddress)
+	vm, err := service.getVMFromAddress(vmInput.RecipientAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	vmInput := service.createVMCallInput(query)
 	vmOutput, err := vm.RunSmartContractCall(vmInput)
 	if err != nil {
 		return nil, err
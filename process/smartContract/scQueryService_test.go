@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
@@ -230,3 +231,85 @@ func TestExecuteQuery_ShouldCallRunScSequentially(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestComputeScCallGasLimit_NilTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	target, _ := NewSCQueryService(&mock.VMContainerMock{}, uint64(math.MaxUint64))
+
+	gasLimit, err := target.ComputeScCallGasLimit(nil)
+
+	assert.Equal(t, uint64(0), gasLimit)
+	assert.Equal(t, process.ErrNilTransaction, err)
+}
+
+func TestComputeScCallGasLimit_ShouldReturnConsumedGas(t *testing.T) {
+	t.Parallel()
+
+	gasLimitPerBlock := uint64(1000000)
+	gasRemaining := uint64(999000)
+	sndAddr := []byte("sender")
+	callValue := big.NewInt(37)
+	mockVM := &mock.VMExecutionHandlerStub{
+		RunSmartContractCallCalled: func(input *vmcommon.ContractCallInput) (output *vmcommon.VMOutput, e error) {
+			assert.Equal(t, "function", input.Function)
+			assert.Equal(t, [][]byte{{0xaa}}, input.Arguments)
+			assert.Equal(t, sndAddr, input.CallerAddr)
+			assert.Equal(t, callValue, input.CallValue)
+			assert.Equal(t, gasLimitPerBlock, input.GasProvided)
+
+			return &vmcommon.VMOutput{
+				ReturnCode:   vmcommon.Ok,
+				GasRemaining: gasRemaining,
+			}, nil
+		},
+	}
+	target, _ := NewSCQueryService(
+		&mock.VMContainerMock{
+			GetCalled: func(key []byte) (handler vmcommon.VMExecutionHandler, e error) {
+				return mockVM, nil
+			},
+		},
+		gasLimitPerBlock,
+	)
+
+	tx := &transaction.Transaction{
+		SndAddr: sndAddr,
+		RcvAddr: []byte(DummyScAddress),
+		Value:   callValue,
+		Data:    []byte("function@aa"),
+	}
+	gasLimit, err := target.ComputeScCallGasLimit(tx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, gasLimitPerBlock-gasRemaining, gasLimit)
+}
+
+func TestComputeScCallGasLimit_WhenNotOkCodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mockVM := &mock.VMExecutionHandlerStub{
+		RunSmartContractCallCalled: func(input *vmcommon.ContractCallInput) (output *vmcommon.VMOutput, e error) {
+			return &vmcommon.VMOutput{
+				ReturnCode: vmcommon.UserError,
+			}, nil
+		},
+	}
+	target, _ := NewSCQueryService(
+		&mock.VMContainerMock{
+			GetCalled: func(key []byte) (handler vmcommon.VMExecutionHandler, e error) {
+				return mockVM, nil
+			},
+		},
+		uint64(math.MaxUint64),
+	)
+
+	tx := &transaction.Transaction{
+		RcvAddr: []byte(DummyScAddress),
+		Data:    []byte("function"),
+	}
+	gasLimit, err := target.ComputeScCallGasLimit(tx)
+
+	assert.Equal(t, uint64(0), gasLimit)
+	assert.NotNil(t, err)
+}
@@ -44,3 +44,12 @@ var ErrRollBackBehindForkNonce = errors.New("roll back behind fork nonce is not
 
 // ErrGenesisTimeMissmatch signals that a received header has a genesis time missmatch
 var ErrGenesisTimeMissmatch = errors.New("genesis time missmatch")
+
+// ErrNilTrieStorage signals that a nil trie storage has been provided
+var ErrNilTrieStorage = errors.New("nil trie storage")
+
+// ErrIncompleteBlockInStorage signals that a block recorded in the bootstrap storage was not fully written
+var ErrIncompleteBlockInStorage = errors.New("incomplete block in storage")
+
+// ErrNoCompleteBlockInStorage signals that none of the blocks recorded in the bootstrap storage was fully written
+var ErrNoCompleteBlockInStorage = errors.New("no complete block in storage")
@@ -0,0 +1,227 @@
+package storageBootstrap
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/block/bootstrapStorage"
+	"github.com/ElrondNetwork/elrond-go/process/sync"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// ArgsLastBlockChecker is the structure used to create a new last block checker
+type ArgsLastBlockChecker struct {
+	BootStorer              process.BootStorer
+	Store                   dataRetriever.StorageService
+	Marshalizer             marshal.Marshalizer
+	Hasher                  hashing.Hasher
+	Uint64Converter         typeConverters.Uint64ByteSliceConverter
+	ShardCoordinator        sharding.Coordinator
+	AccountsTrieStorage     data.DBWriteCacher
+	PeerAccountsTrieStorage data.DBWriteCacher
+	TrieCheckDepth          uint32
+	FullTrieCheck           bool
+}
+
+// lastBlockChecker verifies, before the blocks are loaded from storage, that the last block recorded in the bootstrap
+// storage was fully written. The databases are written in batches, so a node stopped while committing a block can
+// hold the bootstrap record of a block whose header, miniblocks or state trie nodes were not flushed yet. Committing a
+// block rewrites the trie nodes on the paths from the root to the changed leaves, so only the top levels of the tries,
+// which nearly every block rewrites, are checked unless the full check is requested
+type lastBlockChecker struct {
+	bootStorer              process.BootStorer
+	store                   dataRetriever.StorageService
+	marshalizer             marshal.Marshalizer
+	hasher                  hashing.Hasher
+	uint64Converter         typeConverters.Uint64ByteSliceConverter
+	shardCoordinator        sharding.Coordinator
+	accountsTrieStorage     data.DBWriteCacher
+	peerAccountsTrieStorage data.DBWriteCacher
+	trieCheckDepth          uint32
+	fullTrieCheck           bool
+}
+
+// NewLastBlockChecker creates a new last block checker instance
+func NewLastBlockChecker(args ArgsLastBlockChecker) (*lastBlockChecker, error) {
+	if check.IfNil(args.BootStorer) {
+		return nil, bootstrapStorage.ErrNilBootStorer
+	}
+	if check.IfNil(args.Store) {
+		return nil, process.ErrNilStore
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, process.ErrNilMarshalizer
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, process.ErrNilHasher
+	}
+	if check.IfNil(args.Uint64Converter) {
+		return nil, process.ErrNilUint64Converter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, process.ErrNilShardCoordinator
+	}
+	if check.IfNil(args.AccountsTrieStorage) {
+		return nil, sync.ErrNilTrieStorage
+	}
+	isMetachain := args.ShardCoordinator.SelfId() == sharding.MetachainShardId
+	if isMetachain && check.IfNil(args.PeerAccountsTrieStorage) {
+		return nil, sync.ErrNilTrieStorage
+	}
+
+	return &lastBlockChecker{
+		bootStorer:              args.BootStorer,
+		store:                   args.Store,
+		marshalizer:             args.Marshalizer,
+		hasher:                  args.Hasher,
+		uint64Converter:         args.Uint64Converter,
+		shardCoordinator:        args.ShardCoordinator,
+		accountsTrieStorage:     args.AccountsTrieStorage,
+		peerAccountsTrieStorage: args.PeerAccountsTrieStorage,
+		trieCheckDepth:          args.TrieCheckDepth,
+		fullTrieCheck:           args.FullTrieCheck,
+	}, nil
+}
+
+// CheckAndRecover walks back from the highest round found in the bootstrap storage until it finds a block that was
+// fully written, and saves its round as the highest one so the blocks are loaded starting from it. It returns the
+// round the node will start from, or an error if none of the recorded blocks is complete
+func (lbc *lastBlockChecker) CheckAndRecover() (int64, error) {
+	highestRound := lbc.bootStorer.GetHighestRound()
+	if highestRound == 0 {
+		return 0, nil
+	}
+
+	round := highestRound
+	for {
+		bootData, err := lbc.bootStorer.Get(round)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s for round %d", sync.ErrNoCompleteBlockInStorage, err.Error(), round)
+		}
+
+		err = lbc.checkBlock(bootData.LastHeader)
+		if err == nil {
+			break
+		}
+
+		log.Warn("last block checker: incomplete block found in storage",
+			"round", round,
+			"nonce", bootData.LastHeader.Nonce,
+			"hash", bootData.LastHeader.Hash,
+			"error", err.Error(),
+		)
+
+		if round == bootData.LastRound {
+			return 0, fmt.Errorf("%w: %s", sync.ErrNoCompleteBlockInStorage, sync.ErrCorruptBootstrapFromStorageDb.Error())
+		}
+		round = bootData.LastRound
+	}
+
+	if round == highestRound {
+		return round, nil
+	}
+
+	err := lbc.bootStorer.SaveLastRound(round)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info("last block checker: storage rolled back to the last complete block",
+		"highest round", highestRound,
+		"recovered round", round,
+	)
+
+	return round, nil
+}
+
+func (lbc *lastBlockChecker) checkBlock(headerInfo bootstrapStorage.BootstrapHeaderInfo) error {
+	header, hash, err := process.GetHeaderFromStorageWithNonce(
+		headerInfo.Nonce,
+		headerInfo.ShardId,
+		lbc.store,
+		lbc.uint64Converter,
+		lbc.marshalizer,
+	)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, headerInfo.Hash) {
+		return fmt.Errorf("%w: nonce to hash entry does not match the bootstrap record", sync.ErrIncompleteBlockInStorage)
+	}
+
+	err = lbc.checkMiniBlocks(header)
+	if err != nil {
+		return err
+	}
+
+	err = lbc.checkTrie(header.GetRootHash(), lbc.accountsTrieStorage)
+	if err != nil {
+		return err
+	}
+
+	metaBlock, ok := header.(*block.MetaBlock)
+	if !ok {
+		return nil
+	}
+
+	return lbc.checkTrie(metaBlock.GetValidatorStatsRootHash(), lbc.peerAccountsTrieStorage)
+}
+
+func (lbc *lastBlockChecker) checkMiniBlocks(header data.HeaderHandler) error {
+	var miniBlockHeaders []block.MiniBlockHeader
+	switch hdr := header.(type) {
+	case *block.Header:
+		miniBlockHeaders = hdr.MiniBlockHeaders
+	case *block.MetaBlock:
+		miniBlockHeaders = hdr.MiniBlockHeaders
+	default:
+		return process.ErrWrongTypeAssertion
+	}
+
+	miniBlocksStorer := lbc.store.GetStorer(dataRetriever.MiniBlockUnit)
+	if check.IfNil(miniBlocksStorer) {
+		return process.ErrNilStorage
+	}
+
+	for _, miniBlockHeader := range miniBlockHeaders {
+		err := miniBlocksStorer.Has(miniBlockHeader.Hash)
+		if err != nil {
+			return fmt.Errorf("%w: miniblock %x: %s", sync.ErrIncompleteBlockInStorage, miniBlockHeader.Hash, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (lbc *lastBlockChecker) checkTrie(rootHash []byte, trieStorage data.DBWriteCacher) error {
+	maxDepth := lbc.trieCheckDepth
+	if lbc.fullTrieCheck {
+		maxDepth = math.MaxUint32
+	}
+
+	report, err := trie.CheckReachabilityUpToDepth(rootHash, maxDepth, trieStorage, lbc.marshalizer, lbc.hasher)
+	if err != nil {
+		return err
+	}
+	if !report.IsComplete() {
+		return fmt.Errorf("%w: trie with root %x has %d missing and %d corrupted nodes",
+			sync.ErrIncompleteBlockInStorage, rootHash, len(report.MissingHashes), len(report.CorruptedHashes))
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lbc *lastBlockChecker) IsInterfaceNil() bool {
+	return lbc == nil
+}
@@ -0,0 +1,216 @@
+package storageBootstrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/block/bootstrapStorage"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/sync"
+	"github.com/ElrondNetwork/elrond-go/process/sync/storageBootstrap"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockToSave struct {
+	round           int64
+	nonce           uint64
+	rootHash        []byte
+	miniBlockHash   []byte
+	skipMiniBlock   bool
+	skipNonceToHash bool
+	skipHeader      bool
+}
+
+func createMemUnit() storage.Storer {
+	cache, _ := lrucache.NewCache(10)
+	unit, _ := storageUnit.NewStorageUnit(cache, memorydb.New())
+
+	return unit
+}
+
+func createLastBlockCheckerArgs() storageBootstrap.ArgsLastBlockChecker {
+	store := dataRetriever.NewChainStorer()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
+
+	marshalizer := &mock.MarshalizerMock{}
+	bootStorer, _ := bootstrapStorage.NewBootstrapStorer(marshalizer, store.GetStorer(dataRetriever.BootstrapUnit))
+
+	return storageBootstrap.ArgsLastBlockChecker{
+		BootStorer:          bootStorer,
+		Store:               store,
+		Marshalizer:         marshalizer,
+		Hasher:              &mock.HasherMock{},
+		Uint64Converter:     uint64ByteSlice.NewBigEndianConverter(),
+		ShardCoordinator:    mock.NewOneShardCoordinatorMock(),
+		AccountsTrieStorage: memorydb.New(),
+	}
+}
+
+func saveBlock(t *testing.T, args storageBootstrap.ArgsLastBlockChecker, b blockToSave) {
+	header := &block.Header{
+		Nonce:    b.nonce,
+		Round:    uint64(b.round),
+		RootHash: b.rootHash,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: b.miniBlockHash},
+		},
+	}
+	headerBytes, err := args.Marshalizer.Marshal(header)
+	require.Nil(t, err)
+	headerHash := args.Hasher.Compute(string(headerBytes))
+
+	if !b.skipHeader {
+		err = args.Store.Put(dataRetriever.BlockHeaderUnit, headerHash, headerBytes)
+		require.Nil(t, err)
+	}
+	if !b.skipNonceToHash {
+		err = args.Store.Put(dataRetriever.ShardHdrNonceHashDataUnit, args.Uint64Converter.ToByteSlice(b.nonce), headerHash)
+		require.Nil(t, err)
+	}
+	if !b.skipMiniBlock {
+		err = args.Store.Put(dataRetriever.MiniBlockUnit, b.miniBlockHash, []byte("miniblock"))
+		require.Nil(t, err)
+	}
+
+	err = args.BootStorer.Put(b.round, bootstrapStorage.BootstrapData{
+		LastHeader: bootstrapStorage.BootstrapHeaderInfo{
+			ShardId: 0,
+			Nonce:   b.nonce,
+			Hash:    headerHash,
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestNewLastBlockChecker_NilBootStorerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createLastBlockCheckerArgs()
+	args.BootStorer = nil
+
+	lbc, err := storageBootstrap.NewLastBlockChecker(args)
+
+	assert.Nil(t, lbc)
+	assert.Equal(t, bootstrapStorage.ErrNilBootStorer, err)
+}
+
+func TestNewLastBlockChecker_NilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createLastBlockCheckerArgs()
+	args.Store = nil
+
+	lbc, err := storageBootstrap.NewLastBlockChecker(args)
+
+	assert.Nil(t, lbc)
+	assert.Equal(t, process.ErrNilStore, err)
+}
+
+func TestNewLastBlockChecker_NilAccountsTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createLastBlockCheckerArgs()
+	args.AccountsTrieStorage = nil
+
+	lbc, err := storageBootstrap.NewLastBlockChecker(args)
+
+	assert.Nil(t, lbc)
+	assert.Equal(t, sync.ErrNilTrieStorage, err)
+}
+
+func TestNewLastBlockChecker_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	lbc, err := storageBootstrap.NewLastBlockChecker(createLastBlockCheckerArgs())
+
+	assert.False(t, lbc.IsInterfaceNil())
+	assert.Nil(t, err)
+}
+
+func TestLastBlockChecker_CheckAndRecoverEmptyStorageShouldReturnZero(t *testing.T) {
+	t.Parallel()
+
+	lbc, _ := storageBootstrap.NewLastBlockChecker(createLastBlockCheckerArgs())
+
+	round, err := lbc.CheckAndRecover()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), round)
+}
+
+func TestLastBlockChecker_CheckAndRecoverCompleteBlockShouldKeepTheRound(t *testing.T) {
+	t.Parallel()
+
+	args := createLastBlockCheckerArgs()
+	saveBlock(t, args, blockToSave{round: 1, nonce: 1, miniBlockHash: []byte("mb1")})
+	saveBlock(t, args, blockToSave{round: 2, nonce: 2, miniBlockHash: []byte("mb2")})
+	lbc, _ := storageBootstrap.NewLastBlockChecker(args)
+
+	round, err := lbc.CheckAndRecover()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), round)
+	assert.Equal(t, int64(2), args.BootStorer.GetHighestRound())
+}
+
+func TestLastBlockChecker_CheckAndRecoverShouldDropIncompleteBlocks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		lastBlock blockToSave
+	}{
+		{"missing miniblock", blockToSave{skipMiniBlock: true}},
+		{"missing header", blockToSave{skipHeader: true}},
+		{"missing nonce to hash entry", blockToSave{skipNonceToHash: true}},
+		{"missing trie root", blockToSave{rootHash: []byte("missing root")}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			args := createLastBlockCheckerArgs()
+			saveBlock(t, args, blockToSave{round: 1, nonce: 1, miniBlockHash: []byte("mb1")})
+			saveBlock(t, args, blockToSave{round: 3, nonce: 2, miniBlockHash: []byte("mb2")})
+
+			lastBlock := tt.lastBlock
+			lastBlock.round = 5
+			lastBlock.nonce = 3
+			lastBlock.miniBlockHash = []byte("mb3")
+			saveBlock(t, args, lastBlock)
+			lbc, _ := storageBootstrap.NewLastBlockChecker(args)
+
+			round, err := lbc.CheckAndRecover()
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(3), round)
+			assert.Equal(t, int64(3), args.BootStorer.GetHighestRound())
+		})
+	}
+}
+
+func TestLastBlockChecker_CheckAndRecoverNoCompleteBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createLastBlockCheckerArgs()
+	saveBlock(t, args, blockToSave{round: 1, nonce: 1, miniBlockHash: []byte("mb1"), skipMiniBlock: true})
+	saveBlock(t, args, blockToSave{round: 2, nonce: 2, miniBlockHash: []byte("mb2"), skipHeader: true})
+	lbc, _ := storageBootstrap.NewLastBlockChecker(args)
+
+	round, err := lbc.CheckAndRecover()
+
+	assert.True(t, errors.Is(err, sync.ErrNoCompleteBlockInStorage))
+	assert.Equal(t, int64(0), round)
+}
@@ -0,0 +1,9 @@
+package transactionEvaluator
+
+import "github.com/ElrondNetwork/elrond-go/data/transaction"
+
+// SCQueryService defines how the gas consumed by a smart contract call can be computed without altering the state
+type SCQueryService interface {
+	ComputeScCallGasLimit(tx *transaction.Transaction) (uint64, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,72 @@
+package transactionEvaluator
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// transactionCostEstimator estimates the gas limit a transaction needs in order to be successfully executed
+type transactionCostEstimator struct {
+	txTypeHandler process.TxTypeHandler
+	feeHandler    process.FeeHandler
+	query         SCQueryService
+}
+
+// NewTransactionCostEstimator creates a new transaction cost estimator
+func NewTransactionCostEstimator(
+	txTypeHandler process.TxTypeHandler,
+	feeHandler process.FeeHandler,
+	query SCQueryService,
+) (*transactionCostEstimator, error) {
+	if check.IfNil(txTypeHandler) {
+		return nil, process.ErrNilTxTypeHandler
+	}
+	if check.IfNil(feeHandler) {
+		return nil, process.ErrNilEconomicsFeeHandler
+	}
+	if check.IfNil(query) {
+		return nil, process.ErrNilSCDataGetter
+	}
+
+	return &transactionCostEstimator{
+		txTypeHandler: txTypeHandler,
+		feeHandler:    feeHandler,
+		query:         query,
+	}, nil
+}
+
+// ComputeTransactionGasLimit returns the gas limit needed by the provided transaction. For a transfer it is the
+// cost of moving the data, as defined in the economics config. For a smart contract call the gas consumed by the
+// VM when running the call on the current state is added on top
+func (tce *transactionCostEstimator) ComputeTransactionGasLimit(tx *transaction.Transaction) (uint64, error) {
+	if check.IfNil(tx) {
+		return 0, process.ErrNilTransaction
+	}
+
+	txType, err := tce.txTypeHandler.ComputeTransactionType(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	moveBalanceGasLimit := tce.feeHandler.ComputeGasLimit(tx)
+
+	switch txType {
+	case process.MoveBalance:
+		return moveBalanceGasLimit, nil
+	case process.SCInvoking:
+		scCallGasLimit, errQuery := tce.query.ComputeScCallGasLimit(tx)
+		if errQuery != nil {
+			return 0, errQuery
+		}
+
+		return moveBalanceGasLimit + scCallGasLimit, nil
+	default:
+		return 0, process.ErrGasEstimationNotSupported
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tce *transactionCostEstimator) IsInterfaceNil() bool {
+	return tce == nil
+}
@@ -0,0 +1,151 @@
+package transactionEvaluator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTxTypeHandler(txType process.TransactionType) *mock.TxTypeHandlerMock {
+	return &mock.TxTypeHandlerMock{
+		ComputeTransactionTypeCalled: func(tx data.TransactionHandler) (process.TransactionType, error) {
+			return txType, nil
+		},
+	}
+}
+
+func createFeeHandler(moveBalanceGasLimit uint64) *mock.FeeHandlerStub {
+	return &mock.FeeHandlerStub{
+		ComputeGasLimitCalled: func(tx process.TransactionWithFeeHandler) uint64 {
+			return moveBalanceGasLimit
+		},
+	}
+}
+
+func TestNewTransactionCostEstimator_NilTxTypeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tce, err := NewTransactionCostEstimator(nil, &mock.FeeHandlerStub{}, &mock.ScQueryMock{})
+
+	assert.True(t, check.IfNil(tce))
+	assert.Equal(t, process.ErrNilTxTypeHandler, err)
+}
+
+func TestNewTransactionCostEstimator_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tce, err := NewTransactionCostEstimator(&mock.TxTypeHandlerMock{}, nil, &mock.ScQueryMock{})
+
+	assert.True(t, check.IfNil(tce))
+	assert.Equal(t, process.ErrNilEconomicsFeeHandler, err)
+}
+
+func TestNewTransactionCostEstimator_NilQueryServiceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tce, err := NewTransactionCostEstimator(&mock.TxTypeHandlerMock{}, &mock.FeeHandlerStub{}, nil)
+
+	assert.True(t, check.IfNil(tce))
+	assert.Equal(t, process.ErrNilSCDataGetter, err)
+}
+
+func TestNewTransactionCostEstimator_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tce, err := NewTransactionCostEstimator(&mock.TxTypeHandlerMock{}, &mock.FeeHandlerStub{}, &mock.ScQueryMock{})
+
+	assert.False(t, check.IfNil(tce))
+	assert.Nil(t, err)
+}
+
+func TestTransactionCostEstimator_ComputeTransactionGasLimitNilTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tce, _ := NewTransactionCostEstimator(&mock.TxTypeHandlerMock{}, &mock.FeeHandlerStub{}, &mock.ScQueryMock{})
+	gasLimit, err := tce.ComputeTransactionGasLimit(nil)
+
+	assert.Equal(t, uint64(0), gasLimit)
+	assert.Equal(t, process.ErrNilTransaction, err)
+}
+
+func TestTransactionCostEstimator_ComputeTransactionGasLimitMoveBalance(t *testing.T) {
+	t.Parallel()
+
+	queryWasCalled := false
+	tce, _ := NewTransactionCostEstimator(
+		createTxTypeHandler(process.MoveBalance),
+		createFeeHandler(50000),
+		&mock.ScQueryMock{
+			ComputeScCallGasLimitCalled: func(tx *transaction.Transaction) (uint64, error) {
+				queryWasCalled = true
+				return 0, nil
+			},
+		},
+	)
+
+	gasLimit, err := tce.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(50000), gasLimit)
+	assert.False(t, queryWasCalled)
+}
+
+func TestTransactionCostEstimator_ComputeTransactionGasLimitScCallShouldAddMoveBalanceCost(t *testing.T) {
+	t.Parallel()
+
+	tce, _ := NewTransactionCostEstimator(
+		createTxTypeHandler(process.SCInvoking),
+		createFeeHandler(50000),
+		&mock.ScQueryMock{
+			ComputeScCallGasLimitCalled: func(tx *transaction.Transaction) (uint64, error) {
+				return 1234, nil
+			},
+		},
+	)
+
+	gasLimit, err := tce.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(51234), gasLimit)
+}
+
+func TestTransactionCostEstimator_ComputeTransactionGasLimitScCallErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	tce, _ := NewTransactionCostEstimator(
+		createTxTypeHandler(process.SCInvoking),
+		createFeeHandler(50000),
+		&mock.ScQueryMock{
+			ComputeScCallGasLimitCalled: func(tx *transaction.Transaction) (uint64, error) {
+				return 0, expectedErr
+			},
+		},
+	)
+
+	gasLimit, err := tce.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Equal(t, uint64(0), gasLimit)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestTransactionCostEstimator_ComputeTransactionGasLimitDeployShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tce, _ := NewTransactionCostEstimator(
+		createTxTypeHandler(process.SCDeployment),
+		createFeeHandler(50000),
+		&mock.ScQueryMock{},
+	)
+
+	gasLimit, err := tce.ComputeTransactionGasLimit(&transaction.Transaction{})
+
+	assert.Equal(t, uint64(0), gasLimit)
+	assert.Equal(t, process.ErrGasEstimationNotSupported, err)
+}
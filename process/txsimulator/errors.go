@@ -0,0 +1,9 @@
+package txsimulator
+
+import "errors"
+
+// ErrNilSimulationAccounts signals that a nil accounts adapter was provided for the simulations
+var ErrNilSimulationAccounts = errors.New("nil simulation accounts adapter")
+
+// ErrNilResultsCollector signals that a nil intermediate results collector was provided
+var ErrNilResultsCollector = errors.New("nil intermediate results collector")
@@ -0,0 +1,104 @@
+package txsimulator
+
+import (
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// intermediateResultsCollector implements IntermediateTransactionHandler and only keeps in memory the results
+// generated while simulating a transaction. It never creates miniblocks and never saves anything to storage
+type intermediateResultsCollector struct {
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+
+	mutResults sync.RWMutex
+	results    map[string]data.TransactionHandler
+}
+
+// NewIntermediateResultsCollector creates a new intermediate results collector
+func NewIntermediateResultsCollector(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*intermediateResultsCollector, error) {
+	if check.IfNil(marshalizer) {
+		return nil, process.ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return nil, process.ErrNilHasher
+	}
+
+	return &intermediateResultsCollector{
+		marshalizer: marshalizer,
+		hasher:      hasher,
+		results:     make(map[string]data.TransactionHandler),
+	}, nil
+}
+
+// AddIntermediateTransactions saves the provided results, indexed by their hashes
+func (irc *intermediateResultsCollector) AddIntermediateTransactions(txs []data.TransactionHandler) error {
+	irc.mutResults.Lock()
+	defer irc.mutResults.Unlock()
+
+	for _, tx := range txs {
+		txHash, err := core.CalculateHash(irc.marshalizer, irc.hasher, tx)
+		if err != nil {
+			return err
+		}
+
+		irc.results[string(txHash)] = tx
+	}
+
+	return nil
+}
+
+// CreateAllInterMiniBlocks returns an empty map as simulated results are never included in miniblocks
+func (irc *intermediateResultsCollector) CreateAllInterMiniBlocks() map[uint32]*block.MiniBlock {
+	return make(map[uint32]*block.MiniBlock)
+}
+
+// VerifyInterMiniBlocks returns nil as simulated results are never included in miniblocks
+func (irc *intermediateResultsCollector) VerifyInterMiniBlocks(_ block.Body) error {
+	return nil
+}
+
+// SaveCurrentIntermediateTxToStorage does nothing as simulated results must never reach the storage
+func (irc *intermediateResultsCollector) SaveCurrentIntermediateTxToStorage() error {
+	return nil
+}
+
+// GetAllCurrentFinishedTxs returns all the results collected since the last CreateBlockStarted call
+func (irc *intermediateResultsCollector) GetAllCurrentFinishedTxs() map[string]data.TransactionHandler {
+	irc.mutResults.RLock()
+	defer irc.mutResults.RUnlock()
+
+	results := make(map[string]data.TransactionHandler, len(irc.results))
+	for hash, tx := range irc.results {
+		results[hash] = tx
+	}
+
+	return results
+}
+
+// CreateBlockStarted removes all the collected results
+func (irc *intermediateResultsCollector) CreateBlockStarted() {
+	irc.mutResults.Lock()
+	irc.results = make(map[string]data.TransactionHandler)
+	irc.mutResults.Unlock()
+}
+
+// GetCreatedInShardMiniBlock returns nil as simulated results are never included in miniblocks
+func (irc *intermediateResultsCollector) GetCreatedInShardMiniBlock() *block.MiniBlock {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (irc *intermediateResultsCollector) IsInterfaceNil() bool {
+	return irc == nil
+}
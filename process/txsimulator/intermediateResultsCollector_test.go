@@ -0,0 +1,50 @@
+package txsimulator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIntermediateResultsCollector_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	irc, err := NewIntermediateResultsCollector(nil, &mock.HasherMock{})
+
+	assert.True(t, check.IfNil(irc))
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewIntermediateResultsCollector_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	irc, err := NewIntermediateResultsCollector(&mock.MarshalizerMock{}, nil)
+
+	assert.True(t, check.IfNil(irc))
+	assert.Equal(t, process.ErrNilHasher, err)
+}
+
+func TestIntermediateResultsCollector_AddAndReset(t *testing.T) {
+	t.Parallel()
+
+	irc, _ := NewIntermediateResultsCollector(&mock.MarshalizerMock{}, &mock.HasherMock{})
+	err := irc.AddIntermediateTransactions([]data.TransactionHandler{
+		&smartContractResult.SmartContractResult{Value: big.NewInt(1)},
+		&receipt.Receipt{Value: big.NewInt(2)},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(irc.GetAllCurrentFinishedTxs()))
+	assert.Equal(t, 0, len(irc.CreateAllInterMiniBlocks()))
+	assert.Nil(t, irc.SaveCurrentIntermediateTxToStorage())
+
+	irc.CreateBlockStarted()
+	assert.Equal(t, 0, len(irc.GetAllCurrentFinishedTxs()))
+}
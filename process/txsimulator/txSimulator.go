@@ -0,0 +1,380 @@
+package txsimulator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/api"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+var log = logger.GetOrCreate("process/txsimulator")
+
+// ArgsTxSimulator holds the components needed to create a new transaction simulator
+type ArgsTxSimulator struct {
+	TransactionProcessor process.TransactionProcessor
+	BlockChain           data.ChainHandler
+	SimulationAccounts   state.AccountsAdapter
+	ResultsCollector     process.IntermediateTransactionHandler
+	GasHandler           process.GasHandler
+	TxTypeHandler        process.TxTypeHandler
+	EconomicsFee         process.FeeHandler
+	ShardCoordinator     sharding.Coordinator
+	AddressConverter     state.AddressConverter
+	Marshalizer          marshal.Marshalizer
+	Hasher               hashing.Hasher
+}
+
+// transactionSimulator executes transactions on a throwaway copy of the accounts state, without committing
+// anything, so callers can find out the outcome of a transaction before broadcasting it. The copy is recreated at
+// the root hash of the last committed block, as the node's own state may hold the changes of a block being processed
+type transactionSimulator struct {
+	txProcessor        process.TransactionProcessor
+	blockChain         data.ChainHandler
+	simulationAccounts state.AccountsAdapter
+	resultsCollector   process.IntermediateTransactionHandler
+	gasHandler         process.GasHandler
+	txTypeHandler      process.TxTypeHandler
+	economicsFee       process.FeeHandler
+	shardCoordinator   sharding.Coordinator
+	addressConverter   state.AddressConverter
+	marshalizer        marshal.Marshalizer
+	hasher             hashing.Hasher
+
+	mutSimulation sync.Mutex
+}
+
+// NewTransactionSimulator creates a new transaction simulator. The provided transaction processor should work
+// on the simulation accounts and should forward all the results it generates to the results collector
+func NewTransactionSimulator(args ArgsTxSimulator) (*transactionSimulator, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionSimulator{
+		txProcessor:        args.TransactionProcessor,
+		blockChain:         args.BlockChain,
+		simulationAccounts: args.SimulationAccounts,
+		resultsCollector:   args.ResultsCollector,
+		gasHandler:         args.GasHandler,
+		txTypeHandler:      args.TxTypeHandler,
+		economicsFee:       args.EconomicsFee,
+		shardCoordinator:   args.ShardCoordinator,
+		addressConverter:   args.AddressConverter,
+		marshalizer:        args.Marshalizer,
+		hasher:             args.Hasher,
+	}, nil
+}
+
+func checkArgs(args ArgsTxSimulator) error {
+	if check.IfNil(args.TransactionProcessor) {
+		return process.ErrNilTxProcessor
+	}
+	if check.IfNil(args.BlockChain) {
+		return process.ErrNilBlockChain
+	}
+	if check.IfNil(args.SimulationAccounts) {
+		return ErrNilSimulationAccounts
+	}
+	if check.IfNil(args.ResultsCollector) {
+		return ErrNilResultsCollector
+	}
+	if check.IfNil(args.GasHandler) {
+		return process.ErrNilGasHandler
+	}
+	if check.IfNil(args.TxTypeHandler) {
+		return process.ErrNilTxTypeHandler
+	}
+	if check.IfNil(args.EconomicsFee) {
+		return process.ErrNilEconomicsFeeHandler
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return process.ErrNilShardCoordinator
+	}
+	if check.IfNil(args.AddressConverter) {
+		return process.ErrNilAddressConverter
+	}
+	if check.IfNil(args.Marshalizer) {
+		return process.ErrNilMarshalizer
+	}
+	if check.IfNil(args.Hasher) {
+		return process.ErrNilHasher
+	}
+
+	return nil
+}
+
+// ProcessTx executes the provided transaction on top of the current state and returns the balance changes,
+// the generated results, the consumed gas and the reason of the failure, if any. All the changes are reverted
+// before returning. Simulations are executed one at a time
+func (ts *transactionSimulator) ProcessTx(tx *transaction.Transaction) (*api.SimulationResults, error) {
+	if check.IfNil(tx) {
+		return nil, process.ErrNilTransaction
+	}
+
+	txHash, err := core.CalculateHash(ts.marshalizer, ts.hasher, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mutSimulation.Lock()
+	defer ts.mutSimulation.Unlock()
+
+	rootHash, err := ts.getCommittedRootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ts.simulationAccounts.RecreateTrie(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.resultsCollector.CreateBlockStarted()
+	ts.gasHandler.Init()
+
+	errProcess := ts.txProcessor.ProcessTransaction(tx)
+	results := ts.createSimulationResults(tx, txHash, errProcess)
+
+	touchedAddresses := ts.computeTouchedAddresses(tx)
+	balancesAfter := ts.getBalances(touchedAddresses)
+
+	err = ts.simulationAccounts.RevertToSnapshot(0)
+	if err != nil {
+		return nil, err
+	}
+
+	balancesBefore := ts.getBalances(touchedAddresses)
+	for address, balanceAfter := range balancesAfter {
+		delta := big.NewInt(0).Sub(balanceAfter, balancesBefore[address])
+		if delta.Sign() == 0 {
+			continue
+		}
+
+		results.BalanceDeltas[hex.EncodeToString([]byte(address))] = delta.String()
+	}
+
+	return results, nil
+}
+
+// getCommittedRootHash returns the root hash of the last committed block or, before the first commit, of the genesis
+func (ts *transactionSimulator) getCommittedRootHash() ([]byte, error) {
+	header := ts.blockChain.GetCurrentBlockHeader()
+	if check.IfNil(header) {
+		header = ts.blockChain.GetGenesisHeader()
+	}
+	if check.IfNil(header) {
+		return nil, process.ErrNilBlockHeader
+	}
+
+	return header.GetRootHash(), nil
+}
+
+func (ts *transactionSimulator) createSimulationResults(
+	tx *transaction.Transaction,
+	txHash []byte,
+	errProcess error,
+) *api.SimulationResults {
+	results := &api.SimulationResults{
+		Hash:          hex.EncodeToString(txHash),
+		BalanceDeltas: make(map[string]string),
+	}
+
+	for hash, result := range ts.resultsCollector.GetAllCurrentFinishedTxs() {
+		switch res := result.(type) {
+		case *smartContractResult.SmartContractResult:
+			results.SmartContractResults = append(results.SmartContractResults, convertSmartContractResult([]byte(hash), res))
+		case *receipt.Receipt:
+			results.Receipts = append(results.Receipts, convertReceipt([]byte(hash), res))
+		}
+	}
+
+	sort.Slice(results.SmartContractResults, func(i, j int) bool {
+		return results.SmartContractResults[i].Hash < results.SmartContractResults[j].Hash
+	})
+	sort.Slice(results.Receipts, func(i, j int) bool {
+		return results.Receipts[i].Hash < results.Receipts[j].Hash
+	})
+
+	results.FailReason = ts.computeFailReason(tx, errProcess)
+	results.GasUsed = ts.computeGasUsed(tx, txHash, errProcess)
+
+	return results
+}
+
+// computeFailReason returns the error that would make the transaction fail. Smart contract execution errors are
+// not returned by the processor, they are sent back to the sender as results having the return code as first argument
+func (ts *transactionSimulator) computeFailReason(tx *transaction.Transaction, errProcess error) string {
+	if errProcess != nil {
+		if errors.Is(errProcess, process.ErrFailedTransaction) {
+			for _, result := range ts.resultsCollector.GetAllCurrentFinishedTxs() {
+				rpt, ok := result.(*receipt.Receipt)
+				if ok && len(rpt.Data) > 0 {
+					return string(rpt.Data)
+				}
+			}
+		}
+
+		return errProcess.Error()
+	}
+
+	for _, result := range ts.resultsCollector.GetAllCurrentFinishedTxs() {
+		scr, ok := result.(*smartContractResult.SmartContractResult)
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(scr.RcvAddr, tx.SndAddr) || !bytes.Equal(scr.SndAddr, tx.RcvAddr) {
+			continue
+		}
+
+		returnCode := extractReturnCode(scr.Data)
+		if returnCode != "" && returnCode != vmcommon.Ok.String() {
+			return returnCode
+		}
+	}
+
+	return ""
+}
+
+func extractReturnCode(scrData []byte) string {
+	arguments := strings.Split(string(scrData), "@")
+	if len(arguments) < 2 {
+		return ""
+	}
+
+	returnCode, err := hex.DecodeString(arguments[1])
+	if err != nil {
+		return ""
+	}
+
+	return string(returnCode)
+}
+
+func (ts *transactionSimulator) computeGasUsed(tx *transaction.Transaction, txHash []byte, errProcess error) uint64 {
+	if errProcess != nil {
+		if errors.Is(errProcess, process.ErrFailedTransaction) {
+			return ts.economicsFee.ComputeGasLimit(tx)
+		}
+
+		return 0
+	}
+
+	txType, err := ts.txTypeHandler.ComputeTransactionType(tx)
+	if err != nil {
+		return 0
+	}
+	if txType == process.MoveBalance {
+		return ts.economicsFee.ComputeGasLimit(tx)
+	}
+
+	gasRefunded := ts.gasHandler.GasRefunded(txHash)
+	if gasRefunded > tx.GasLimit {
+		return 0
+	}
+
+	return tx.GasLimit - gasRefunded
+}
+
+func (ts *transactionSimulator) computeTouchedAddresses(tx *transaction.Transaction) [][]byte {
+	addresses := [][]byte{tx.SndAddr, tx.RcvAddr}
+	for _, result := range ts.resultsCollector.GetAllCurrentFinishedTxs() {
+		addresses = append(addresses, result.GetRecvAddress())
+	}
+
+	return addresses
+}
+
+// getBalances reads from the simulation accounts the balances of the provided addresses which belong to the
+// current shard. Missing accounts have a zero balance
+func (ts *transactionSimulator) getBalances(addresses [][]byte) map[string]*big.Int {
+	balances := make(map[string]*big.Int)
+	for _, address := range addresses {
+		if len(address) == 0 {
+			continue
+		}
+
+		addressContainer, err := ts.addressConverter.CreateAddressFromPublicKeyBytes(address)
+		if err != nil {
+			continue
+		}
+		if ts.shardCoordinator.ComputeId(addressContainer) != ts.shardCoordinator.SelfId() {
+			continue
+		}
+
+		balances[string(address)] = ts.getBalance(addressContainer)
+	}
+
+	return balances
+}
+
+func (ts *transactionSimulator) getBalance(addressContainer state.AddressContainer) *big.Int {
+	accountHandler, err := ts.simulationAccounts.GetExistingAccount(addressContainer)
+	if err != nil {
+		if !errors.Is(err, state.ErrAccNotFound) {
+			log.Debug("transactionSimulator.getBalance", "error", err.Error())
+		}
+		return big.NewInt(0)
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok || account.Balance == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(account.Balance)
+}
+
+func convertSmartContractResult(hash []byte, scr *smartContractResult.SmartContractResult) *api.SmartContractResult {
+	apiScr := &api.SmartContractResult{
+		Hash:           hex.EncodeToString(hash),
+		Nonce:          scr.Nonce,
+		Receiver:       hex.EncodeToString(scr.RcvAddr),
+		Sender:         hex.EncodeToString(scr.SndAddr),
+		Data:           scr.Data,
+		GasLimit:       scr.GasLimit,
+		GasPrice:       scr.GasPrice,
+		OriginalTxHash: hex.EncodeToString(scr.TxHash),
+	}
+	if scr.Value != nil {
+		apiScr.Value = scr.Value.String()
+	}
+
+	return apiScr
+}
+
+func convertReceipt(hash []byte, rpt *receipt.Receipt) *api.Receipt {
+	apiReceipt := &api.Receipt{
+		Hash:   hex.EncodeToString(hash),
+		Sender: hex.EncodeToString(rpt.SndAddr),
+		Data:   rpt.Data,
+		TxHash: hex.EncodeToString(rpt.TxHash),
+	}
+	if rpt.Value != nil {
+		apiReceipt.Value = rpt.Value.String()
+	}
+
+	return apiReceipt
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ts *transactionSimulator) IsInterfaceNil() bool {
+	return ts == nil
+}
@@ -0,0 +1,376 @@
+package txsimulator
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sndAddr = []byte("sender")
+var rcvAddr = []byte("receiver")
+
+func createMockArgsTxSimulator() ArgsTxSimulator {
+	collector, _ := NewIntermediateResultsCollector(&mock.MarshalizerMock{}, &mock.HasherMock{})
+
+	return ArgsTxSimulator{
+		TransactionProcessor: &mock.TxProcessorMock{},
+		BlockChain: &mock.BlockChainMock{
+			GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{RootHash: []byte("root hash")}
+			},
+		},
+		SimulationAccounts: &mock.AccountsStub{},
+		ResultsCollector:   collector,
+		GasHandler: &mock.GasHandlerMock{
+			InitCalled: func() {},
+		},
+		TxTypeHandler:    &mock.TxTypeHandlerMock{},
+		EconomicsFee:     &mock.FeeHandlerStub{},
+		ShardCoordinator: mock.NewOneShardCoordinatorMock(),
+		AddressConverter: &mock.AddressConverterMock{},
+		Marshalizer:      &mock.MarshalizerMock{},
+		Hasher:           &mock.HasherMock{},
+	}
+}
+
+// createSimulationAccounts returns an accounts stub which reports the provided balances after the
+// transaction was processed and the initial ones after the changes were reverted
+func createSimulationAccounts(initial map[string]int64, processed map[string]int64) *mock.AccountsStub {
+	reverted := false
+	return &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			reverted = false
+			return nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			reverted = true
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			balances := processed
+			if reverted {
+				balances = initial
+			}
+
+			balance, ok := balances[string(addressContainer.Bytes())]
+			if !ok {
+				return nil, state.ErrAccNotFound
+			}
+
+			return &state.Account{Balance: big.NewInt(balance)}, nil
+		},
+	}
+}
+
+func TestNewTransactionSimulator_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsTxSimulator()
+	args.TransactionProcessor = nil
+	ts, err := NewTransactionSimulator(args)
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilTxProcessor, err)
+
+	args = createMockArgsTxSimulator()
+	args.BlockChain = nil
+	ts, err = NewTransactionSimulator(args)
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilBlockChain, err)
+
+	args = createMockArgsTxSimulator()
+	args.SimulationAccounts = nil
+	ts, err = NewTransactionSimulator(args)
+	assert.Nil(t, ts)
+	assert.Equal(t, ErrNilSimulationAccounts, err)
+
+	args = createMockArgsTxSimulator()
+	args.ResultsCollector = nil
+	ts, err = NewTransactionSimulator(args)
+	assert.Nil(t, ts)
+	assert.Equal(t, ErrNilResultsCollector, err)
+
+	args = createMockArgsTxSimulator()
+	args.GasHandler = nil
+	ts, err = NewTransactionSimulator(args)
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilGasHandler, err)
+}
+
+func TestNewTransactionSimulator_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ts, err := NewTransactionSimulator(createMockArgsTxSimulator())
+
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(ts))
+}
+
+func TestTransactionSimulator_ProcessTxNilTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := NewTransactionSimulator(createMockArgsTxSimulator())
+	results, err := ts.ProcessTx(nil)
+
+	assert.Nil(t, results)
+	assert.Equal(t, process.ErrNilTransaction, err)
+}
+
+func TestTransactionSimulator_ProcessTxRecreateTrieFailsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return expectedErr
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+	results, err := ts.ProcessTx(&transaction.Transaction{})
+
+	assert.Nil(t, results)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestTransactionSimulator_ProcessTxShouldRecreateTheCommittedState(t *testing.T) {
+	t.Parallel()
+
+	committedRootHash := []byte("committed root hash")
+	var recreatedRootHash []byte
+	args := createMockArgsTxSimulator()
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(transaction *transaction.Transaction) error {
+			return nil
+		},
+	}
+	args.BlockChain = &mock.BlockChainMock{
+		GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: committedRootHash}
+		},
+	}
+	args.SimulationAccounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRootHash = rootHash
+			return nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	_, err := ts.ProcessTx(&transaction.Transaction{})
+
+	require.Nil(t, err)
+	assert.Equal(t, committedRootHash, recreatedRootHash)
+}
+
+func TestTransactionSimulator_ProcessTxBeforeTheFirstCommitShouldUseTheGenesisState(t *testing.T) {
+	t.Parallel()
+
+	genesisRootHash := []byte("genesis root hash")
+	var recreatedRootHash []byte
+	args := createMockArgsTxSimulator()
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(transaction *transaction.Transaction) error {
+			return nil
+		},
+	}
+	args.BlockChain = &mock.BlockChainMock{
+		GetGenesisHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: genesisRootHash}
+		},
+	}
+	args.SimulationAccounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRootHash = rootHash
+			return nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	_, err := ts.ProcessTx(&transaction.Transaction{})
+
+	require.Nil(t, err)
+	assert.Equal(t, genesisRootHash, recreatedRootHash)
+}
+
+func TestTransactionSimulator_ProcessTxMoveBalanceShouldComputeDeltas(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = createSimulationAccounts(
+		map[string]int64{string(sndAddr): 100},
+		map[string]int64{string(sndAddr): 60, string(rcvAddr): 30},
+	)
+	args.EconomicsFee = &mock.FeeHandlerStub{
+		ComputeGasLimitCalled: func(tx process.TransactionWithFeeHandler) uint64 {
+			return 10
+		},
+	}
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(tx *transaction.Transaction) error {
+			return args.ResultsCollector.AddIntermediateTransactions([]data.TransactionHandler{
+				&receipt.Receipt{Value: big.NewInt(5), SndAddr: sndAddr, Data: []byte("refundedGas")},
+			})
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	results, err := ts.ProcessTx(&transaction.Transaction{SndAddr: sndAddr, RcvAddr: rcvAddr, GasLimit: 20})
+	require.Nil(t, err)
+
+	assert.Equal(t, "", results.FailReason)
+	assert.Equal(t, uint64(10), results.GasUsed)
+	assert.Equal(t, 1, len(results.Receipts))
+	assert.Equal(t, "-40", results.BalanceDeltas[hex.EncodeToString(sndAddr)])
+	assert.Equal(t, "30", results.BalanceDeltas[hex.EncodeToString(rcvAddr)])
+}
+
+func TestTransactionSimulator_ProcessTxFailedTransactionShouldReturnReason(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = createSimulationAccounts(
+		map[string]int64{string(sndAddr): 100},
+		map[string]int64{string(sndAddr): 90},
+	)
+	args.EconomicsFee = &mock.FeeHandlerStub{
+		ComputeGasLimitCalled: func(tx process.TransactionWithFeeHandler) uint64 {
+			return 10
+		},
+	}
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(tx *transaction.Transaction) error {
+			_ = args.ResultsCollector.AddIntermediateTransactions([]data.TransactionHandler{
+				&receipt.Receipt{Value: big.NewInt(10), SndAddr: sndAddr, Data: []byte(process.ErrInsufficientFunds.Error())},
+			})
+			return process.ErrFailedTransaction
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	results, err := ts.ProcessTx(&transaction.Transaction{SndAddr: sndAddr, RcvAddr: rcvAddr, Value: big.NewInt(1000)})
+	require.Nil(t, err)
+
+	assert.Equal(t, process.ErrInsufficientFunds.Error(), results.FailReason)
+	assert.Equal(t, uint64(10), results.GasUsed)
+	assert.Equal(t, "-10", results.BalanceDeltas[hex.EncodeToString(sndAddr)])
+}
+
+func TestTransactionSimulator_ProcessTxSmartContractErrorShouldReturnReturnCode(t *testing.T) {
+	t.Parallel()
+
+	returnCode := "user error"
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = createSimulationAccounts(nil, nil)
+	args.TxTypeHandler = &mock.TxTypeHandlerMock{
+		ComputeTransactionTypeCalled: func(tx data.TransactionHandler) (process.TransactionType, error) {
+			return process.SCInvoking, nil
+		},
+	}
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(tx *transaction.Transaction) error {
+			return args.ResultsCollector.AddIntermediateTransactions([]data.TransactionHandler{
+				&smartContractResult.SmartContractResult{
+					RcvAddr: sndAddr,
+					SndAddr: rcvAddr,
+					Data:    []byte("@" + hex.EncodeToString([]byte(returnCode)) + "@" + hex.EncodeToString([]byte("tx hash"))),
+				},
+			})
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	results, err := ts.ProcessTx(&transaction.Transaction{SndAddr: sndAddr, RcvAddr: rcvAddr, GasLimit: 50})
+	require.Nil(t, err)
+
+	assert.Equal(t, returnCode, results.FailReason)
+	assert.Equal(t, uint64(50), results.GasUsed)
+	assert.Equal(t, 1, len(results.SmartContractResults))
+}
+
+func TestTransactionSimulator_ProcessTxSmartContractCallShouldComputeGasUsed(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = createSimulationAccounts(nil, nil)
+	args.TxTypeHandler = &mock.TxTypeHandlerMock{
+		ComputeTransactionTypeCalled: func(tx data.TransactionHandler) (process.TransactionType, error) {
+			return process.SCInvoking, nil
+		},
+	}
+	args.GasHandler = &mock.GasHandlerMock{
+		InitCalled: func() {},
+		GasRefundedCalled: func(hash []byte) uint64 {
+			return 15
+		},
+	}
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(tx *transaction.Transaction) error {
+			return args.ResultsCollector.AddIntermediateTransactions([]data.TransactionHandler{
+				&smartContractResult.SmartContractResult{
+					RcvAddr: sndAddr,
+					SndAddr: rcvAddr,
+					Data:    []byte("@" + hex.EncodeToString([]byte("ok"))),
+				},
+			})
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	results, err := ts.ProcessTx(&transaction.Transaction{SndAddr: sndAddr, RcvAddr: rcvAddr, GasLimit: 50})
+	require.Nil(t, err)
+
+	assert.Equal(t, "", results.FailReason)
+	assert.Equal(t, uint64(35), results.GasUsed)
+}
+
+func TestTransactionSimulator_ProcessTxShouldRevertChanges(t *testing.T) {
+	t.Parallel()
+
+	reverted := false
+	args := createMockArgsTxSimulator()
+	args.SimulationAccounts = &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			reverted = snapshot == 0
+			return nil
+		},
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+	args.TransactionProcessor = &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(tx *transaction.Transaction) error {
+			return process.ErrHigherNonceInTransaction
+		},
+	}
+	ts, _ := NewTransactionSimulator(args)
+
+	results, err := ts.ProcessTx(&transaction.Transaction{SndAddr: sndAddr, RcvAddr: rcvAddr})
+	require.Nil(t, err)
+
+	assert.True(t, reverted)
+	assert.Equal(t, process.ErrHigherNonceInTransaction.Error(), results.FailReason)
+	assert.Equal(t, uint64(0), results.GasUsed)
+	assert.Equal(t, 0, len(results.BalanceDeltas))
+}
@@ -2,6 +2,11 @@ package presenter
 
 import "github.com/ElrondNetwork/elrond-go/core"
 
+// GetCacheHitRates will return the hit rates of the data pool caches
+func (psh *PresenterStatusHandler) GetCacheHitRates() string {
+	return psh.getFromCacheAsString(core.MetricCacheHitRates)
+}
+
 // GetCpuLoadPercent wil return cpu load
 func (psh *PresenterStatusHandler) GetCpuLoadPercent() uint64 {
 	return psh.getFromCacheAsUint64(core.MetricCpuLoadPercent)
@@ -116,3 +116,14 @@ func TestPresenterStatusHandler_GetNetworkSentBpsPeak(t *testing.T) {
 
 	assert.Equal(t, networkSentBpsPeak, result)
 }
+
+func TestPresenterStatusHandler_GetCacheHitRates(t *testing.T) {
+	t.Parallel()
+
+	hitRates := "miniblocks 50.0%, trie_nodes 90.0%"
+	presenterStatusHandler := NewPresenterStatusHandler()
+	presenterStatusHandler.SetStringValue(core.MetricCacheHitRates, hitRates)
+	result := presenterStatusHandler.GetCacheHitRates()
+
+	assert.Equal(t, hitRates, result)
+}
@@ -37,6 +37,7 @@ type Presenter interface {
 	GetNetworkSentPercent() uint64
 	GetNetworkSentBps() uint64
 	GetNetworkSentBpsPeak() uint64
+	GetCacheHitRates() string
 	GetLogLines() []string
 	GetNumTxProcessed() uint64
 	GetCurrentBlockHash() string
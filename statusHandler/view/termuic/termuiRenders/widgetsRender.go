@@ -244,6 +244,9 @@ func (wr *WidgetsRender) prepareChainInfo() {
 	rows[8] = []string{fmt.Sprintf("Peers / Validators / Nodes: %d / %d / %d",
 		numConnectedPeers, numLiveValidators, numConnectedNodes)}
 
+	cacheHitRates := wr.presenter.GetCacheHitRates()
+	rows[9] = []string{fmt.Sprintf("Cache hit rates: %s", cacheHitRates)}
+
 	wr.chainInfo.Title = "Chain info"
 	wr.chainInfo.RowSeparator = false
 	wr.chainInfo.Rows = rows
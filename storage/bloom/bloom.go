@@ -27,6 +27,9 @@ const (
 	bitsInByte = 8
 )
 
+// ErrInvalidFilterLength signals that the data loaded in a filter does not match the filter's size
+var ErrInvalidFilterLength = errors.New("invalid filter length")
+
 // Bloom represents a bloom filter. It holds the filter itself, the hashing functions that must be
 // applied to values that are added to the filter and a mutex to handle concurrent accesses to the filter
 type Bloom struct {
@@ -99,6 +102,31 @@ func (b *Bloom) Clear() {
 	}
 }
 
+// Bytes returns a copy of the filter, which can be restored later through Load
+func (b *Bloom) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	buff := make([]byte, len(b.filter))
+	copy(buff, b.filter)
+
+	return buff
+}
+
+// Load replaces the filter with the provided one, which must have been obtained from a filter of the same size
+func (b *Bloom) Load(buff []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(buff) != len(b.filter) {
+		return ErrInvalidFilterLength
+	}
+
+	copy(b.filter, buff)
+
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (b *Bloom) IsInterfaceNil() bool {
 	return b == nil
@@ -116,17 +144,23 @@ func getBytePositionAndBitMask(index uint64) (pos uint64, val byte) {
 
 // getBitsIndexes returns a slice which contains indexes that represent bits from the filter that have to be set.
 func getBitsIndexes(b *Bloom, data []byte) []uint64 {
-	var ch = make(chan uint64, len(b.hashFunc))
+	return getPositions(b.hashFunc, data, uint64(len(b.filter)*bitsInByte))
+}
+
+// getPositions returns the positions, one for each hashing function, that correspond to the data in a filter
+// having numPositions positions
+func getPositions(hashFunc []hashing.Hasher, data []byte, numPositions uint64) []uint64 {
+	var ch = make(chan uint64, len(hashFunc))
 	var wg sync.WaitGroup
 	var res []uint64
 
-	wg.Add(len(b.hashFunc))
-	for i := range b.hashFunc {
-		go getBitIndexFromHash(b.hashFunc[i], data, len(b.filter), &wg, ch)
+	wg.Add(len(hashFunc))
+	for i := range hashFunc {
+		go getPositionFromHash(hashFunc[i], data, numPositions, &wg, ch)
 	}
 	wg.Wait()
 
-	for i := 0; i < len(b.hashFunc); i++ {
+	for i := 0; i < len(hashFunc); i++ {
 		res = append(res, <-ch)
 	}
 
@@ -134,13 +168,13 @@ func getBitsIndexes(b *Bloom, data []byte) []uint64 {
 
 }
 
-// getBitIndexFromHash hashes with the given hasher implementation the data received,
-// then writes on the channel the index of the bit from the filter that has to be set.
-func getBitIndexFromHash(h hashing.Hasher, data []byte, size int, wg *sync.WaitGroup, ch chan uint64) {
+// getPositionFromHash hashes with the given hasher implementation the data received,
+// then writes on the channel the position from the filter that corresponds to the hash.
+func getPositionFromHash(h hashing.Hasher, data []byte, numPositions uint64, wg *sync.WaitGroup, ch chan uint64) {
 
 	hash := h.Compute(string(data))
 	hash64 := binary.BigEndian.Uint64(hash)
-	val := hash64 % uint64(size*bitsInByte)
+	val := hash64 % numPositions
 
 	ch <- val
 	wg.Done()
@@ -86,3 +86,15 @@ func TestConcurrency(t *testing.T) {
 		assert.True(t, b.MayContain([]byte("j"+strconv.Itoa(i))), "j"+strconv.Itoa(i))
 	}
 }
+
+func TestFilter_BytesAndLoad(t *testing.T) {
+	b := bloom.NewDefaultFilter()
+	b.Add([]byte("test"))
+
+	restored := bloom.NewDefaultFilter()
+	err := restored.Load(b.Bytes())
+
+	assert.Nil(t, err)
+	assert.True(t, restored.MayContain([]byte("test")))
+	assert.Equal(t, bloom.ErrInvalidFilterLength, restored.Load([]byte("short")))
+}
@@ -0,0 +1,151 @@
+package bloom
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/hashing"
+)
+
+const (
+	counterBits     = 4
+	countersInByte  = bitsInByte / counterBits
+	maxCounterValue = 1<<counterBits - 1
+)
+
+// CountingBloom is a bloom filter keeping a 4 bits counter instead of a bit for every position, so the added values
+// can also be removed. A counter reaching its maximum value is never decremented, which keeps the filter free of
+// false negatives at the cost of a few false positives. Removing a value which was not added breaks the filter
+type CountingBloom struct {
+	counters     []byte
+	numPositions uint64
+	hashFunc     []hashing.Hasher
+	mutex        sync.RWMutex
+}
+
+// NewCountingFilter returns a new CountingBloom object having the same number of positions as a Bloom of the given
+// size, which means it uses four times more memory. It returns an error if there are no hashing functions, or if the
+// size of the filter is too small
+func NewCountingFilter(size uint, h []hashing.Hasher) (*CountingBloom, error) {
+	if size <= uint(len(h)) {
+		return nil, errors.New("filter size is too low")
+	}
+
+	if len(h) == 0 {
+		return nil, errors.New("too few hashing functions")
+	}
+
+	numPositions := uint64(size) * bitsInByte
+
+	return &CountingBloom{
+		counters:     make([]byte, numPositions/countersInByte),
+		numPositions: numPositions,
+		hashFunc:     h,
+	}, nil
+}
+
+// Add increments the counters that correspond to the hashes of the data
+func (cb *CountingBloom) Add(data []byte) {
+	positions := getPositions(cb.hashFunc, data, cb.numPositions)
+
+	cb.mutex.Lock()
+	for _, position := range positions {
+		counter := cb.getCounter(position)
+		if counter < maxCounterValue {
+			cb.setCounter(position, counter+1)
+		}
+	}
+	cb.mutex.Unlock()
+}
+
+// Remove decrements the counters that correspond to the hashes of the data. It must be called only for data
+// previously added to the filter
+func (cb *CountingBloom) Remove(data []byte) {
+	positions := getPositions(cb.hashFunc, data, cb.numPositions)
+
+	cb.mutex.Lock()
+	for _, position := range positions {
+		counter := cb.getCounter(position)
+		if counter > 0 && counter < maxCounterValue {
+			cb.setCounter(position, counter-1)
+		}
+	}
+	cb.mutex.Unlock()
+}
+
+// MayContain checks if the counters that correspond to the hashes of the data are set.
+// If all the counters are set, it returns true, otherwise it returns false
+func (cb *CountingBloom) MayContain(data []byte) bool {
+	positions := getPositions(cb.hashFunc, data, cb.numPositions)
+
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	for _, position := range positions {
+		if cb.getCounter(position) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clear resets the counters of the bloom filter
+func (cb *CountingBloom) Clear() {
+	cb.mutex.Lock()
+	for i := 0; i < len(cb.counters); i++ {
+		cb.counters[i] = 0
+	}
+	cb.mutex.Unlock()
+}
+
+// Bytes returns a copy of the counters, which can be restored later through Load
+func (cb *CountingBloom) Bytes() []byte {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	buff := make([]byte, len(cb.counters))
+	copy(buff, cb.counters)
+
+	return buff
+}
+
+// Load replaces the counters with the provided ones, which must have been obtained from a filter of the same size
+func (cb *CountingBloom) Load(buff []byte) error {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if len(buff) != len(cb.counters) {
+		return ErrInvalidFilterLength
+	}
+
+	copy(cb.counters, buff)
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cb *CountingBloom) IsInterfaceNil() bool {
+	return cb == nil
+}
+
+// getCounter returns the counter found at the given position. Should be called under mutex
+func (cb *CountingBloom) getCounter(position uint64) byte {
+	pos, shift := getBytePositionAndShift(position)
+
+	return (cb.counters[pos] >> shift) & maxCounterValue
+}
+
+// setCounter writes the counter found at the given position. Should be called under mutex
+func (cb *CountingBloom) setCounter(position uint64, value byte) {
+	pos, shift := getBytePositionAndShift(position)
+
+	cb.counters[pos] &^= maxCounterValue << shift
+	cb.counters[pos] |= value << shift
+}
+
+// getBytePositionAndShift takes the index of a counter and returns the position of the byte holding it and the
+// number of bits the counter is shifted by inside that byte
+func getBytePositionAndShift(position uint64) (uint64, uint) {
+	return position / countersInByte, uint(position%countersInByte) * counterBits
+}
@@ -0,0 +1,133 @@
+package bloom_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/hashing/fnv"
+	"github.com/ElrondNetwork/elrond-go/hashing/keccak"
+	"github.com/ElrondNetwork/elrond-go/storage/bloom"
+	"github.com/stretchr/testify/assert"
+)
+
+func createDefaultCountingFilter() *bloom.CountingBloom {
+	cb, _ := bloom.NewCountingFilter(2048, []hashing.Hasher{keccak.Keccak{}, blake2b.Blake2b{}, fnv.Fnv{}})
+
+	return cb
+}
+
+func TestNewCountingFilter(t *testing.T) {
+	cb, err := bloom.NewCountingFilter(200, []hashing.Hasher{keccak.Keccak{}, blake2b.Blake2b{}, fnv.Fnv{}})
+
+	assert.Nil(t, err)
+	assert.False(t, cb.IsInterfaceNil())
+}
+
+func TestNewCountingFilterWithSmallSize(t *testing.T) {
+	cb, err := bloom.NewCountingFilter(1, []hashing.Hasher{keccak.Keccak{}, blake2b.Blake2b{}})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, cb)
+}
+
+func TestNewCountingFilterWithZeroHashFunctions(t *testing.T) {
+	cb, err := bloom.NewCountingFilter(2048, []hashing.Hasher{})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, cb)
+}
+
+func TestCountingFilter_AddAndRemove(t *testing.T) {
+	cb := createDefaultCountingFilter()
+
+	values := [][]byte{[]byte("12345"), []byte(" "), []byte("BloomFilter"), []byte("test")}
+	for _, value := range values {
+		cb.Add(value)
+		assert.True(t, cb.MayContain(value))
+	}
+
+	cb.Remove(values[0])
+	assert.False(t, cb.MayContain(values[0]))
+	for _, value := range values[1:] {
+		assert.True(t, cb.MayContain(value))
+	}
+
+	cb.Clear()
+	for _, value := range values {
+		assert.False(t, cb.MayContain(value))
+	}
+}
+
+func TestCountingFilter_ValueAddedTwiceShouldNeedTwoRemovals(t *testing.T) {
+	cb := createDefaultCountingFilter()
+	value := []byte("test")
+
+	cb.Add(value)
+	cb.Add(value)
+	cb.Remove(value)
+	assert.True(t, cb.MayContain(value))
+
+	cb.Remove(value)
+	assert.False(t, cb.MayContain(value))
+}
+
+func TestCountingFilter_SaturatedCountersShouldNotBeDecremented(t *testing.T) {
+	cb := createDefaultCountingFilter()
+	value := []byte("test")
+
+	for i := 0; i < 20; i++ {
+		cb.Add(value)
+	}
+	for i := 0; i < 20; i++ {
+		cb.Remove(value)
+	}
+
+	assert.True(t, cb.MayContain(value))
+}
+
+func TestCountingFilter_BytesAndLoad(t *testing.T) {
+	cb := createDefaultCountingFilter()
+	cb.Add([]byte("test"))
+
+	restored := createDefaultCountingFilter()
+	err := restored.Load(cb.Bytes())
+
+	assert.Nil(t, err)
+	assert.True(t, restored.MayContain([]byte("test")))
+
+	restored.Remove([]byte("test"))
+	assert.False(t, restored.MayContain([]byte("test")))
+	assert.True(t, cb.MayContain([]byte("test")))
+
+	assert.Equal(t, bloom.ErrInvalidFilterLength, restored.Load(bloom.NewDefaultFilter().Bytes()))
+}
+
+func TestCountingFilter_Concurrency(t *testing.T) {
+	cb := createDefaultCountingFilter()
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	maxIterations := 1000
+
+	addValues := func(base string) {
+		for i := 0; i < maxIterations; i++ {
+			cb.Add([]byte(base + strconv.Itoa(i)))
+		}
+
+		wg.Done()
+	}
+
+	go addValues("i")
+	go addValues("j")
+
+	wg.Wait()
+
+	for i := 0; i < maxIterations; i++ {
+		assert.True(t, cb.MayContain([]byte("i"+strconv.Itoa(i))), "i"+strconv.Itoa(i))
+		assert.True(t, cb.MayContain([]byte("j"+strconv.Itoa(i))), "j"+strconv.Itoa(i))
+	}
+}
@@ -0,0 +1,325 @@
+package boltdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+// read + write + execute for owner only
+const rwxOwner = 0700
+
+// read + write for owner only
+const rwOwner = 0600
+
+const dbFileName = "data.db"
+
+const openTimeout = time.Second
+
+const readOnlyBatchDelaySeconds = 1
+
+// iterateChunkSize is the maximum number of pairs read inside a single read transaction while iterating
+const iterateChunkSize = 1024
+
+var bucketName = []byte("data")
+
+var log = logger.GetOrCreate("storage/boltdb")
+
+// DB holds a pointer to the bolt database and the path to where it is stored.
+// As with the leveldb persister, the written pairs are gathered in a batch which is committed in a single
+// transaction when it is full or when the batch delay elapses, so they are not visible to Get or Has before
+type DB struct {
+	db                *bolt.DB
+	path              string
+	maxBatchSize      int
+	batchDelaySeconds int
+	batch             map[string][]byte
+	mutBatch          sync.Mutex
+	dbClosed          chan struct{}
+}
+
+// NewDB is a constructor for the bolt persister
+// It creates the database file in the directory given as parameter
+func NewDB(path string, batchDelaySeconds int, maxBatchSize int) (*DB, error) {
+	err := os.MkdirAll(path, rwxOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(filepath.Join(path, dbFileName), rwOwner, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, errCreate := tx.CreateBucketIfNotExists(bucketName)
+		return errCreate
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return newDB(db, path, batchDelaySeconds, maxBatchSize), nil
+}
+
+// NewReadOnlyDB opens an existing bolt database without allowing any change to its file.
+// The Put and Remove calls return an error
+func NewReadOnlyDB(path string) (*DB, error) {
+	options := &bolt.Options{
+		Timeout:  openTimeout,
+		ReadOnly: true,
+	}
+	db, err := bolt.Open(filepath.Join(path, dbFileName), rwOwner, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketName) == nil {
+			return bolt.ErrBucketNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// a batch of one pair makes the Put calls fail right away
+	return newDB(db, path, readOnlyBatchDelaySeconds, 1), nil
+}
+
+func newDB(db *bolt.DB, path string, batchDelaySeconds int, maxBatchSize int) *DB {
+	dbStore := &DB{
+		db:                db,
+		path:              path,
+		maxBatchSize:      maxBatchSize,
+		batchDelaySeconds: batchDelaySeconds,
+		batch:             make(map[string][]byte),
+		dbClosed:          make(chan struct{}),
+	}
+
+	go dbStore.batchTimeoutHandle()
+
+	return dbStore
+}
+
+func (s *DB) batchTimeoutHandle() {
+	for {
+		select {
+		case <-time.After(time.Duration(s.batchDelaySeconds) * time.Second):
+			s.mutBatch.Lock()
+			err := s.putBatch()
+			s.mutBatch.Unlock()
+			if err != nil {
+				log.Warn("boltdb putBatch", "error", err.Error())
+			}
+		case <-s.dbClosed:
+			return
+		}
+	}
+}
+
+// Put adds the value to the (key, val) storage medium
+func (s *DB) Put(key, val []byte) error {
+	s.mutBatch.Lock()
+	defer s.mutBatch.Unlock()
+
+	s.batch[string(key)] = append([]byte(nil), val...)
+	if len(s.batch) < s.maxBatchSize {
+		return nil
+	}
+
+	err := s.putBatch()
+	if err != nil {
+		log.Warn("boltdb putBatch", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// putBatch commits the batch in a single transaction and empties it. The caller must hold the batch mutex
+func (s *DB) putBatch() error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		for key, val := range s.batch {
+			errPut := bucket.Put([]byte(key), val)
+			if errPut != nil {
+				return errPut
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.batch = make(map[string][]byte)
+
+	return nil
+}
+
+// Flush commits the pending batch, without waiting for the batch delay to elapse or for the batch to be full
+func (s *DB) Flush() error {
+	s.mutBatch.Lock()
+	defer s.mutBatch.Unlock()
+
+	return s.putBatch()
+}
+
+// Get returns the value associated to the key
+func (s *DB) Get(key []byte) ([]byte, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		val := tx.Bucket(bucketName).Get(key)
+		if val != nil {
+			// the returned slice is only valid for the life of the transaction
+			data = append([]byte{}, val...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+
+	return data, nil
+}
+
+// Has returns true if the given key is present in the persistence medium
+func (s *DB) Has(key []byte) error {
+	_, err := s.Get(key)
+
+	return err
+}
+
+// Init initializes the storage medium and prepares it for usage
+func (s *DB) Init() error {
+	// no special initialization needed
+	return nil
+}
+
+// Close closes the files/resources associated to the storage medium
+func (s *DB) Close() error {
+	s.mutBatch.Lock()
+	_ = s.putBatch()
+	s.mutBatch.Unlock()
+
+	s.dbClosed <- struct{}{}
+
+	return s.db.Close()
+}
+
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+// handler returns false. The pending batch is written first. The pairs are read in chunks, each in its own read
+// transaction, so the handler can write to the database
+func (s *DB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	err := s.Flush()
+	if err != nil {
+		return err
+	}
+
+	var start []byte
+	if keyRange != nil {
+		start = keyRange.Start
+	}
+
+	for {
+		keys, vals, errRead := s.readChunk(start, keyRange)
+		if errRead != nil {
+			return errRead
+		}
+
+		for i := range keys {
+			if !handler(keys[i], vals[i]) {
+				return nil
+			}
+		}
+
+		if len(keys) < iterateChunkSize {
+			return nil
+		}
+
+		// the smallest key greater than the last visited one
+		start = append(keys[len(keys)-1], 0)
+	}
+}
+
+func (s *DB) readChunk(start []byte, keyRange *storage.KeyRange) ([][]byte, [][]byte, error) {
+	keys := make([][]byte, 0)
+	vals := make([][]byte, 0)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(bucketName).Cursor()
+
+		key, val := cursor.First()
+		if len(start) > 0 {
+			key, val = cursor.Seek(start)
+		}
+
+		for ; key != nil && len(keys) < iterateChunkSize; key, val = cursor.Next() {
+			if keyRange != nil && len(keyRange.Limit) > 0 && bytes.Compare(key, keyRange.Limit) >= 0 {
+				break
+			}
+
+			keys = append(keys, append([]byte(nil), key...))
+			vals = append(vals, append([]byte(nil), val...))
+		}
+
+		return nil
+	})
+
+	return keys, vals, err
+}
+
+// Remove removes the data associated to the given key
+func (s *DB) Remove(key []byte) error {
+	s.mutBatch.Lock()
+	delete(s.batch, string(key))
+	s.mutBatch.Unlock()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete(key)
+	})
+}
+
+// Destroy removes the storage medium stored data
+func (s *DB) Destroy() error {
+	s.mutBatch.Lock()
+	s.batch = make(map[string][]byte)
+	s.mutBatch.Unlock()
+
+	s.dbClosed <- struct{}{}
+	err := s.db.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(s.path)
+}
+
+// DestroyClosed removes the already closed storage medium stored data
+func (s *DB) DestroyClosed() error {
+	return os.RemoveAll(s.path)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *DB) IsInterfaceNil() bool {
+	return s == nil
+}
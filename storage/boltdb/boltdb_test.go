@@ -0,0 +1,93 @@
+package boltdb_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/boltdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func createBoltDb(t *testing.T, batchDelaySeconds int, maxBatchSize int) *boltdb.DB {
+	dir, _ := ioutil.TempDir("", "boltdb_temp")
+	db, err := boltdb.NewDB(dir, batchDelaySeconds, maxBatchSize)
+
+	assert.Nil(t, err, "Failed creating boltdb database file")
+	return db
+}
+
+func TestDB_GetErrorAfterPutBeforeTimeout(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	db := createBoltDb(t, 1, 100)
+	defer func() {
+		_ = db.Destroy()
+	}()
+
+	err := db.Put(key, val)
+	assert.Nil(t, err)
+	v, err := db.Get(key)
+	assert.Nil(t, v)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
+
+func TestDB_GetOKAfterPutWithTimeout(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	db := createBoltDb(t, 1, 100)
+	defer func() {
+		_ = db.Destroy()
+	}()
+
+	err := db.Put(key, val)
+	assert.Nil(t, err)
+	time.Sleep(time.Second * 3)
+
+	v, err := db.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+}
+
+func TestDB_RemoveShouldDropThePendingPut(t *testing.T) {
+	key := []byte("key")
+	db := createBoltDb(t, 10, 100)
+	defer func() {
+		_ = db.Destroy()
+	}()
+
+	_ = db.Put(key, []byte("value"))
+	err := db.Remove(key)
+	assert.Nil(t, err)
+
+	_ = db.Iterate(nil, func(key []byte, val []byte) bool {
+		assert.Fail(t, "removed key should not be visited")
+		return true
+	})
+}
+
+func TestDB_IterateShouldWalkAllChunks(t *testing.T) {
+	db := createBoltDb(t, 10, 100)
+	defer func() {
+		_ = db.Destroy()
+	}()
+
+	numKeys := 3000
+	for i := 0; i < numKeys; i++ {
+		_ = db.Put([]byte(fmt.Sprintf("key%05d", i)), []byte("value"))
+	}
+
+	visited := 0
+	var lastKey []byte
+	err := db.Iterate(storage.PrefixRange([]byte("key")), func(key []byte, val []byte) bool {
+		assert.True(t, string(key) > string(lastKey))
+		lastKey = key
+		visited++
+
+		// writing from the handler should not deadlock
+		return db.Put([]byte("other"+string(key)), val) == nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, numKeys, visited)
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"sync/atomic"
+)
+
+// CacheStats holds the usage counters of a cache, gathered since its creation
+type CacheStats struct {
+	NumGets       uint64
+	NumHits       uint64
+	NumMisses     uint64
+	NumPuts       uint64
+	NumEvictions  uint64
+	NumBytesAdded uint64
+}
+
+// HitRatePercent returns the percentage of the gets which found the key in the cache
+func (cs CacheStats) HitRatePercent() float64 {
+	if cs.NumGets == 0 {
+		return 0
+	}
+
+	return float64(cs.NumHits) * 100 / float64(cs.NumGets)
+}
+
+// Add returns the sum of the counters of both stats
+func (cs CacheStats) Add(other CacheStats) CacheStats {
+	return CacheStats{
+		NumGets:       cs.NumGets + other.NumGets,
+		NumHits:       cs.NumHits + other.NumHits,
+		NumMisses:     cs.NumMisses + other.NumMisses,
+		NumPuts:       cs.NumPuts + other.NumPuts,
+		NumEvictions:  cs.NumEvictions + other.NumEvictions,
+		NumBytesAdded: cs.NumBytesAdded + other.NumBytesAdded,
+	}
+}
+
+// CacheCounters counts the operations done on a cache. It is safe for concurrent use
+type CacheCounters struct {
+	numGets       uint64
+	numHits       uint64
+	numMisses     uint64
+	numPuts       uint64
+	numEvictions  uint64
+	numBytesAdded uint64
+}
+
+// RecordGet counts a lookup of a key
+func (cc *CacheCounters) RecordGet(found bool) {
+	atomic.AddUint64(&cc.numGets, 1)
+	if found {
+		atomic.AddUint64(&cc.numHits, 1)
+		return
+	}
+
+	atomic.AddUint64(&cc.numMisses, 1)
+}
+
+// RecordPut counts an added value having the provided size
+func (cc *CacheCounters) RecordPut(numBytes int) {
+	atomic.AddUint64(&cc.numPuts, 1)
+	atomic.AddUint64(&cc.numBytesAdded, uint64(numBytes))
+}
+
+// RecordEvictions counts the values removed by the cache in order to make room for new ones
+func (cc *CacheCounters) RecordEvictions(numEvicted int) {
+	if numEvicted <= 0 {
+		return
+	}
+
+	atomic.AddUint64(&cc.numEvictions, uint64(numEvicted))
+}
+
+// Stats returns a snapshot of the counters
+func (cc *CacheCounters) Stats() CacheStats {
+	return CacheStats{
+		NumGets:       atomic.LoadUint64(&cc.numGets),
+		NumHits:       atomic.LoadUint64(&cc.numHits),
+		NumMisses:     atomic.LoadUint64(&cc.numMisses),
+		NumPuts:       atomic.LoadUint64(&cc.numPuts),
+		NumEvictions:  atomic.LoadUint64(&cc.numEvictions),
+		NumBytesAdded: atomic.LoadUint64(&cc.numBytesAdded),
+	}
+}
+
+// UnitStats holds the counters of the lookups done in a storer, split by the place the lookup ended
+type UnitStats struct {
+	NumGets            uint64
+	NumCacheHits       uint64
+	NumPersisterHits   uint64
+	NumBloomFilterMiss uint64
+	NumMisses          uint64
+}
+
+// UnitCounters counts the lookups done in a storer. It is safe for concurrent use
+type UnitCounters struct {
+	numGets            uint64
+	numCacheHits       uint64
+	numPersisterHits   uint64
+	numBloomFilterMiss uint64
+	numMisses          uint64
+}
+
+// RecordCacheHit counts a lookup answered by the cache
+func (uc *UnitCounters) RecordCacheHit() {
+	atomic.AddUint64(&uc.numGets, 1)
+	atomic.AddUint64(&uc.numCacheHits, 1)
+}
+
+// RecordPersisterHit counts a lookup answered by the persister
+func (uc *UnitCounters) RecordPersisterHit() {
+	atomic.AddUint64(&uc.numGets, 1)
+	atomic.AddUint64(&uc.numPersisterHits, 1)
+}
+
+// RecordBloomFilterMiss counts a lookup ended by the bloom filter, without reaching the persister
+func (uc *UnitCounters) RecordBloomFilterMiss() {
+	atomic.AddUint64(&uc.numGets, 1)
+	atomic.AddUint64(&uc.numBloomFilterMiss, 1)
+	atomic.AddUint64(&uc.numMisses, 1)
+}
+
+// RecordMiss counts a lookup which did not find the key in the persister
+func (uc *UnitCounters) RecordMiss() {
+	atomic.AddUint64(&uc.numGets, 1)
+	atomic.AddUint64(&uc.numMisses, 1)
+}
+
+// Stats returns a snapshot of the counters
+func (uc *UnitCounters) Stats() UnitStats {
+	return UnitStats{
+		NumGets:            atomic.LoadUint64(&uc.numGets),
+		NumCacheHits:       atomic.LoadUint64(&uc.numCacheHits),
+		NumPersisterHits:   atomic.LoadUint64(&uc.numPersisterHits),
+		NumBloomFilterMiss: atomic.LoadUint64(&uc.numBloomFilterMiss),
+		NumMisses:          atomic.LoadUint64(&uc.numMisses),
+	}
+}
+
+// sizer is implemented by the values able to report their size in bytes
+type sizer interface {
+	Size() int
+}
+
+// EstimateSize returns the size in bytes of the provided value if it can be computed cheaply, or 0 otherwise
+func EstimateSize(value interface{}) int {
+	switch v := value.(type) {
+	case []byte:
+		return len(v)
+	case string:
+		return len(v)
+	case sizer:
+		return v.Size()
+	}
+
+	return 0
+}
@@ -0,0 +1,65 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStats_HitRatePercent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, float64(0), storage.CacheStats{}.HitRatePercent())
+	assert.Equal(t, float64(25), storage.CacheStats{NumGets: 4, NumHits: 1}.HitRatePercent())
+}
+
+func TestCacheCounters_StatsShouldReturnTheRecordedOperations(t *testing.T) {
+	t.Parallel()
+
+	counters := storage.CacheCounters{}
+	counters.RecordGet(true)
+	counters.RecordGet(false)
+	counters.RecordPut(10)
+	counters.RecordEvictions(3)
+	counters.RecordEvictions(-1)
+
+	expected := storage.CacheStats{
+		NumGets:       2,
+		NumHits:       1,
+		NumMisses:     1,
+		NumPuts:       1,
+		NumEvictions:  3,
+		NumBytesAdded: 10,
+	}
+	assert.Equal(t, expected, counters.Stats())
+	assert.Equal(t, storage.CacheStats{NumGets: 4, NumHits: 2, NumMisses: 2, NumPuts: 2, NumEvictions: 6, NumBytesAdded: 20},
+		expected.Add(counters.Stats()))
+}
+
+func TestUnitCounters_StatsShouldReturnTheRecordedLookups(t *testing.T) {
+	t.Parallel()
+
+	counters := storage.UnitCounters{}
+	counters.RecordCacheHit()
+	counters.RecordPersisterHit()
+	counters.RecordBloomFilterMiss()
+	counters.RecordMiss()
+
+	expected := storage.UnitStats{
+		NumGets:            4,
+		NumCacheHits:       1,
+		NumPersisterHits:   1,
+		NumBloomFilterMiss: 1,
+		NumMisses:          2,
+	}
+	assert.Equal(t, expected, counters.Stats())
+}
+
+func TestEstimateSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3, storage.EstimateSize([]byte("abc")))
+	assert.Equal(t, 2, storage.EstimateSize("ab"))
+	assert.Equal(t, 0, storage.EstimateSize(struct{}{}))
+}
@@ -0,0 +1,159 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// EncryptedDB wraps a persister and encrypts every value with AES-GCM before it reaches the persister. Each value is
+// sealed with a random nonce, stored in front of the ciphertext, and with its key as additional data, so a value
+// copied under another key is rejected. The keys are stored as they are: they are mostly hashes and the persisters
+// need them in clear to look them up and to iterate them in order
+type EncryptedDB struct {
+	persister storage.Persister
+	aead      cipher.AEAD
+}
+
+// NewEncryptedDB creates a new encrypting wrapper over the provided persister, using the provided AES-256 key
+func NewEncryptedDB(persister storage.Persister, key []byte) (*EncryptedDB, error) {
+	if check.IfNil(persister) {
+		return nil, storage.ErrNilPersister
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedDB{
+		persister: persister,
+		aead:      aead,
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, storage.ErrInvalidEncryptionKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Put encrypts the value and stores it in the wrapped persister
+func (edb *EncryptedDB) Put(key, val []byte) error {
+	nonce := make([]byte, edb.aead.NonceSize(), edb.aead.NonceSize()+len(val)+edb.aead.Overhead())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return err
+	}
+
+	return edb.persister.Put(key, edb.aead.Seal(nonce, nonce, val, key))
+}
+
+// Get reads the value from the wrapped persister and decrypts it
+func (edb *EncryptedDB) Get(key []byte) ([]byte, error) {
+	sealed, err := edb.persister.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return edb.open(key, sealed)
+}
+
+func (edb *EncryptedDB) open(key []byte, sealed []byte) ([]byte, error) {
+	nonceSize := edb.aead.NonceSize()
+	if len(sealed) < nonceSize+edb.aead.Overhead() {
+		return nil, fmt.Errorf("%w for key %x", storage.ErrDecryptionFailed, key)
+	}
+
+	val, err := edb.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], key)
+	if err != nil {
+		return nil, fmt.Errorf("%w for key %x", storage.ErrDecryptionFailed, key)
+	}
+
+	return val, nil
+}
+
+// Has returns nil if the given key is present in the wrapped persister
+func (edb *EncryptedDB) Has(key []byte) error {
+	return edb.persister.Has(key)
+}
+
+// Init initializes the wrapped persister
+func (edb *EncryptedDB) Init() error {
+	return edb.persister.Init()
+}
+
+// Close closes the wrapped persister
+func (edb *EncryptedDB) Close() error {
+	return edb.persister.Close()
+}
+
+// Remove removes the key from the wrapped persister
+func (edb *EncryptedDB) Remove(key []byte) error {
+	return edb.persister.Remove(key)
+}
+
+// Destroy removes the data of the wrapped persister
+func (edb *EncryptedDB) Destroy() error {
+	return edb.persister.Destroy()
+}
+
+// DestroyClosed removes the data of the already closed wrapped persister
+func (edb *EncryptedDB) DestroyClosed() error {
+	return edb.persister.DestroyClosed()
+}
+
+// Iterate calls the handler with the decrypted values of the pairs in the provided range. The iteration stops with
+// an error at the first value which can not be decrypted
+func (edb *EncryptedDB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	var errOpen error
+	err := edb.persister.Iterate(keyRange, func(key []byte, sealed []byte) bool {
+		var val []byte
+		val, errOpen = edb.open(key, sealed)
+		if errOpen != nil {
+			return false
+		}
+
+		return handler(key, val)
+	})
+	if err != nil {
+		return err
+	}
+
+	return errOpen
+}
+
+// Flush writes the pending batch of the wrapped persister, if it buffers its writes
+func (edb *EncryptedDB) Flush() error {
+	flusher, ok := edb.persister.(storage.Flusher)
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush()
+}
+
+// Compact compacts the data files of the wrapped persister, if it supports it
+func (edb *EncryptedDB) Compact() error {
+	c, ok := edb.persister.(interface{ Compact() error })
+	if !ok {
+		return nil
+	}
+
+	return c.Compact()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (edb *EncryptedDB) IsInterfaceNil() bool {
+	return edb == nil
+}
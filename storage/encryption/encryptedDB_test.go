@@ -0,0 +1,158 @@
+package encryption_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, encryption.KeySize)
+}
+
+func TestNewEncryptedDB_NilPersisterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	edb, err := encryption.NewEncryptedDB(nil, createKey(1))
+
+	assert.Nil(t, edb)
+	assert.Equal(t, storage.ErrNilPersister, err)
+}
+
+func TestNewEncryptedDB_InvalidKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	edb, err := encryption.NewEncryptedDB(memorydb.New(), []byte("short key"))
+
+	assert.Nil(t, edb)
+	assert.Equal(t, storage.ErrInvalidEncryptionKey, err)
+}
+
+func TestNewEncryptedDB_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	edb, err := encryption.NewEncryptedDB(memorydb.New(), createKey(1))
+
+	assert.Nil(t, err)
+	assert.False(t, edb.IsInterfaceNil())
+}
+
+func TestEncryptedDB_PutShouldNotStoreThePlainValue(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	key, val := []byte("key"), []byte("a value which should not be readable")
+
+	err := edb.Put(key, val)
+	require.Nil(t, err)
+
+	stored, err := db.Get(key)
+	require.Nil(t, err)
+	assert.False(t, bytes.Contains(stored, val))
+
+	recovered, err := edb.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, recovered)
+}
+
+func TestEncryptedDB_SameValueShouldBeStoredDifferently(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	val := []byte("value")
+
+	_ = edb.Put([]byte("key1"), val)
+	_ = edb.Put([]byte("key2"), val)
+
+	stored1, _ := db.Get([]byte("key1"))
+	stored2, _ := db.Get([]byte("key2"))
+	assert.NotEqual(t, stored1, stored2)
+}
+
+func TestEncryptedDB_GetWithAnotherKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	_ = edb.Put([]byte("key"), []byte("value"))
+
+	otherEdb, _ := encryption.NewEncryptedDB(db, createKey(2))
+	val, err := otherEdb.Get([]byte("key"))
+
+	assert.Nil(t, val)
+	assert.True(t, errors.Is(err, storage.ErrDecryptionFailed))
+}
+
+func TestEncryptedDB_GetValueMovedUnderAnotherKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	_ = edb.Put([]byte("key1"), []byte("value"))
+
+	stored, _ := db.Get([]byte("key1"))
+	_ = db.Put([]byte("key2"), stored)
+	val, err := edb.Get([]byte("key2"))
+
+	assert.Nil(t, val)
+	assert.True(t, errors.Is(err, storage.ErrDecryptionFailed))
+}
+
+func TestEncryptedDB_GetPlainOrShortValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	_ = db.Put([]byte("short"), []byte("v"))
+	_ = db.Put([]byte("plain"), []byte("a plain value written before the encryption was enabled"))
+
+	_, err := edb.Get([]byte("short"))
+	assert.True(t, errors.Is(err, storage.ErrDecryptionFailed))
+
+	_, err = edb.Get([]byte("plain"))
+	assert.True(t, errors.Is(err, storage.ErrDecryptionFailed))
+}
+
+func TestEncryptedDB_IterateShouldDecryptTheValues(t *testing.T) {
+	t.Parallel()
+
+	edb, _ := encryption.NewEncryptedDB(memorydb.New(), createKey(1))
+	_ = edb.Put([]byte("a"), []byte("val_a"))
+	_ = edb.Put([]byte("b"), []byte("val_b"))
+
+	pairs := make(map[string]string)
+	err := edb.Iterate(nil, func(key []byte, val []byte) bool {
+		pairs[string(key)] = string(val)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": "val_a", "b": "val_b"}, pairs)
+}
+
+func TestEncryptedDB_IterateShouldStopAtTheFirstValueWhichCanNotBeDecrypted(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	edb, _ := encryption.NewEncryptedDB(db, createKey(1))
+	_ = edb.Put([]byte("a"), []byte("val_a"))
+	_ = db.Put([]byte("b"), []byte("plain value of b"))
+	_ = edb.Put([]byte("c"), []byte("val_c"))
+
+	keys := make([]string, 0)
+	err := edb.Iterate(nil, func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+
+	assert.True(t, errors.Is(err, storage.ErrDecryptionFailed))
+	assert.Equal(t, []string{"a"}, keys)
+}
@@ -0,0 +1,41 @@
+package encryption
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// KeySize is the size in bytes of the AES-256 key used to encrypt the stored values
+const KeySize = 32
+
+// LoadKey reads the hex encoded key from the provided file. If no file is provided, the key is read from the
+// provided environment variable
+func LoadKey(keyFile string, envVariable string) ([]byte, error) {
+	var encodedKey string
+	switch {
+	case len(keyFile) > 0:
+		buff, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey = string(buff)
+	case len(envVariable) > 0:
+		encodedKey = os.Getenv(envVariable)
+	}
+
+	encodedKey = strings.TrimSpace(encodedKey)
+	if len(encodedKey) == 0 {
+		return nil, storage.ErrMissingEncryptionKey
+	}
+
+	key, err := hex.DecodeString(encodedKey)
+	if err != nil || len(key) != KeySize {
+		return nil, storage.ErrInvalidEncryptionKey
+	}
+
+	return key, nil
+}
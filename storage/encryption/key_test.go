@@ -0,0 +1,95 @@
+package encryption_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "encryption_key")
+	require.Nil(t, err)
+
+	keyFile := filepath.Join(dir, "storage.key")
+	err = ioutil.WriteFile(keyFile, []byte(content), 0600)
+	require.Nil(t, err)
+
+	return keyFile
+}
+
+func TestLoadKey_FromFileShouldWork(t *testing.T) {
+	t.Parallel()
+
+	key := createKey(7)
+	keyFile := writeKeyFile(t, hex.EncodeToString(key)+"\n")
+	defer func() {
+		_ = os.RemoveAll(filepath.Dir(keyFile))
+	}()
+
+	loaded, err := encryption.LoadKey(keyFile, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, key, loaded)
+}
+
+func TestLoadKey_MissingFileShouldErr(t *testing.T) {
+	t.Parallel()
+
+	loaded, err := encryption.LoadKey(filepath.Join(os.TempDir(), "missing_storage.key"), "")
+
+	assert.Nil(t, loaded)
+	assert.NotNil(t, err)
+}
+
+func TestLoadKey_FromEnvironmentShouldWork(t *testing.T) {
+	key := createKey(9)
+	envVariable := "ERD_TEST_STORAGE_KEY"
+	_ = os.Setenv(envVariable, hex.EncodeToString(key))
+	defer func() {
+		_ = os.Unsetenv(envVariable)
+	}()
+
+	loaded, err := encryption.LoadKey("", envVariable)
+
+	assert.Nil(t, err)
+	assert.Equal(t, key, loaded)
+}
+
+func TestLoadKey_NoSourceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	loaded, err := encryption.LoadKey("", "")
+
+	assert.Nil(t, loaded)
+	assert.Equal(t, storage.ErrMissingEncryptionKey, err)
+}
+
+func TestLoadKey_InvalidKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"not hex":   "not a hex key",
+		"too short": hex.EncodeToString([]byte("short")),
+	}
+	for name, content := range tests {
+		content := content
+		t.Run(name, func(t *testing.T) {
+			keyFile := writeKeyFile(t, content)
+			defer func() {
+				_ = os.RemoveAll(filepath.Dir(keyFile))
+			}()
+
+			loaded, err := encryption.LoadKey(keyFile, "")
+
+			assert.Nil(t, loaded)
+			assert.Equal(t, storage.ErrInvalidEncryptionKey, err)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// MarkerFileName is the file, written in a chain's database directory, which records whether the stored values are
+// encrypted and lets the key they are encrypted with be verified
+const MarkerFileName = "storage-encryption.json"
+
+// keyCheckValue is sealed with the encryption key in the marker, so that a wrong key is detected before any database
+// is opened
+var keyCheckValue = []byte("storage encryption key check")
+
+type marker struct {
+	Encrypted bool   `json:"encrypted"`
+	KeyCheck  string `json:"keyCheck,omitempty"`
+}
+
+// CheckMarker verifies that the databases of the provided directory were written with the storage encryption set as
+// the provided key implies: enabled, with the same key, if the key is not empty, disabled otherwise. The marker is
+// written if the directory does not hold one yet
+func CheckMarker(dbPath string, key []byte) error {
+	exists, err := VerifyMarker(dbPath, key)
+	if err != nil || exists {
+		return err
+	}
+
+	return writeMarker(dbPath, key)
+}
+
+// VerifyMarker verifies, without writing anything, that the databases of the provided directory were written with
+// the storage encryption set as the provided key implies. A directory holding databases but no marker was written
+// before the marker was introduced, with the storage encryption disabled. It returns true if the marker exists
+func VerifyMarker(dbPath string, key []byte) (bool, error) {
+	buff, err := ioutil.ReadFile(filepath.Join(dbPath, MarkerFileName))
+	if os.IsNotExist(err) {
+		if len(key) > 0 && holdsDatabases(dbPath) {
+			return false, fmt.Errorf("%w: the databases in %s are not encrypted", storage.ErrEncryptionMismatch, dbPath)
+		}
+
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	m := &marker{}
+	err = json.Unmarshal(buff, m)
+	if err != nil {
+		return true, fmt.Errorf("%w: %v", storage.ErrInvalidEncryptionMarker, err)
+	}
+
+	isEncrypted := len(key) > 0
+	if m.Encrypted != isEncrypted {
+		return true, fmt.Errorf("%w: the databases in %s have encryption set to %v",
+			storage.ErrEncryptionMismatch, dbPath, m.Encrypted)
+	}
+	if !isEncrypted {
+		return true, nil
+	}
+
+	return true, checkKey(m.KeyCheck, key)
+}
+
+func checkKey(keyCheck string, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := hex.DecodeString(keyCheck)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return storage.ErrInvalidEncryptionMarker
+	}
+
+	_, err = aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return storage.ErrWrongEncryptionKey
+	}
+
+	return nil
+}
+
+func writeMarker(dbPath string, key []byte) error {
+	m := &marker{}
+	if len(key) > 0 {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return err
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		_, err = rand.Read(nonce)
+		if err != nil {
+			return err
+		}
+
+		m.Encrypted = true
+		m.KeyCheck = hex.EncodeToString(aead.Seal(nonce, nonce, keyCheckValue, nil))
+	}
+
+	buff, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dbPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dbPath, MarkerFileName), buff, core.FileModeUserReadWrite)
+}
+
+func holdsDatabases(dbPath string) bool {
+	entries, err := ioutil.ReadDir(dbPath)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		if entry.Name() != MarkerFileName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package encryption_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDbDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "encryption_marker")
+	require.Nil(t, err)
+
+	return dir
+}
+
+func TestCheckMarker_EncryptedDirectoryShouldAcceptOnlyTheSameKey(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createDbDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	key := createKey(1)
+	err := encryption.CheckMarker(dbPath, key)
+	require.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dbPath, encryption.MarkerFileName))
+	require.Nil(t, err)
+
+	assert.Nil(t, encryption.CheckMarker(dbPath, key))
+	err = encryption.CheckMarker(dbPath, createKey(2))
+	assert.True(t, errors.Is(err, storage.ErrWrongEncryptionKey))
+	err = encryption.CheckMarker(dbPath, nil)
+	assert.True(t, errors.Is(err, storage.ErrEncryptionMismatch))
+}
+
+func TestCheckMarker_PlaintextDirectoryShouldRefuseAKey(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createDbDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	err := encryption.CheckMarker(dbPath, nil)
+	require.Nil(t, err)
+
+	assert.Nil(t, encryption.CheckMarker(dbPath, nil))
+	err = encryption.CheckMarker(dbPath, createKey(1))
+	assert.True(t, errors.Is(err, storage.ErrEncryptionMismatch))
+}
+
+func TestCheckMarker_DatabasesWithoutMarkerShouldBePlaintext(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createDbDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+	err := os.MkdirAll(filepath.Join(dbPath, "Static"), os.ModePerm)
+	require.Nil(t, err)
+
+	err = encryption.CheckMarker(dbPath, createKey(1))
+	assert.True(t, errors.Is(err, storage.ErrEncryptionMismatch))
+	_, err = os.Stat(filepath.Join(dbPath, encryption.MarkerFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	err = encryption.CheckMarker(dbPath, nil)
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dbPath, encryption.MarkerFileName))
+	assert.Nil(t, err)
+}
+
+func TestVerifyMarker_ShouldNotWriteTheMarker(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createDbDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+
+	exists, err := encryption.VerifyMarker(dbPath, createKey(1))
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	_, err = os.Stat(filepath.Join(dbPath, encryption.MarkerFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestVerifyMarker_InvalidMarkerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dbPath := createDbDir(t)
+	defer func() {
+		_ = os.RemoveAll(dbPath)
+	}()
+	err := ioutil.WriteFile(filepath.Join(dbPath, encryption.MarkerFileName), []byte("not json"), 0600)
+	require.Nil(t, err)
+
+	exists, err := encryption.VerifyMarker(dbPath, nil)
+	assert.True(t, exists)
+	assert.True(t, errors.Is(err, storage.ErrInvalidEncryptionMarker))
+}
@@ -22,6 +22,12 @@ var ErrNotSupportedDBType = errors.New("nit supported db type")
 // ErrNotSupportedHashType is raised when an unsupported hasher is provided
 var ErrNotSupportedHashType = errors.New("hash type not supported")
 
+// ErrNotSupportedBloomFilterType is raised when an unsupported bloom filter type is provided
+var ErrNotSupportedBloomFilterType = errors.New("bloom filter type not supported")
+
+// ErrInvalidBloomFilterFile is raised when a saved bloom filter does not match the configured filter or its checksum
+var ErrInvalidBloomFilterFile = errors.New("invalid bloom filter file")
+
 // ErrKeyNotFound is raised when a key is not found
 var ErrKeyNotFound = errors.New("key not found")
 
@@ -84,3 +90,65 @@ var ErrClosingPersisters = errors.New("cannot close all the persisters")
 
 // ErrCacheSizeIsLowerThanBatchSize signals that size of cache is lower than size of batch
 var ErrCacheSizeIsLowerThanBatchSize = errors.New("cache size is lower than batch size")
+
+// ErrNilArchivePathManager signals that the archive is enabled but a nil archive path manager has been provided
+var ErrNilArchivePathManager = errors.New("nil archive path manager")
+
+// ErrInvalidArchiveEntry signals that an archive holds a file which would be extracted outside its destination
+var ErrInvalidArchiveEntry = errors.New("invalid archive entry")
+
+// ErrInvalidCacheSize signals that a cache has been configured to hold a non positive number of items
+var ErrInvalidCacheSize = errors.New("cache size must be positive")
+
+// ErrInvalidCacheSizeInBytes signals that a cache has been configured with a non positive capacity in bytes
+var ErrInvalidCacheSizeInBytes = errors.New("cache size in bytes must be positive")
+
+// ErrNilSnapshotWriter signals that a nil writer has been provided for a snapshot archive
+var ErrNilSnapshotWriter = errors.New("nil snapshot writer")
+
+// ErrNilSnapshotReader signals that a nil reader has been provided for a snapshot archive
+var ErrNilSnapshotReader = errors.New("nil snapshot reader")
+
+// ErrNilSnapshotManifest signals that a snapshot archive is written without a manifest
+var ErrNilSnapshotManifest = errors.New("nil snapshot manifest")
+
+// ErrInvalidSnapshotArchive signals that the data read is not a snapshot archive or is corrupted
+var ErrInvalidSnapshotArchive = errors.New("invalid snapshot archive")
+
+// ErrTruncatedSnapshotArchive signals that a snapshot archive ends before its end record
+var ErrTruncatedSnapshotArchive = errors.New("truncated snapshot archive")
+
+// ErrUnsupportedSnapshotVersion signals that a snapshot archive was written with an unknown format version
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot archive version")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilSnapshotUnits signals that no unit has been provided for importing a snapshot archive
+var ErrNilSnapshotUnits = errors.New("nil snapshot units")
+
+// ErrUnknownSnapshotUnit signals that a snapshot archive holds pairs for a unit the importer does not have
+var ErrUnknownSnapshotUnit = errors.New("unknown snapshot unit")
+
+// ErrSnapshotShardMismatch signals that a snapshot archive was exported from another shard
+var ErrSnapshotShardMismatch = errors.New("snapshot archive belongs to another shard")
+
+// ErrInvalidEncryptionKey signals that the storage encryption key is not a hex encoded AES-256 key
+var ErrInvalidEncryptionKey = errors.New("invalid storage encryption key")
+
+// ErrMissingEncryptionKey signals that the storage encryption is enabled but no key was provided
+var ErrMissingEncryptionKey = errors.New("missing storage encryption key")
+
+// ErrDecryptionFailed signals that a stored value could not be decrypted, either because it was written with another
+// key, it was written unencrypted or it was altered
+var ErrDecryptionFailed = errors.New("stored value decryption failed")
+
+// ErrEncryptionMismatch signals that the databases were written with the storage encryption enabled and are opened
+// with it disabled, or the other way around
+var ErrEncryptionMismatch = errors.New("storage encryption setting does not match the one the databases were written with")
+
+// ErrWrongEncryptionKey signals that the databases were written with another storage encryption key
+var ErrWrongEncryptionKey = errors.New("storage encryption key does not match the one the databases were written with")
+
+// ErrInvalidEncryptionMarker signals that the storage encryption marker of a database directory can not be read
+var ErrInvalidEncryptionMarker = errors.New("invalid storage encryption marker")
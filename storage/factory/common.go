@@ -2,6 +2,7 @@ package factory
 
 import (
 	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 )
 
@@ -22,6 +23,7 @@ func GetDBFromConfig(cfg config.DBConfig) storageUnit.DBConfig {
 		MaxBatchSize:      cfg.MaxBatchSize,
 		BatchDelaySeconds: cfg.BatchDelaySeconds,
 		MaxOpenFiles:      cfg.MaxOpenFiles,
+		FlushOnCommit:     cfg.FlushOnCommit,
 	}
 }
 
@@ -40,5 +42,16 @@ func GetBloomFromConfig(cfg config.BloomFilterConfig) storageUnit.BloomConfig {
 	return storageUnit.BloomConfig{
 		Size:     cfg.Size,
 		HashFunc: hashFuncs,
+		Type:     storageUnit.BloomFilterType(cfg.Type),
+		Persist:  cfg.Persist,
 	}
 }
+
+// LoadEncryptionKey will return the key the stored values are encrypted with, or nil if the encryption is disabled
+func LoadEncryptionKey(cfg config.StorageEncryptionConfig) ([]byte, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	return encryption.LoadKey(cfg.KeyFile, cfg.KeyEnvVariable)
+}
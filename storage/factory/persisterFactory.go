@@ -5,6 +5,8 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/boltdb"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
 	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 )
@@ -15,6 +17,7 @@ type PersisterFactory struct {
 	batchDelaySeconds int
 	maxBatchSize      int
 	maxOpenFiles      int
+	encryptionKey     []byte
 }
 
 // NewPersisterFactory will return a new instance of a PersisterFactory
@@ -27,22 +30,81 @@ func NewPersisterFactory(config config.DBConfig) *PersisterFactory {
 	}
 }
 
+// NewEncryptedPersisterFactory will return a new instance of a PersisterFactory whose databases encrypt the stored
+// values with the provided AES-256 key
+func NewEncryptedPersisterFactory(config config.DBConfig, encryptionKey []byte) *PersisterFactory {
+	pf := NewPersisterFactory(config)
+	pf.encryptionKey = encryptionKey
+
+	return pf
+}
+
 // Create will return a new instance of a DB with a given path
 func (pf *PersisterFactory) Create(path string) (storage.Persister, error) {
 	if len(path) == 0 {
 		return nil, errors.New("invalid file path")
 	}
 
+	db, err := pf.createDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pf.wrapIfEncrypted(db)
+}
+
+func (pf *PersisterFactory) createDB(path string) (storage.Persister, error) {
 	switch storageUnit.DBType(pf.dbType) {
 	case storageUnit.LvlDB:
 		return leveldb.NewDB(path, pf.batchDelaySeconds, pf.maxBatchSize, pf.maxOpenFiles)
 	case storageUnit.LvlDbSerial:
 		return leveldb.NewSerialDB(path, pf.batchDelaySeconds, pf.maxBatchSize, pf.maxOpenFiles)
+	case storageUnit.BoltDB:
+		return boltdb.NewDB(path, pf.batchDelaySeconds, pf.maxBatchSize)
 	default:
 		return nil, storage.ErrNotSupportedDBType
 	}
 }
 
+// CreateReadOnly will open an existing DB from the given path without allowing any change to its files
+func (pf *PersisterFactory) CreateReadOnly(path string) (storage.Persister, error) {
+	if len(path) == 0 {
+		return nil, errors.New("invalid file path")
+	}
+
+	db, err := pf.createReadOnlyDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pf.wrapIfEncrypted(db)
+}
+
+func (pf *PersisterFactory) createReadOnlyDB(path string) (storage.Persister, error) {
+	switch storageUnit.DBType(pf.dbType) {
+	case storageUnit.LvlDB, storageUnit.LvlDbSerial:
+		return leveldb.NewReadOnlyDB(path, pf.maxOpenFiles)
+	case storageUnit.BoltDB:
+		return boltdb.NewReadOnlyDB(path)
+	default:
+		return nil, storage.ErrNotSupportedDBType
+	}
+}
+
+func (pf *PersisterFactory) wrapIfEncrypted(db storage.Persister) (storage.Persister, error) {
+	if len(pf.encryptionKey) == 0 {
+		return db, nil
+	}
+
+	encryptedDB, err := encryption.NewEncryptedDB(db, pf.encryptionKey)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return encryptedDB, nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (pf *PersisterFactory) IsInterfaceNil() bool {
 	return pf == nil
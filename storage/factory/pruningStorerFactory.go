@@ -26,8 +26,10 @@ type StorageServiceFactory struct {
 	generalConfig      *config.Config
 	shardCoordinator   sharding.Coordinator
 	pathManager        storage.PathManagerHandler
+	archivePathManager storage.PathManagerHandler
 	epochStartNotifier storage.EpochStartNotifier
 	currentEpoch       uint32
+	encryptionKey      []byte
 }
 
 // NewStorageServiceFactory will return a new instance of StorageServiceFactory
@@ -35,6 +37,7 @@ func NewStorageServiceFactory(
 	config *config.Config,
 	shardCoordinator sharding.Coordinator,
 	pathManager storage.PathManagerHandler,
+	archivePathManager storage.PathManagerHandler,
 	epochStartNotifier storage.EpochStartNotifier,
 	currentEpoch uint32,
 ) (*StorageServiceFactory, error) {
@@ -53,16 +56,26 @@ func NewStorageServiceFactory(
 	if check.IfNil(pathManager) {
 		return nil, storage.ErrNilPathManager
 	}
+	if config.StoragePruning.Archive.Enabled && check.IfNil(archivePathManager) {
+		return nil, storage.ErrNilArchivePathManager
+	}
 	if check.IfNil(epochStartNotifier) {
 		return nil, storage.ErrNilEpochStartNotifier
 	}
 
+	encryptionKey, err := LoadEncryptionKey(config.StorageEncryption)
+	if err != nil {
+		return nil, err
+	}
+
 	return &StorageServiceFactory{
 		generalConfig:      config,
 		shardCoordinator:   shardCoordinator,
 		pathManager:        pathManager,
+		archivePathManager: archivePathManager,
 		epochStartNotifier: epochStartNotifier,
 		currentEpoch:       currentEpoch,
+		encryptionKey:      encryptionKey,
 	}, nil
 }
 
@@ -75,6 +88,7 @@ func (psf *StorageServiceFactory) CreateForShard() (dataRetriever.StorageService
 	var metachainHeaderUnit *pruning.PruningStorer
 	var unsignedTxUnit *pruning.PruningStorer
 	var rewardTxUnit *pruning.PruningStorer
+	var txMetadataUnit *pruning.PruningStorer
 	var metaHdrHashNonceUnit *pruning.PruningStorer
 	var shardHdrHashNonceUnit *pruning.PruningStorer
 	var bootstrapUnit *pruning.PruningStorer
@@ -111,6 +125,13 @@ func (psf *StorageServiceFactory) CreateForShard() (dataRetriever.StorageService
 	}
 	successfullyCreatedStorers = append(successfullyCreatedStorers, rewardTxUnit)
 
+	txMetadataUnitArgs := psf.createPruningStorerArgs(psf.generalConfig.TxMetadataStorage)
+	txMetadataUnit, err = pruning.NewPruningStorer(txMetadataUnitArgs)
+	if err != nil {
+		return nil, err
+	}
+	successfullyCreatedStorers = append(successfullyCreatedStorers, txMetadataUnit)
+
 	miniBlockUnitArgs := psf.createPruningStorerArgs(psf.generalConfig.MiniBlocksStorage)
 	miniBlockUnit, err = pruning.NewPruningStorer(miniBlockUnitArgs)
 	if err != nil {
@@ -157,6 +178,7 @@ func (psf *StorageServiceFactory) CreateForShard() (dataRetriever.StorageService
 	shardId := core.GetShardIdString(psf.shardCoordinator.SelfId())
 	dbPath := psf.pathManager.PathForStatic(shardId, psf.generalConfig.Heartbeat.HeartbeatStorage.DB.FilePath)
 	heartbeatDbConfig.FilePath = dbPath
+	heartbeatDbConfig.EncryptionKey = psf.encryptionKey
 	heartbeatStorageUnit, err := storageUnit.NewStorageUnitFromConf(
 		GetCacherFromConfig(psf.generalConfig.Heartbeat.HeartbeatStorage.Cache),
 		heartbeatDbConfig,
@@ -170,6 +192,7 @@ func (psf *StorageServiceFactory) CreateForShard() (dataRetriever.StorageService
 	shardId = core.GetShardIdString(psf.shardCoordinator.SelfId())
 	dbPath = psf.pathManager.PathForStatic(shardId, psf.generalConfig.StatusMetricsStorage.DB.FilePath)
 	statusMetricsDbConfig.FilePath = dbPath
+	statusMetricsDbConfig.EncryptionKey = psf.encryptionKey
 	statusMetricsStorageUnit, err := storageUnit.NewStorageUnitFromConf(
 		GetCacherFromConfig(psf.generalConfig.StatusMetricsStorage.Cache),
 		statusMetricsDbConfig,
@@ -194,6 +217,7 @@ func (psf *StorageServiceFactory) CreateForShard() (dataRetriever.StorageService
 	store.AddStorer(dataRetriever.MetaBlockUnit, metachainHeaderUnit)
 	store.AddStorer(dataRetriever.UnsignedTransactionUnit, unsignedTxUnit)
 	store.AddStorer(dataRetriever.RewardTransactionUnit, rewardTxUnit)
+	store.AddStorer(dataRetriever.TransactionMetadataUnit, txMetadataUnit)
 	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, metaHdrHashNonceUnit)
 	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(psf.shardCoordinator.SelfId())
 	store.AddStorer(hdrNonceHashDataUnit, shardHdrHashNonceUnit)
@@ -264,6 +288,7 @@ func (psf *StorageServiceFactory) CreateForMeta() (dataRetriever.StorageService,
 	heartbeatDbConfig := GetDBFromConfig(psf.generalConfig.Heartbeat.HeartbeatStorage.DB)
 	dbPath := psf.pathManager.PathForStatic(shardId, psf.generalConfig.Heartbeat.HeartbeatStorage.DB.FilePath)
 	heartbeatDbConfig.FilePath = dbPath
+	heartbeatDbConfig.EncryptionKey = psf.encryptionKey
 	heartbeatStorageUnit, err := storageUnit.NewStorageUnitFromConf(
 		GetCacherFromConfig(psf.generalConfig.Heartbeat.HeartbeatStorage.Cache),
 		heartbeatDbConfig,
@@ -277,6 +302,7 @@ func (psf *StorageServiceFactory) CreateForMeta() (dataRetriever.StorageService,
 	shardId = core.GetShardIdString(psf.shardCoordinator.SelfId())
 	dbPath = psf.pathManager.PathForStatic(shardId, psf.generalConfig.StatusMetricsStorage.DB.FilePath)
 	statusMetricsDbConfig.FilePath = dbPath
+	statusMetricsDbConfig.EncryptionKey = psf.encryptionKey
 	statusMetricsStorageUnit, err := storageUnit.NewStorageUnitFromConf(
 		GetCacherFromConfig(psf.generalConfig.StatusMetricsStorage.Cache),
 		statusMetricsDbConfig,
@@ -340,11 +366,20 @@ func (psf *StorageServiceFactory) CreateForMeta() (dataRetriever.StorageService,
 	return store, err
 }
 
+func (psf *StorageServiceFactory) createPersisterFactory(dbConfig config.DBConfig) *PersisterFactory {
+	if len(psf.encryptionKey) == 0 {
+		return NewPersisterFactory(dbConfig)
+	}
+
+	return NewEncryptedPersisterFactory(dbConfig, psf.encryptionKey)
+}
+
 func (psf *StorageServiceFactory) createPruningStorerArgs(storageConfig config.StorageConfig) *pruning.StorerArgs {
 	fullArchiveMode := psf.generalConfig.StoragePruning.FullArchive
 	numOfEpochsToKeep := uint32(psf.generalConfig.StoragePruning.NumEpochsToKeep)
 	numOfActivePersisters := uint32(psf.generalConfig.StoragePruning.NumActivePersisters)
 	pruningEnabled := psf.generalConfig.StoragePruning.Enabled
+	archiveConfig := psf.generalConfig.StoragePruning.Archive
 	shardId := core.GetShardIdString(psf.shardCoordinator.SelfId())
 	dbPath := filepath.Join(psf.pathManager.PathForEpoch(shardId, 0, storageConfig.DB.FilePath))
 	args := &pruning.StorerArgs{
@@ -356,12 +391,17 @@ func (psf *StorageServiceFactory) createPruningStorerArgs(storageConfig config.S
 		CacheConf:             GetCacherFromConfig(storageConfig.Cache),
 		PathManager:           psf.pathManager,
 		DbPath:                dbPath,
-		PersisterFactory:      NewPersisterFactory(storageConfig.DB),
+		PersisterFactory:      psf.createPersisterFactory(storageConfig.DB),
 		BloomFilterConf:       GetBloomFromConfig(storageConfig.Bloom),
 		NumOfEpochsToKeep:     numOfEpochsToKeep,
 		NumOfActivePersisters: numOfActivePersisters,
 		Notifier:              psf.epochStartNotifier,
 		MaxBatchSize:          storageConfig.DB.MaxBatchSize,
+		ArchiveEnabled:        archiveConfig.Enabled,
+		CompressArchive:       archiveConfig.Compress,
+		ArchivePathManager:    psf.archivePathManager,
+		MaxOpenedArchives:     archiveConfig.MaxOpenedArchives,
+		FlushOnCommit:         storageConfig.DB.FlushOnCommit,
 	}
 
 	return args
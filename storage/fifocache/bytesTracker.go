@@ -0,0 +1,94 @@
+package fifocache
+
+import (
+	"container/list"
+	"sync"
+)
+
+type trackedEntry struct {
+	key         string
+	sizeInBytes int64
+}
+
+// bytesTracker keeps the keys of a byte bounded cache in the order they were added, together with their sizes.
+// The underlying sharded map evicts on its own, per shard, without signaling it, so the tracker may still hold keys
+// already gone from the map. They are bounded by the number of items of the cache and dropped once they become the
+// oldest, so the tracked size never underestimates the real one
+type bytesTracker struct {
+	mut      sync.Mutex
+	maxItems int
+	maxBytes int64
+	numBytes int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newBytesTracker(maxItems int, maxBytes int64) *bytesTracker {
+	return &bytesTracker{
+		maxItems: maxItems,
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// add records the key and returns the oldest keys which have to be removed from the cache so that it fits again
+// in its capacity. The key just added is never returned
+func (bt *bytesTracker) add(key string, sizeInBytes int64) []string {
+	bt.mut.Lock()
+	defer bt.mut.Unlock()
+
+	element, ok := bt.entries[key]
+	if ok {
+		entry := element.Value.(*trackedEntry)
+		bt.numBytes += sizeInBytes - entry.sizeInBytes
+		entry.sizeInBytes = sizeInBytes
+	} else {
+		bt.entries[key] = bt.order.PushBack(&trackedEntry{key: key, sizeInBytes: sizeInBytes})
+		bt.numBytes += sizeInBytes
+	}
+
+	keysToRemove := make([]string, 0)
+	for bt.order.Len() > 1 && (bt.order.Len() > bt.maxItems || bt.numBytes > bt.maxBytes) {
+		oldest := bt.order.Front()
+		if oldest.Value.(*trackedEntry).key == key {
+			bt.order.MoveToBack(oldest)
+			continue
+		}
+
+		keysToRemove = append(keysToRemove, bt.removeElement(oldest))
+	}
+
+	return keysToRemove
+}
+
+// remove stops tracking the key
+func (bt *bytesTracker) remove(key string) {
+	bt.mut.Lock()
+	defer bt.mut.Unlock()
+
+	element, ok := bt.entries[key]
+	if !ok {
+		return
+	}
+
+	bt.removeElement(element)
+}
+
+func (bt *bytesTracker) removeElement(element *list.Element) string {
+	entry := bt.order.Remove(element).(*trackedEntry)
+	delete(bt.entries, entry.key)
+	bt.numBytes -= entry.sizeInBytes
+
+	return entry.key
+}
+
+// clear stops tracking all the keys
+func (bt *bytesTracker) clear() {
+	bt.mut.Lock()
+	defer bt.mut.Unlock()
+
+	bt.order.Init()
+	bt.entries = make(map[string]*list.Element)
+	bt.numBytes = 0
+}
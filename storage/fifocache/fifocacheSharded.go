@@ -5,14 +5,17 @@ import (
 
 	cmap "github.com/ElrondNetwork/concurrent-map"
 	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 var log = logger.GetOrCreate("storage/fifocache")
 
 // FIFOShardedCache implements a First In First Out eviction cache
 type FIFOShardedCache struct {
-	cache   *cmap.ConcurrentMap
-	maxsize int
+	cache    *cmap.ConcurrentMap
+	maxsize  int
+	counters storage.CacheCounters
+	tracker  *bytesTracker
 
 	mutAddedDataHandlers sync.RWMutex
 	addedDataHandlers    []func(key []byte)
@@ -31,17 +34,46 @@ func NewShardedCache(size int, shards int) (*FIFOShardedCache, error) {
 	return fifoShardedCache, nil
 }
 
+// NewShardedCacheWithSizeInBytes creates a new cache instance bounded both by the number of items and by the sum of
+// the sizes of the keys and values it holds. The size of a value is known only for byte slices, strings and the
+// values implementing Size() int, the other values being accounted only by the size of their key
+func NewShardedCacheWithSizeInBytes(size int, shards int, sizeInBytes int64) (*FIFOShardedCache, error) {
+	if size <= 0 {
+		return nil, storage.ErrInvalidCacheSize
+	}
+	if sizeInBytes <= 0 {
+		return nil, storage.ErrInvalidCacheSizeInBytes
+	}
+
+	fifoShardedCache, err := NewShardedCache(size, shards)
+	if err != nil {
+		return nil, err
+	}
+	fifoShardedCache.tracker = newBytesTracker(size, sizeInBytes)
+
+	return fifoShardedCache, nil
+}
+
 // Clear is used to completely clear the cache.
 func (c *FIFOShardedCache) Clear() {
 	keys := c.cache.Keys()
 	for _, key := range keys {
 		c.cache.Remove(key)
 	}
+	if c.tracker != nil {
+		c.tracker.clear()
+	}
 }
 
 // Put adds a value to the cache.  Returns true if an eviction occurred.
 func (c *FIFOShardedCache) Put(key []byte, value interface{}) (evicted bool) {
+	numItems, isNewKey := c.cache.Count(), !c.cache.Has(string(key))
 	c.cache.Set(string(key), value)
+	c.recordEviction(numItems, isNewKey)
+
+	numBytes := storage.EstimateSize(value)
+	c.trackAdded(key, numBytes)
+	c.counters.RecordPut(numBytes)
 	c.callAddedDataHandlers(key)
 
 	return true
@@ -61,7 +93,10 @@ func (c *FIFOShardedCache) RegisterHandler(handler func(key []byte)) {
 
 // Get looks up a key's value from the cache.
 func (c *FIFOShardedCache) Get(key []byte) (value interface{}, ok bool) {
-	return c.cache.Get(string(key))
+	value, ok = c.cache.Get(string(key))
+	c.counters.RecordGet(ok)
+
+	return value, ok
 }
 
 // Has checks if a key is in the cache, without updating the
@@ -73,22 +108,56 @@ func (c *FIFOShardedCache) Has(key []byte) bool {
 // Peek returns the key value (or undefined if not found) without updating
 // the "recently used"-ness of the key.
 func (c *FIFOShardedCache) Peek(key []byte) (value interface{}, ok bool) {
-	return c.cache.Get(string(key))
+	value, ok = c.cache.Get(string(key))
+	c.counters.RecordGet(ok)
+
+	return value, ok
 }
 
 // HasOrAdd checks if a key is in the cache  without updating the
 // recent-ness or deleting it for being stale,  and if not, adds the value.
 // Returns whether found and whether an eviction occurred.
 func (c *FIFOShardedCache) HasOrAdd(key []byte, value interface{}) (found, evicted bool) {
+	numItems := c.cache.Count()
 	added := c.cache.SetIfAbsent(string(key), value)
+	c.recordEviction(numItems, added)
 
 	if added {
+		numBytes := storage.EstimateSize(value)
+		c.trackAdded(key, numBytes)
+		c.counters.RecordPut(numBytes)
 		c.callAddedDataHandlers(key)
 	}
 
 	return !added, true
 }
 
+// recordEviction counts an eviction if adding a new key did not increase the number of items. The underlying map
+// does not report its evictions so the count is an estimate when items are added concurrently
+func (c *FIFOShardedCache) recordEviction(numItemsBeforeAdd int, isNewKey bool) {
+	if isNewKey && c.cache.Count() <= numItemsBeforeAdd {
+		c.counters.RecordEvictions(1)
+	}
+}
+
+// trackAdded accounts the size of the added item and removes the oldest items while the cache exceeds its capacity
+// in bytes. Does nothing if the cache is bounded only by the number of items
+func (c *FIFOShardedCache) trackAdded(key []byte, numBytes int) {
+	if c.tracker == nil {
+		return
+	}
+
+	keysToRemove := c.tracker.add(string(key), int64(len(key)+numBytes))
+	for _, keyToRemove := range keysToRemove {
+		if !c.cache.Has(keyToRemove) {
+			continue
+		}
+
+		c.cache.Remove(keyToRemove)
+		c.counters.RecordEvictions(1)
+	}
+}
+
 func (c *FIFOShardedCache) callAddedDataHandlers(key []byte) {
 	c.mutAddedDataHandlers.RLock()
 	for _, handler := range c.addedDataHandlers {
@@ -100,6 +169,9 @@ func (c *FIFOShardedCache) callAddedDataHandlers(key []byte) {
 // Remove removes the provided key from the cache.
 func (c *FIFOShardedCache) Remove(key []byte) {
 	c.cache.Remove(string(key))
+	if c.tracker != nil {
+		c.tracker.remove(string(key))
+	}
 }
 
 // RemoveOldest removes the oldest item from the cache.
@@ -130,6 +202,11 @@ func (c *FIFOShardedCache) MaxSize() int {
 	return c.maxsize
 }
 
+// Stats returns the counters of the operations done on the cache
+func (c *FIFOShardedCache) Stats() storage.CacheStats {
+	return c.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (c *FIFOShardedCache) IsInterfaceNil() bool {
 	if c == nil {
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/fifocache"
 	"github.com/stretchr/testify/assert"
 )
@@ -380,3 +381,93 @@ func TestFIFOShardedCache_CacherRegisterHasOrAddAddedDataHandlerNotAddedShouldNo
 
 	assert.Equal(t, 1, len(c.AddedDataHandlers()))
 }
+
+func TestFIFOShardedCache_StatsShouldCountOperations(t *testing.T) {
+	t.Parallel()
+
+	// the underlying map keeps one item less than the size of a shard
+	c, _ := fifocache.NewShardedCache(3, 1)
+
+	c.Put([]byte("key1"), []byte("val1"))
+	c.Put([]byte("key2"), []byte("val2"))
+	c.Put([]byte("key3"), []byte("value3"))
+	_, _ = c.Get([]byte("key1"))
+	_, _ = c.Get([]byte("key3"))
+	_, _ = c.Peek([]byte("key2"))
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(3), stats.NumGets)
+	assert.Equal(t, uint64(2), stats.NumHits)
+	assert.Equal(t, uint64(1), stats.NumMisses)
+	assert.Equal(t, uint64(3), stats.NumPuts)
+	assert.Equal(t, uint64(1), stats.NumEvictions)
+	assert.Equal(t, uint64(14), stats.NumBytesAdded)
+}
+
+func TestFIFOShardedCache_NewShardedCacheWithSizeInBytesInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	c, err := fifocache.NewShardedCacheWithSizeInBytes(0, 1, 100)
+	assert.Nil(t, c)
+	assert.Equal(t, storage.ErrInvalidCacheSize, err)
+
+	c, err = fifocache.NewShardedCacheWithSizeInBytes(10, 1, 0)
+	assert.Nil(t, c)
+	assert.Equal(t, storage.ErrInvalidCacheSizeInBytes, err)
+}
+
+func TestFIFOShardedCache_PutWithSizeInBytesShouldEvictUntilUnderCapacity(t *testing.T) {
+	t.Parallel()
+
+	// each item accounts 4 bytes for the key and 6 bytes for the value
+	c, _ := fifocache.NewShardedCacheWithSizeInBytes(10, 2, 30)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	c.Put([]byte("key3"), []byte("value3"))
+	assert.Equal(t, 3, c.Len())
+
+	_, _ = c.Get([]byte("key1"))
+	c.Put([]byte("key4"), []byte("a larger value"))
+
+	assert.Equal(t, 2, c.Len())
+	assert.True(t, c.Has([]byte("key3")))
+	assert.True(t, c.Has([]byte("key4")))
+	assert.Equal(t, uint64(2), c.Stats().NumEvictions)
+}
+
+func TestFIFOShardedCache_HasOrAddWithSizeInBytesShouldEvictUntilUnderCapacity(t *testing.T) {
+	t.Parallel()
+
+	c, _ := fifocache.NewShardedCacheWithSizeInBytes(10, 2, 20)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	found, _ := c.HasOrAdd([]byte("key3"), []byte("value3"))
+
+	assert.False(t, found)
+	assert.Equal(t, 2, c.Len())
+	assert.False(t, c.Has([]byte("key1")))
+
+	found, _ = c.HasOrAdd([]byte("key2"), []byte("a larger value"))
+	assert.True(t, found)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestFIFOShardedCache_RemoveWithSizeInBytesShouldFreeCapacity(t *testing.T) {
+	t.Parallel()
+
+	c, _ := fifocache.NewShardedCacheWithSizeInBytes(10, 2, 20)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	c.Remove([]byte("key1"))
+	c.Put([]byte("key3"), []byte("value3"))
+
+	assert.Equal(t, 2, c.Len())
+
+	c.Clear()
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	assert.Equal(t, 2, c.Len())
+}
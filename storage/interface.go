@@ -23,10 +23,19 @@ type Persister interface {
 	Destroy() error
 	// DestroyClosed removes the already closed persistence medium stored data
 	DestroyClosed() error
+	// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+	// handler returns false. A nil range selects all the keys
+	Iterate(keyRange *KeyRange, handler func(key []byte, val []byte) bool) error
 	// IsInterfaceNil returns true if there is no value under the interface
 	IsInterfaceNil() bool
 }
 
+// Flusher is implemented by the persisters and the storers buffering their writes, so that the buffered writes can be
+// made durable on request
+type Flusher interface {
+	Flush() error
+}
+
 // Batcher allows to batch the data first then write the batch to the persister in one go
 type Batcher interface {
 	// Put inserts one entry - key, value pair - into the batch
@@ -86,6 +95,19 @@ type BloomFilter interface {
 	IsInterfaceNil() bool
 }
 
+// RemovableBloomFilter is a bloom filter supporting the removal of the added values
+type RemovableBloomFilter interface {
+	BloomFilter
+	Remove([]byte)
+}
+
+// PersistableBloomFilter is a bloom filter whose content can be saved and restored later
+type PersistableBloomFilter interface {
+	BloomFilter
+	Bytes() []byte
+	Load(buff []byte) error
+}
+
 // Storer provides storage services in a two layered storage construct, where the first layer is
 // represented by a cache and second layer by a persitent storage (DB-like)
 type Storer interface {
@@ -95,6 +117,7 @@ type Storer interface {
 	Has(key []byte) error
 	HasInEpoch(key []byte, epoch uint32) error
 	SearchFirst(key []byte) ([]byte, error)
+	Iterate(keyRange *KeyRange, handler func(key []byte, val []byte) bool) error
 	Remove(key []byte) error
 	ClearCache()
 	DestroyUnit() error
@@ -110,6 +133,16 @@ type EpochStartNotifier interface {
 	IsInterfaceNil() bool
 }
 
+// CacheStatsHandler is implemented by the caches counting the operations done on them
+type CacheStatsHandler interface {
+	Stats() CacheStats
+}
+
+// UnitStatsHandler is implemented by the storers counting the lookups done on them
+type UnitStatsHandler interface {
+	Stats() UnitStats
+}
+
 // PathManagerHandler defines which actions should be done for generating paths for databases directories
 type PathManagerHandler interface {
 	PathForEpoch(shardId string, epoch uint32, identifier string) string
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"bytes"
+)
+
+// KeyRange selects the keys visited by an iteration: the keys greater than or equal to Start and lower than Limit.
+// A nil Start means the range begins with the first key and a nil Limit means it ends after the last key
+type KeyRange struct {
+	Start []byte
+	Limit []byte
+}
+
+// PrefixRange returns the range holding all the keys starting with the provided prefix
+func PrefixRange(prefix []byte) *KeyRange {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			break
+		}
+	}
+
+	return &KeyRange{
+		Start: prefix,
+		Limit: limit,
+	}
+}
+
+// Contains returns true if the provided key is inside the range. A nil range contains all the keys
+func (kr *KeyRange) Contains(key []byte) bool {
+	if kr == nil {
+		return true
+	}
+	if len(kr.Start) > 0 && bytes.Compare(key, kr.Start) < 0 {
+		return false
+	}
+	if len(kr.Limit) > 0 && bytes.Compare(key, kr.Limit) >= 0 {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixRange(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, &storage.KeyRange{Start: []byte("ab"), Limit: []byte("ac")}, storage.PrefixRange([]byte("ab")))
+	assert.Equal(t, &storage.KeyRange{Start: []byte{1, 0xff}, Limit: []byte{2}}, storage.PrefixRange([]byte{1, 0xff}))
+	assert.Equal(t, &storage.KeyRange{Start: []byte{0xff}, Limit: nil}, storage.PrefixRange([]byte{0xff}))
+}
+
+func TestKeyRange_Contains(t *testing.T) {
+	t.Parallel()
+
+	var nilRange *storage.KeyRange
+	assert.True(t, nilRange.Contains([]byte("any")))
+
+	prefixRange := storage.PrefixRange([]byte("ab"))
+	assert.True(t, prefixRange.Contains([]byte("ab")))
+	assert.True(t, prefixRange.Contains([]byte("abz")))
+	assert.False(t, prefixRange.Contains([]byte("aa")))
+	assert.False(t, prefixRange.Contains([]byte("ac")))
+
+	openRange := &storage.KeyRange{Start: []byte("b")}
+	assert.False(t, openRange.Contains([]byte("a")))
+	assert.True(t, openRange.Contains([]byte("zzz")))
+}
@@ -0,0 +1,34 @@
+package leveldb
+
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func toLevelDBRange(keyRange *storage.KeyRange) *util.Range {
+	if keyRange == nil {
+		return nil
+	}
+
+	return &util.Range{
+		Start: keyRange.Start,
+		Limit: keyRange.Limit,
+	}
+}
+
+// iterate walks the provided iterator, releasing it at the end. Keys and values are copied as the iterator
+// reuses its buffers
+func iterate(iter iterator.Iterator, handler func(key []byte, val []byte) bool) error {
+	defer iter.Release()
+
+	for iter.Next() {
+		key := append([]byte(nil), iter.Key()...)
+		val := append([]byte(nil), iter.Value()...)
+		if !handler(key, val) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
@@ -10,11 +10,14 @@ import (
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // read + write + execute for owner only
 const rwxOwner = 0700
 
+const readOnlyBatchDelaySeconds = 1
+
 var log = logger.GetOrCreate("storage/leveldb")
 
 // DB holds a pointer to the leveldb database and the path to where it is stored.
@@ -47,6 +50,29 @@ func NewDB(path string, batchDelaySeconds int, maxBatchSize int, maxOpenFiles in
 		OpenFilesCacheCapacity: maxOpenFiles,
 	}
 
+	return openDB(path, batchDelaySeconds, maxBatchSize, options)
+}
+
+// NewReadOnlyDB opens an existing leveldb database without allowing any change to its files.
+// The Put and Remove calls return an error
+func NewReadOnlyDB(path string, maxOpenFiles int) (*DB, error) {
+	if maxOpenFiles < 1 {
+		return nil, storage.ErrInvalidNumOpenFiles
+	}
+
+	options := &opt.Options{
+		// disable internal cache
+		BlockCacheCapacity:     -1,
+		OpenFilesCacheCapacity: maxOpenFiles,
+		ReadOnly:               true,
+		ErrorIfMissing:         true,
+	}
+
+	// a batch of one pair makes the Put calls fail right away
+	return openDB(path, readOnlyBatchDelaySeconds, 1, options)
+}
+
+func openDB(path string, batchDelaySeconds int, maxBatchSize int, options *opt.Options) (*DB, error) {
 	db, err := leveldb.OpenFile(path, options)
 	if err != nil {
 		return nil, err
@@ -184,6 +210,44 @@ func (s *DB) Close() error {
 	return s.db.Close()
 }
 
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+// handler returns false. The pending batch is written first and the iteration runs on a snapshot of the database
+func (s *DB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	err := s.Flush()
+	if err != nil {
+		return err
+	}
+
+	return iterate(s.db.NewIterator(toLevelDBRange(keyRange), nil), handler)
+}
+
+// Compact writes the pending batch and compacts the whole key space, dropping the overwritten and the removed pairs
+func (s *DB) Compact() error {
+	err := s.Flush()
+	if err != nil {
+		return err
+	}
+
+	return s.db.CompactRange(util.Range{})
+}
+
+// Flush synchronously writes the pending batch, without waiting for the batch delay to elapse or for the batch to
+// be full
+func (s *DB) Flush() error {
+	s.mutBatch.Lock()
+	defer s.mutBatch.Unlock()
+
+	err := s.putBatch(s.batch)
+	if err != nil {
+		return err
+	}
+
+	s.batch.Reset()
+	s.sizeBatch = 0
+
+	return nil
+}
+
 // Remove removes the data associated to the given key
 func (s *DB) Remove(key []byte) error {
 	s.mutBatch.Lock()
@@ -9,7 +9,9 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // SerialDB holds a pointer to the leveldb database and the path to where it is stored.
@@ -216,6 +218,57 @@ func (s *SerialDB) Close() error {
 	return s.db.Close()
 }
 
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+// handler returns false. The pending batch is written first and the iterator is created in the serial access loop,
+// on a snapshot of the database, so the iteration itself does not block the other requests
+func (s *SerialDB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	if s.isClosed() {
+		return storage.ErrSerialDBIsClosed
+	}
+
+	err := s.putBatch()
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan iterator.Iterator)
+	req := &iterAct{
+		keyRange: toLevelDBRange(keyRange),
+		resChan:  ch,
+	}
+
+	s.dbAccess <- req
+	iter := <-ch
+	close(ch)
+
+	return iterate(iter, handler)
+}
+
+// Compact writes the pending batch and compacts the whole key space, dropping the overwritten and the removed pairs.
+// The compaction runs outside the serial access loop so the other operations are not blocked meanwhile
+func (s *SerialDB) Compact() error {
+	if s.isClosed() {
+		return storage.ErrSerialDBIsClosed
+	}
+
+	err := s.putBatch()
+	if err != nil {
+		return err
+	}
+
+	return s.db.CompactRange(util.Range{})
+}
+
+// Flush synchronously writes the pending batch, without waiting for the batch delay to elapse or for the batch to
+// be full
+func (s *SerialDB) Flush() error {
+	if s.isClosed() {
+		return storage.ErrSerialDBIsClosed
+	}
+
+	return s.putBatch()
+}
+
 // Remove removes the data associated to the given key
 func (s *SerialDB) Remove(key []byte) error {
 	if s.isClosed() {
@@ -195,3 +195,77 @@ func TestSerialDB_Destroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestSerialDB_IterateShouldIncludeThePendingBatch(t *testing.T) {
+	ldb := createSerialLevelDb(t, 10, 100, 10)
+	_ = ldb.Put([]byte("b2"), []byte("v2"))
+	_ = ldb.Put([]byte("a1"), []byte("v0"))
+	_ = ldb.Put([]byte("b1"), []byte("v1"))
+
+	keys := make([]string, 0)
+	err := ldb.Iterate(storage.PrefixRange([]byte("b")), func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		// the serial access loop should not be blocked while iterating
+		_, errGet := ldb.Get(key)
+		assert.Nil(t, errGet)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+
+	_ = ldb.Destroy()
+}
+
+func TestSerialDB_IterateOnClosedDBShouldErr(t *testing.T) {
+	ldb := createSerialLevelDb(t, 10, 1, 10)
+	_ = ldb.Close()
+
+	err := ldb.Iterate(nil, func(key []byte, val []byte) bool {
+		return true
+	})
+
+	assert.Equal(t, storage.ErrSerialDBIsClosed, err)
+}
+
+func TestSerialDB_CompactShouldKeepTheData(t *testing.T) {
+	ldb := createSerialLevelDb(t, 10, 100, 10)
+	_ = ldb.Put([]byte("key1"), []byte("val1"))
+
+	err := ldb.Compact()
+	assert.Nil(t, err)
+
+	v, err := ldb.Get([]byte("key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("val1"), v)
+
+	_ = ldb.Destroy()
+}
+
+func TestSerialDB_FlushShouldWriteThePendingBatch(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	ldb := createSerialLevelDb(t, 10, 100, 10)
+
+	_ = ldb.Put(key, val)
+	v, err := ldb.Get(key)
+	assert.Nil(t, v)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	err = ldb.Flush()
+	assert.Nil(t, err)
+
+	v, err = ldb.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+
+	_ = ldb.Destroy()
+}
+
+func TestSerialDB_FlushOnClosedDBShouldErr(t *testing.T) {
+	ldb := createSerialLevelDb(t, 10, 1, 10)
+	_ = ldb.Close()
+
+	err := ldb.Flush()
+
+	assert.Equal(t, storage.ErrSerialDBIsClosed, err)
+}
@@ -190,3 +190,77 @@ func TestDB_Destroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestDB_IterateShouldIncludeThePendingBatch(t *testing.T) {
+	ldb := createLevelDb(t, 10, 100, 10)
+	_ = ldb.Put([]byte("b2"), []byte("v2"))
+	_ = ldb.Put([]byte("a1"), []byte("v0"))
+	_ = ldb.Put([]byte("b1"), []byte("v1"))
+
+	keys := make([]string, 0)
+	vals := make([]string, 0)
+	err := ldb.Iterate(storage.PrefixRange([]byte("b")), func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		vals = append(vals, string(val))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+	assert.Equal(t, []string{"v1", "v2"}, vals)
+
+	_ = ldb.Destroy()
+}
+
+func TestDB_IterateShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	ldb := createLevelDb(t, 10, 1, 10)
+	_ = ldb.Put([]byte("a"), []byte("v"))
+	_ = ldb.Put([]byte("b"), []byte("v"))
+
+	numCalls := 0
+	err := ldb.Iterate(nil, func(key []byte, val []byte) bool {
+		numCalls++
+		return false
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+
+	_ = ldb.Destroy()
+}
+
+func TestDB_CompactShouldKeepTheData(t *testing.T) {
+	ldb := createLevelDb(t, 10, 100, 10)
+	_ = ldb.Put([]byte("key1"), []byte("val1"))
+	_ = ldb.Put([]byte("key2"), []byte("val2"))
+	_ = ldb.Remove([]byte("key2"))
+
+	err := ldb.Compact()
+	assert.Nil(t, err)
+
+	v, err := ldb.Get([]byte("key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("val1"), v)
+	assert.Equal(t, storage.ErrKeyNotFound, ldb.Has([]byte("key2")))
+
+	_ = ldb.Destroy()
+}
+
+func TestDB_FlushShouldWriteThePendingBatch(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	ldb := createLevelDb(t, 10, 100, 10)
+
+	_ = ldb.Put(key, val)
+	v, err := ldb.Get(key)
+	assert.Nil(t, v)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	err = ldb.Flush()
+	assert.Nil(t, err)
+
+	v, err = ldb.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+
+	_ = ldb.Destroy()
+}
@@ -2,7 +2,9 @@ package leveldb
 
 import (
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 type putBatchAct struct {
@@ -34,6 +36,11 @@ type hasAct struct {
 	resChan chan<- error
 }
 
+type iterAct struct {
+	keyRange *util.Range
+	resChan  chan<- iterator.Iterator
+}
+
 func (p *putBatchAct) request(s *SerialDB) {
 	wopt := &opt.WriteOptions{
 		Sync: true,
@@ -74,3 +81,7 @@ func (h *hasAct) request(s *SerialDB) {
 
 	h.resChan <- storage.ErrKeyNotFound
 }
+
+func (i *iterAct) request(s *SerialDB) {
+	i.resChan <- s.db.NewIterator(i.keyRange, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/logger"
+	"github.com/ElrondNetwork/elrond-go/storage"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -11,8 +12,9 @@ var log = logger.GetOrCreate("storage/lrucache")
 
 // LRUCache implements a Least Recently Used eviction cache
 type LRUCache struct {
-	cache   *lru.Cache
-	maxsize int
+	cache    sizedLRUCacheHandler
+	maxsize  int
+	counters storage.CacheCounters
 
 	mutAddedDataHandlers sync.RWMutex
 	addedDataHandlers    []func(key []byte)
@@ -26,14 +28,28 @@ func NewCache(size int) (*LRUCache, error) {
 		return nil, err
 	}
 
-	lruCache := &LRUCache{
+	return createLRUCache(size, &simpleLRUAdapter{Cache: cache}), nil
+}
+
+// NewCacheWithSizeInBytes creates a new LRU cache instance bounded both by the number of items and by the sum of
+// the sizes of the keys and values it holds. The size of a value is known only for byte slices, strings and the
+// values implementing Size() int, the other values being accounted only by the size of their key
+func NewCacheWithSizeInBytes(size int, sizeInBytes int64) (*LRUCache, error) {
+	cache, err := newSizedLRU(size, sizeInBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return createLRUCache(size, cache), nil
+}
+
+func createLRUCache(size int, cache sizedLRUCacheHandler) *LRUCache {
+	return &LRUCache{
 		cache:                cache,
 		maxsize:              size,
 		mutAddedDataHandlers: sync.RWMutex{},
 		addedDataHandlers:    make([]func(key []byte), 0),
 	}
-
-	return lruCache, nil
 }
 
 // Clear is used to completely clear the cache.
@@ -43,11 +59,14 @@ func (c *LRUCache) Clear() {
 
 // Put adds a value to the cache.  Returns true if an eviction occurred.
 func (c *LRUCache) Put(key []byte, value interface{}) (evicted bool) {
-	evicted = c.cache.Add(string(key), value)
+	numBytes := storage.EstimateSize(value)
+	numEvicted := c.cache.AddSized(string(key), value, int64(len(key)+numBytes))
 
+	c.counters.RecordPut(numBytes)
+	c.counters.RecordEvictions(numEvicted)
 	c.callAddedDataHandlers(key)
 
-	return evicted
+	return numEvicted > 0
 }
 
 // RegisterHandler registers a new handler to be called when a new data is added
@@ -64,7 +83,10 @@ func (c *LRUCache) RegisterHandler(handler func(key []byte)) {
 
 // Get looks up a key's value from the cache.
 func (c *LRUCache) Get(key []byte) (value interface{}, ok bool) {
-	return c.cache.Get(string(key))
+	value, ok = c.cache.Get(string(key))
+	c.counters.RecordGet(ok)
+
+	return value, ok
 }
 
 // Has checks if a key is in the cache, without updating the
@@ -77,6 +99,7 @@ func (c *LRUCache) Has(key []byte) bool {
 // the "recently used"-ness of the key.
 func (c *LRUCache) Peek(key []byte) (value interface{}, ok bool) {
 	v, ok := c.cache.Peek(string(key))
+	c.counters.RecordGet(ok)
 
 	if !ok {
 		return nil, ok
@@ -89,13 +112,16 @@ func (c *LRUCache) Peek(key []byte) (value interface{}, ok bool) {
 // recent-ness or deleting it for being stale,  and if not, adds the value.
 // Returns whether found and whether an eviction occurred.
 func (c *LRUCache) HasOrAdd(key []byte, value interface{}) (found, evicted bool) {
-	found, evicted = c.cache.ContainsOrAdd(string(key), value)
+	numBytes := storage.EstimateSize(value)
+	found, numEvicted := c.cache.AddSizedIfMissing(string(key), value, int64(len(key)+numBytes))
 
+	c.counters.RecordEvictions(numEvicted)
 	if !found {
+		c.counters.RecordPut(numBytes)
 		c.callAddedDataHandlers(key)
 	}
 
-	return
+	return found, numEvicted > 0
 }
 
 func (c *LRUCache) callAddedDataHandlers(key []byte) {
@@ -138,6 +164,11 @@ func (c *LRUCache) MaxSize() int {
 	return c.maxsize
 }
 
+// Stats returns the counters of the operations done on the cache
+func (c *LRUCache) Stats() storage.CacheStats {
+	return c.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (c *LRUCache) IsInterfaceNil() bool {
 	if c == nil {
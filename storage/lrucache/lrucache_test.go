@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/stretchr/testify/assert"
 )
@@ -399,3 +400,122 @@ func TestLRUCache_CacherRegisterHasOrAddAddedDataHandlerNotAddedShouldNotCall(t
 
 	assert.Equal(t, 1, len(c.AddedDataHandlers()))
 }
+
+func TestLRUCache_StatsShouldCountOperations(t *testing.T) {
+	t.Parallel()
+
+	c, _ := lrucache.NewCache(2)
+
+	c.Put([]byte("key1"), []byte("val1"))
+	c.Put([]byte("key2"), []byte("val2"))
+	c.Put([]byte("key3"), []byte("value3"))
+	_, _ = c.Get([]byte("key1"))
+	_, _ = c.Get([]byte("key3"))
+	_, _ = c.Peek([]byte("key2"))
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(3), stats.NumGets)
+	assert.Equal(t, uint64(2), stats.NumHits)
+	assert.Equal(t, uint64(1), stats.NumMisses)
+	assert.Equal(t, uint64(3), stats.NumPuts)
+	assert.Equal(t, uint64(1), stats.NumEvictions)
+	assert.Equal(t, uint64(14), stats.NumBytesAdded)
+}
+
+func TestLRUCache_NewCacheWithSizeInBytesInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	c, err := lrucache.NewCacheWithSizeInBytes(0, 100)
+	assert.Nil(t, c)
+	assert.Equal(t, storage.ErrInvalidCacheSize, err)
+
+	c, err = lrucache.NewCacheWithSizeInBytes(10, 0)
+	assert.Nil(t, c)
+	assert.Equal(t, storage.ErrInvalidCacheSizeInBytes, err)
+}
+
+func TestLRUCache_PutWithSizeInBytesShouldEvictUntilUnderCapacity(t *testing.T) {
+	t.Parallel()
+
+	// each item accounts 4 bytes for the key and 6 bytes for the value
+	c, _ := lrucache.NewCacheWithSizeInBytes(10, 30)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	c.Put([]byte("key3"), []byte("value3"))
+	assert.Equal(t, 3, c.Len())
+
+	_, _ = c.Get([]byte("key1"))
+	evicted := c.Put([]byte("key4"), []byte("a larger value"))
+
+	assert.True(t, evicted)
+	assert.Equal(t, 2, c.Len())
+	assert.True(t, c.Has([]byte("key1")))
+	assert.True(t, c.Has([]byte("key4")))
+	assert.Equal(t, uint64(2), c.Stats().NumEvictions)
+}
+
+func TestLRUCache_PutWithSizeInBytesShouldAccountRewrittenValues(t *testing.T) {
+	t.Parallel()
+
+	c, _ := lrucache.NewCacheWithSizeInBytes(10, 30)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	c.Put([]byte("key1"), []byte("a much larger value"))
+
+	assert.Equal(t, 1, c.Len())
+	assert.True(t, c.Has([]byte("key1")))
+
+	c.Put([]byte("key1"), []byte("v"))
+	c.Put([]byte("key2"), []byte("value2"))
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRUCache_PutWithSizeInBytesShouldKeepAValueLargerThanCapacity(t *testing.T) {
+	t.Parallel()
+
+	c, _ := lrucache.NewCacheWithSizeInBytes(10, 5)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+
+	assert.Equal(t, [][]byte{[]byte("key2")}, c.Keys())
+}
+
+func TestLRUCache_HasOrAddWithSizeInBytesShouldEvictUntilUnderCapacity(t *testing.T) {
+	t.Parallel()
+
+	c, _ := lrucache.NewCacheWithSizeInBytes(10, 20)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	found, evicted := c.HasOrAdd([]byte("key3"), []byte("value3"))
+
+	assert.False(t, found)
+	assert.True(t, evicted)
+	assert.Equal(t, [][]byte{[]byte("key2"), []byte("key3")}, c.Keys())
+
+	found, evicted = c.HasOrAdd([]byte("key2"), []byte("a larger value"))
+	assert.True(t, found)
+	assert.False(t, evicted)
+}
+
+func TestLRUCache_RemoveWithSizeInBytesShouldFreeCapacity(t *testing.T) {
+	t.Parallel()
+
+	c, _ := lrucache.NewCacheWithSizeInBytes(10, 20)
+
+	c.Put([]byte("key1"), []byte("value1"))
+	c.Put([]byte("key2"), []byte("value2"))
+	c.Remove([]byte("key1"))
+	evicted := c.Put([]byte("key3"), []byte("value3"))
+
+	assert.False(t, evicted)
+	assert.Equal(t, 2, c.Len())
+
+	c.Clear()
+	c.Put([]byte("key1"), []byte("value1"))
+	evicted = c.Put([]byte("key2"), []byte("value2"))
+	assert.False(t, evicted)
+}
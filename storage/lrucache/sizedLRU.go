@@ -0,0 +1,246 @@
+package lrucache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// sizedLRUCacheHandler is the LRU implementation used by LRUCache. The size of each added item is provided
+// so that the implementations bounded by bytes can account it
+type sizedLRUCacheHandler interface {
+	AddSized(key, value interface{}, sizeInBytes int64) (numEvicted int)
+	AddSizedIfMissing(key, value interface{}, sizeInBytes int64) (found bool, numEvicted int)
+	Get(key interface{}) (value interface{}, ok bool)
+	Contains(key interface{}) bool
+	Peek(key interface{}) (value interface{}, ok bool)
+	Remove(key interface{}) bool
+	RemoveOldest() (key interface{}, value interface{}, ok bool)
+	Keys() []interface{}
+	Len() int
+	Purge()
+}
+
+// simpleLRUAdapter adapts the item count bounded LRU, ignoring the sizes of the items
+type simpleLRUAdapter struct {
+	*lru.Cache
+}
+
+// AddSized adds the value to the cache. Returns the number of evicted items
+func (sla *simpleLRUAdapter) AddSized(key, value interface{}, _ int64) int {
+	if sla.Add(key, value) {
+		return 1
+	}
+
+	return 0
+}
+
+// AddSizedIfMissing adds the value to the cache if the key is not already present. Returns whether the key was
+// found and the number of evicted items
+func (sla *simpleLRUAdapter) AddSizedIfMissing(key, value interface{}, _ int64) (bool, int) {
+	found, evicted := sla.ContainsOrAdd(key, value)
+	if evicted {
+		return found, 1
+	}
+
+	return found, 0
+}
+
+type sizedEntry struct {
+	key         interface{}
+	value       interface{}
+	sizeInBytes int64
+}
+
+// sizedLRU is an LRU bounded both by the number of items and by the sum of their sizes. The most recently added
+// item is never evicted, so a value larger than the whole capacity is still cached, alone
+type sizedLRU struct {
+	mut       sync.Mutex
+	maxSize   int
+	maxBytes  int64
+	numBytes  int64
+	evictList *list.List
+	items     map[interface{}]*list.Element
+}
+
+func newSizedLRU(size int, sizeInBytes int64) (*sizedLRU, error) {
+	if size <= 0 {
+		return nil, storage.ErrInvalidCacheSize
+	}
+	if sizeInBytes <= 0 {
+		return nil, storage.ErrInvalidCacheSizeInBytes
+	}
+
+	return &sizedLRU{
+		maxSize:   size,
+		maxBytes:  sizeInBytes,
+		evictList: list.New(),
+		items:     make(map[interface{}]*list.Element),
+	}, nil
+}
+
+// AddSized adds or replaces the value and evicts the least recently used items until the cache fits in its
+// capacity. Returns the number of evicted items
+func (sl *sizedLRU) AddSized(key, value interface{}, sizeInBytes int64) int {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	element, ok := sl.items[key]
+	if ok {
+		entry := element.Value.(*sizedEntry)
+		sl.numBytes += sizeInBytes - entry.sizeInBytes
+		entry.value = value
+		entry.sizeInBytes = sizeInBytes
+		sl.evictList.MoveToFront(element)
+
+		return sl.evictIfNeeded()
+	}
+
+	sl.addNew(key, value, sizeInBytes)
+
+	return sl.evictIfNeeded()
+}
+
+// AddSizedIfMissing adds the value only if the key is not present, without updating the recent-ness of an
+// existing key. Returns whether the key was found and the number of evicted items
+func (sl *sizedLRU) AddSizedIfMissing(key, value interface{}, sizeInBytes int64) (bool, int) {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	_, ok := sl.items[key]
+	if ok {
+		return true, 0
+	}
+
+	sl.addNew(key, value, sizeInBytes)
+
+	return false, sl.evictIfNeeded()
+}
+
+func (sl *sizedLRU) addNew(key, value interface{}, sizeInBytes int64) {
+	entry := &sizedEntry{
+		key:         key,
+		value:       value,
+		sizeInBytes: sizeInBytes,
+	}
+	sl.items[key] = sl.evictList.PushFront(entry)
+	sl.numBytes += sizeInBytes
+}
+
+func (sl *sizedLRU) evictIfNeeded() int {
+	numEvicted := 0
+	for sl.evictList.Len() > 1 && (sl.evictList.Len() > sl.maxSize || sl.numBytes > sl.maxBytes) {
+		sl.removeElement(sl.evictList.Back())
+		numEvicted++
+	}
+
+	return numEvicted
+}
+
+func (sl *sizedLRU) removeElement(element *list.Element) {
+	entry := sl.evictList.Remove(element).(*sizedEntry)
+	delete(sl.items, entry.key)
+	sl.numBytes -= entry.sizeInBytes
+}
+
+// Get returns the value of the key and marks it as the most recently used
+func (sl *sizedLRU) Get(key interface{}) (interface{}, bool) {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	element, ok := sl.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	sl.evictList.MoveToFront(element)
+
+	return element.Value.(*sizedEntry).value, true
+}
+
+// Contains checks if the key is present, without updating its recent-ness
+func (sl *sizedLRU) Contains(key interface{}) bool {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	_, ok := sl.items[key]
+
+	return ok
+}
+
+// Peek returns the value of the key without updating its recent-ness
+func (sl *sizedLRU) Peek(key interface{}) (interface{}, bool) {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	element, ok := sl.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	return element.Value.(*sizedEntry).value, true
+}
+
+// Remove removes the key, returning whether it was present
+func (sl *sizedLRU) Remove(key interface{}) bool {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	element, ok := sl.items[key]
+	if !ok {
+		return false
+	}
+
+	sl.removeElement(element)
+
+	return true
+}
+
+// RemoveOldest removes the least recently used item
+func (sl *sizedLRU) RemoveOldest() (interface{}, interface{}, bool) {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	element := sl.evictList.Back()
+	if element == nil {
+		return nil, nil, false
+	}
+
+	entry := element.Value.(*sizedEntry)
+	sl.removeElement(element)
+
+	return entry.key, entry.value, true
+}
+
+// Keys returns the keys, from the oldest to the newest
+func (sl *sizedLRU) Keys() []interface{} {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	keys := make([]interface{}, 0, sl.evictList.Len())
+	for element := sl.evictList.Back(); element != nil; element = element.Prev() {
+		keys = append(keys, element.Value.(*sizedEntry).key)
+	}
+
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (sl *sizedLRU) Len() int {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	return sl.evictList.Len()
+}
+
+// Purge removes all the items
+func (sl *sizedLRU) Purge() {
+	sl.mut.Lock()
+	defer sl.mut.Unlock()
+
+	sl.evictList.Init()
+	sl.items = make(map[interface{}]*list.Element)
+	sl.numBytes = 0
+}
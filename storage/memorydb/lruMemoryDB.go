@@ -1,6 +1,9 @@
 package memorydb
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 )
@@ -79,6 +82,38 @@ func (l *lruDB) DestroyClosed() error {
 	return l.Destroy()
 }
 
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+// handler returns false. Only the keys in the range are copied, each value is read when its key is visited and a key
+// evicted in the meantime is skipped. Reading the pairs does not change their recent-ness
+func (l *lruDB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	keys := make([][]byte, 0)
+	for _, key := range l.cacher.Keys() {
+		if keyRange.Contains(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	for _, key := range keys {
+		val, ok := l.cacher.Peek(key)
+		if !ok {
+			continue
+		}
+		buff, ok := val.([]byte)
+		if !ok {
+			continue
+		}
+
+		if !handler(key, buff) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (l *lruDB) IsInterfaceNil() bool {
 	return l == nil
@@ -135,3 +135,19 @@ func TestLruDB_Destroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestLruDB_IterateShouldWalkTheRangeInOrder(t *testing.T) {
+	mdb, _ := memorydb.NewlruDB(10)
+	_ = mdb.Put([]byte("b2"), []byte("v2"))
+	_ = mdb.Put([]byte("a1"), []byte("v0"))
+	_ = mdb.Put([]byte("b1"), []byte("v1"))
+
+	keys := make([]string, 0)
+	err := mdb.Iterate(&storage.KeyRange{Start: []byte("b")}, func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+}
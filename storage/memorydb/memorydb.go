@@ -4,7 +4,10 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // DB represents the memory database storage. It holds a map of key value pairs
@@ -96,6 +99,36 @@ func (s *DB) DestroyClosed() error {
 	return s.Destroy()
 }
 
+// Iterate calls the handler for every (key, val) pair in the provided range, in ascending key order, until the
+// handler returns false. Only the keys in the range are copied before calling the handler, each value is read when its
+// key is visited, so the handler can modify the database. A key removed in the meantime is skipped
+func (s *DB) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	s.mutx.RLock()
+	keys := make([]string, 0)
+	for key := range s.db {
+		if keyRange.Contains([]byte(key)) {
+			keys = append(keys, key)
+		}
+	}
+	s.mutx.RUnlock()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s.mutx.RLock()
+		val, ok := s.db[key]
+		s.mutx.RUnlock()
+		if !ok {
+			continue
+		}
+
+		if !handler([]byte(key), val) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (s *DB) IsInterfaceNil() bool {
 	return s == nil
@@ -3,6 +3,7 @@ package memorydb_test
 import (
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 	"github.com/stretchr/testify/assert"
 )
@@ -98,3 +99,57 @@ func TestDestroy(t *testing.T) {
 	err := mdb.Destroy()
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestDB_IterateShouldWalkTheRangeInOrder(t *testing.T) {
+	mdb := memorydb.New()
+	_ = mdb.Put([]byte("b2"), []byte("v2"))
+	_ = mdb.Put([]byte("a1"), []byte("v0"))
+	_ = mdb.Put([]byte("b1"), []byte("v1"))
+	_ = mdb.Put([]byte("c1"), []byte("v3"))
+
+	keys := make([]string, 0)
+	vals := make([]string, 0)
+	err := mdb.Iterate(storage.PrefixRange([]byte("b")), func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		vals = append(vals, string(val))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+	assert.Equal(t, []string{"v1", "v2"}, vals)
+}
+
+func TestDB_IterateShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	mdb := memorydb.New()
+	_ = mdb.Put([]byte("a"), []byte("v"))
+	_ = mdb.Put([]byte("b"), []byte("v"))
+
+	numCalls := 0
+	err := mdb.Iterate(nil, func(key []byte, val []byte) bool {
+		numCalls++
+		// writing from the handler should not deadlock
+		_ = mdb.Put([]byte("c"), val)
+		return false
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
+
+func TestDB_IterateShouldSkipTheKeysRemovedByTheHandler(t *testing.T) {
+	mdb := memorydb.New()
+	_ = mdb.Put([]byte("a"), []byte("v"))
+	_ = mdb.Put([]byte("b"), []byte("v"))
+	_ = mdb.Put([]byte("c"), []byte("v"))
+
+	keys := make([]string, 0)
+	err := mdb.Iterate(nil, func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		_ = mdb.Remove([]byte("b"))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "c"}, keys)
+}
@@ -8,7 +8,8 @@ import (
 
 // PersisterFactoryStub -
 type PersisterFactoryStub struct {
-	CreateCalled func(path string) (storage.Persister, error)
+	CreateCalled         func(path string) (storage.Persister, error)
+	CreateReadOnlyCalled func(path string) (storage.Persister, error)
 }
 
 // Create -
@@ -20,6 +21,15 @@ func (pfs *PersisterFactoryStub) Create(path string) (storage.Persister, error)
 	return nil, errors.New("not implemented")
 }
 
+// CreateReadOnly -
+func (pfs *PersisterFactoryStub) CreateReadOnly(path string) (storage.Persister, error) {
+	if pfs.CreateReadOnlyCalled != nil {
+		return pfs.CreateReadOnlyCalled(path)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
 // IsInterfaceNil -
 func (pfs *PersisterFactoryStub) IsInterfaceNil() bool {
 	return pfs == nil
@@ -7,3 +7,15 @@ func (ps *PruningStorer) ChangeEpoch(epochNum uint32) error {
 func RemoveDirectoryIfEmpty(path string) {
 	removeDirectoryIfEmpty(path)
 }
+
+func CompressDirectory(source string, destinationFile string) error {
+	return compressDirectory(source, destinationFile)
+}
+
+func ExtractArchive(sourceFile string, destination string) error {
+	return extractArchive(sourceFile, destination)
+}
+
+func (ps *PruningStorer) WaitForArchiving() {
+	ps.archiveWg.Wait()
+}
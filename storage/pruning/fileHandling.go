@@ -1,12 +1,22 @@
 package pruning
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
+// read + write + execute for owner only
+const rwxOwner = 0700
+
+// read + write for owner only
+const rwOwner = 0600
+
 // removeDirectoryIfEmpty will clean the directories after all persisters for one epoch were destroyed
 // the structure is this way :
 // workspace/db/Epoch_X/Shard_Y/DbName
@@ -52,3 +62,198 @@ func isDirectoryEmpty(name string) bool {
 	_, err = f.Readdirnames(1) // Or f.Readdir(1)
 	return err == io.EOF
 }
+
+// copyDirectory copies the files of the source directory, recursively, into the destination directory. It does
+// not rely on renaming so the destination can be on a different file system
+func copyDirectory(source string, destination string) error {
+	return filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, relativePath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, rwxOwner)
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(source string, destination string) error {
+	src, err := os.Open(filepath.Clean(source))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	dst, err := os.OpenFile(filepath.Clean(destination), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, rwOwner)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	err = dst.Sync()
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
+
+// compressDirectory writes the files of the source directory, recursively, in a gzip compressed tar file
+func compressDirectory(source string, destinationFile string) error {
+	err := os.MkdirAll(filepath.Dir(destinationFile), rwxOwner)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Clean(destinationFile), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, rwOwner)
+	if err != nil {
+		return err
+	}
+
+	err = writeTarGz(source, file)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func writeTarGz(source string, writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err := filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relativePath)
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tarWriter, file)
+		_ = file.Close()
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// extractArchive writes the files of a gzip compressed tar file, created by compressDirectory, in the destination
+// directory
+func extractArchive(sourceFile string, destination string) error {
+	file, err := os.Open(filepath.Clean(sourceFile))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, errNext := tarReader.Next()
+		if errNext == io.EOF {
+			return nil
+		}
+		if errNext != nil {
+			return errNext
+		}
+
+		target := filepath.Join(destination, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destination)+string(os.PathSeparator)) {
+			return storage.ErrInvalidArchiveEntry
+		}
+
+		err = extractFile(tarReader, target)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), rwxOwner)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, rwOwner)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, reader)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// replacePath moves the source file or directory to the destination path, replacing what was found there
+func replacePath(source string, destination string) error {
+	err := os.RemoveAll(destination)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(source, destination)
+}
@@ -4,6 +4,11 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/core"
@@ -18,11 +23,66 @@ import (
 
 var log = logger.GetOrCreate("storage/pruning")
 
+// archiveFileExtension is appended to the path of the compressed archived persisters
+const archiveFileExtension = ".tar.gz"
+
+// stagingExtension is appended to the archive path while an epoch is being archived, so an interrupted archiving never
+// leaves an incomplete archive in place
+const stagingExtension = ".staging"
+
+// extractedExtension is appended to the archive path for the directories in which a compressed archive is extracted
+// while it is opened. They are placed next to the archive so the extraction does not fill the system's temporary
+// directory
+const extractedExtension = ".extracted"
+
+// archiveBloomFilterExtension is appended to the archive path for the file holding the bloom filter of the archived
+// keys. The filter lets the lookups in all the epochs skip the archives which do not hold the searched key
+const archiveBloomFilterExtension = ".bloom"
+
+// archiveBloomFilterBitsPerKey sizes the bloom filter of an archive after the number of archived keys, for a false
+// positive rate of about 1% with three hash functions
+const archiveBloomFilterBitsPerKey = 10
+
+// minArchiveBloomFilterSize is the size in bytes of the bloom filter of an archive holding few keys
+const minArchiveBloomFilterSize = 2048
+
+// defaultMaxOpenedArchives is the number of archived persisters kept open between the lookups when it is not configured
+const defaultMaxOpenedArchives = 4
+
+var archiveBloomFilterHashFunc = []storageUnit.HasherType{storageUnit.Keccak, storageUnit.Blake2b, storageUnit.Fnv}
+
 // persisterData structure is used so the persister and its path can be kept in the same place
 type persisterData struct {
+	persister    storage.Persister
+	path         string
+	epoch        uint32
+	isClosed     bool
+	isArchived   bool
+	isCompressed bool
+	isArchiving  bool
+	keysFilter   storage.BloomFilter
+	openMut      sync.Mutex
+	opened       *openedPersister
+}
+
+// mayContain returns false if the archived persister does not hold the key. An archive without a bloom filter may
+// hold any key
+func (pd *persisterData) mayContain(key []byte) bool {
+	return check.IfNil(pd.keysFilter) || pd.keysFilter.MayContain(key)
+}
+
+// openedPersister is a read-only instance of a closed persister, kept open between the lookups. An opened archive is
+// closed when it was evicted from the opened archives and the lookups using it are done
+type openedPersister struct {
 	persister storage.Persister
-	path      string
-	isClosed  bool
+	close     func()
+	numUsers  int
+	isEvicted bool
+}
+
+// compacter is implemented by the persisters able to compact their data files
+type compacter interface {
+	Compact() error
 }
 
 // PruningStorer represents a storer which creates a new persister for each epoch and removes older activePersisters
@@ -41,6 +101,15 @@ type PruningStorer struct {
 	identifier            string
 	fullArchive           bool
 	pruningEnabled        bool
+	archiveEnabled        bool
+	compressArchive       bool
+	archivePathManager    storage.PathManagerHandler
+	openedArchives        []uint32
+	maxOpenedArchives     int
+	archiveWg             sync.WaitGroup
+	isArchivingStopped    bool
+	flushOnCommit         bool
+	counters              storage.UnitCounters
 }
 
 // NewPruningStorer will return a new instance of PruningStorer without sharded directories' naming scheme
@@ -88,11 +157,18 @@ func initPruningStorer(
 	if check.IfNil(args.PathManager) {
 		return nil, storage.ErrNilPathManager
 	}
+	if args.ArchiveEnabled && check.IfNil(args.ArchivePathManager) {
+		return nil, storage.ErrNilArchivePathManager
+	}
 	if args.MaxBatchSize > int(args.CacheConf.Size) {
 		return nil, storage.ErrCacheSizeIsLowerThanBatchSize
 	}
+	maxOpenedArchives := int(args.MaxOpenedArchives)
+	if maxOpenedArchives == 0 {
+		maxOpenedArchives = defaultMaxOpenedArchives
+	}
 
-	cache, err = storageUnit.NewCache(args.CacheConf.Type, args.CacheConf.Size, args.CacheConf.Shards)
+	cache, err = storageUnit.NewCacheFromConfig(args.CacheConf)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +187,7 @@ func initPruningStorer(
 	persisters = append(persisters, &persisterData{
 		persister: db,
 		path:      filePath,
+		epoch:     args.StartingEpoch,
 		isClosed:  false,
 	})
 
@@ -131,6 +208,11 @@ func initPruningStorer(
 		dbPath:                args.DbPath,
 		numOfEpochsToKeep:     args.NumOfEpochsToKeep,
 		numOfActivePersisters: args.NumOfActivePersisters,
+		archiveEnabled:        args.ArchiveEnabled && !args.FullArchive,
+		compressArchive:       args.CompressArchive,
+		archivePathManager:    args.ArchivePathManager,
+		maxOpenedArchives:     maxOpenedArchives,
+		flushOnCommit:         args.FlushOnCommit,
 	}
 
 	if args.BloomFilterConf.Size != 0 { // if size is 0, that means an empty config was used so bloom filter will be nil
@@ -147,6 +229,10 @@ func initPruningStorer(
 		return nil, err
 	}
 
+	if pdb.archiveEnabled {
+		pdb.loadArchiveIndex(args.StartingEpoch)
+	}
+
 	pdb.registerHandler(args.Notifier)
 
 	return pdb, nil
@@ -182,33 +268,47 @@ func (ps *PruningStorer) Get(key []byte) ([]byte, error) {
 	var err error
 
 	if !ok {
+		if ps.bloomFilter != nil && !ps.bloomFilter.MayContain(key) {
+			ps.counters.RecordBloomFilterMiss()
+			return nil, fmt.Errorf("key %s not found in %s",
+				base64.StdEncoding.EncodeToString(key), ps.identifier)
+		}
+
 		// not found in cache
 		// search it in active persisters
 		found := false
 		for idx := uint32(0); (idx < ps.numOfActivePersisters) && (idx < uint32(len(ps.activePersisters))); idx++ {
-			if ps.bloomFilter == nil || ps.bloomFilter.MayContain(key) {
-				v, err = ps.activePersisters[idx].persister.Get(key)
-				if err != nil {
-					continue
-				}
-
-				found = true
-				// if found in persistence unit, add it to cache
-				ps.cacher.Put(key, v)
-				break
+			v, err = ps.activePersisters[idx].persister.Get(key)
+			if err != nil {
+				continue
 			}
+
+			found = true
+			// if found in persistence unit, add it to cache
+			ps.cacher.Put(key, v)
+			break
 		}
 		if !found {
+			ps.counters.RecordMiss()
 			return nil, fmt.Errorf("key %s not found in %s",
 				base64.StdEncoding.EncodeToString(key), ps.identifier)
 		}
+		ps.counters.RecordPersisterHit()
+	} else {
+		ps.counters.RecordCacheHit()
 	}
 
 	return v.([]byte), nil
 }
 
-// Close will close PruningStorer
+// Close will close PruningStorer. It waits for the archiving in progress to finish
 func (ps *PruningStorer) Close() error {
+	ps.stopArchiving()
+
+	ps.lock.Lock()
+	ps.closeOpenedPersisters()
+	ps.lock.Unlock()
+
 	closedSuccessfully := true
 	for _, persister := range ps.activePersisters {
 		err := persister.persister.Close()
@@ -226,49 +326,83 @@ func (ps *PruningStorer) Close() error {
 	return storage.ErrClosingPersisters
 }
 
+// Flush is called when a block is committed. If the storer was configured to be flushed on commit, the writes
+// buffered by the active persisters are made durable, otherwise they are left to be written by the persisters' batch
+// timers
+func (ps *PruningStorer) Flush() error {
+	if !ps.flushOnCommit {
+		return nil
+	}
+
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	for _, pd := range ps.activePersisters {
+		flusher, ok := pd.persister.(storage.Flusher)
+		if !ok || pd.isClosed {
+			continue
+		}
+
+		err := flusher.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetFromEpoch will search a key only in the persister for the given epoch
 func (ps *PruningStorer) GetFromEpoch(key []byte, epoch uint32) ([]byte, error) {
+	res, archivedPd, err := ps.getFromNotArchivedEpoch(key, epoch)
+	if archivedPd == nil {
+		return res, err
+	}
+
+	res, err = ps.getFromArchive(key, archivedPd)
+	if err == nil {
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("key %s not found in %s",
+		base64.StdEncoding.EncodeToString(key), ps.identifier)
+}
+
+// getFromNotArchivedEpoch searches the key in the cache and in the persister of the epoch. If the epoch is archived,
+// its persister data is returned instead, so that the archive is read without holding the storer's lock
+func (ps *PruningStorer) getFromNotArchivedEpoch(key []byte, epoch uint32) ([]byte, *persisterData, error) {
 	// TODO: this will be used when requesting from resolvers
 	ps.lock.Lock()
 	defer ps.lock.Unlock()
 
 	v, ok := ps.cacher.Get(key)
 	if ok {
-		return v.([]byte), nil
+		return v.([]byte), nil, nil
 	}
 
 	pd, exists := ps.persistersMapByEpoch[epoch]
 	if !exists {
-		return nil, fmt.Errorf("key %s not found in %s",
+		return nil, nil, fmt.Errorf("key %s not found in %s",
 			base64.StdEncoding.EncodeToString(key), ps.identifier)
 	}
-
-	if !pd.isClosed {
-		return pd.persister.Get(key)
+	if pd.isArchived {
+		return nil, pd, nil
 	}
 
-	persister, err := ps.persisterFactory.Create(pd.path)
-	if err != nil {
-		log.Debug("open old persister", "error", err.Error())
-		return nil, err
+	if !pd.isClosed {
+		res, err := pd.persister.Get(key)
+		return res, nil, err
 	}
 
-	defer func() {
-		err = persister.Close()
-		if err != nil {
-			log.Debug("persister.Close()", "error", err.Error())
-		}
-	}()
-
-	err = persister.Init()
+	persister, closePersister, err := ps.getClosedPersister(pd, epoch)
 	if err != nil {
-		log.Debug("init old persister", "error", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
+	defer closePersister()
 
 	res, err := persister.Get(key)
 	if err == nil {
-		return res, nil
+		return res, nil, nil
 	}
 
 	log.Warn("get from closed persister",
@@ -277,36 +411,107 @@ func (ps *PruningStorer) GetFromEpoch(key []byte, epoch uint32) ([]byte, error)
 		"key", key,
 		"error", err.Error())
 
-	return nil, fmt.Errorf("key %s not found in %s",
+	return nil, nil, fmt.Errorf("key %s not found in %s",
 		base64.StdEncoding.EncodeToString(key), ps.identifier)
-
 }
 
-// SearchFirst will search a given key in all the active persisters, from the newest to the oldest
+// SearchFirst will search a given key in all the active persisters, from the newest to the oldest. When the archive
+// is enabled, the closed persisters and then the archived ones are searched as well. An archive is opened only if
+// its bloom filter may contain the key, so a missing key seldom opens the archives
 func (ps *PruningStorer) SearchFirst(key []byte) ([]byte, error) {
+	res, archivedPds, err := ps.searchFirstInNotArchivedEpochs(key)
+	if err == nil {
+		return res, nil
+	}
+
+	for _, pd := range archivedPds {
+		res, err = ps.getFromArchive(key, pd)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w - SearchFirst, unit = %s, key = %s, num searched archives = %d",
+		storage.ErrKeyNotFound,
+		ps.identifier,
+		base64.StdEncoding.EncodeToString(key),
+		len(archivedPds),
+	)
+}
+
+// searchFirstInNotArchivedEpochs searches the key in the cache, the active persisters and, when the archive is
+// enabled, the closed persisters not archived yet. If the key is not found, the archived epochs which may hold it
+// are returned, from the newest to the oldest
+func (ps *PruningStorer) searchFirstInNotArchivedEpochs(key []byte) ([]byte, []*persisterData, error) {
 	ps.lock.Lock()
 	defer ps.lock.Unlock()
 
 	v, ok := ps.cacher.Get(key)
 	if ok {
-		return v.([]byte), nil
+		return v.([]byte), nil, nil
 	}
 
-	var res []byte
-	var err error
 	for _, pd := range ps.activePersisters {
-		res, err = pd.persister.Get(key)
+		res, err := pd.persister.Get(key)
 		if err == nil {
-			return res, nil
+			return res, nil, nil
 		}
 	}
 
-	return nil, fmt.Errorf("%w - SearchFirst, unit = %s, key = %s, num active persisters = %d",
-		storage.ErrKeyNotFound,
-		ps.identifier,
-		base64.StdEncoding.EncodeToString(key),
-		len(ps.activePersisters),
-	)
+	if !ps.archiveEnabled {
+		return nil, nil, storage.ErrKeyNotFound
+	}
+
+	res, err := ps.searchInClosedPersisters(key)
+	if err == nil {
+		return res, nil, nil
+	}
+
+	return nil, ps.getArchivesMayContain(key), storage.ErrKeyNotFound
+}
+
+// Iterate walks the pairs in the provided range across all the active persisters, from the newest epoch to the
+// oldest. The keys are in ascending order within an epoch and a key found in several epochs is visited only once,
+// with the value from the newest epoch: a key of an older epoch is skipped if a newer persister has it, so the
+// iteration does not keep the visited keys in memory
+func (ps *PruningStorer) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	ps.lock.RLock()
+	persisters := make([]storage.Persister, 0, len(ps.activePersisters))
+	for _, pd := range ps.activePersisters {
+		persisters = append(persisters, pd.persister)
+	}
+	ps.lock.RUnlock()
+
+	shouldContinue := true
+	for i, persister := range persisters {
+		newerPersisters := persisters[:i]
+		err := persister.Iterate(keyRange, func(key []byte, val []byte) bool {
+			if isInAnyPersister(newerPersisters, key) {
+				return true
+			}
+
+			shouldContinue = handler(key, val)
+			return shouldContinue
+		})
+		if err != nil {
+			return err
+		}
+		if !shouldContinue {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func isInAnyPersister(persisters []storage.Persister, key []byte) bool {
+	for _, persister := range persisters {
+		if persister.Has(key) == nil {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Has checks if the key is in the Unit.
@@ -338,48 +543,55 @@ func (ps *PruningStorer) Has(key []byte) error {
 // It first checks the cache. If it is not found, it checks the bloom filter
 // and if present it checks the db
 func (ps *PruningStorer) HasInEpoch(key []byte, epoch uint32) error {
+	archivedPd, err := ps.hasInNotArchivedEpoch(key, epoch)
+	if archivedPd == nil {
+		return err
+	}
+
+	persister, release, err := ps.acquireArchive(archivedPd)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return persister.Has(key)
+}
+
+// hasInNotArchivedEpoch checks the key in the cache and in the persister of the epoch. If the epoch is archived, its
+// persister data is returned instead, so that the archive is read without holding the storer's lock
+func (ps *PruningStorer) hasInNotArchivedEpoch(key []byte, epoch uint32) (*persisterData, error) {
 	// TODO: this will be used when requesting from resolvers
-	ps.lock.RLock()
-	defer ps.lock.RUnlock()
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
 
 	has := ps.cacher.Has(key)
 	if has {
-		return nil
+		return nil, nil
 	}
 
-	if ps.bloomFilter == nil || ps.bloomFilter.MayContain(key) {
-		pd, ok := ps.persistersMapByEpoch[epoch]
-		if !ok {
-			return storage.ErrKeyNotFound
-		}
-
-		if !pd.isClosed {
-			return pd.persister.Has(key)
-		}
-
-		persister, err := ps.persisterFactory.Create(pd.path)
-		if err != nil {
-			log.Debug("open old persister", "error", err.Error())
-			return err
-		}
+	if ps.bloomFilter != nil && !ps.bloomFilter.MayContain(key) {
+		return nil, storage.ErrKeyNotFound
+	}
 
-		defer func() {
-			err = persister.Close()
-			if err != nil {
-				log.Debug("persister.Close()", "error", err.Error())
-			}
-		}()
+	pd, ok := ps.persistersMapByEpoch[epoch]
+	if !ok {
+		return nil, storage.ErrKeyNotFound
+	}
+	if pd.isArchived {
+		return pd, nil
+	}
 
-		err = persister.Init()
-		if err != nil {
-			log.Debug("init old persister", "error", err.Error())
-			return err
-		}
+	if !pd.isClosed {
+		return nil, pd.persister.Has(key)
+	}
 
-		return persister.Has(key)
+	persister, closePersister, err := ps.getClosedPersister(pd, epoch)
+	if err != nil {
+		return nil, err
 	}
+	defer closePersister()
 
-	return storage.ErrKeyNotFound
+	return nil, persister.Has(key)
 }
 
 // Remove removes the data associated to the given key from both cache and persistence medium
@@ -404,8 +616,10 @@ func (ps *PruningStorer) ClearCache() {
 	ps.cacher.Clear()
 }
 
-// DestroyUnit cleans up the bloom filter, the cache, and the dbs
+// DestroyUnit cleans up the bloom filter, the cache, and the dbs. It waits for the archiving in progress to finish
 func (ps *PruningStorer) DestroyUnit() error {
+	ps.stopArchiving()
+
 	ps.lock.Lock()
 	defer ps.lock.Unlock()
 
@@ -414,11 +628,18 @@ func (ps *PruningStorer) DestroyUnit() error {
 	}
 
 	ps.cacher.Clear()
+	ps.closeOpenedPersisters()
 
 	var err error
 	numOfPersistersRemoved := 0
 	totalNumOfPersisters := len(ps.persistersMapByEpoch)
 	for _, pd := range ps.persistersMapByEpoch {
+		if pd.isArchived {
+			// the archive is kept as it lives outside the node's database directory
+			numOfPersistersRemoved++
+			continue
+		}
+
 		if pd.isClosed {
 			err = pd.persister.DestroyClosed()
 		} else {
@@ -479,6 +700,7 @@ func (ps *PruningStorer) changeEpoch(epoch uint32) error {
 	newPersister := &persisterData{
 		persister: db,
 		path:      filePath,
+		epoch:     epoch,
 		isClosed:  false,
 	}
 
@@ -516,6 +738,11 @@ func (ps *PruningStorer) closeAndDestroyPersisters(epoch uint32) error {
 		ps.persistersMapByEpoch[epochToClose] = persisterToClose
 	}
 
+	if !ps.fullArchive && ps.archiveEnabled {
+		ps.startArchiving(epoch)
+		return nil
+	}
+
 	if !ps.fullArchive && uint32(len(ps.persistersMapByEpoch)) > ps.numOfEpochsToKeep {
 		epochToRemove := epoch - ps.numOfEpochsToKeep
 		persisterToDestroy, ok := ps.persistersMapByEpoch[epochToRemove]
@@ -534,6 +761,476 @@ func (ps *PruningStorer) closeAndDestroyPersisters(epoch uint32) error {
 	return nil
 }
 
+// startArchiving archives, in background, the persister which is no longer kept. The persister remains readable from
+// its place until its archive is written, then it is replaced in the epochs map by the archived one. If archiving
+// fails, the persister is left closed in its place so no data is lost
+func (ps *PruningStorer) startArchiving(epoch uint32) {
+	if epoch < ps.numOfEpochsToKeep || ps.isArchivingStopped {
+		return
+	}
+
+	epochToArchive := epoch - ps.numOfEpochsToKeep
+	pd, ok := ps.persistersMapByEpoch[epochToArchive]
+	if !ok || !pd.isClosed || pd.isArchived || pd.isArchiving {
+		return
+	}
+	pd.isArchiving = true
+
+	ps.archiveWg.Add(1)
+	go func() {
+		defer ps.archiveWg.Done()
+		ps.archivePersister(pd, epochToArchive)
+	}()
+}
+
+// stopArchiving prevents new archiving from being started and waits for the one in progress to finish
+func (ps *PruningStorer) stopArchiving() {
+	ps.lock.Lock()
+	ps.isArchivingStopped = true
+	ps.lock.Unlock()
+
+	ps.archiveWg.Wait()
+}
+
+func (ps *PruningStorer) archivePersister(pd *persisterData, epoch uint32) {
+	archivedPd, err := ps.writeArchive(pd.path, epoch)
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	pd.isArchiving = false
+	if err != nil {
+		log.Warn("archive persister",
+			"id", ps.identifier,
+			"epoch", epoch,
+			"error", err.Error())
+		return
+	}
+
+	ps.closeOpenedPersister(pd, epoch)
+	ps.persistersMapByEpoch[epoch] = archivedPd
+
+	err = pd.persister.DestroyClosed()
+	if err != nil {
+		log.Debug("destroy archived persister", "path", pd.path, "error", err.Error())
+		return
+	}
+	removeDirectoryIfEmpty(pd.path)
+
+	log.Debug("persister archived", "id", ps.identifier, "epoch", epoch, "path", archivedPd.path)
+}
+
+// writeArchive copies the closed persister in a staging directory next to its archive path, compacts the copy and
+// then moves it, or its compressed form, to the archive path. The bloom filter of the archived keys is saved next to
+// the archive before the archive is put in place. The closed persister is only read
+func (ps *PruningStorer) writeArchive(path string, epoch uint32) (*persisterData, error) {
+	shardId := core.GetShardIdString(ps.shardCoordinator.SelfId())
+	archivePath := ps.archivePathManager.PathForEpoch(shardId, epoch, ps.identifier)
+	stagingPath := archivePath + stagingExtension
+	defer func() {
+		_ = os.RemoveAll(stagingPath)
+	}()
+
+	err := os.RemoveAll(stagingPath)
+	if err != nil {
+		return nil, err
+	}
+	err = copyDirectory(path, stagingPath)
+	if err != nil {
+		return nil, err
+	}
+
+	keysFilter, err := ps.prepareArchive(stagingPath, archivePath+archiveBloomFilterExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ps.compressArchive {
+		err = replacePath(stagingPath, archivePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return &persisterData{
+			path:       archivePath,
+			epoch:      epoch,
+			isClosed:   true,
+			isArchived: true,
+			keysFilter: keysFilter,
+		}, nil
+	}
+
+	archiveFile := archivePath + archiveFileExtension
+	stagingFile := archiveFile + stagingExtension
+	err = compressDirectory(stagingPath, stagingFile)
+	if err == nil {
+		err = replacePath(stagingFile, archiveFile)
+	}
+	if err != nil {
+		_ = os.Remove(stagingFile)
+		return nil, err
+	}
+
+	return &persisterData{
+		path:         archiveFile,
+		epoch:        epoch,
+		isClosed:     true,
+		isArchived:   true,
+		isCompressed: true,
+		keysFilter:   keysFilter,
+	}, nil
+}
+
+// prepareArchive compacts the staging copy of a persister and saves the bloom filter of its keys in the provided
+// file. A failed compaction only affects the archive's size, so the persister is archived anyway
+func (ps *PruningStorer) prepareArchive(stagingPath string, bloomFilePath string) (storage.BloomFilter, error) {
+	persister, err := ps.persisterFactory.Create(stagingPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = persister.Close()
+	}()
+
+	c, ok := persister.(compacter)
+	if ok {
+		err = c.Compact()
+		if err != nil {
+			log.Debug("compact persister before archiving", "path", stagingPath, "error", err.Error())
+		}
+	}
+
+	numKeys := 0
+	err = persister.Iterate(nil, func(_ []byte, _ []byte) bool {
+		numKeys++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conf := storageUnit.BloomConfig{
+		Size:     newArchiveBloomFilterSize(numKeys),
+		HashFunc: archiveBloomFilterHashFunc,
+	}
+	keysFilter, err := storageUnit.NewBloomFilter(conf)
+	if err != nil {
+		return nil, err
+	}
+	err = persister.Iterate(nil, func(key []byte, _ []byte) bool {
+		keysFilter.Add(key)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	persistableFilter, ok := keysFilter.(storage.PersistableBloomFilter)
+	if !ok {
+		return nil, storage.ErrNotSupportedBloomFilterType
+	}
+
+	err = storageUnit.SaveBloomFilterFile(bloomFilePath, conf, persistableFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return keysFilter, nil
+}
+
+func newArchiveBloomFilterSize(numKeys int) uint {
+	size := uint(numKeys) * archiveBloomFilterBitsPerKey / 8
+	if size < minArchiveBloomFilterSize {
+		return minArchiveBloomFilterSize
+	}
+
+	return size
+}
+
+// loadArchiveIndex adds to the epochs map the epochs archived before the node was started, along with the bloom
+// filters of their keys, so that all the lookups find them. The leftovers of an interrupted archiving and the
+// directories of the archives extracted before the node was stopped are removed
+func (ps *PruningStorer) loadArchiveIndex(startingEpoch uint32) {
+	shardId := core.GetShardIdString(ps.shardCoordinator.SelfId())
+	for epoch := uint32(0); epoch < startingEpoch; epoch++ {
+		archivePath := ps.archivePathManager.PathForEpoch(shardId, epoch, ps.identifier)
+		_ = os.RemoveAll(archivePath + stagingExtension)
+		_ = os.Remove(archivePath + archiveFileExtension + stagingExtension)
+		removeExtractedArchives(archivePath)
+
+		_, exists := ps.persistersMapByEpoch[epoch]
+		if exists {
+			continue
+		}
+
+		pd := &persisterData{
+			path:       archivePath,
+			epoch:      epoch,
+			isClosed:   true,
+			isArchived: true,
+		}
+		_, err := os.Stat(archivePath)
+		if err != nil {
+			pd.path = archivePath + archiveFileExtension
+			pd.isCompressed = true
+			_, err = os.Stat(pd.path)
+		}
+		if err != nil {
+			continue
+		}
+
+		pd.keysFilter, err = storageUnit.LoadBloomFilterFile(archivePath + archiveBloomFilterExtension)
+		if err != nil {
+			log.Debug("archive without bloom filter, it will be searched for any key",
+				"path", pd.path,
+				"error", err.Error())
+		}
+		ps.persistersMapByEpoch[epoch] = pd
+	}
+}
+
+func removeExtractedArchives(archivePath string) {
+	extractedPaths, err := filepath.Glob(archivePath + extractedExtension + "*")
+	if err != nil {
+		return
+	}
+
+	for _, extractedPath := range extractedPaths {
+		_ = os.RemoveAll(extractedPath)
+	}
+}
+
+// getClosedPersister returns the persister of a closed epoch which is not archived. When the archive is enabled, the
+// closed persisters are opened read-only and kept open until they are archived. The returned function has to be
+// called once the persister is no longer needed
+func (ps *PruningStorer) getClosedPersister(pd *persisterData, epoch uint32) (storage.Persister, func(), error) {
+	if !ps.archiveEnabled {
+		return ps.openClosedPersister(pd)
+	}
+
+	if pd.opened == nil {
+		persister, closePersister, err := ps.openClosedPersister(pd)
+		if err != nil {
+			return nil, nil, err
+		}
+		pd.opened = &openedPersister{persister: persister, close: closePersister}
+	}
+
+	return pd.opened.persister, func() {}, nil
+}
+
+// getFromArchive reads the key from an archived epoch
+func (ps *PruningStorer) getFromArchive(key []byte, pd *persisterData) ([]byte, error) {
+	persister, release, err := ps.acquireArchive(pd)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return persister.Get(key)
+}
+
+// acquireArchive returns the opened instance of an archived persister, opening it if needed. The archive is opened,
+// and extracted if compressed, while holding only the archive's own lock, so the lookups in the other epochs are not
+// blocked meanwhile. The returned function has to be called once the persister is no longer needed
+func (ps *PruningStorer) acquireArchive(pd *persisterData) (storage.Persister, func(), error) {
+	pd.openMut.Lock()
+	defer pd.openMut.Unlock()
+
+	ps.lock.Lock()
+	if pd.opened != nil {
+		opened := ps.useArchive(pd)
+		ps.lock.Unlock()
+
+		return opened.persister, ps.releaseArchive(opened), nil
+	}
+	ps.lock.Unlock()
+
+	persister, closePersister, err := ps.openClosedPersister(pd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	pd.opened = &openedPersister{persister: persister, close: closePersister}
+	opened := ps.useArchive(pd)
+	if ps.isArchivingStopped {
+		// the storer was closed while the archive was being opened, so the archive is closed after this lookup
+		ps.closeOpenedPersister(pd, pd.epoch)
+	}
+
+	return persister, ps.releaseArchive(opened), nil
+}
+
+func (ps *PruningStorer) useArchive(pd *persisterData) *openedPersister {
+	pd.opened.numUsers++
+	ps.markArchiveAsUsed(pd.epoch)
+
+	return pd.opened
+}
+
+func (ps *PruningStorer) releaseArchive(opened *openedPersister) func() {
+	return func() {
+		ps.lock.Lock()
+		opened.numUsers--
+		shouldClose := opened.isEvicted && opened.numUsers == 0
+		ps.lock.Unlock()
+
+		if shouldClose {
+			opened.close()
+		}
+	}
+}
+
+func (ps *PruningStorer) markArchiveAsUsed(epoch uint32) {
+	ps.removeFromOpenedArchives(epoch)
+	ps.openedArchives = append(ps.openedArchives, epoch)
+
+	for len(ps.openedArchives) > ps.maxOpenedArchives {
+		leastRecentlyUsed := ps.openedArchives[0]
+		ps.closeOpenedPersister(ps.persistersMapByEpoch[leastRecentlyUsed], leastRecentlyUsed)
+	}
+}
+
+func (ps *PruningStorer) removeFromOpenedArchives(epoch uint32) {
+	for i, openedEpoch := range ps.openedArchives {
+		if openedEpoch == epoch {
+			ps.openedArchives = append(ps.openedArchives[:i], ps.openedArchives[i+1:]...)
+			return
+		}
+	}
+}
+
+// closeOpenedPersister evicts the opened instance of a closed persister. The instance is closed at once if no lookup
+// uses it, otherwise when the last lookup using it is done
+func (ps *PruningStorer) closeOpenedPersister(pd *persisterData, epoch uint32) {
+	ps.removeFromOpenedArchives(epoch)
+	if pd == nil || pd.opened == nil {
+		return
+	}
+
+	opened := pd.opened
+	pd.opened = nil
+	opened.isEvicted = true
+	if opened.numUsers == 0 {
+		opened.close()
+	}
+}
+
+func (ps *PruningStorer) closeOpenedPersisters() {
+	for epoch, pd := range ps.persistersMapByEpoch {
+		ps.closeOpenedPersister(pd, epoch)
+	}
+}
+
+// openClosedPersister opens a persister which is no longer active. The archived persisters, and all the closed ones
+// when the archive is enabled, are opened read-only. The compressed ones are extracted in a new directory next to
+// the archive, removed when the persister is closed. The returned function has to be called once the persister is no
+// longer needed
+func (ps *PruningStorer) openClosedPersister(pd *persisterData) (storage.Persister, func(), error) {
+	path := pd.path
+	removeExtractedDir := func() {}
+	if pd.isCompressed {
+		archivePath := strings.TrimSuffix(pd.path, archiveFileExtension)
+		extractedDir, err := ioutil.TempDir(filepath.Dir(archivePath), filepath.Base(archivePath)+extractedExtension)
+		if err != nil {
+			return nil, nil, err
+		}
+		removeExtractedDir = func() {
+			_ = os.RemoveAll(extractedDir)
+		}
+
+		err = extractArchive(pd.path, extractedDir)
+		if err != nil {
+			log.Debug("extract archived persister", "path", pd.path, "error", err.Error())
+			removeExtractedDir()
+			return nil, nil, err
+		}
+		path = extractedDir
+	}
+
+	var persister storage.Persister
+	var err error
+	if pd.isArchived || ps.archiveEnabled {
+		persister, err = ps.persisterFactory.CreateReadOnly(path)
+	} else {
+		persister, err = ps.persisterFactory.Create(path)
+	}
+	if err != nil {
+		log.Debug("open old persister", "error", err.Error())
+		removeExtractedDir()
+		return nil, nil, err
+	}
+
+	closePersister := func() {
+		errClose := persister.Close()
+		if errClose != nil {
+			log.Debug("persister.Close()", "error", errClose.Error())
+		}
+		removeExtractedDir()
+	}
+
+	err = persister.Init()
+	if err != nil {
+		log.Debug("init old persister", "error", err.Error())
+		closePersister()
+		return nil, nil, err
+	}
+
+	return persister, closePersister, nil
+}
+
+// getArchivesMayContain returns the archived epochs whose bloom filter may contain the key, from the newest to the
+// oldest
+func (ps *PruningStorer) getArchivesMayContain(key []byte) []*persisterData {
+	archivedPds := make([]*persisterData, 0)
+	for _, pd := range ps.persistersMapByEpoch {
+		if pd.isArchived && pd.mayContain(key) {
+			archivedPds = append(archivedPds, pd)
+		}
+	}
+	sort.Slice(archivedPds, func(i, j int) bool {
+		return archivedPds[i].epoch > archivedPds[j].epoch
+	})
+
+	return archivedPds
+}
+
+// searchInClosedPersisters searches the key in the closed persisters not archived yet, from the newest to the oldest
+func (ps *PruningStorer) searchInClosedPersisters(key []byte) ([]byte, error) {
+	epochs := make([]uint32, 0, len(ps.persistersMapByEpoch))
+	for epoch, pd := range ps.persistersMapByEpoch {
+		if pd.isClosed && !pd.isArchived {
+			epochs = append(epochs, epoch)
+		}
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] > epochs[j]
+	})
+
+	for _, epoch := range epochs {
+		persister, closePersister, err := ps.getClosedPersister(ps.persistersMapByEpoch[epoch], epoch)
+		if err != nil {
+			continue
+		}
+
+		res, err := persister.Get(key)
+		closePersister()
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	return nil, storage.ErrKeyNotFound
+}
+
+// Stats returns the counters of the lookups done with Get in the active persisters
+func (ps *PruningStorer) Stats() storage.UnitStats {
+	return ps.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (ps *PruningStorer) IsInterfaceNil() bool {
 	return ps == nil
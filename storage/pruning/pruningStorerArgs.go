@@ -22,4 +22,9 @@ type StorerArgs struct {
 	PruningEnabled        bool
 	FullArchive           bool
 	MaxBatchSize          int
+	ArchiveEnabled        bool
+	CompressArchive       bool
+	ArchivePathManager    storage.PathManagerHandler
+	MaxOpenedArchives     uint32
+	FlushOnCommit         bool
 }
@@ -4,14 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/ElrondNetwork/elrond-go/storage/factory"
+	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 	"github.com/ElrondNetwork/elrond-go/storage/mock"
+	"github.com/ElrondNetwork/elrond-go/storage/pathmanager"
 	"github.com/ElrondNetwork/elrond-go/storage/pruning"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
@@ -114,6 +121,18 @@ func TestNewPruningStorer_NilPersisterFactoryShouldErr(t *testing.T) {
 	assert.Equal(t, storage.ErrNilPersisterFactory, err)
 }
 
+func TestNewPruningStorer_ArchiveEnabledWithNilArchivePathManagerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := getDefaultArgs()
+	args.ArchiveEnabled = true
+	args.ArchivePathManager = nil
+	ps, err := pruning.NewPruningStorer(args)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, storage.ErrNilArchivePathManager, err)
+}
+
 func TestNewPruningStorer_CacheSizeLowerThanBatchSizeShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -173,6 +192,24 @@ func TestPruningStorer_PutAndGetShouldWork(t *testing.T) {
 	assert.Equal(t, testVal, res)
 }
 
+func TestPruningStorer_GetShouldCountLookups(t *testing.T) {
+	t.Parallel()
+
+	ps, _ := pruning.NewPruningStorer(getDefaultArgs())
+	_ = ps.Put([]byte("key"), []byte("value"))
+	_, _ = ps.Get([]byte("key"))
+	ps.ClearCache()
+	_, _ = ps.Get([]byte("key"))
+	_, _ = ps.Get([]byte("missing"))
+
+	stats := ps.Stats()
+	assert.Equal(t, uint64(3), stats.NumGets)
+	assert.Equal(t, uint64(1), stats.NumCacheHits)
+	assert.Equal(t, uint64(1), stats.NumPersisterHits)
+	assert.Equal(t, uint64(0), stats.NumBloomFilterMiss)
+	assert.Equal(t, uint64(1), stats.NumMisses)
+}
+
 func TestPruningStorer_RemoveShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -493,3 +530,494 @@ func TestDirectories(t *testing.T) {
 
 	_ = os.RemoveAll("user-directory")
 }
+
+func TestPruningStorer_IterateShouldWalkAllActivePersisters(t *testing.T) {
+	t.Parallel()
+
+	args := getDefaultArgs()
+	args.NumOfActivePersisters = 2
+	args.NumOfEpochsToKeep = 3
+	ps, _ := pruning.NewPruningStorer(args)
+
+	_ = ps.Put([]byte("key1"), []byte("epoch0"))
+	_ = ps.Put([]byte("key2"), []byte("epoch0"))
+	_ = ps.ChangeEpoch(1)
+	_ = ps.Put([]byte("key2"), []byte("epoch1"))
+	_ = ps.Put([]byte("key3"), []byte("epoch1"))
+
+	pairs := make(map[string]string)
+	keys := make([]string, 0)
+	err := ps.Iterate(nil, func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		pairs[string(key)] = string(val)
+		return true
+	})
+	assert.Nil(t, err)
+
+	// newest epoch first, each key visited once, with the newest value
+	assert.Equal(t, []string{"key2", "key3", "key1"}, keys)
+	assert.Equal(t, map[string]string{"key1": "epoch0", "key2": "epoch1", "key3": "epoch1"}, pairs)
+
+	numCalls := 0
+	err = ps.Iterate(nil, func(key []byte, val []byte) bool {
+		numCalls++
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
+
+func createPathManager(t *testing.T, rootDir string) storage.PathManagerHandler {
+	pathManager, err := pathmanager.NewPathManager(
+		filepath.Join(rootDir, "Epoch_[E]", "Shard_[S]", "[I]"),
+		filepath.Join(rootDir, "Static", "Shard_[S]", "[I]"))
+	assert.Nil(t, err)
+
+	return pathManager
+}
+
+func getArchiveArgs(t *testing.T, dbDir string, archiveDir string, compress bool) *pruning.StorerArgs {
+	args := getDefaultArgs()
+	args.PathManager = createPathManager(t, dbDir)
+	args.PersisterFactory = factory.NewPersisterFactory(config.DBConfig{
+		Type:              string(storageUnit.LvlDbSerial),
+		BatchDelaySeconds: 2,
+		MaxBatchSize:      1,
+		MaxOpenFiles:      10,
+	})
+	args.NumOfEpochsToKeep = 2
+	args.NumOfActivePersisters = 1
+	args.ArchiveEnabled = true
+	args.CompressArchive = compress
+	args.ArchivePathManager = createPathManager(t, archiveDir)
+
+	return args
+}
+
+func TestPruningStorer_ArchivedPersisterShouldBeReadable(t *testing.T) {
+	t.Parallel()
+
+	testArchivedPersisterShouldBeReadable(t, false)
+}
+
+func TestPruningStorer_CompressedArchivedPersisterShouldBeReadable(t *testing.T) {
+	t.Parallel()
+
+	testArchivedPersisterShouldBeReadable(t, true)
+}
+
+func testArchivedPersisterShouldBeReadable(t *testing.T, compress bool) {
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, compress)
+	ps, err := pruning.NewPruningStorer(args)
+	assert.Nil(t, err)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	err = ps.Put(testKey, testVal)
+	assert.Nil(t, err)
+
+	for epoch := uint32(1); epoch <= 3; epoch++ {
+		err = ps.ChangeEpoch(epoch)
+		assert.Nil(t, err)
+	}
+	ps.WaitForArchiving()
+	ps.ClearCache()
+
+	_, err = os.Stat(filepath.Join(dbDir, "Epoch_0"))
+	assert.True(t, os.IsNotExist(err))
+
+	archivePath := filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id")
+	if compress {
+		archivePath += ".tar.gz"
+	}
+	_, err = os.Stat(archivePath)
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id.bloom"))
+	assert.Nil(t, err)
+
+	res, err := ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	assert.Nil(t, ps.HasInEpoch(testKey, 0))
+
+	ps.ClearCache()
+	res, err = ps.SearchFirst(testKey)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	_ = ps.Close()
+}
+
+func TestPruningStorer_EncryptedArchivedPersisterShouldBeReadable(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, false)
+	args.PersisterFactory = factory.NewEncryptedPersisterFactory(config.DBConfig{
+		Type:              string(storageUnit.LvlDbSerial),
+		BatchDelaySeconds: 2,
+		MaxBatchSize:      1,
+		MaxOpenFiles:      10,
+	}, make([]byte, encryption.KeySize))
+	ps, err := pruning.NewPruningStorer(args)
+	assert.Nil(t, err)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	err = ps.Put(testKey, testVal)
+	assert.Nil(t, err)
+
+	for epoch := uint32(1); epoch <= 3; epoch++ {
+		err = ps.ChangeEpoch(epoch)
+		assert.Nil(t, err)
+	}
+	ps.WaitForArchiving()
+	ps.ClearCache()
+
+	res, err := ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	_ = ps.Close()
+}
+
+func TestPruningStorer_ArchivedPersisterShouldBeFoundAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, true)
+	ps, _ := pruning.NewPruningStorer(args)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	_ = ps.Put(testKey, testVal)
+	for epoch := uint32(1); epoch <= 3; epoch++ {
+		_ = ps.ChangeEpoch(epoch)
+	}
+	_ = ps.Close()
+
+	args = getArchiveArgs(t, dbDir, archiveDir, true)
+	args.StartingEpoch = 3
+	ps, err := pruning.NewPruningStorer(args)
+	assert.Nil(t, err)
+
+	assert.Nil(t, ps.HasInEpoch(testKey, 0))
+	res, err := ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	ps.ClearCache()
+	res, err = ps.SearchFirst(testKey)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	_ = ps.Close()
+}
+
+func TestPruningStorer_ArchivingShouldNotBlockTheStorer(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, false)
+	dbFactory := args.PersisterFactory
+	chCompacting := make(chan struct{}, 1)
+	chRelease := make(chan struct{})
+	args.PersisterFactory = &mock.PersisterFactoryStub{
+		CreateCalled: func(path string) (storage.Persister, error) {
+			if strings.HasSuffix(path, ".staging") {
+				chCompacting <- struct{}{}
+				<-chRelease
+			}
+			return dbFactory.Create(path)
+		},
+		CreateReadOnlyCalled: dbFactory.CreateReadOnly,
+	}
+	ps, _ := pruning.NewPruningStorer(args)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	_ = ps.Put(testKey, testVal)
+	for epoch := uint32(1); epoch <= 2; epoch++ {
+		_ = ps.ChangeEpoch(epoch)
+	}
+	<-chCompacting
+
+	// the archived epoch is still readable from its place while its archive is written
+	ps.ClearCache()
+	res, err := ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+	assert.Nil(t, ps.Put([]byte("key2"), testVal))
+
+	close(chRelease)
+	ps.WaitForArchiving()
+
+	_, err = os.Stat(filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id.staging"))
+	assert.True(t, os.IsNotExist(err))
+	res, err = ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	_ = ps.Close()
+}
+
+func TestPruningStorer_CompressedArchiveShouldBeExtractedOnce(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, true)
+	dbFactory := args.PersisterFactory
+	numReadOnlyOpens := 0
+	args.PersisterFactory = &mock.PersisterFactoryStub{
+		CreateCalled: dbFactory.Create,
+		CreateReadOnlyCalled: func(path string) (storage.Persister, error) {
+			numReadOnlyOpens++
+			return dbFactory.CreateReadOnly(path)
+		},
+	}
+	ps, _ := pruning.NewPruningStorer(args)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	_ = ps.Put(testKey, testVal)
+	for epoch := uint32(1); epoch <= 3; epoch++ {
+		_ = ps.ChangeEpoch(epoch)
+	}
+	ps.WaitForArchiving()
+	ps.ClearCache()
+
+	// the first lookups open the archive of epoch 0 and the closed, not archived yet, persister of epoch 2
+	res, err := ps.GetFromEpoch(testKey, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+	_, _ = ps.SearchFirst([]byte("missing key"))
+	assert.Equal(t, 2, numReadOnlyOpens)
+
+	for i := 0; i < 3; i++ {
+		res, err = ps.GetFromEpoch(testKey, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, testVal, res)
+		_, _ = ps.SearchFirst([]byte("missing key"))
+	}
+	assert.Equal(t, 2, numReadOnlyOpens)
+
+	_ = ps.Close()
+}
+
+func TestPruningStorer_SearchFirstShouldNotOpenTheArchivesWithoutTheKey(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, true)
+	dbFactory := args.PersisterFactory
+	openedPaths := make([]string, 0)
+	args.PersisterFactory = &mock.PersisterFactoryStub{
+		CreateCalled: dbFactory.Create,
+		CreateReadOnlyCalled: func(path string) (storage.Persister, error) {
+			openedPaths = append(openedPaths, path)
+			return dbFactory.CreateReadOnly(path)
+		},
+	}
+	ps, _ := pruning.NewPruningStorer(args)
+
+	for epoch := uint32(1); epoch <= 4; epoch++ {
+		_ = ps.Put([]byte(fmt.Sprintf("key%d", epoch-1)), []byte("value"))
+		_ = ps.ChangeEpoch(epoch)
+		ps.WaitForArchiving()
+	}
+	ps.ClearCache()
+
+	for i := 0; i < 100; i++ {
+		_, err := ps.SearchFirst([]byte(fmt.Sprintf("missing key %d", i)))
+		assert.True(t, errors.Is(err, storage.ErrKeyNotFound))
+	}
+	for _, path := range openedPaths {
+		assert.False(t, strings.Contains(path, archiveDir), "archive %s should not have been opened", path)
+	}
+
+	// only the archive of the epoch holding the key is opened
+	res, err := ps.SearchFirst([]byte("key0"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), res)
+	numOpenedArchives := 0
+	for _, path := range openedPaths {
+		if strings.Contains(path, archiveDir) {
+			numOpenedArchives++
+			assert.True(t, strings.HasPrefix(path, filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id.extracted")))
+		}
+	}
+	assert.Equal(t, 1, numOpenedArchives)
+
+	_ = ps.Close()
+	extractedDirs, _ := filepath.Glob(filepath.Join(archiveDir, "Epoch_0", "Shard_0", "id.extracted*"))
+	assert.Equal(t, 0, len(extractedDirs))
+}
+
+func TestPruningStorer_OpeningAnArchiveShouldNotBlockTheStorer(t *testing.T) {
+	t.Parallel()
+
+	dbDir, _ := ioutil.TempDir("", "pruning-db")
+	archiveDir, _ := ioutil.TempDir("", "pruning-archive")
+	defer func() {
+		_ = os.RemoveAll(dbDir)
+		_ = os.RemoveAll(archiveDir)
+	}()
+
+	args := getArchiveArgs(t, dbDir, archiveDir, true)
+	dbFactory := args.PersisterFactory
+	chOpening := make(chan struct{}, 1)
+	chRelease := make(chan struct{})
+	args.PersisterFactory = &mock.PersisterFactoryStub{
+		CreateCalled: dbFactory.Create,
+		CreateReadOnlyCalled: func(path string) (storage.Persister, error) {
+			if strings.Contains(path, ".extracted") {
+				chOpening <- struct{}{}
+				<-chRelease
+			}
+			return dbFactory.CreateReadOnly(path)
+		},
+	}
+	ps, _ := pruning.NewPruningStorer(args)
+
+	testKey, testVal := []byte("key"), []byte("value")
+	_ = ps.Put(testKey, testVal)
+	for epoch := uint32(1); epoch <= 3; epoch++ {
+		_ = ps.ChangeEpoch(epoch)
+	}
+	ps.WaitForArchiving()
+	ps.ClearCache()
+
+	chDone := make(chan struct{})
+	go func() {
+		res, err := ps.GetFromEpoch(testKey, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, testVal, res)
+		close(chDone)
+	}()
+	<-chOpening
+
+	// the storer is usable while the archive of epoch 0 is opened
+	assert.Nil(t, ps.Put([]byte("key2"), testVal))
+	res, err := ps.GetFromEpoch([]byte("key2"), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, testVal, res)
+
+	close(chRelease)
+	<-chDone
+
+	_ = ps.Close()
+}
+
+func TestCompressDirectoryAndExtractArchive(t *testing.T) {
+	t.Parallel()
+
+	sourceDir, _ := ioutil.TempDir("", "source")
+	destinationDir, _ := ioutil.TempDir("", "destination")
+	defer func() {
+		_ = os.RemoveAll(sourceDir)
+		_ = os.RemoveAll(destinationDir)
+	}()
+
+	_ = os.MkdirAll(filepath.Join(sourceDir, "sub"), os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(sourceDir, "file1"), []byte("content1"), 0600)
+	_ = ioutil.WriteFile(filepath.Join(sourceDir, "sub", "file2"), []byte("content2"), 0600)
+
+	archiveFile := filepath.Join(destinationDir, "archive.tar.gz")
+	err := pruning.CompressDirectory(sourceDir, archiveFile)
+	assert.Nil(t, err)
+
+	extractDir := filepath.Join(destinationDir, "extracted")
+	err = pruning.ExtractArchive(archiveFile, extractDir)
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(extractDir, "file1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("content1"), content)
+
+	content, err = ioutil.ReadFile(filepath.Join(extractDir, "sub", "file2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("content2"), content)
+}
+
+func createArgsWithLevelDBPersisters(t *testing.T, flushOnCommit bool) (*pruning.StorerArgs, *[]*leveldb.DB) {
+	persisters := make([]*leveldb.DB, 0)
+	args := getDefaultArgs()
+	args.FlushOnCommit = flushOnCommit
+	args.PersisterFactory = &mock.PersisterFactoryStub{
+		CreateCalled: func(path string) (storage.Persister, error) {
+			dir, _ := ioutil.TempDir("", "leveldb_temp")
+			ldb, err := leveldb.NewDB(dir, 10, 100, 10)
+			assert.Nil(t, err)
+			persisters = append(persisters, ldb)
+
+			return ldb, err
+		},
+	}
+
+	return args, &persisters
+}
+
+func TestPruningStorer_FlushWithoutFlushOnCommitShouldNotWriteThePendingBatch(t *testing.T) {
+	t.Parallel()
+
+	args, persisters := createArgsWithLevelDBPersisters(t, false)
+	ps, _ := pruning.NewPruningStorer(args)
+	key := []byte("key")
+	_ = ps.Put(key, []byte("value"))
+
+	err := ps.Flush()
+
+	assert.Nil(t, err)
+	assert.Equal(t, storage.ErrKeyNotFound, (*persisters)[0].Has(key))
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_FlushWithFlushOnCommitShouldWriteThePendingBatch(t *testing.T) {
+	t.Parallel()
+
+	args, persisters := createArgsWithLevelDBPersisters(t, true)
+	ps, _ := pruning.NewPruningStorer(args)
+	key := []byte("key")
+	_ = ps.Put(key, []byte("value"))
+
+	err := ps.Flush()
+
+	assert.Nil(t, err)
+	assert.Nil(t, (*persisters)[0].Has(key))
+	_ = ps.DestroyUnit()
+}
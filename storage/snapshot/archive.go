@@ -0,0 +1,319 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// ArchiveVersion is the version of the archive format written by this package
+const ArchiveVersion = uint32(1)
+
+// maxFieldLength bounds the length of a single field so a corrupted archive can not trigger huge allocations
+const maxFieldLength = 1 << 28
+
+var archiveMagic = []byte("ERDSNAP")
+
+const (
+	manifestRecord byte = iota + 1
+	pairRecord
+	endRecord
+)
+
+// Manifest describes the block the snapshot was taken at. It is the first record of the archive
+type Manifest struct {
+	Version                uint32
+	ShardID                uint32
+	Round                  int64
+	Nonce                  uint64
+	HeaderHash             []byte
+	RootHash               []byte
+	ValidatorStatsRootHash []byte
+	NumHeaders             uint32
+}
+
+// Pair is a key-value pair read from an archive, together with the unit it has to be written to
+type Pair struct {
+	Unit  string
+	Key   []byte
+	Value []byte
+}
+
+// Writer writes a snapshot archive: a gzip stream holding the manifest followed by the pairs of all the exported
+// units and an end record counting them, so that a truncated archive is detected when read
+type Writer struct {
+	gzipWriter *gzip.Writer
+	writer     *bufio.Writer
+	numPairs   uint64
+}
+
+// NewWriter writes the archive header and the manifest to the provided writer and returns a Writer ready to
+// receive the pairs. The provided writer is not closed by the returned Writer
+func NewWriter(w io.Writer, manifest *Manifest, marshalizer marshal.Marshalizer) (*Writer, error) {
+	if w == nil {
+		return nil, storage.ErrNilSnapshotWriter
+	}
+	if manifest == nil {
+		return nil, storage.ErrNilSnapshotManifest
+	}
+	if check.IfNil(marshalizer) {
+		return nil, storage.ErrNilMarshalizer
+	}
+
+	manifest.Version = ArchiveVersion
+	manifestBytes, err := marshalizer.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	aw := &Writer{
+		gzipWriter: gzipWriter,
+		writer:     bufio.NewWriter(gzipWriter),
+	}
+
+	_, err = aw.writer.Write(archiveMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = aw.writer.WriteByte(manifestRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	err = aw.writeField(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return aw, nil
+}
+
+// Put appends a pair belonging to the given unit
+func (aw *Writer) Put(unit string, key []byte, value []byte) error {
+	err := aw.writer.WriteByte(pairRecord)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range [][]byte{[]byte(unit), key, value} {
+		err = aw.writeField(field)
+		if err != nil {
+			return err
+		}
+	}
+
+	aw.numPairs++
+
+	return nil
+}
+
+// NumPairs returns the number of pairs written so far
+func (aw *Writer) NumPairs() uint64 {
+	return aw.numPairs
+}
+
+// Close writes the end record and flushes the compressed stream
+func (aw *Writer) Close() error {
+	err := aw.writer.WriteByte(endRecord)
+	if err != nil {
+		return err
+	}
+
+	err = aw.writeUvarint(aw.numPairs)
+	if err != nil {
+		return err
+	}
+
+	err = aw.writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	return aw.gzipWriter.Close()
+}
+
+func (aw *Writer) writeField(field []byte) error {
+	err := aw.writeUvarint(uint64(len(field)))
+	if err != nil {
+		return err
+	}
+
+	_, err = aw.writer.Write(field)
+
+	return err
+}
+
+func (aw *Writer) writeUvarint(value uint64) error {
+	buff := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buff, value)
+	_, err := aw.writer.Write(buff[:n])
+
+	return err
+}
+
+// Reader reads the pairs of a snapshot archive, in the order they were written
+type Reader struct {
+	gzipReader *gzip.Reader
+	reader     *bufio.Reader
+	manifest   *Manifest
+	numPairs   uint64
+	finished   bool
+}
+
+// NewReader reads the archive header and the manifest from the provided reader
+func NewReader(r io.Reader, marshalizer marshal.Marshalizer) (*Reader, error) {
+	if r == nil {
+		return nil, storage.ErrNilSnapshotReader
+	}
+	if check.IfNil(marshalizer) {
+		return nil, storage.ErrNilMarshalizer
+	}
+
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, storage.ErrInvalidSnapshotArchive
+	}
+
+	ar := &Reader{
+		gzipReader: gzipReader,
+		reader:     bufio.NewReader(gzipReader),
+	}
+
+	magic := make([]byte, len(archiveMagic))
+	_, err = io.ReadFull(ar.reader, magic)
+	if err != nil || !bytes.Equal(magic, archiveMagic) {
+		return nil, storage.ErrInvalidSnapshotArchive
+	}
+
+	recordType, err := ar.reader.ReadByte()
+	if err != nil || recordType != manifestRecord {
+		return nil, storage.ErrInvalidSnapshotArchive
+	}
+
+	manifestBytes, err := ar.readField()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	err = marshalizer.Unmarshal(manifest, manifestBytes)
+	if err != nil {
+		return nil, storage.ErrInvalidSnapshotArchive
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, storage.ErrUnsupportedSnapshotVersion
+	}
+
+	ar.manifest = manifest
+
+	return ar, nil
+}
+
+// Manifest returns the manifest of the archive
+func (ar *Reader) Manifest() *Manifest {
+	return ar.manifest
+}
+
+// Next returns the next pair of the archive. It returns io.EOF once all the pairs were read and the archive was
+// found to be complete
+func (ar *Reader) Next() (*Pair, error) {
+	if ar.finished {
+		return nil, io.EOF
+	}
+
+	recordType, err := ar.reader.ReadByte()
+	if err != nil {
+		return nil, convertReadError(err)
+	}
+
+	switch recordType {
+	case pairRecord:
+		return ar.readPair()
+	case endRecord:
+		return nil, ar.readEnd()
+	}
+
+	return nil, storage.ErrInvalidSnapshotArchive
+}
+
+func (ar *Reader) readPair() (*Pair, error) {
+	fields := make([][]byte, 3)
+	for i := range fields {
+		field, err := ar.readField()
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = field
+	}
+
+	ar.numPairs++
+
+	return &Pair{
+		Unit:  string(fields[0]),
+		Key:   fields[1],
+		Value: fields[2],
+	}, nil
+}
+
+// readEnd checks the number of pairs written in the end record and that nothing follows it. Reading the compressed
+// stream up to its end also verifies its checksum
+func (ar *Reader) readEnd() error {
+	numPairs, err := binary.ReadUvarint(ar.reader)
+	if err != nil {
+		return convertReadError(err)
+	}
+	if numPairs != ar.numPairs {
+		return storage.ErrTruncatedSnapshotArchive
+	}
+
+	_, err = ar.reader.ReadByte()
+	if err != io.EOF {
+		if err != nil {
+			return convertReadError(err)
+		}
+		return storage.ErrInvalidSnapshotArchive
+	}
+
+	ar.finished = true
+
+	return io.EOF
+}
+
+// Close releases the decompressor. The provided reader is not closed
+func (ar *Reader) Close() error {
+	return ar.gzipReader.Close()
+}
+
+func (ar *Reader) readField() ([]byte, error) {
+	length, err := binary.ReadUvarint(ar.reader)
+	if err != nil {
+		return nil, convertReadError(err)
+	}
+	if length > maxFieldLength {
+		return nil, storage.ErrInvalidSnapshotArchive
+	}
+
+	field := make([]byte, length)
+	_, err = io.ReadFull(ar.reader, field)
+	if err != nil {
+		return nil, convertReadError(err)
+	}
+
+	return field, nil
+}
+
+func convertReadError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return storage.ErrTruncatedSnapshotArchive
+	}
+
+	return err
+}
@@ -0,0 +1,119 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createManifest() *snapshot.Manifest {
+	return &snapshot.Manifest{
+		ShardID:    1,
+		Round:      37,
+		Nonce:      35,
+		HeaderHash: []byte("header hash"),
+		RootHash:   []byte("root hash"),
+		NumHeaders: 4,
+	}
+}
+
+func writeArchive(t *testing.T, pairs []snapshot.Pair) []byte {
+	buff := &bytes.Buffer{}
+	writer, err := snapshot.NewWriter(buff, createManifest(), &marshal.JsonMarshalizer{})
+	require.Nil(t, err)
+
+	for _, pair := range pairs {
+		err = writer.Put(pair.Unit, pair.Key, pair.Value)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, uint64(len(pairs)), writer.NumPairs())
+
+	err = writer.Close()
+	require.Nil(t, err)
+
+	return buff.Bytes()
+}
+
+func readAll(reader *snapshot.Reader) ([]snapshot.Pair, error) {
+	pairs := make([]snapshot.Pair, 0)
+	for {
+		pair, err := reader.Next()
+		if err == io.EOF {
+			return pairs, nil
+		}
+		if err != nil {
+			return pairs, err
+		}
+		pairs = append(pairs, *pair)
+	}
+}
+
+func TestNewWriter_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := snapshot.NewWriter(nil, createManifest(), &marshal.JsonMarshalizer{})
+	assert.Equal(t, storage.ErrNilSnapshotWriter, err)
+
+	_, err = snapshot.NewWriter(&bytes.Buffer{}, nil, &marshal.JsonMarshalizer{})
+	assert.Equal(t, storage.ErrNilSnapshotManifest, err)
+
+	_, err = snapshot.NewWriter(&bytes.Buffer{}, createManifest(), nil)
+	assert.Equal(t, storage.ErrNilMarshalizer, err)
+}
+
+func TestArchive_WriteAndReadShouldRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	pairs := []snapshot.Pair{
+		{Unit: "BlockHeaders", Key: []byte("hash1"), Value: []byte("header1")},
+		{Unit: "AccountsTrie/MainDB", Key: []byte("node"), Value: bytes.Repeat([]byte("a"), 100000)},
+		{Unit: "BootstrapData", Key: []byte("37"), Value: []byte{}},
+	}
+	archive := writeArchive(t, pairs)
+
+	reader, err := snapshot.NewReader(bytes.NewReader(archive), &marshal.JsonMarshalizer{})
+	require.Nil(t, err)
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	expectedManifest := createManifest()
+	expectedManifest.Version = snapshot.ArchiveVersion
+	assert.Equal(t, expectedManifest, reader.Manifest())
+
+	readPairs, err := readAll(reader)
+	require.Nil(t, err)
+	assert.Equal(t, pairs, readPairs)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNewReader_NotAnArchiveShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := snapshot.NewReader(bytes.NewReader([]byte("not an archive")), &marshal.JsonMarshalizer{})
+	assert.Equal(t, storage.ErrInvalidSnapshotArchive, err)
+}
+
+func TestReader_TruncatedArchiveShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pairs := []snapshot.Pair{
+		{Unit: "BlockHeaders", Key: []byte("hash1"), Value: bytes.Repeat([]byte("h"), 1000)},
+		{Unit: "BlockHeaders", Key: []byte("hash2"), Value: bytes.Repeat([]byte("i"), 1000)},
+	}
+	archive := writeArchive(t, pairs)
+
+	reader, err := snapshot.NewReader(bytes.NewReader(archive[:len(archive)-20]), &marshal.JsonMarshalizer{})
+	require.Nil(t, err)
+
+	_, err = readAll(reader)
+	assert.Equal(t, storage.ErrTruncatedSnapshotArchive, err)
+}
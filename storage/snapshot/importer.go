@@ -0,0 +1,110 @@
+package snapshot
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// UnitWriter is implemented by the storers and the trie databases the imported pairs are written to
+type UnitWriter interface {
+	Put(key, val []byte) error
+	IsInterfaceNil() bool
+}
+
+// ArgsImporter holds all the arguments needed to create a snapshot importer
+type ArgsImporter struct {
+	Units            map[string]UnitWriter
+	Marshalizer      marshal.Marshalizer
+	ShardCoordinator sharding.Coordinator
+}
+
+// Result holds the outcome of an import
+type Result struct {
+	Manifest *Manifest
+	NumPairs uint64
+}
+
+// importer writes the pairs of a snapshot archive into the units of a node, before the node loads its blocks from
+// storage. The units are matched by the identifiers they were exported with
+type importer struct {
+	units            map[string]UnitWriter
+	marshalizer      marshal.Marshalizer
+	shardCoordinator sharding.Coordinator
+}
+
+// NewImporter creates a new snapshot importer instance
+func NewImporter(args ArgsImporter) (*importer, error) {
+	if len(args.Units) == 0 {
+		return nil, storage.ErrNilSnapshotUnits
+	}
+	for identifier, unit := range args.Units {
+		if check.IfNil(unit) {
+			return nil, fmt.Errorf("%w for unit %s", storage.ErrNilSnapshotUnits, identifier)
+		}
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, storage.ErrNilMarshalizer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, storage.ErrNilShardCoordinator
+	}
+
+	return &importer{
+		units:            args.Units,
+		marshalizer:      args.Marshalizer,
+		shardCoordinator: args.ShardCoordinator,
+	}, nil
+}
+
+// Import reads the archive and writes all its pairs. The archive must have been exported from the node's shard.
+// The pairs are written as they are read, so a failed import leaves the units partially written
+func (imp *importer) Import(r io.Reader) (*Result, error) {
+	reader, err := NewReader(r, imp.marshalizer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	manifest := reader.Manifest()
+	if manifest.ShardID != imp.shardCoordinator.SelfId() {
+		return nil, fmt.Errorf("%w: archive shard %d, node shard %d",
+			storage.ErrSnapshotShardMismatch, manifest.ShardID, imp.shardCoordinator.SelfId())
+	}
+
+	result := &Result{
+		Manifest: manifest,
+	}
+	for {
+		pair, errNext := reader.Next()
+		if errNext == io.EOF {
+			return result, nil
+		}
+		if errNext != nil {
+			return nil, errNext
+		}
+
+		unit, ok := imp.units[pair.Unit]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", storage.ErrUnknownSnapshotUnit, pair.Unit)
+		}
+
+		err = unit.Put(pair.Key, pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s while writing to unit %s", err.Error(), pair.Unit)
+		}
+
+		result.NumPairs++
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (imp *importer) IsInterfaceNil() bool {
+	return imp == nil
+}
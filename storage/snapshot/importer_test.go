@@ -0,0 +1,103 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/mock"
+	"github.com/ElrondNetwork/elrond-go/storage/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgsImporter(units map[string]snapshot.UnitWriter) snapshot.ArgsImporter {
+	return snapshot.ArgsImporter{
+		Units:            units,
+		Marshalizer:      &marshal.JsonMarshalizer{},
+		ShardCoordinator: mock.NewShardCoordinatorMock(1, 2),
+	}
+}
+
+func TestNewImporter_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := snapshot.NewImporter(createArgsImporter(nil))
+	assert.Equal(t, storage.ErrNilSnapshotUnits, err)
+
+	_, err = snapshot.NewImporter(createArgsImporter(map[string]snapshot.UnitWriter{"unit": nil}))
+	assert.True(t, errors.Is(err, storage.ErrNilSnapshotUnits))
+
+	args := createArgsImporter(map[string]snapshot.UnitWriter{"unit": memorydb.New()})
+	args.Marshalizer = nil
+	_, err = snapshot.NewImporter(args)
+	assert.Equal(t, storage.ErrNilMarshalizer, err)
+
+	args = createArgsImporter(map[string]snapshot.UnitWriter{"unit": memorydb.New()})
+	args.ShardCoordinator = nil
+	_, err = snapshot.NewImporter(args)
+	assert.Equal(t, storage.ErrNilShardCoordinator, err)
+}
+
+func TestImporter_ImportShouldWriteThePairsInTheirUnits(t *testing.T) {
+	t.Parallel()
+
+	headers := memorydb.New()
+	trieNodes := memorydb.New()
+	archive := writeArchive(t, []snapshot.Pair{
+		{Unit: "BlockHeaders", Key: []byte("hash"), Value: []byte("header")},
+		{Unit: "AccountsTrie/MainDB", Key: []byte("node hash"), Value: []byte("node")},
+	})
+
+	imp, err := snapshot.NewImporter(createArgsImporter(map[string]snapshot.UnitWriter{
+		"BlockHeaders":        headers,
+		"AccountsTrie/MainDB": trieNodes,
+	}))
+	require.Nil(t, err)
+
+	result, err := imp.Import(bytes.NewReader(archive))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), result.NumPairs)
+	assert.Equal(t, int64(37), result.Manifest.Round)
+
+	val, err := headers.Get([]byte("hash"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("header"), val)
+
+	val, err = trieNodes.Get([]byte("node hash"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("node"), val)
+}
+
+func TestImporter_ImportUnknownUnitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	archive := writeArchive(t, []snapshot.Pair{
+		{Unit: "MiniBlocks", Key: []byte("hash"), Value: []byte("miniblock")},
+	})
+
+	imp, _ := snapshot.NewImporter(createArgsImporter(map[string]snapshot.UnitWriter{"BlockHeaders": memorydb.New()}))
+
+	_, err := imp.Import(bytes.NewReader(archive))
+	assert.True(t, errors.Is(err, storage.ErrUnknownSnapshotUnit))
+}
+
+func TestImporter_ImportFromAnotherShardShouldErr(t *testing.T) {
+	t.Parallel()
+
+	headers := memorydb.New()
+	archive := writeArchive(t, []snapshot.Pair{
+		{Unit: "BlockHeaders", Key: []byte("hash"), Value: []byte("header")},
+	})
+
+	args := createArgsImporter(map[string]snapshot.UnitWriter{"BlockHeaders": headers})
+	args.ShardCoordinator = mock.NewShardCoordinatorMock(0, 2)
+	imp, _ := snapshot.NewImporter(args)
+
+	_, err := imp.Import(bytes.NewReader(archive))
+	assert.True(t, errors.Is(err, storage.ErrSnapshotShardMismatch))
+	assert.NotNil(t, headers.Has([]byte("hash")))
+}
@@ -0,0 +1,147 @@
+package storageUnit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// bloomFilterFileHeader is written on the first line of a saved bloom filter file, before the filter's content. A
+// saved filter is loaded only if its header matches the configured filter and its content matches the checksum
+type bloomFilterFileHeader struct {
+	Type          BloomFilterType `json:"type"`
+	Size          uint            `json:"size"`
+	HashFunctions []HasherType    `json:"hashFunctions"`
+	Checksum      string          `json:"checksum"`
+}
+
+func newBloomFilterFileHeader(conf BloomConfig, content []byte) bloomFilterFileHeader {
+	filterType := conf.Type
+	if filterType == "" {
+		filterType = StandardBloomFilter
+	}
+
+	hashFunctions := make([]HasherType, len(conf.HashFunc))
+	copy(hashFunctions, conf.HashFunc)
+
+	checksum := sha256.Sum256(content)
+
+	return bloomFilterFileHeader{
+		Type:          filterType,
+		Size:          conf.Size,
+		HashFunctions: hashFunctions,
+		Checksum:      hex.EncodeToString(checksum[:]),
+	}
+}
+
+// encodeBloomFilterFile returns the header line, describing the configured filter and the content, followed by the
+// filter's content
+func encodeBloomFilterFile(conf BloomConfig, content []byte) ([]byte, error) {
+	header, err := json.Marshal(newBloomFilterFileHeader(conf, content))
+	if err != nil {
+		return nil, err
+	}
+
+	buff := make([]byte, 0, len(header)+1+len(content))
+	buff = append(buff, header...)
+	buff = append(buff, '\n')
+
+	return append(buff, content...), nil
+}
+
+// decodeBloomFilterFile returns the filter's content saved in the provided file, or an error if the file was saved
+// by a filter with a different type, size or hash functions, or if its content does not match the checksum
+func decodeBloomFilterFile(conf BloomConfig, buff []byte) ([]byte, error) {
+	savedHeader, content, err := splitBloomFilterFile(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedHeader := newBloomFilterFileHeader(conf, content)
+	if !reflect.DeepEqual(savedHeader, expectedHeader) {
+		return nil, fmt.Errorf("%w: saved %+v, expected %+v", storage.ErrInvalidBloomFilterFile, savedHeader, expectedHeader)
+	}
+
+	return content, nil
+}
+
+func splitBloomFilterFile(buff []byte) (bloomFilterFileHeader, []byte, error) {
+	savedHeader := bloomFilterFileHeader{}
+	headerEnd := bytes.IndexByte(buff, '\n')
+	if headerEnd < 0 {
+		return savedHeader, nil, fmt.Errorf("%w: missing header", storage.ErrInvalidBloomFilterFile)
+	}
+
+	err := json.Unmarshal(buff[:headerEnd], &savedHeader)
+	if err != nil {
+		return savedHeader, nil, fmt.Errorf("%w: %v", storage.ErrInvalidBloomFilterFile, err)
+	}
+
+	return savedHeader, buff[headerEnd+1:], nil
+}
+
+// SaveBloomFilterFile writes the filter, after a header describing it, in a temporary file which then replaces the
+// provided file, so that an interrupted save does not leave a truncated filter behind
+func SaveBloomFilterFile(filePath string, conf BloomConfig, filter storage.PersistableBloomFilter) error {
+	buff, err := encodeBloomFilterFile(conf, filter.Bytes())
+	if err != nil {
+		return err
+	}
+
+	tempFilePath := filePath + ".tmp"
+	err = ioutil.WriteFile(tempFilePath, buff, core.FileModeUserReadWrite)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
+
+// LoadBloomFilterFile creates the filter described by the header of a file written by SaveBloomFilterFile and loads
+// the saved content in it
+func LoadBloomFilterFile(filePath string) (storage.BloomFilter, error) {
+	buff, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	savedHeader, _, err := splitBloomFilterFile(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := BloomConfig{
+		Size:     savedHeader.Size,
+		HashFunc: savedHeader.HashFunctions,
+		Type:     savedHeader.Type,
+	}
+	content, err := decodeBloomFilterFile(conf, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := NewBloomFilter(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	persistableBloomFilter, ok := filter.(storage.PersistableBloomFilter)
+	if !ok {
+		return nil, storage.ErrNotSupportedBloomFilterType
+	}
+
+	err = persistableBloomFilter.Load(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
@@ -7,3 +7,7 @@ import (
 func (u *Unit) GetBlomFilter() storage.BloomFilter {
 	return u.bloomFilter
 }
+
+func (u *Unit) SetFlushOnCommit(flushOnCommit bool) {
+	u.flushOnCommit = flushOnCommit
+}
@@ -1,5 +1,9 @@
 package storageUnit
 
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
 type nilStorer struct {
 }
 
@@ -23,6 +27,11 @@ func (ns *nilStorer) SearchFirst(key []byte) ([]byte, error) {
 	return nil, nil
 }
 
+// Iterate will do nothing
+func (ns *nilStorer) Iterate(_ *storage.KeyRange, _ func(key []byte, val []byte) bool) error {
+	return nil
+}
+
 // Put will do nothing
 func (ns *nilStorer) Put(key, data []byte) error {
 	return nil
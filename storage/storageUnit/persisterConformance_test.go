@@ -0,0 +1,244 @@
+package storageUnit_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type persisterCreator struct {
+	name     string
+	onDisk   bool
+	open     func(t testing.TB, path string) storage.Persister
+	tempPath func(t testing.TB) string
+}
+
+func createDiskPersister(dbType storageUnit.DBType) func(t testing.TB, path string) storage.Persister {
+	return func(t testing.TB, path string) storage.Persister {
+		// a batch of one pair makes every write visible right away
+		db, err := storageUnit.NewDB(dbType, path, 10, 1, 10)
+		require.Nil(t, err)
+
+		return db
+	}
+}
+
+func createTempDir(t testing.TB) string {
+	dir, err := ioutil.TempDir("", "persister_conformance")
+	require.Nil(t, err)
+
+	return dir
+}
+
+func noPath(_ testing.TB) string {
+	return ""
+}
+
+// allPersisters lists every persister implementation. A new implementation should be added here so it is checked
+// against the same expectations as the existing ones
+func allPersisters() []persisterCreator {
+	return []persisterCreator{
+		{
+			name: "memorydb",
+			open: func(_ testing.TB, _ string) storage.Persister {
+				return memorydb.New()
+			},
+			tempPath: noPath,
+		},
+		{
+			name: "lruDB",
+			open: func(t testing.TB, _ string) storage.Persister {
+				db, err := memorydb.NewlruDB(1000)
+				require.Nil(t, err)
+				return db
+			},
+			tempPath: noPath,
+		},
+		{name: string(storageUnit.LvlDB), onDisk: true, open: createDiskPersister(storageUnit.LvlDB), tempPath: createTempDir},
+		{name: string(storageUnit.LvlDbSerial), onDisk: true, open: createDiskPersister(storageUnit.LvlDbSerial), tempPath: createTempDir},
+		{name: string(storageUnit.BoltDB), onDisk: true, open: createDiskPersister(storageUnit.BoltDB), tempPath: createTempDir},
+		{
+			name:   "encrypted " + string(storageUnit.LvlDB),
+			onDisk: true,
+			open: func(t testing.TB, path string) storage.Persister {
+				db, err := encryption.NewEncryptedDB(createDiskPersister(storageUnit.LvlDB)(t, path), bytes.Repeat([]byte{1}, encryption.KeySize))
+				require.Nil(t, err)
+				return db
+			},
+			tempPath: createTempDir,
+		},
+	}
+}
+
+func collectKeys(t *testing.T, db storage.Persister, keyRange *storage.KeyRange) []string {
+	keys := make([]string, 0)
+	err := db.Iterate(keyRange, func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+	require.Nil(t, err)
+
+	return keys
+}
+
+func TestPersisterConformance(t *testing.T) {
+	for _, pc := range allPersisters() {
+		pc := pc
+		t.Run(pc.name, func(t *testing.T) {
+			t.Run("put get has", func(t *testing.T) {
+				db := pc.open(t, pc.tempPath(t))
+				defer func() { _ = db.Destroy() }()
+
+				assert.Nil(t, db.Init())
+				assert.Nil(t, db.Put([]byte("key"), []byte("val")))
+
+				val, err := db.Get([]byte("key"))
+				assert.Nil(t, err)
+				assert.Equal(t, []byte("val"), val)
+				assert.Nil(t, db.Has([]byte("key")))
+			})
+
+			t.Run("missing key", func(t *testing.T) {
+				db := pc.open(t, pc.tempPath(t))
+				defer func() { _ = db.Destroy() }()
+
+				val, err := db.Get([]byte("missing"))
+				assert.Nil(t, val)
+				assert.NotNil(t, err)
+				assert.NotNil(t, db.Has([]byte("missing")))
+			})
+
+			t.Run("overwrite", func(t *testing.T) {
+				db := pc.open(t, pc.tempPath(t))
+				defer func() { _ = db.Destroy() }()
+
+				_ = db.Put([]byte("key"), []byte("val1"))
+				_ = db.Put([]byte("key"), []byte("val2"))
+
+				val, err := db.Get([]byte("key"))
+				assert.Nil(t, err)
+				assert.Equal(t, []byte("val2"), val)
+			})
+
+			t.Run("remove", func(t *testing.T) {
+				db := pc.open(t, pc.tempPath(t))
+				defer func() { _ = db.Destroy() }()
+
+				_ = db.Put([]byte("key"), []byte("val"))
+				assert.Nil(t, db.Remove([]byte("key")))
+
+				_, err := db.Get([]byte("key"))
+				assert.NotNil(t, err)
+				assert.NotNil(t, db.Has([]byte("key")))
+				assert.Nil(t, db.Remove([]byte("key")))
+			})
+
+			t.Run("iterate", func(t *testing.T) {
+				db := pc.open(t, pc.tempPath(t))
+				defer func() { _ = db.Destroy() }()
+
+				for _, key := range []string{"b2", "a1", "b1", "c1", "b3"} {
+					_ = db.Put([]byte(key), []byte("val_"+key))
+				}
+				_ = db.Remove([]byte("b3"))
+
+				assert.Equal(t, []string{"a1", "b1", "b2", "c1"}, collectKeys(t, db, nil))
+				assert.Equal(t, []string{"b1", "b2"}, collectKeys(t, db, storage.PrefixRange([]byte("b"))))
+				assert.Equal(t, []string{"b2", "c1"}, collectKeys(t, db, &storage.KeyRange{Start: []byte("b2")}))
+				assert.Equal(t, []string{"a1", "b1"}, collectKeys(t, db, &storage.KeyRange{Limit: []byte("b2")}))
+
+				err := db.Iterate(nil, func(key []byte, val []byte) bool {
+					assert.Equal(t, "val_"+string(key), string(val))
+					return true
+				})
+				assert.Nil(t, err)
+
+				numCalls := 0
+				err = db.Iterate(nil, func(key []byte, val []byte) bool {
+					numCalls++
+					return false
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 1, numCalls)
+			})
+
+			if !pc.onDisk {
+				return
+			}
+
+			t.Run("close keeps data", func(t *testing.T) {
+				path := pc.tempPath(t)
+				db := pc.open(t, path)
+				for i := 0; i < 10; i++ {
+					_ = db.Put([]byte(fmt.Sprintf("key%d", i)), []byte("val"))
+				}
+				require.Nil(t, db.Close())
+
+				db = pc.open(t, path)
+				defer func() { _ = db.Destroy() }()
+
+				assert.Equal(t, 10, len(collectKeys(t, db, nil)))
+				assert.Nil(t, db.Has([]byte("key9")))
+			})
+
+			t.Run("destroy removes data", func(t *testing.T) {
+				path := pc.tempPath(t)
+				db := pc.open(t, path)
+				_ = db.Put([]byte("key"), []byte("val"))
+				require.Nil(t, db.Destroy())
+
+				db = pc.open(t, path)
+				defer func() { _ = db.Destroy() }()
+
+				assert.NotNil(t, db.Has([]byte("key")))
+			})
+
+			t.Run("destroy closed removes data", func(t *testing.T) {
+				path := pc.tempPath(t)
+				db := pc.open(t, path)
+				_ = db.Put([]byte("key"), []byte("val"))
+				require.Nil(t, db.Close())
+				require.Nil(t, db.DestroyClosed())
+
+				db = pc.open(t, path)
+				defer func() { _ = db.Destroy() }()
+
+				assert.NotNil(t, db.Has([]byte("key")))
+			})
+		})
+	}
+}
+
+// BenchmarkPersisters_PutBatches writes trie-like 32 bytes keys with small values in batches of 100 pairs. Comparing
+// the bytes written to the disk during the runs gives the write amplification of each on-disk persister
+func BenchmarkPersisters_PutBatches(b *testing.B) {
+	value := make([]byte, 128)
+
+	for _, pc := range allPersisters() {
+		if !pc.onDisk {
+			continue
+		}
+
+		pc := pc
+		b.Run(pc.name, func(b *testing.B) {
+			db, err := storageUnit.NewDB(storageUnit.DBType(pc.name), pc.tempPath(b), 10, 100, 10)
+			require.Nil(b, err)
+			defer func() { _ = db.Destroy() }()
+
+			key := make([]byte, 32)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(key, fmt.Sprintf("%032d", i*7919))
+				_ = db.Put(key, value)
+			}
+		})
+	}
+}
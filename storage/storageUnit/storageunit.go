@@ -3,6 +3,8 @@ package storageUnit
 import (
 	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"sync"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/ElrondNetwork/elrond-go/logger"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/bloom"
+	"github.com/ElrondNetwork/elrond-go/storage/boltdb"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
 	"github.com/ElrondNetwork/elrond-go/storage/fifocache"
 	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
@@ -28,6 +32,9 @@ type DBType string
 // HasherType represents the type of the supported hash functions
 type HasherType string
 
+// BloomFilterType represents the type of the supported bloom filters
+type BloomFilterType string
+
 // LRUCache is currently the only supported Cache type
 const (
 	LRUCache         CacheType = "LRU"
@@ -36,13 +43,25 @@ const (
 
 var log = logger.GetOrCreate("storage/storageUnit")
 
-// LvlDB currently the only supported DBs
+// LvlDB and LvlDbSerial are the LevelDB backed databases, BoltDB is the BoltDB backed one
 // More to be added
 const (
 	LvlDB       DBType = "LvlDB"
 	LvlDbSerial DBType = "LvlDBSerial"
+	BoltDB      DBType = "BoltDB"
+)
+
+const (
+	// StandardBloomFilter is the bloom filter keeping a bit for every position. It is used when no type is configured
+	StandardBloomFilter BloomFilterType = "Standard"
+	// CountingBloomFilter is the bloom filter keeping a counter for every position, able to remove values
+	CountingBloomFilter BloomFilterType = "Counting"
 )
 
+// bloomFilterFileExtension is appended to the path of a unit's database to obtain the file its bloom filter is
+// saved in when the unit is closed
+const bloomFilterFileExtension = ".bloom"
+
 const (
 	// Keccak is the string representation of the keccak hashing function
 	Keccak HasherType = "Keccak"
@@ -74,21 +93,29 @@ type DBConfig struct {
 	BatchDelaySeconds int
 	MaxBatchSize      int
 	MaxOpenFiles      int
+	FlushOnCommit     bool
+	EncryptionKey     []byte
 }
 
 // BloomConfig holds the configurable elements of a bloom filter
 type BloomConfig struct {
 	Size     uint
 	HashFunc []HasherType
+	Type     BloomFilterType
+	Persist  bool
 }
 
 // Unit represents a storer's data bank
 // holding the cache, persistence unit and bloom filter
 type Unit struct {
-	lock        sync.RWMutex
-	persister   storage.Persister
-	cacher      storage.Cacher
-	bloomFilter storage.BloomFilter
+	lock            sync.RWMutex
+	persister       storage.Persister
+	cacher          storage.Cacher
+	bloomFilter     storage.BloomFilter
+	bloomFilterConf BloomConfig
+	bloomFilePath   string
+	flushOnCommit   bool
+	counters        storage.UnitCounters
 }
 
 // Put adds data to both cache and persistence medium and updates the bloom filter
@@ -111,9 +138,14 @@ func (u *Unit) Put(key, data []byte) error {
 	return err
 }
 
-// Close will close unit
+// Close will close unit. If the unit persists its bloom filter, the filter is saved before closing the persister
 func (u *Unit) Close() error {
-	err := u.persister.Close()
+	err := u.saveBloomFilter()
+	if err != nil {
+		log.Warn("cannot save storage unit bloom filter", "path", u.bloomFilePath, "error", err.Error())
+	}
+
+	err = u.persister.Close()
 	if err != nil {
 		log.Error("cannot close storage unit persister", err)
 		return err
@@ -122,6 +154,21 @@ func (u *Unit) Close() error {
 	return nil
 }
 
+// Flush is called when a block is committed. If the unit was configured to be flushed on commit, the writes buffered
+// by the persister are made durable, otherwise they are left to be written by the persister's batch timer
+func (u *Unit) Flush() error {
+	if !u.flushOnCommit {
+		return nil
+	}
+
+	flusher, ok := u.persister.(storage.Flusher)
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush()
+}
+
 // Get searches the key in the cache. In case it is not found, it searches
 // for the key in bloom filter first and if found
 // it further searches it in the associated database.
@@ -140,14 +187,19 @@ func (u *Unit) Get(key []byte) ([]byte, error) {
 			v, err = u.persister.Get(key)
 
 			if err != nil {
+				u.counters.RecordMiss()
 				return nil, err
 			}
 
+			u.counters.RecordPersisterHit()
 			// if found in persistence unit, add it in cache
 			u.cacher.Put(key, v)
 		} else {
+			u.counters.RecordBloomFilterMiss()
 			return nil, fmt.Errorf("key: %s not found", base64.StdEncoding.EncodeToString(key))
 		}
+	} else {
+		u.counters.RecordCacheHit()
 	}
 
 	return v.([]byte), nil
@@ -182,18 +234,36 @@ func (u *Unit) SearchFirst(key []byte) ([]byte, error) {
 	return u.Get(key)
 }
 
+// Iterate calls the handler for every (key, val) pair of the persistence medium in the provided range, in ascending
+// key order, until the handler returns false
+func (u *Unit) Iterate(keyRange *storage.KeyRange, handler func(key []byte, val []byte) bool) error {
+	return u.persister.Iterate(keyRange, handler)
+}
+
 // HasInEpoch will call the Has method as this storer doesn't handle epochs
 func (u *Unit) HasInEpoch(key []byte, _ uint32) error {
 	return u.Has(key)
 }
 
-// Remove removes the data associated to the given key from both cache and persistence medium
+// Remove removes the data associated to the given key from both cache and persistence medium. The key is also
+// removed from the bloom filter if the filter supports it
 func (u *Unit) Remove(key []byte) error {
 	u.lock.Lock()
 	defer u.lock.Unlock()
 
 	u.cacher.Remove(key)
+
+	removableBloomFilter, ok := u.bloomFilter.(storage.RemovableBloomFilter)
+	if !ok {
+		return u.persister.Remove(key)
+	}
+
+	// a counting filter breaks if a key which was never added is removed from it
+	isPersisted := u.persister.Has(key) == nil
 	err := u.persister.Remove(key)
+	if err == nil && isPersisted {
+		removableBloomFilter.Remove(key)
+	}
 
 	return err
 }
@@ -211,11 +281,63 @@ func (u *Unit) DestroyUnit() error {
 	if u.bloomFilter != nil {
 		u.bloomFilter.Clear()
 	}
+	if len(u.bloomFilePath) > 0 {
+		_ = os.Remove(u.bloomFilePath)
+	}
 
 	u.cacher.Clear()
 	return u.persister.Destroy()
 }
 
+// restoreBloomFilter loads the bloom filter saved when the unit was last closed. The saved file is removed once
+// read, so that a crash can not leave behind a filter missing the keys added afterwards. Without a saved filter, or
+// if the saved one was written by a filter with another type, size or hash functions, or was altered, the filter is
+// rebuilt from the keys of the persister
+func (u *Unit) restoreBloomFilter() error {
+	persistableBloomFilter, ok := u.bloomFilter.(storage.PersistableBloomFilter)
+	if ok {
+		buff, err := ioutil.ReadFile(u.bloomFilePath)
+		if err == nil {
+			_ = os.Remove(u.bloomFilePath)
+			buff, err = decodeBloomFilterFile(u.bloomFilterConf, buff)
+		}
+		if err == nil {
+			err = persistableBloomFilter.Load(buff)
+		}
+		if err == nil {
+			return nil
+		}
+
+		log.Debug("rebuilding storage unit bloom filter", "path", u.bloomFilePath, "reason", err.Error())
+	}
+
+	u.bloomFilter.Clear()
+
+	return u.persister.Iterate(nil, func(key []byte, _ []byte) bool {
+		u.bloomFilter.Add(key)
+		return true
+	})
+}
+
+// saveBloomFilter writes the bloom filter next to the persister, so that it is restored when the unit is reopened
+func (u *Unit) saveBloomFilter() error {
+	if len(u.bloomFilePath) == 0 {
+		return nil
+	}
+
+	persistableBloomFilter, ok := u.bloomFilter.(storage.PersistableBloomFilter)
+	if !ok {
+		return nil
+	}
+
+	return SaveBloomFilterFile(u.bloomFilePath, u.bloomFilterConf, persistableBloomFilter)
+}
+
+// Stats returns the counters of the lookups done in the unit
+func (u *Unit) Stats() storage.UnitStats {
+	return u.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (u *Unit) IsInterfaceNil() bool {
 	return u == nil
@@ -289,18 +411,25 @@ func NewStorageUnitFromConf(cacheConf CacheConfig, dbConf DBConfig, bloomFilterC
 		return nil, storage.ErrCacheSizeIsLowerThanBatchSize
 	}
 
-	cache, err = NewCache(cacheConf.Type, cacheConf.Size, cacheConf.Shards)
+	cache, err = NewCacheFromConfig(cacheConf)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err = NewDB(dbConf.Type, dbConf.FilePath, dbConf.BatchDelaySeconds, dbConf.MaxBatchSize, dbConf.MaxOpenFiles)
+	db, err = NewDBFromConf(dbConf)
 	if err != nil {
 		return nil, err
 	}
 
 	if reflect.DeepEqual(bloomFilterConf, BloomConfig{}) {
-		return NewStorageUnit(cache, db)
+		var unit *Unit
+		unit, err = NewStorageUnit(cache, db)
+		if err != nil {
+			return nil, err
+		}
+
+		unit.flushOnCommit = dbConf.FlushOnCommit
+		return unit, nil
 	}
 
 	bf, err = NewBloomFilter(bloomFilterConf)
@@ -308,11 +437,28 @@ func NewStorageUnitFromConf(cacheConf CacheConfig, dbConf DBConfig, bloomFilterC
 		return nil, err
 	}
 
-	return NewStorageUnitWithBloomFilter(cache, db, bf)
+	unit, err := NewStorageUnitWithBloomFilter(cache, db, bf)
+	if err != nil {
+		return nil, err
+	}
+	unit.flushOnCommit = dbConf.FlushOnCommit
+
+	if bloomFilterConf.Persist {
+		unit.bloomFilePath = dbConf.FilePath + bloomFilterFileExtension
+		unit.bloomFilterConf = bloomFilterConf
+		// the database is only closed, not destroyed, as it already holds the data the filter is restored from
+		errRestore := unit.restoreBloomFilter()
+		if errRestore != nil {
+			_ = db.Close()
+			return nil, errRestore
+		}
+	}
+
+	return unit, nil
 }
 
-//NewCache creates a new cache from a cache config
-//TODO: add a cacher factory or a cacheConfig param instead
+// NewCache creates a new cache from a cache config
+// TODO: add a cacher factory or a cacheConfig param instead
 func NewCache(cacheType CacheType, size uint32, shards uint32) (storage.Cacher, error) {
 	var cacher storage.Cacher
 	var err error
@@ -337,6 +483,32 @@ func NewCache(cacheType CacheType, size uint32, shards uint32) (storage.Cacher,
 	return cacher, nil
 }
 
+// NewCacheFromConfig creates a new cache from a cache config. A non zero SizeInBytes bounds the cache also by the sum
+// of the sizes of the items it holds
+func NewCacheFromConfig(cacheConf CacheConfig) (storage.Cacher, error) {
+	if cacheConf.SizeInBytes == 0 {
+		return NewCache(cacheConf.Type, cacheConf.Size, cacheConf.Shards)
+	}
+
+	var cacher storage.Cacher
+	var err error
+
+	switch cacheConf.Type {
+	case LRUCache:
+		cacher, err = lrucache.NewCacheWithSizeInBytes(int(cacheConf.Size), int64(cacheConf.SizeInBytes))
+	case FIFOShardedCache:
+		cacher, err = fifocache.NewShardedCacheWithSizeInBytes(int(cacheConf.Size), int(cacheConf.Shards), int64(cacheConf.SizeInBytes))
+	default:
+		return nil, storage.ErrNotSupportedCacheType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cacher, nil
+}
+
 // NewDB creates a new database from database config
 func NewDB(dbType DBType, path string, batchDelaySeconds int, maxBatchSize int, maxOpenFiles int) (storage.Persister, error) {
 	var db storage.Persister
@@ -347,6 +519,8 @@ func NewDB(dbType DBType, path string, batchDelaySeconds int, maxBatchSize int,
 		db, err = leveldb.NewDB(path, batchDelaySeconds, maxBatchSize, maxOpenFiles)
 	case LvlDbSerial:
 		db, err = leveldb.NewSerialDB(path, batchDelaySeconds, maxBatchSize, maxOpenFiles)
+	case BoltDB:
+		db, err = boltdb.NewDB(path, batchDelaySeconds, maxBatchSize)
 	default:
 		return nil, storage.ErrNotSupportedDBType
 	}
@@ -358,6 +532,26 @@ func NewDB(dbType DBType, path string, batchDelaySeconds int, maxBatchSize int,
 	return db, nil
 }
 
+// NewDBFromConf creates a new database from database config. If the config holds an encryption key, the values are
+// encrypted before they reach the database
+func NewDBFromConf(dbConf DBConfig) (storage.Persister, error) {
+	db, err := NewDB(dbConf.Type, dbConf.FilePath, dbConf.BatchDelaySeconds, dbConf.MaxBatchSize, dbConf.MaxOpenFiles)
+	if err != nil {
+		return nil, err
+	}
+	if len(dbConf.EncryptionKey) == 0 {
+		return db, nil
+	}
+
+	encryptedDb, err := encryption.NewEncryptedDB(db, dbConf.EncryptionKey)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return encryptedDb, nil
+}
+
 // NewBloomFilter creates a new bloom filter from bloom filter config
 func NewBloomFilter(conf BloomConfig) (storage.BloomFilter, error) {
 	var bf storage.BloomFilter
@@ -374,7 +568,14 @@ func NewBloomFilter(conf BloomConfig) (storage.BloomFilter, error) {
 		}
 	}
 
-	bf, err = bloom.NewFilter(conf.Size, hashers)
+	switch conf.Type {
+	case StandardBloomFilter, "":
+		bf, err = bloom.NewFilter(conf.Size, hashers)
+	case CountingBloomFilter:
+		bf, err = bloom.NewCountingFilter(conf.Size, hashers)
+	default:
+		return nil, storage.ErrNotSupportedBloomFilterType
+	}
 	if err != nil {
 		return nil, err
 	}
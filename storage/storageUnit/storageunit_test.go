@@ -15,11 +15,13 @@ import (
 	"github.com/ElrondNetwork/elrond-go/hashing/keccak"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/bloom"
+	"github.com/ElrondNetwork/elrond-go/storage/encryption"
 	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
 	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func logError(err error) {
@@ -250,6 +252,24 @@ func TestGetPresentWithNilBloomFilter(t *testing.T) {
 	assert.Equal(t, val, v, "expected %s but got %s", val, v)
 }
 
+func TestGetShouldCountLookups(t *testing.T) {
+	s := initStorageUnitWithBloomFilter(t, 10)
+	_ = s.Put([]byte("key1"), []byte("value1"))
+	_ = s.Put([]byte("key2"), []byte("value2"))
+	s.ClearCache()
+
+	_, _ = s.Get([]byte("key1"))
+	_, _ = s.Get([]byte("key1"))
+	_, _ = s.Get([]byte("missing"))
+
+	stats := s.Stats()
+	assert.Equal(t, uint64(3), stats.NumGets)
+	assert.Equal(t, uint64(1), stats.NumCacheHits)
+	assert.Equal(t, uint64(1), stats.NumPersisterHits)
+	assert.Equal(t, uint64(1), stats.NumBloomFilterMiss)
+	assert.Equal(t, uint64(1), stats.NumMisses)
+}
+
 func TestHasNotPresent(t *testing.T) {
 	key := []byte("key6")
 	s := initStorageUnitWithBloomFilter(t, 10)
@@ -481,6 +501,25 @@ func TestCreateDBFromConfLvlDBOk(t *testing.T) {
 	assert.Nil(t, err, "no error expected destroying the persister")
 }
 
+func TestCreateDBFromConfWithInvalidEncryptionKeyShouldErr(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbConf := storageUnit.DBConfig{
+		FilePath:          dir,
+		Type:              storageUnit.LvlDbSerial,
+		BatchDelaySeconds: 10,
+		MaxBatchSize:      10,
+		MaxOpenFiles:      10,
+		EncryptionKey:     []byte("short key"),
+	}
+
+	persister, err := storageUnit.NewDBFromConf(dbConf)
+	assert.Nil(t, persister)
+	assert.Equal(t, storage.ErrInvalidEncryptionKey, err)
+}
+
 func TestCreateBloomFilterFromConfWrongSize(t *testing.T) {
 	bfConfig := storageUnit.BloomConfig{
 		Size:     2,
@@ -594,6 +633,39 @@ func TestNewStorageUnit_FromConfLvlDBOk(t *testing.T) {
 	assert.Nil(t, err, "no error expected destroying the persister")
 }
 
+func TestNewStorageUnit_FromConfWithEncryptionKeyShouldEncryptTheValues(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	key, val := []byte("key"), []byte("value")
+
+	storer, err := storageUnit.NewStorageUnitFromConf(storageUnit.CacheConfig{
+		Size: 10,
+		Type: storageUnit.LRUCache,
+	}, storageUnit.DBConfig{
+		FilePath:          dir,
+		Type:              storageUnit.LvlDbSerial,
+		BatchDelaySeconds: 1,
+		MaxBatchSize:      1,
+		MaxOpenFiles:      10,
+		EncryptionKey:     make([]byte, encryption.KeySize),
+	}, storageUnit.BloomConfig{})
+	require.Nil(t, err)
+
+	err = storer.Put(key, val)
+	assert.Nil(t, err)
+	readVal, err := storer.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, readVal)
+	_ = storer.Close()
+
+	persister, _ := storageUnit.NewDB(storageUnit.LvlDbSerial, dir, 1, 1, 10)
+	storedVal, err := persister.Get(key)
+	assert.Nil(t, err)
+	assert.NotEqual(t, val, storedVal)
+
+	err = persister.Destroy()
+	assert.Nil(t, err, "no error expected destroying the persister")
+}
+
 func TestNewStorageUnit_WithBlankBloomFilterShouldWorkLvlDB(t *testing.T) {
 	storer, err := storageUnit.NewStorageUnitFromConf(storageUnit.CacheConfig{
 		Size: 10,
@@ -844,3 +916,309 @@ func BenchmarkStorageUnit_GetNotPresentWithBloomFilter(b *testing.B) {
 		logError(err)
 	}
 }
+
+func TestStorageUnit_IterateShouldWalkThePersister(t *testing.T) {
+	s := initStorageUnitWithNilBloomFilter(t, 10)
+	_ = s.Put([]byte("key2"), []byte("val2"))
+	_ = s.Put([]byte("key1"), []byte("val1"))
+	_ = s.Put([]byte("other"), []byte("val3"))
+	s.ClearCache()
+
+	keys := make([]string, 0)
+	err := s.Iterate(storage.PrefixRange([]byte("key")), func(key []byte, val []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+}
+
+func TestNewCacheFromConfigWrongType(t *testing.T) {
+	cacheConf := storageUnit.CacheConfig{Type: "NotLRU", Size: 100, Shards: 1, SizeInBytes: 20}
+	cacher, err := storageUnit.NewCacheFromConfig(cacheConf)
+
+	assert.Equal(t, storage.ErrNotSupportedCacheType, err)
+	assert.Nil(t, cacher)
+}
+
+func TestNewCacheFromConfigWithoutSizeInBytesShouldBoundOnlyTheNumberOfItems(t *testing.T) {
+	cacher, err := storageUnit.NewCacheFromConfig(storageUnit.CacheConfig{Type: storageUnit.LRUCache, Size: 10, Shards: 1})
+	assert.Nil(t, err, "no error expected but got %s", err)
+
+	cacher.Put([]byte("key1"), []byte("value1"))
+	cacher.Put([]byte("key2"), []byte("value2"))
+	cacher.Put([]byte("key3"), []byte("value3"))
+	assert.Equal(t, 3, cacher.Len())
+}
+
+func TestNewCacheFromConfigWithSizeInBytesOK(t *testing.T) {
+	for _, cacheType := range []storageUnit.CacheType{storageUnit.LRUCache, storageUnit.FIFOShardedCache} {
+		cacheConf := storageUnit.CacheConfig{Type: cacheType, Size: 10, Shards: 1, SizeInBytes: 20}
+		cacher, err := storageUnit.NewCacheFromConfig(cacheConf)
+		assert.Nil(t, err, "no error expected but got %s", err)
+
+		cacher.Put([]byte("key1"), []byte("value1"))
+		cacher.Put([]byte("key2"), []byte("value2"))
+		cacher.Put([]byte("key3"), []byte("value3"))
+		assert.Equal(t, 2, cacher.Len())
+	}
+}
+
+func TestCreateBloomFilterFromConfCountingOk(t *testing.T) {
+	bfConfig := storageUnit.BloomConfig{
+		Size:     2048,
+		HashFunc: []storageUnit.HasherType{storageUnit.Keccak, storageUnit.Blake2b, storageUnit.Fnv},
+		Type:     storageUnit.CountingBloomFilter,
+	}
+
+	bf, err := storageUnit.NewBloomFilter(bfConfig)
+
+	assert.Nil(t, err)
+	_, ok := bf.(storage.RemovableBloomFilter)
+	assert.True(t, ok)
+}
+
+func TestCreateBloomFilterFromConfWrongType(t *testing.T) {
+	bfConfig := storageUnit.BloomConfig{
+		Size:     2048,
+		HashFunc: []storageUnit.HasherType{storageUnit.Keccak, storageUnit.Blake2b, storageUnit.Fnv},
+		Type:     "NotABloom",
+	}
+
+	bf, err := storageUnit.NewBloomFilter(bfConfig)
+
+	assert.Equal(t, storage.ErrNotSupportedBloomFilterType, err)
+	assert.Nil(t, bf)
+}
+
+func TestStorageUnit_RemoveShouldRemoveFromCountingBloomFilter(t *testing.T) {
+	cache, _ := lrucache.NewCache(10)
+	bf, _ := bloom.NewCountingFilter(2048, []hashing.Hasher{keccak.Keccak{}, blake2b.Blake2b{}, fnv.Fnv{}})
+	sUnit, _ := storageUnit.NewStorageUnitWithBloomFilter(cache, memorydb.New(), bf)
+
+	key := []byte("key")
+	_ = sUnit.Put(key, []byte("value"))
+	assert.True(t, bf.MayContain(key))
+
+	err := sUnit.Remove(key)
+	assert.Nil(t, err)
+	assert.False(t, bf.MayContain(key))
+
+	err = sUnit.Remove(key)
+	assert.Nil(t, err)
+	assert.False(t, bf.MayContain(key))
+}
+
+func createPersistedBloomConfig() storageUnit.BloomConfig {
+	return storageUnit.BloomConfig{
+		Size:     2048,
+		HashFunc: []storageUnit.HasherType{storageUnit.Keccak, storageUnit.Blake2b, storageUnit.Fnv},
+		Type:     storageUnit.CountingBloomFilter,
+		Persist:  true,
+	}
+}
+
+func createPersistedBloomUnit(t *testing.T, dbPath string, bloomConf storageUnit.BloomConfig) *storageUnit.Unit {
+	sUnit, err := storageUnit.NewStorageUnitFromConf(storageUnit.CacheConfig{
+		Size: 10,
+		Type: storageUnit.LRUCache,
+	}, storageUnit.DBConfig{
+		FilePath:          dbPath,
+		Type:              storageUnit.LvlDB,
+		BatchDelaySeconds: 1,
+		MaxBatchSize:      1,
+		MaxOpenFiles:      10,
+	}, bloomConf)
+	require.Nil(t, err)
+
+	return sUnit
+}
+
+// putBehindTheUnit writes a key directly in the closed unit's database, so the key is known by the unit's bloom
+// filter only if the filter is rebuilt from the database, not restored from the saved file
+func putBehindTheUnit(t *testing.T, dbPath string, key []byte) {
+	db, err := leveldb.NewDB(dbPath, 1, 1, 10)
+	require.Nil(t, err)
+	err = db.Put(key, []byte("value"))
+	require.Nil(t, err)
+	err = db.Close()
+	require.Nil(t, err)
+}
+
+func TestNewStorageUnit_FromConfWithPersistedBloomFilterShouldSaveAndRestore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "persisted_bloom")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbPath := filepath.Join(dir, "Blocks")
+	key, keyBehind := []byte("key"), []byte("key written behind the unit")
+
+	sUnit := createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	_ = sUnit.Put(key, []byte("value"))
+	err := sUnit.Close()
+	assert.Nil(t, err)
+	putBehindTheUnit(t, dbPath, keyBehind)
+
+	sUnit = createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	sUnit.ClearCache()
+	assert.Nil(t, sUnit.Has(key))
+	assert.Equal(t, storage.ErrKeyNotFound, sUnit.Has(keyBehind))
+
+	_, err = os.Stat(dbPath + ".bloom")
+	assert.True(t, os.IsNotExist(err))
+	_ = sUnit.Close()
+}
+
+func TestNewStorageUnit_FromConfWithPersistedBloomFilterShouldRebuildIfNotSaved(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "persisted_bloom")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbPath := filepath.Join(dir, "Blocks")
+	key := []byte("key")
+
+	sUnit := createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	_ = sUnit.Put(key, []byte("value"))
+	_ = sUnit.Close()
+	_ = os.Remove(dbPath + ".bloom")
+
+	sUnit = createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	sUnit.ClearCache()
+	assert.Nil(t, sUnit.Has(key))
+	_ = sUnit.Close()
+}
+
+func TestNewStorageUnit_FromConfWithPersistedBloomFilterShouldRebuildOnInvalidFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "persisted_bloom")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbPath := filepath.Join(dir, "Blocks")
+	key := []byte("key")
+
+	sUnit := createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	_ = sUnit.Put(key, []byte("value"))
+	_ = sUnit.Close()
+	_ = ioutil.WriteFile(dbPath+".bloom", []byte("truncated"), 0600)
+
+	sUnit = createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	sUnit.ClearCache()
+	assert.Nil(t, sUnit.Has(key))
+	_ = sUnit.Close()
+}
+
+func TestNewStorageUnit_FromConfWithPersistedBloomFilterShouldRebuildOnAlteredContent(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "persisted_bloom")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbPath := filepath.Join(dir, "Blocks")
+	keyBehind := []byte("key written behind the unit")
+
+	sUnit := createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	_ = sUnit.Put([]byte("key"), []byte("value"))
+	_ = sUnit.Close()
+	putBehindTheUnit(t, dbPath, keyBehind)
+
+	savedFilter, err := ioutil.ReadFile(dbPath + ".bloom")
+	require.Nil(t, err)
+	savedFilter[len(savedFilter)-1] ^= 0xff
+	err = ioutil.WriteFile(dbPath+".bloom", savedFilter, 0600)
+	require.Nil(t, err)
+
+	sUnit = createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+	sUnit.ClearCache()
+	assert.Nil(t, sUnit.Has(keyBehind))
+	_ = sUnit.Close()
+}
+
+func TestNewStorageUnit_FromConfWithPersistedBloomFilterShouldRebuildOnChangedConfig(t *testing.T) {
+	t.Parallel()
+
+	changedHashFunc := createPersistedBloomConfig()
+	changedHashFunc.HashFunc = []storageUnit.HasherType{storageUnit.Fnv, storageUnit.Keccak, storageUnit.Blake2b}
+	fewerHashFunc := createPersistedBloomConfig()
+	fewerHashFunc.HashFunc = []storageUnit.HasherType{storageUnit.Keccak, storageUnit.Blake2b}
+	changedSize := createPersistedBloomConfig()
+	changedSize.Size = 4096
+	changedType := createPersistedBloomConfig()
+	changedType.Type = storageUnit.StandardBloomFilter
+	changedType.Size = 8192
+
+	for name, bloomConf := range map[string]storageUnit.BloomConfig{
+		"hash functions order": changedHashFunc,
+		"hash functions":       fewerHashFunc,
+		"size":                 changedSize,
+		"type":                 changedType,
+	} {
+		bloomConf := bloomConf
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, _ := ioutil.TempDir("", "persisted_bloom")
+			defer func() {
+				_ = os.RemoveAll(dir)
+			}()
+			dbPath := filepath.Join(dir, "Blocks")
+			key, keyBehind := []byte("key"), []byte("key written behind the unit")
+
+			sUnit := createPersistedBloomUnit(t, dbPath, createPersistedBloomConfig())
+			_ = sUnit.Put(key, []byte("value"))
+			_ = sUnit.Close()
+			putBehindTheUnit(t, dbPath, keyBehind)
+
+			sUnit = createPersistedBloomUnit(t, dbPath, bloomConf)
+			sUnit.ClearCache()
+			assert.Nil(t, sUnit.Has(key))
+			assert.Nil(t, sUnit.Has(keyBehind))
+			_ = sUnit.Close()
+		})
+	}
+}
+
+func createUnitWithLevelDB(t *testing.T, flushOnCommit bool) (*storageUnit.Unit, *leveldb.DB) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	ldb, err := leveldb.NewDB(dir, 10, 100, 10)
+	assert.Nil(t, err)
+	cache, _ := lrucache.NewCache(10)
+
+	sUnit, err := storageUnit.NewStorageUnit(cache, ldb)
+	assert.Nil(t, err)
+	sUnit.SetFlushOnCommit(flushOnCommit)
+
+	return sUnit, ldb
+}
+
+func TestUnit_FlushWithoutFlushOnCommitShouldNotWriteThePendingBatch(t *testing.T) {
+	sUnit, ldb := createUnitWithLevelDB(t, false)
+	key := []byte("key")
+	_ = sUnit.Put(key, []byte("value"))
+
+	err := sUnit.Flush()
+
+	assert.Nil(t, err)
+	assert.Equal(t, storage.ErrKeyNotFound, ldb.Has(key))
+	_ = sUnit.DestroyUnit()
+}
+
+func TestUnit_FlushWithFlushOnCommitShouldWriteThePendingBatch(t *testing.T) {
+	sUnit, ldb := createUnitWithLevelDB(t, true)
+	key := []byte("key")
+	_ = sUnit.Put(key, []byte("value"))
+
+	err := sUnit.Flush()
+
+	assert.Nil(t, err)
+	assert.Nil(t, ldb.Has(key))
+	_ = sUnit.DestroyUnit()
+}
+
+func TestUnit_FlushWithFlushOnCommitAndPersisterWithoutBatchShouldWork(t *testing.T) {
+	sUnit := initStorageUnitWithNilBloomFilter(t, 10)
+	sUnit.SetFlushOnCommit(true)
+
+	err := sUnit.Flush()
+
+	assert.Nil(t, err)
+}
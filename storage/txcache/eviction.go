@@ -43,6 +43,7 @@ func (cache *TxCache) doEviction() {
 	}
 
 	cache.evictionJournal = journal
+	cache.counters.RecordEvictions(int(journal.passOneNumTxs + journal.passTwoNumTxs))
 	cache.monitorEvictionEnd(stopWatch)
 	cache.destroySnapshotOfSenders()
 }
@@ -150,6 +150,7 @@ func TestEviction_DoEvictionDoneInPassTwo_BecauseOfCount(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, int64(1), cache.CountSenders())
 	require.Equal(t, int64(1), cache.CountTx())
+	require.Equal(t, uint64(2), cache.Stats().NumEvictions)
 }
 
 func TestEviction_DoEvictionDoneInPassTwo_BecauseOfSize(t *testing.T) {
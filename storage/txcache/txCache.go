@@ -6,6 +6,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core/atomic"
 	"github.com/ElrondNetwork/elrond-go/core/check"
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // TxCache represents a cache-like structure (it has a fixed capacity and implements an eviction mechanism) for holding transactions
@@ -22,6 +23,7 @@ type TxCache struct {
 	numTxAddedDuringEviction      atomic.Counter
 	numTxRemovedBetweenSelections atomic.Counter
 	numTxRemovedDuringEviction    atomic.Counter
+	counters                      storage.CacheCounters
 }
 
 // NewTxCache creates a new transaction cache
@@ -60,6 +62,7 @@ func (cache *TxCache) AddTx(txHash []byte, tx data.TransactionHandler) (ok bool,
 	added = cache.txByHash.addTx(txHash, tx)
 	if added {
 		cache.txListBySender.addTx(txHash, tx)
+		cache.counters.RecordPut(int(estimateTxSize(tx)))
 		cache.monitorTxAddition()
 	}
 
@@ -69,6 +72,7 @@ func (cache *TxCache) AddTx(txHash []byte, tx data.TransactionHandler) (ok bool,
 // GetByTxHash gets the transaction by hash
 func (cache *TxCache) GetByTxHash(txHash []byte) (data.TransactionHandler, bool) {
 	tx, ok := cache.txByHash.getTx(string(txHash))
+	cache.counters.RecordGet(ok)
 	return tx, ok
 }
 
@@ -219,6 +223,11 @@ func (cache *TxCache) RegisterHandler(func(key []byte)) {
 	log.Error("TxCache.RegisterHandler is not implemented")
 }
 
+// Stats returns the counters of the operations done on the cache
+func (cache *TxCache) Stats() storage.CacheStats {
+	return cache.counters.Stats()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (cache *TxCache) IsInterfaceNil() bool {
 	return cache == nil
@@ -274,3 +274,19 @@ func Test_IsInterfaceNil(t *testing.T) {
 func newCacheToTest() *TxCache {
 	return NewTxCache(CacheConfig{Name: "test", NumChunksHint: 16})
 }
+
+func Test_Stats(t *testing.T) {
+	cache := newCacheToTest()
+
+	cache.AddTx([]byte("hash-1"), createTx("alice", 1))
+	cache.AddTx([]byte("hash-1"), createTx("alice", 1))
+	_, _ = cache.GetByTxHash([]byte("hash-1"))
+	_, _ = cache.Get([]byte("hash-2"))
+
+	stats := cache.Stats()
+	require.Equal(t, uint64(2), stats.NumGets)
+	require.Equal(t, uint64(1), stats.NumHits)
+	require.Equal(t, uint64(1), stats.NumMisses)
+	require.Equal(t, uint64(1), stats.NumPuts)
+	require.Equal(t, estimatedSizeOfBoundedTxFields, stats.NumBytesAdded)
+}